@@ -1,4 +1,4 @@
-// Code generated by swaggo/swag. DO NOT EDIT
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
 package docs
 
 import "github.com/swaggo/swag"
@@ -172,6 +172,32 @@ const docTemplate = `{
                 }
             }
         },
+        "/category/tree": {
+            "get": {
+                "description": "Get the categories as a tree, root categories with their subcategories nested under them",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Get category tree",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Category"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/category/{id}": {
             "get": {
                 "description": "Get an instance of Category",
@@ -288,6 +314,50 @@ const docTemplate = `{
                 }
             }
         },
+        "/category/{id}/move": {
+            "put": {
+                "description": "Moves a category with its subcategories under another parent, or to the root when no parent is given",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Move Category",
+                "parameters": [
+                    {
+                        "description": "new parent",
+                        "name": "move",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.CategoryMove"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/channel": {
             "get": {
                 "description": "Get all active channels",
@@ -1043,6 +1113,166 @@ const docTemplate = `{
                 }
             }
         },
+        "/dress-type/{id}/bill-of-materials": {
+            "get": {
+                "description": "Get the products consumed to stitch one piece of a DressType",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DressType"
+                ],
+                "summary": "Get DressType bill of materials",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "DressType id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DressTypeComponent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the products consumed to stitch one piece of a DressType",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DressType"
+                ],
+                "summary": "Update DressType bill of materials",
+                "parameters": [
+                    {
+                        "description": "bill of materials",
+                        "name": "components",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/requestModel.DressTypeComponent"
+                            }
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "DressType id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/dress-type/{id}/measurement-schema": {
+            "get": {
+                "description": "Get the measurements taken for a DressType, in the order they are shown",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DressType"
+                ],
+                "summary": "Get DressType measurement schema",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "DressType id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.MeasurementField"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the measurements taken for a DressType, their units, bounds and grouping",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DressType"
+                ],
+                "summary": "Update DressType measurement schema",
+                "parameters": [
+                    {
+                        "description": "measurement schema",
+                        "name": "fields",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/requestModel.MeasurementField"
+                            }
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "DressType id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/enquiry": {
             "get": {
                 "description": "Get all active enquiries",
@@ -2045,59 +2275,197 @@ const docTemplate = `{
                 }
             }
         },
-        "/inventory/low-stock": {
-            "get": {
-                "description": "Get all items with stock below threshold",
+        "/inventory-log/{id}/reverse": {
+            "post": {
+                "description": "Undoes a movement recorded by mistake with a linked reversing entry and restores the stock",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Inventory"
+                    "InventoryLog"
+                ],
+                "summary": "Reverse an Inventory Log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Inventory Log id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "reversal",
+                        "name": "reversal",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.InventoryLogReversal"
+                        }
+                    }
                 ],
-                "summary": "Get low stock items",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.LowStockItem"
+                            "$ref": "#/definitions/responseModel.InventoryLog"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.DataResponse"
+                            "$ref": "#/definitions/responseModel.Response"
                         }
                     }
                 }
             }
         },
-        "/inventory/movement": {
-            "post": {
-                "description": "Record a stock IN, OUT, or ADJUST movement",
+        "/inventory/cogs": {
+            "get": {
+                "description": "Get the cost of stock consumed and written off in a date range, using the channel's costing method",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
                     "Inventory"
                 ],
-                "summary": "Record stock movement",
+                "summary": "Get cost of goods report",
                 "parameters": [
                     {
-                        "description": "stock movement",
-                        "name": "movement",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/requestModel.StockMovementRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/responseModel.StockMovementResponse"
-                        }
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD), defaults to the start of the month",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date (YYYY-MM-DD), defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category, its subcategories are included",
+                        "name": "categoryId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.COGSReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/consolidated": {
+            "get": {
+                "description": "Get the stock of each product in every channel the user can access, with the total and the quantity in transit",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get consolidated stock",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product id",
+                        "name": "productId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.ConsolidatedStock"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/low-stock": {
+            "get": {
+                "description": "Get all items with stock below threshold",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get low stock items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category, its subcategories are included",
+                        "name": "categoryId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.LowStockItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/movement": {
+            "post": {
+                "description": "Record a stock IN, OUT, or ADJUST movement",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Record stock movement",
+                "parameters": [
+                    {
+                        "description": "stock movement",
+                        "name": "movement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.StockMovementRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "retries with the same key are booked once",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.StockMovementResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2149,6 +2517,121 @@ const docTemplate = `{
                 }
             }
         },
+        "/inventory/product/{productId}/lots": {
+            "get": {
+                "description": "Get the lots of a product in the current channel, oldest first, which is the order outgoing stock is taken from them",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get lots of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include lots that are used up",
+                        "name": "includeEmpty",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.InventoryLot"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/reorder-suggestions": {
+            "get": {
+                "description": "Get the low stock items with a suggested reorder quantity based on the consumption over the reorder window",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get reorder suggestions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category, its subcategories are included",
+                        "name": "categoryId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.ReorderSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/valuation": {
+            "get": {
+                "description": "Get the quantity and value of stock on hand at the end of a day, using the channel's costing method",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get inventory valuation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "As of date (YYYY-MM-DD), defaults to today",
+                        "name": "asOf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category, its subcategories are included",
+                        "name": "categoryId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.InventoryValuation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/inventory/{id}": {
             "get": {
                 "description": "Get an instance of Inventory",
@@ -2503,7 +2986,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Saves an instance of Measurement",
+                "description": "Saves an instance of Measurement, values that look like mistakes are returned as warnings and flagged for review",
                 "consumes": [
                     "application/json"
                 ],
@@ -2526,7 +3009,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.MeasurementAnomaly"
                         }
                     },
                     "400": {
@@ -2544,21 +3027,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/measurement-history": {
+        "/measurement-flag": {
             "get": {
-                "description": "Get all active measurement histories",
+                "description": "Lists the values that looked like mistakes when measurements were saved, for review",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "MeasurementHistory"
+                    "Measurement"
                 ],
-                "summary": "Get all active measurement histories",
+                "summary": "Get flagged Measurements",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "search",
-                        "name": "search",
+                        "description": "OPEN (default), REVIEWED or SUPERSEDED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Person id",
+                        "name": "personId",
                         "in": "query"
                     }
                 ],
@@ -2566,7 +3055,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.MeasurementHistory"
+                            "$ref": "#/definitions/responseModel.MeasurementFlag"
                         }
                     },
                     "400": {
@@ -2576,30 +3065,39 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Saves an instance of MeasurementHistory",
+            }
+        },
+        "/measurement-flag/{id}/review": {
+            "put": {
+                "description": "Closes an open flag once the measurement was checked with the person",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "MeasurementHistory"
+                    "Measurement"
                 ],
-                "summary": "Save MeasurementHistory",
+                "summary": "Review Measurement flag",
                 "parameters": [
                     {
-                        "description": "measurementHistory",
-                        "name": "measurementHistory",
+                        "type": "integer",
+                        "description": "Measurement flag id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "review",
+                        "name": "review",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requestModel.MeasurementHistory"
+                            "$ref": "#/definitions/requestModel.MeasurementFlagReview"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
@@ -2609,17 +3107,86 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
-                    },
-                    "501": {
-                        "description": "Not Implemented",
-                        "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
-                        }
                     }
                 }
             }
         },
-        "/measurement-history/measurement/{measurementId}": {
+        "/measurement-history": {
+            "get": {
+                "description": "Get all active measurement histories",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MeasurementHistory"
+                ],
+                "summary": "Get all active measurement histories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.MeasurementHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Saves an instance of MeasurementHistory",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MeasurementHistory"
+                ],
+                "summary": "Save MeasurementHistory",
+                "parameters": [
+                    {
+                        "description": "measurementHistory",
+                        "name": "measurementHistory",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.MeasurementHistory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/measurement-history/measurement/{measurementId}": {
             "get": {
                 "description": "Get measurement histories by measurement id",
                 "consumes": [
@@ -2691,7 +3258,7 @@ const docTemplate = `{
         },
         "/measurement/bulk": {
             "put": {
-                "description": "Updates an array of measurements by their IDs",
+                "description": "Updates an array of measurements by their IDs, values that look like mistakes are returned as warnings and flagged for review",
                 "consumes": [
                     "application/json"
                 ],
@@ -2717,7 +3284,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.MeasurementAnomaly"
                         }
                     },
                     "400": {
@@ -2735,7 +3302,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Saves multiple measurements for multiple persons in bulk",
+                "description": "Saves multiple measurements for multiple persons in bulk, values that look like mistakes are returned as warnings and flagged for review",
                 "consumes": [
                     "application/json"
                 ],
@@ -2761,7 +3328,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.MeasurementAnomaly"
                         }
                     },
                     "400": {
@@ -2781,7 +3348,7 @@ const docTemplate = `{
         },
         "/measurement/{id}": {
             "get": {
-                "description": "Get an instance of Measurement",
+                "description": "Get an instance of Measurement, its values are shown in the unit asked for or the user's display unit",
                 "consumes": [
                     "application/json"
                 ],
@@ -2796,6 +3363,12 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "INCH or CM",
+                        "name": "unit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2814,7 +3387,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates a single measurement by its ID",
+                "description": "Updates a single measurement by its ID, values that look like mistakes are returned as warnings and flagged for review",
                 "consumes": [
                     "application/json"
                 ],
@@ -2844,7 +3417,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.MeasurementAnomaly"
                         }
                     },
                     "400": {
@@ -2895,6 +3468,102 @@ const docTemplate = `{
                 }
             }
         },
+        "/measurement/{id}/diff": {
+            "get": {
+                "description": "Compares two versions of a Measurement field by field. A version is a history entry, the values before that change, or current.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Measurement"
+                ],
+                "summary": "Compare Measurement versions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Measurement id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "History id or current",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "History id or current, current by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "INCH or CM",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.MeasurementDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/measurement/{id}/restore/{historyId}": {
+            "post": {
+                "description": "Puts back the values a Measurement had before a change in its history, the replaced values are kept in a new history entry. Values that look like mistakes are returned as warnings and flagged for review",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Measurement"
+                ],
+                "summary": "Restore Measurement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Measurement id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Measurement history id",
+                        "name": "historyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.MeasurementAnomaly"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/order": {
             "get": {
                 "description": "Get all active orders",
@@ -3312,6 +3981,50 @@ const docTemplate = `{
                 }
             }
         },
+        "/order-item/{id}/stage": {
+            "post": {
+                "description": "Moves an OrderItem to a production stage and derives the Order status from its items",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OrderItem"
+                ],
+                "summary": "Move OrderItem stage",
+                "parameters": [
+                    {
+                        "description": "target stage, optional reason and tailor",
+                        "name": "stage",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.OrderItemStage"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "OrderItem id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/order/{id}": {
             "get": {
                 "description": "Get an instance of Order",
@@ -3428,21 +4141,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/person": {
+        "/order/{id}/invoice": {
             "get": {
-                "description": "Get all active persons",
-                "consumes": [
-                    "application/json"
+                "description": "Renders the issued invoice of an Order with its items, payments and balance",
+                "produces": [
+                    "text/html",
+                    "application/pdf"
                 ],
                 "tags": [
-                    "Person"
+                    "Order"
                 ],
-                "summary": "Get all active persons",
+                "summary": "Get Order invoice",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "search",
-                        "name": "search",
+                        "description": "html (default) or pdf",
+                        "name": "format",
                         "in": "query"
                     }
                 ],
@@ -3450,40 +4171,35 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Person"
+                            "type": "file"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.DataResponse"
+                            "$ref": "#/definitions/responseModel.Response"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Saves an instance of Person",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Gives the Order the next invoice number of the channel, orders are also invoiced when delivered",
                 "tags": [
-                    "Person"
+                    "Order"
                 ],
-                "summary": "Save Person",
+                "summary": "Issue Order invoice",
                 "parameters": [
                     {
-                        "description": "person",
-                        "name": "person",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/requestModel.Person"
-                        }
+                        "type": "integer",
+                        "description": "Order id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
@@ -3493,31 +4209,25 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
-                    },
-                    "501": {
-                        "description": "Not Implemented",
-                        "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
-                        }
                     }
                 }
             }
         },
-        "/person/customer/{customerId}": {
+        "/order/{id}/payment": {
             "get": {
-                "description": "Get persons by customer id",
+                "description": "Get all payments recorded against an order",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Person"
+                    "OrderPayment"
                 ],
-                "summary": "Get persons by customer id",
+                "summary": "Get payments by order id",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "customer id",
-                        "name": "customerId",
+                        "description": "Order id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -3526,7 +4236,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Person"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/responseModel.OrderPayment"
+                            }
                         }
                     },
                     "400": {
@@ -3536,111 +4249,128 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/person/{id}": {
-            "get": {
-                "description": "Get an instance of Person",
+            },
+            "post": {
+                "description": "Records a payment (advance or balance) against an order",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Person"
+                    "OrderPayment"
                 ],
-                "summary": "Get a specific Person",
+                "summary": "Save OrderPayment",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Person id",
+                        "description": "Order id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "order payment",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.OrderPayment"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Person"
+                            "$ref": "#/definitions/responseModel.Response"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.DataResponse"
+                            "$ref": "#/definitions/responseModel.Response"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an instance of Person",
+            }
+        },
+        "/order/{id}/payment/{paymentId}": {
+            "get": {
+                "description": "Get a payment recorded against an order",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Person"
+                    "OrderPayment"
                 ],
-                "summary": "Update Person",
+                "summary": "Get OrderPayment",
                 "parameters": [
                     {
-                        "description": "person",
-                        "name": "person",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/requestModel.Person"
-                        }
+                        "type": "integer",
+                        "description": "Order id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Person id",
-                        "name": "id",
+                        "description": "OrderPayment id",
+                        "name": "paymentId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.OrderPayment"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
-                        }
-                    },
-                    "501": {
-                        "description": "Not Implemented",
-                        "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.DataResponse"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Deletes an instance of Person",
+            "put": {
+                "description": "Updates a payment recorded against an order",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Person"
+                    "OrderPayment"
                 ],
-                "summary": "Delete Person",
+                "summary": "Update OrderPayment",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "person id",
+                        "description": "Order id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "OrderPayment id",
+                        "name": "paymentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "order payment",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.OrderPayment"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
@@ -3652,64 +4382,79 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/product": {
-            "get": {
-                "description": "Get all active products with current stock",
+            },
+            "delete": {
+                "description": "Deletes a payment recorded against an order",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "OrderPayment"
                 ],
-                "summary": "Get all active products",
+                "summary": "Delete OrderPayment",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "search",
-                        "name": "search",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Order id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "OrderPayment id",
+                        "name": "paymentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Product"
+                            "$ref": "#/definitions/responseModel.Response"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.DataResponse"
+                            "$ref": "#/definitions/responseModel.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/order/{id}/transition": {
             "post": {
-                "description": "Saves an instance of Product",
+                "description": "Moves an Order to the given status if the channel's transition rules allow it",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Order"
                 ],
-                "summary": "Save Product",
+                "summary": "Transition Order status",
                 "parameters": [
                     {
-                        "description": "product",
-                        "name": "product",
+                        "description": "target status and optional reason",
+                        "name": "status",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requestModel.Product"
+                            "$ref": "#/definitions/requestModel.Status"
                         }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Order id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
@@ -3719,26 +4464,20 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
-                        }
                     }
                 }
             }
         },
-        "/product/autocomplete": {
+        "/person": {
             "get": {
-                "description": "Autocomplete for products with stock info",
+                "description": "Get all active persons",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Person"
                 ],
-                "summary": "Autocomplete for products",
+                "summary": "Get all active persons",
                 "parameters": [
                     {
                         "type": "string",
@@ -3751,7 +4490,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.ProductAutoComplete"
+                            "$ref": "#/definitions/responseModel.Person"
                         }
                     },
                     "400": {
@@ -3761,50 +4500,65 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/product/low-stock": {
-            "get": {
-                "description": "Get all products with stock below threshold",
+            },
+            "post": {
+                "description": "Saves an instance of Person",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Person"
+                ],
+                "summary": "Save Person",
+                "parameters": [
+                    {
+                        "description": "person",
+                        "name": "person",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.Person"
+                        }
+                    }
                 ],
-                "summary": "Get low stock products",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Product"
+                            "$ref": "#/definitions/responseModel.Response"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.DataResponse"
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
                         }
                     }
                 }
             }
         },
-        "/product/sku": {
+        "/person/customer/{customerId}": {
             "get": {
-                "description": "Get product details by SKU",
+                "description": "Get persons by customer id",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Person"
                 ],
-                "summary": "Get product by SKU",
+                "summary": "Get persons by customer id",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Product SKU",
-                        "name": "sku",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "customer id",
+                        "name": "customerId",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -3812,7 +4566,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Product"
+                            "$ref": "#/definitions/responseModel.Person"
                         }
                     },
                     "400": {
@@ -3824,20 +4578,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/{id}": {
+        "/person/{id}": {
             "get": {
-                "description": "Get an instance of Product with inventory",
+                "description": "Get an instance of Person",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Person"
                 ],
-                "summary": "Get a specific Product",
+                "summary": "Get a specific Person",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product id",
+                        "description": "Person id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3847,7 +4601,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Product"
+                            "$ref": "#/definitions/responseModel.Person"
                         }
                     },
                     "400": {
@@ -3859,35 +4613,35 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates an instance of Product",
+                "description": "Updates an instance of Person",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Person"
                 ],
-                "summary": "Update Product",
+                "summary": "Update Person",
                 "parameters": [
                     {
-                        "description": "product",
-                        "name": "product",
+                        "description": "person",
+                        "name": "person",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requestModel.Product"
+                            "$ref": "#/definitions/requestModel.Person"
                         }
                     },
                     {
                         "type": "integer",
-                        "description": "Product id",
+                        "description": "Person id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Accepted",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
@@ -3898,8 +4652,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/responseModel.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "501": {
+                        "description": "Not Implemented",
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
@@ -3907,18 +4661,18 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes an instance of Product",
+                "description": "Deletes an instance of Person",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Person"
                 ],
-                "summary": "Delete Product",
+                "summary": "Delete Person",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "product id",
+                        "description": "person id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3940,32 +4694,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/task": {
+        "/product": {
             "get": {
-                "description": "Get all active tasks",
+                "description": "Get all active products with current stock",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Task"
+                    "Product"
                 ],
-                "summary": "Get all active tasks",
+                "summary": "Get all active products",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "search",
                         "name": "search",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category, its subcategories are included",
+                        "name": "categoryId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/responseModel.Task"
-                            }
+                            "$ref": "#/definitions/responseModel.Product"
                         }
                     },
                     "400": {
@@ -3977,22 +4734,22 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Saves an instance of Task",
+                "description": "Saves an instance of Product",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Task"
+                    "Product"
                 ],
-                "summary": "Save Task",
+                "summary": "Save Product",
                 "parameters": [
                     {
-                        "description": "task",
-                        "name": "task",
+                        "description": "product",
+                        "name": "product",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requestModel.Task"
+                            "$ref": "#/definitions/requestModel.Product"
                         }
                     }
                 ],
@@ -4009,8 +4766,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/responseModel.Response"
                         }
                     },
-                    "501": {
-                        "description": "Not Implemented",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
@@ -4018,30 +4775,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/task/{id}": {
+        "/product/autocomplete": {
             "get": {
-                "description": "Get an instance of Task",
+                "description": "Autocomplete for products with stock info",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Task"
+                    "Product"
                 ],
-                "summary": "Get a specific Task",
+                "summary": "Autocomplete for products",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Task id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Task"
+                            "$ref": "#/definitions/responseModel.ProductAutoComplete"
                         }
                     },
                     "400": {
@@ -4051,39 +4807,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an instance of Task",
+            }
+        },
+        "/product/labels": {
+            "post": {
+                "description": "Renders a PDF sheet of Code128 or QR labels for the SKUs of the given products",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/pdf"
+                ],
                 "tags": [
-                    "Task"
+                    "Product"
                 ],
-                "summary": "Update Task",
+                "summary": "Print product labels",
                 "parameters": [
                     {
-                        "description": "task",
-                        "name": "task",
+                        "description": "labels",
+                        "name": "labels",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requestModel.Task"
+                            "$ref": "#/definitions/requestModel.ProductLabels"
                         }
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Task id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Accepted",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -4091,72 +4845,68 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
-                    },
-                    "501": {
-                        "description": "Not Implemented",
-                        "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
-                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes an instance of Task",
+            }
+        },
+        "/product/low-stock": {
+            "get": {
+                "description": "Get all products with stock below threshold",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Task"
+                    "Product"
                 ],
-                "summary": "Delete Task",
+                "summary": "Get low stock products",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Category, its subcategories are included",
+                        "name": "categoryId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.Product"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.DataResponse"
                         }
                     }
                 }
             }
         },
-        "/user": {
+        "/product/scan/{code}": {
             "get": {
-                "description": "Get all active users",
+                "description": "Resolves a scanned barcode or QR code to the product and its current inventory",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Product"
                 ],
-                "summary": "Get all active users",
+                "summary": "Scan product",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "search",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Scanned code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.User"
+                            "$ref": "#/definitions/responseModel.Product"
                         }
                     },
                     "400": {
@@ -4166,72 +4916,59 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Saves an instance of User",
+            }
+        },
+        "/product/sku": {
+            "get": {
+                "description": "Get product details by SKU",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Product"
                 ],
-                "summary": "Save User",
+                "summary": "Get product by SKU",
                 "parameters": [
                     {
-                        "description": "user",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/requestModel.User"
-                        }
+                        "type": "string",
+                        "description": "Product SKU",
+                        "name": "sku",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.Product"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
-                        }
-                    },
-                    "501": {
-                        "description": "Not Implemented",
-                        "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.DataResponse"
                         }
                     }
                 }
             }
         },
-        "/user/autocomplete": {
+        "/product/{id}": {
             "get": {
-                "description": "Get  Users for AutoComplete",
-                "produces": [
+                "description": "Get an instance of Product with inventory",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Product"
                 ],
-                "summary": "Get  Users for AutoComplete",
+                "summary": "Get a specific Product",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "name",
-                        "name": "name",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "role",
-                        "name": "role",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Product id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -4239,39 +4976,47 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entities.User"
+                            "$ref": "#/definitions/responseModel.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
                         }
                     }
                 }
-            }
-        },
-        "/user/channel": {
-            "post": {
-                "description": "Saves an instance of User Channel Detail",
+            },
+            "put": {
+                "description": "Updates an instance of Product",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "UserChannelDetail"
+                    "Product"
                 ],
-                "summary": "Save User Channel Detail",
+                "summary": "Update Product",
                 "parameters": [
                     {
-                        "description": "userChannelDetail",
-                        "name": "userChannelDetail",
+                        "description": "product",
+                        "name": "product",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/requestModel.UserChannelDetail"
-                            }
+                            "$ref": "#/definitions/requestModel.Product"
                         }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
@@ -4282,63 +5027,105 @@ const docTemplate = `{
                             "$ref": "#/definitions/responseModel.Response"
                         }
                     },
-                    "501": {
-                        "description": "Not Implemented",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
                     }
                 }
-            }
-        },
-        "/user/channel/accessible": {
-            "get": {
-                "description": "Gets the channel the user can access, to be used for channel switcher",
+            },
+            "delete": {
+                "description": "Deletes an instance of Product",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Product"
                 ],
-                "summary": "Gets the channel the user can access",
+                "summary": "Delete Product",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User id",
-                        "name": "userId",
-                        "in": "query",
+                        "description": "product id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
             }
         },
-        "/user/channel/{id}": {
-            "put": {
-                "description": "Update an instance of User Channel Detail",
+        "/purchase-order": {
+            "get": {
+                "description": "Get all PurchaseOrders, optionally for one supplier or status",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "UserChannelDetail"
+                    "PurchaseOrder"
                 ],
-                "summary": "Update User Channel Detail",
+                "summary": "Get all PurchaseOrders",
                 "parameters": [
                     {
-                        "description": "userconfig",
-                        "name": "userChannelDetail",
-                        "in": "body",
-                        "required": true,
+                        "type": "integer",
+                        "description": "supplier id",
+                        "name": "supplierId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ORDERED, PARTIALLY_RECEIVED, RECEIVED or CANCELLED",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/requestModel.UserChannelDetail"
+                            "$ref": "#/definitions/responseModel.PurchaseOrder"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Saves a PurchaseOrder with its lines",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PurchaseOrder"
+                ],
+                "summary": "Save PurchaseOrder",
+                "parameters": [
                     {
-                        "type": "integer",
-                        "description": "userChannelDetailId",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "purchaseOrder",
+                        "name": "purchaseOrder",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.PurchaseOrder"
+                        }
                     }
                 ],
                 "responses": {
@@ -4353,28 +5140,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
-                    },
-                    "501": {
-                        "description": "Not Implemented",
-                        "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
-                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes an instance of User Channel Detail",
+            }
+        },
+        "/purchase-order/{id}": {
+            "get": {
+                "description": "Get a PurchaseOrder with its lines and the expenses created by its receipts",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "UserChannelDetail"
+                    "PurchaseOrder"
                 ],
-                "summary": "Delete User Channel Detail",
+                "summary": "Get a specific PurchaseOrder",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "user channel detail id",
+                        "description": "PurchaseOrder id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4384,42 +5167,47 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.PurchaseOrder"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.DataResponse"
                         }
                     }
                 }
-            }
-        },
-        "/user/config": {
-            "post": {
-                "description": "Saves an instance of User Config",
+            },
+            "put": {
+                "description": "Replaces the details and lines of a PurchaseOrder that has not been received yet",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "UserConfig"
+                    "PurchaseOrder"
                 ],
-                "summary": "Save User Config",
+                "summary": "Update PurchaseOrder",
                 "parameters": [
                     {
-                        "description": "userconfig",
-                        "name": "userConfig",
+                        "description": "purchaseOrder",
+                        "name": "purchaseOrder",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requestModel.UserConfig"
+                            "$ref": "#/definitions/requestModel.PurchaseOrder"
                         }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "PurchaseOrder id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
@@ -4429,84 +5217,78 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
-                    },
-                    "501": {
-                        "description": "Not Implemented",
-                        "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
-                        }
                     }
                 }
             }
         },
-        "/user/config/": {
-            "get": {
-                "description": "Get an instance of UserConfig",
+        "/purchase-order/{id}/cancel": {
+            "post": {
+                "description": "Cancels the outstanding quantities of a PurchaseOrder, received stock is kept",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "UserConfig"
+                    "PurchaseOrder"
                 ],
-                "summary": "Get a specific  UserConfig",
+                "summary": "Cancel PurchaseOrder",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "userId",
+                        "description": "PurchaseOrder id",
                         "name": "id",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/entities.UserConfig"
+                            "$ref": "#/definitions/responseModel.Response"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.DataResponse"
+                            "$ref": "#/definitions/responseModel.Response"
                         }
                     }
                 }
             }
         },
-        "/user/config/{id}": {
-            "put": {
-                "description": "Update an instance of User Config",
+        "/purchase-order/{id}/receive": {
+            "post": {
+                "description": "Books received quantities as IN stock movements and creates an expense for the receipt against the supplier",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "UserConfig"
+                    "PurchaseOrder"
                 ],
-                "summary": "Update User Config",
+                "summary": "Receive PurchaseOrder",
                 "parameters": [
                     {
-                        "description": "userconfig",
-                        "name": "userConfig",
+                        "description": "receipt",
+                        "name": "receipt",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requestModel.UserConfig"
+                            "$ref": "#/definitions/requestModel.PurchaseOrderReceipt"
                         }
                     },
                     {
                         "type": "integer",
-                        "description": "userconfigid",
+                        "description": "PurchaseOrder id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.PurchaseOrder"
                         }
                     },
                     "400": {
@@ -4514,70 +5296,82 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
-                    },
-                    "501": {
-                        "description": "Not Implemented",
-                        "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
-                        }
                     }
                 }
             }
         },
-        "/user/forgot-password": {
-            "post": {
-                "description": "Reset User Password",
+        "/sale": {
+            "get": {
+                "description": "Get the counter sales of the channel, optionally in a date range or for one customer",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "User"
+                    "Sale"
                 ],
-                "summary": "Reset User Password",
+                "summary": "Get all Sales",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "email",
-                        "name": "email",
-                        "in": "query",
-                        "required": true
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Customer id",
+                        "name": "customerId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.Sale"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.DataResponse"
                         }
                     }
                 }
-            }
-        },
-        "/user/login": {
+            },
             "post": {
-                "description": "Saves an instance of User",
+                "description": "Posts a counter sale, the stock of its lines is taken out of the channel's inventory",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Sale"
                 ],
-                "summary": "Login User",
+                "summary": "Save Sale",
                 "parameters": [
                     {
-                        "description": "login",
-                        "name": "userLogin",
+                        "description": "sale",
+                        "name": "sale",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requestModel.Login"
+                            "$ref": "#/definitions/requestModel.Sale"
                         }
                     }
                 ],
                 "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Sale"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -4587,39 +5381,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/refresh-token": {
+        "/sale/{id}": {
             "get": {
-                "description": "Refresh JWT Token",
+                "description": "Get a counter sale with its lines and totals",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
-                ],
-                "summary": "Refresh JWT Token",
-                "responses": {}
-            }
-        },
-        "/user/reset-password": {
-            "post": {
-                "description": "Update User Password",
-                "tags": [
-                    "User"
+                    "Sale"
                 ],
-                "summary": "Update User Password",
+                "summary": "Get a specific Sale",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "resetString",
-                        "name": "resetString",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "password",
-                        "name": "password",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Sale id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -4627,64 +5404,68 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.Sale"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.DataResponse"
                         }
                     }
                 }
             }
         },
-        "/user/switch-channel": {
+        "/sale/{id}/receipt": {
             "get": {
-                "description": "Refresh JWT Token and switches the user to the requested channel",
-                "consumes": [
-                    "application/json"
+                "description": "Renders the printable PDF receipt of a counter sale",
+                "produces": [
+                    "application/pdf"
                 ],
                 "tags": [
-                    "User"
+                    "Sale"
                 ],
-                "summary": "Refresh JWT Token and Switches the Channel",
+                "summary": "Get Sale receipt",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Channel id",
+                        "description": "Sale id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
             }
         },
-        "/user/{id}": {
+        "/stock-take": {
             "get": {
-                "description": "Get an instance of User",
+                "description": "Get all count sessions, latest first",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
-                ],
-                "summary": "Get a specific  User",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "StockTake"
                 ],
+                "summary": "Get all StockTakes",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.User"
+                            "$ref": "#/definitions/responseModel.StockTake"
                         }
                     },
                     "400": {
@@ -4695,38 +5476,31 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Updates an instance of User",
+            "post": {
+                "description": "Opens a count session for a category, or for all products when no category is given",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "StockTake"
                 ],
-                "summary": "Update User",
+                "summary": "Open StockTake",
                 "parameters": [
                     {
-                        "description": "user",
-                        "name": "user",
+                        "description": "stockTake",
+                        "name": "stockTake",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requestModel.User"
+                            "$ref": "#/definitions/requestModel.StockTake"
                         }
-                    },
-                    {
-                        "type": "integer",
-                        "description": "User id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.StockTake"
                         }
                     },
                     "400": {
@@ -4734,28 +5508,57 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/responseModel.Response"
                         }
-                    },
-                    "501": {
-                        "description": "Not Implemented",
+                    }
+                }
+            }
+        },
+        "/stock-take/{id}": {
+            "get": {
+                "description": "Get a StockTake with the expected, current and counted quantity and the variance of every product",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTake"
+                ],
+                "summary": "Get a specific StockTake",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "StockTake id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responseModel.Response"
+                            "$ref": "#/definitions/responseModel.StockTake"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Deletes an instance of User",
+                "description": "Cancels an open StockTake without adjusting stock",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "StockTake"
                 ],
-                "summary": "Delete User",
+                "summary": "Cancel StockTake",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "user id",
+                        "description": "StockTake id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4776,324 +5579,2996 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entities.Channel": {
-            "type": "object",
-            "properties": {
-                "ID": {
+        },
+        "/stock-take/{id}/count": {
+            "put": {
+                "description": "Records counted quantities of products in an open StockTake",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTake"
+                ],
+                "summary": "Submit StockTake counts",
+                "parameters": [
+                    {
+                        "description": "counts",
+                        "name": "counts",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/requestModel.StockTakeCount"
+                            }
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "StockTake id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-take/{id}/post": {
+            "post": {
+                "description": "Books the variance of every counted product as an ADJUST stock movement and closes the StockTake",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTake"
+                ],
+                "summary": "Post StockTake",
+                "parameters": [
+                    {
+                        "description": "reason for the adjustments",
+                        "name": "post",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.StockTakePost"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "StockTake id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.StockTake"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-transfer": {
+            "get": {
+                "description": "Get all StockTransfers sent from or to the current channel, optionally for one status",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTransfer"
+                ],
+                "summary": "Get all StockTransfers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DRAFT, DISPATCHED, RECEIVED or CANCELLED",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Drafts a transfer of stock from the current channel to another channel",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTransfer"
+                ],
+                "summary": "Save StockTransfer",
+                "parameters": [
+                    {
+                        "description": "stockTransfer",
+                        "name": "stockTransfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.StockTransfer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-transfer/{id}": {
+            "get": {
+                "description": "Get a StockTransfer sent from or to the current channel, with its lines",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTransfer"
+                ],
+                "summary": "Get a specific StockTransfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "StockTransfer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the destination and lines of a StockTransfer that is still a draft",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTransfer"
+                ],
+                "summary": "Update StockTransfer",
+                "parameters": [
+                    {
+                        "description": "stockTransfer",
+                        "name": "stockTransfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.StockTransfer"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "StockTransfer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-transfer/{id}/cancel": {
+            "post": {
+                "description": "Cancels a StockTransfer that has not been dispatched",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTransfer"
+                ],
+                "summary": "Cancel StockTransfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "StockTransfer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-transfer/{id}/dispatch": {
+            "post": {
+                "description": "Books the lines as OUT stock movements in the sending channel, the stock is in transit until received",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTransfer"
+                ],
+                "summary": "Dispatch StockTransfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "StockTransfer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-transfer/{id}/receive": {
+            "post": {
+                "description": "Books the lines of a dispatched StockTransfer as IN stock movements in the receiving channel",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTransfer"
+                ],
+                "summary": "Receive StockTransfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "StockTransfer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier": {
+            "get": {
+                "description": "Get all active suppliers",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Get all active suppliers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Saves an instance of Supplier",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Save Supplier",
+                "parameters": [
+                    {
+                        "description": "supplier",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.Supplier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier/autocomplete": {
+            "get": {
+                "description": "Autocomplete for suppliers",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Autocomplete for suppliers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.SupplierAutoComplete"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier/{id}": {
+            "get": {
+                "description": "Get an instance of Supplier",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Get a specific Supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an instance of Supplier",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Update Supplier",
+                "parameters": [
+                    {
+                        "description": "supplier",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.Supplier"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Supplier id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an instance of Supplier",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Delete Supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "supplier id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/task": {
+            "get": {
+                "description": "Get all active tasks",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Get all active tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/responseModel.Task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Saves an instance of Task",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Save Task",
+                "parameters": [
+                    {
+                        "description": "task",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.Task"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}": {
+            "get": {
+                "description": "Get an instance of Task",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Get a specific Task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an instance of Task",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Update Task",
+                "parameters": [
+                    {
+                        "description": "task",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.Task"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an instance of Task",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Delete Task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "get": {
+                "description": "Get all active users",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get all active users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Saves an instance of User",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Save User",
+                "parameters": [
+                    {
+                        "description": "user",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/autocomplete": {
+            "get": {
+                "description": "Get  Users for AutoComplete",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get  Users for AutoComplete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "role",
+                        "name": "role",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.User"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/channel": {
+            "post": {
+                "description": "Saves an instance of User Channel Detail",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserChannelDetail"
+                ],
+                "summary": "Save User Channel Detail",
+                "parameters": [
+                    {
+                        "description": "userChannelDetail",
+                        "name": "userChannelDetail",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/requestModel.UserChannelDetail"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/channel/accessible": {
+            "get": {
+                "description": "Gets the channel the user can access, to be used for channel switcher",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Gets the channel the user can access",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/user/channel/{id}": {
+            "put": {
+                "description": "Update an instance of User Channel Detail",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserChannelDetail"
+                ],
+                "summary": "Update User Channel Detail",
+                "parameters": [
+                    {
+                        "description": "userconfig",
+                        "name": "userChannelDetail",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.UserChannelDetail"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "userChannelDetailId",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an instance of User Channel Detail",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserChannelDetail"
+                ],
+                "summary": "Delete User Channel Detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user channel detail id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/config": {
+            "post": {
+                "description": "Saves an instance of User Config",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserConfig"
+                ],
+                "summary": "Save User Config",
+                "parameters": [
+                    {
+                        "description": "userconfig",
+                        "name": "userConfig",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.UserConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/config/": {
+            "get": {
+                "description": "Get an instance of UserConfig",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserConfig"
+                ],
+                "summary": "Get a specific  UserConfig",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "userId",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.UserConfig"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/config/{id}": {
+            "put": {
+                "description": "Update an instance of User Config",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserConfig"
+                ],
+                "summary": "Update User Config",
+                "parameters": [
+                    {
+                        "description": "userconfig",
+                        "name": "userConfig",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.UserConfig"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "userconfigid",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/forgot-password": {
+            "post": {
+                "description": "Reset User Password",
+                "tags": [
+                    "User"
+                ],
+                "summary": "Reset User Password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "email",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/login": {
+            "post": {
+                "description": "Saves an instance of User",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Login User",
+                "parameters": [
+                    {
+                        "description": "login",
+                        "name": "userLogin",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.Login"
+                        }
+                    }
+                ],
+                "responses": {
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/refresh-token": {
+            "get": {
+                "description": "Refresh JWT Token",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Refresh JWT Token",
+                "responses": {}
+            }
+        },
+        "/user/reset-password": {
+            "post": {
+                "description": "Update User Password",
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update User Password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "resetString",
+                        "name": "resetString",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "password",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/switch-channel": {
+            "get": {
+                "description": "Refresh JWT Token and switches the user to the requested channel",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Refresh JWT Token and Switches the Channel",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Channel id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/user/{id}": {
+            "get": {
+                "description": "Get an instance of User",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get a specific  User",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.DataResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an instance of User",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update User",
+                "parameters": [
+                    {
+                        "description": "user",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestModel.User"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an instance of User",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete User",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responseModel.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entities.Channel": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "integer"
+                },
+                "channelId": {
+                    "description": "Channel Id must be create only since it will interfere with update operations\nUse tx.Exec as raw query to update the channelId as done in channel after-create hook",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "Transient fields for response mapping, not stored in DB",
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "integer"
+                },
+                "gstin": {
+                    "description": "GST registration details, State is used to decide the place of supply",
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ownerUserId": {
+                    "description": "Reference",
+                    "type": "integer"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                },
+                "updatedById": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.RoleType": {
+            "type": "string",
+            "enum": [
+                "SYSTEM ADMIN",
+                "DEV",
+                "SUPER ADMIN",
+                "ADMIN",
+                "STAFF",
+                "OUTSOURCED",
+                "VIEWER"
+            ],
+            "x-enum-comments": {
+                "ADMIN": "to be used by the application administrator",
+                "DEV": "to be used by developer, only read access in PROD",
+                "SUPERADMIN": "to be used for the owner of the application (customer)",
+                "SYSTEM_ADMIN": "to be used for managing system only,not exposed"
+            },
+            "x-enum-descriptions": [
+                "to be used for managing system only,not exposed",
+                "to be used by developer, only read access in PROD",
+                "to be used for the owner of the application (customer)",
+                "to be used by the application administrator",
+                "",
+                "",
+                ""
+            ],
+            "x-enum-varnames": [
+                "SYSTEM_ADMIN",
+                "DEV",
+                "SUPERADMIN",
+                "ADMIN",
+                "STAFF",
+                "OUTSOURCED",
+                "VIEWER"
+            ]
+        },
+        "entities.User": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "integer"
+                },
+                "channelId": {
+                    "description": "Channel Id must be create only since it will interfere with update operations\nUse tx.Exec as raw query to update the channelId as done in channel after-create hook",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "Transient fields for response mapping, not stored in DB",
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "integer"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "experience": {
+                    "type": "string"
+                },
+                "extension": {
+                    "type": "string"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "isLoggedIn": {
+                    "type": "boolean"
+                },
+                "isLoginDisabled": {
+                    "type": "boolean"
+                },
+                "lastLoginTime": {
+                    "type": "string"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "loginFailureCounter": {
+                    "type": "integer"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                },
+                "resetPasswordString": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/entities.RoleType"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                },
+                "updatedById": {
+                    "type": "integer"
+                },
+                "userChannelDetails": {
+                    "description": "References",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.UserChannelDetail"
+                    }
+                }
+            }
+        },
+        "entities.UserChannelDetail": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "integer"
+                },
+                "UserID": {
+                    "description": "References",
+                    "type": "integer"
+                },
+                "channel": {
+                    "$ref": "#/definitions/entities.Channel"
+                },
+                "channelId": {
+                    "description": "Channel Id must be create only since it will interfere with update operations\nUse tx.Exec as raw query to update the channelId as done in channel after-create hook",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "Transient fields for response mapping, not stored in DB",
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                },
+                "updatedById": {
+                    "type": "integer"
+                },
+                "userChannelId": {
+                    "description": "Refers to channelId",
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.UserConfig": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "integer"
+                },
+                "UserID": {
+                    "description": "References",
+                    "type": "integer"
+                },
+                "channelId": {
+                    "description": "Channel Id must be create only since it will interfere with update operations\nUse tx.Exec as raw query to update the channelId as done in channel after-create hook",
+                    "type": "integer"
+                },
+                "config": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "Transient fields for response mapping, not stored in DB",
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                },
+                "updatedById": {
+                    "type": "integer"
+                }
+            }
+        },
+        "requestModel.BulkMeasurementItem": {
+            "type": "object",
+            "properties": {
+                "dressTypeId": {
+                    "type": "integer"
+                },
+                "unit": {
+                    "description": "INCH, CM; defaults to the display unit of the user",
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "requestModel.BulkMeasurementRequest": {
+            "type": "object",
+            "properties": {
+                "measurements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/requestModel.BulkMeasurementItem"
+                    }
+                },
+                "personId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "requestModel.Category": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parentId": {
+                    "description": "Only used on create, use move to re-parent",
+                    "type": "integer"
+                }
+            }
+        },
+        "requestModel.CategoryMove": {
+            "type": "object",
+            "properties": {
+                "parentId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "requestModel.Channel": {
+            "type": "object",
+            "properties": {
+                "gstin": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ownerUserId": {
+                    "type": "integer"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.Customer": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "age": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "whatsappNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.DressType": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "hsnCode": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "measurementFields": {
+                    "description": "Replaces the measurement schema when given, otherwise a schema is made from Measurements",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/requestModel.MeasurementField"
+                    }
+                },
+                "measurements": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "taxRate": {
+                    "type": "number"
+                }
+            }
+        },
+        "requestModel.DressTypeComponent": {
+            "type": "object",
+            "required": [
+                "productId",
+                "quantity"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "In the product's stock unit",
+                    "type": "number"
+                },
+                "singleLot": {
+                    "description": "Cut from one dye lot",
+                    "type": "boolean"
+                }
+            }
+        },
+        "requestModel.Enquiry": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "firstName": {
+                    "description": "Customer fields",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                },
+                "referredBy": {
+                    "type": "string"
+                },
+                "referrerPhoneNumber": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "whatsappNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.EnquiryHistory": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "callBackDate": {
+                    "type": "string"
+                },
+                "customerComment": {
+                    "type": "string"
+                },
+                "employeeComment": {
+                    "type": "string"
+                },
+                "employeeId": {
+                    "type": "integer"
+                },
+                "enquiryDate": {
+                    "type": "string"
+                },
+                "enquiryId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "performedAt": {
+                    "type": "string"
+                },
+                "performedById": {
+                    "type": "integer"
+                },
+                "responseStatus": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "visitingDate": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.ExpenseDetail": {
+            "type": "object",
+            "properties": {
+                "expenseId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.ExpenseTracker": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "billNumber": {
+                    "type": "string"
+                },
+                "companyName": {
+                    "type": "string"
+                },
+                "expenseDetails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/requestModel.ExpenseDetail"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "material": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchaseDate": {
+                    "type": "string"
+                },
+                "supplierId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "requestModel.Inventory": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "lowStockThreshold": {
+                    "type": "number"
+                },
+                "productId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "requestModel.InventoryLogReversal": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.LabelSheetLayout": {
+            "type": "object",
+            "required": [
+                "columns",
+                "labelHeight",
+                "labelWidth",
+                "rows"
+            ],
+            "properties": {
+                "columns": {
+                    "type": "integer"
+                },
+                "gapX": {
+                    "type": "number"
+                },
+                "gapY": {
+                    "type": "number"
+                },
+                "labelHeight": {
+                    "type": "number"
+                },
+                "labelWidth": {
+                    "type": "number"
+                },
+                "marginLeft": {
+                    "type": "number"
+                },
+                "marginTop": {
+                    "type": "number"
+                },
+                "rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "requestModel.Login": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.MasterConfig": {
+            "type": "object",
+            "properties": {
+                "currentValue": {
+                    "type": "string"
+                },
+                "defaultValue": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "previousValue": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "useDefault": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "requestModel.Measurement": {
+            "type": "object",
+            "properties": {
+                "dressTypeId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "personId": {
+                    "type": "integer"
+                },
+                "takenById": {
+                    "type": "integer"
+                },
+                "unit": {
+                    "description": "INCH, CM; defaults to the display unit of the user",
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "requestModel.MeasurementField": {
+            "type": "object",
+            "required": [
+                "label"
+            ],
+            "properties": {
+                "displayOrder": {
+                    "description": "Defaults to the position in the list",
+                    "type": "integer"
+                },
+                "groupName": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "maxValue": {
+                    "type": "number"
+                },
+                "minValue": {
+                    "type": "number"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "unit": {
+                    "description": "INCH, CM; defaults to INCH",
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.MeasurementFlagReview": {
+            "type": "object",
+            "required": [
+                "note"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.MeasurementHistory": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "changedValues": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "measurementId": {
+                    "type": "integer"
+                },
+                "oldValues": {
+                    "description": "JSON string",
+                    "type": "string"
+                },
+                "performedAt": {
+                    "type": "string"
+                },
+                "performedById": {
+                    "type": "integer"
+                }
+            }
+        },
+        "requestModel.Order": {
+            "type": "object",
+            "properties": {
+                "additionalCharges": {
+                    "type": "number"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "deliveredDate": {
+                    "type": "string"
+                },
+                "expectedDeliveryDate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "orderItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/requestModel.OrderItem"
+                    }
+                },
+                "orderTakenById": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.OrderHistory": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "changedFields": {
+                    "type": "string"
+                },
+                "deliveredDate": {
+                    "type": "string"
+                },
+                "expectedDeliveryDate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "orderId": {
+                    "type": "integer"
+                },
+                "orderItemData": {
+                    "description": "JSON string",
+                    "type": "string"
+                },
+                "orderItemId": {
+                    "type": "integer"
+                },
+                "performedAt": {
+                    "type": "string"
+                },
+                "performedById": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.OrderItem": {
+            "type": "object",
+            "properties": {
+                "additionalCharges": {
+                    "type": "number"
+                },
+                "assignedTailorId": {
+                    "type": "integer"
+                },
+                "deliveredDate": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dressTypeId": {
+                    "type": "integer"
+                },
+                "expectedDeliveryDate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "measurementId": {
+                    "type": "integer"
+                },
+                "orderId": {
+                    "type": "integer"
+                },
+                "personId": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "requestModel.OrderItemStage": {
+            "type": "object",
+            "required": [
+                "stage"
+            ],
+            "properties": {
+                "assignedTailorId": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "stage": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.OrderPayment": {
+            "type": "object",
+            "required": [
+                "amount",
+                "mode"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "mode": {
+                    "description": "CASH, UPI, CARD, BANK",
+                    "type": "string"
+                },
+                "orderId": {
+                    "type": "integer"
+                },
+                "paidAt": {
+                    "description": "ISO datetime string",
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.Person": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "lastName": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.Product": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
                     "type": "integer"
                 },
-                "channelId": {
-                    "description": "Channel Id must be create only since it will interfere with update operations\nUse tx.Exec as raw query to update the channelId as done in channel after-create hook",
-                    "type": "integer"
+                "costPrice": {
+                    "type": "number"
                 },
-                "createdAt": {
+                "description": {
                     "type": "string"
                 },
-                "createdBy": {
-                    "description": "Transient fields for response mapping, not stored in DB",
+                "hsnCode": {
                     "type": "string"
                 },
-                "createdById": {
+                "id": {
                     "type": "integer"
                 },
                 "isActive": {
                     "type": "boolean"
                 },
+                "lowStockThreshold": {
+                    "type": "number"
+                },
                 "name": {
                     "type": "string"
                 },
-                "ownerUserId": {
-                    "description": "Reference",
-                    "type": "integer"
+                "sellingPrice": {
+                    "type": "number"
                 },
-                "status": {
+                "sku": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "taxRate": {
+                    "type": "number"
+                },
+                "unit": {
+                    "description": "PIECE, METER, KG, ROLL; defaults to PIECE",
                     "type": "string"
                 },
-                "updatedBy": {
+                "unitConversions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/requestModel.ProductUnitConversion"
+                    }
+                },
+                "variantAttributes": {
+                    "description": "Makes the product a parent with a variant for every combination of the values.\nOn update values can be added to the attributes, the missing variants are created.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/requestModel.ProductAttribute"
+                    }
+                }
+            }
+        },
+        "requestModel.ProductAttribute": {
+            "type": "object",
+            "required": [
+                "name",
+                "values"
+            ],
+            "properties": {
+                "name": {
                     "type": "string"
                 },
-                "updatedById": {
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "requestModel.ProductLabels": {
+            "type": "object",
+            "required": [
+                "productIds"
+            ],
+            "properties": {
+                "copies": {
+                    "description": "defaults to 1",
                     "type": "integer"
+                },
+                "customLayout": {
+                    "$ref": "#/definitions/requestModel.LabelSheetLayout"
+                },
+                "layout": {
+                    "description": "A4-24, A4-40 or A4-65; defaults to A4-24",
+                    "type": "string"
+                },
+                "productIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "symbology": {
+                    "description": "CODE128 or QR; defaults to CODE128",
+                    "type": "string"
                 }
             }
         },
-        "entities.RoleType": {
-            "type": "string",
-            "enum": [
-                "SYSTEM ADMIN",
-                "DEV",
-                "SUPER ADMIN",
-                "ADMIN",
-                "STAFF",
-                "OUTSOURCED",
-                "VIEWER"
+        "requestModel.ProductUnitConversion": {
+            "type": "object",
+            "required": [
+                "factor",
+                "unit"
             ],
-            "x-enum-comments": {
-                "ADMIN": "to be used by the application administrator",
-                "DEV": "to be used by developer, only read access in PROD",
-                "SUPERADMIN": "to be used for the owner of the application (customer)",
-                "SYSTEM_ADMIN": "to be used for managing system only,not exposed"
-            },
-            "x-enum-varnames": [
-                "SYSTEM_ADMIN",
-                "DEV",
-                "SUPERADMIN",
-                "ADMIN",
-                "STAFF",
-                "OUTSOURCED",
-                "VIEWER"
-            ]
+            "properties": {
+                "factor": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
         },
-        "entities.User": {
+        "requestModel.PurchaseOrder": {
             "type": "object",
+            "required": [
+                "supplierId"
+            ],
             "properties": {
-                "ID": {
+                "expectedDate": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "channelId": {
-                    "description": "Channel Id must be create only since it will interfere with update operations\nUse tx.Exec as raw query to update the channelId as done in channel after-create hook",
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/requestModel.PurchaseOrderLine"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "orderDate": {
+                    "type": "string"
+                },
+                "supplierId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "requestModel.PurchaseOrderLine": {
+            "type": "object",
+            "required": [
+                "productId"
+            ],
+            "properties": {
+                "productId": {
                     "type": "integer"
                 },
-                "createdAt": {
+                "quantity": {
+                    "description": "In the product's stock unit",
+                    "type": "number"
+                },
+                "unitCost": {
+                    "type": "number"
+                }
+            }
+        },
+        "requestModel.PurchaseOrderReceipt": {
+            "type": "object",
+            "properties": {
+                "amountPaid": {
+                    "description": "Optional payment made to the supplier on receipt, recorded as an expense detail",
+                    "type": "number"
+                },
+                "billNumber": {
                     "type": "string"
                 },
-                "createdBy": {
-                    "description": "Transient fields for response mapping, not stored in DB",
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/requestModel.PurchaseOrderReceiptLine"
+                    }
+                },
+                "notes": {
                     "type": "string"
                 },
-                "createdById": {
+                "paymentSource": {
+                    "type": "string"
+                },
+                "receivedDate": {
+                    "description": "Defaults to now",
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.PurchaseOrderReceiptLine": {
+            "type": "object",
+            "required": [
+                "lineId"
+            ],
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "lineId": {
                     "type": "integer"
                 },
-                "department": {
+                "lotCode": {
+                    "description": "Lot the received stock is booked as",
                     "type": "string"
                 },
-                "email": {
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "requestModel.Sale": {
+            "type": "object",
+            "required": [
+                "paymentMode"
+            ],
+            "properties": {
+                "customerId": {
+                    "type": "integer"
+                },
+                "discount": {
+                    "description": "Taken off the whole bill",
+                    "type": "number"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/requestModel.SaleLine"
+                    }
+                },
+                "notes": {
                     "type": "string"
                 },
-                "experience": {
+                "paymentMode": {
+                    "description": "CASH, UPI, CARD or BANK",
                     "type": "string"
                 },
-                "extension": {
+                "paymentReference": {
                     "type": "string"
+                }
+            }
+        },
+        "requestModel.SaleLine": {
+            "type": "object",
+            "required": [
+                "productId"
+            ],
+            "properties": {
+                "discount": {
+                    "description": "Taken off this line only",
+                    "type": "number"
                 },
-                "firstName": {
+                "productId": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "In the product's stock unit",
+                    "type": "number"
+                },
+                "sellingPrice": {
+                    "description": "Defaults to the product's selling price",
+                    "type": "number"
+                }
+            }
+        },
+        "requestModel.Status": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
                     "type": "string"
                 },
-                "isActive": {
+                "statusReason": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.StockMovementRequest": {
+            "type": "object",
+            "required": [
+                "changeType",
+                "productId",
+                "quantity",
+                "reason"
+            ],
+            "properties": {
+                "adminOverride": {
+                    "description": "Allow OUT even if stock insufficient",
                     "type": "boolean"
                 },
-                "isLoggedIn": {
-                    "type": "boolean"
+                "changeType": {
+                    "description": "IN, OUT, ADJUST",
+                    "type": "string"
                 },
-                "isLoginDisabled": {
-                    "type": "boolean"
+                "expiresAt": {
+                    "type": "string"
                 },
-                "lastLoginTime": {
+                "idempotencyKey": {
+                    "description": "Taken from the Idempotency-Key header when present",
                     "type": "string"
                 },
-                "lastName": {
+                "lotCode": {
+                    "description": "Incoming stock opens a lot with these details",
                     "type": "string"
                 },
-                "loginFailureCounter": {
+                "lotId": {
+                    "description": "Outgoing stock is taken from the oldest lots first unless a lot is given. SingleLot takes the\nwhole quantity from one lot, so that all the fabric for a garment has the same dye lot.\nA positive ADJUST with a lot puts the stock back into that lot.",
                     "type": "integer"
                 },
-                "phoneNumber": {
+                "notes": {
                     "type": "string"
                 },
-                "resetPasswordString": {
+                "orderItemId": {
+                    "description": "Order item the stock is consumed for",
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "reason": {
                     "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/entities.RoleType"
+                "singleLot": {
+                    "type": "boolean"
                 },
-                "updatedAt": {
+                "supplierId": {
+                    "description": "Supplier the lot came from",
+                    "type": "integer"
+                },
+                "unit": {
+                    "description": "Unit the quantity is given in, converted to the product's stock unit (1 ROLL = 40 METER); defaults to the stock unit",
                     "type": "string"
                 },
-                "updatedBy": {
+                "unitCost": {
+                    "description": "IN only, per unit the quantity is given in, defaults to the product's cost price",
+                    "type": "number"
+                }
+            }
+        },
+        "requestModel.StockTake": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "description": "Count all products when not given",
+                    "type": "integer"
+                },
+                "notes": {
                     "type": "string"
+                }
+            }
+        },
+        "requestModel.StockTakeCount": {
+            "type": "object",
+            "required": [
+                "productId"
+            ],
+            "properties": {
+                "countedQuantity": {
+                    "type": "number"
                 },
-                "updatedById": {
+                "productId": {
                     "type": "integer"
-                },
-                "userChannelDetails": {
-                    "description": "References",
+                }
+            }
+        },
+        "requestModel.StockTakePost": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "requestModel.StockTransfer": {
+            "type": "object",
+            "required": [
+                "toChannelId"
+            ],
+            "properties": {
+                "lines": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entities.UserChannelDetail"
+                        "$ref": "#/definitions/requestModel.StockTransferLine"
                     }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "toChannelId": {
+                    "type": "integer"
                 }
             }
         },
-        "entities.UserChannelDetail": {
+        "requestModel.StockTransferLine": {
             "type": "object",
+            "required": [
+                "productId"
+            ],
             "properties": {
-                "ID": {
-                    "type": "integer"
-                },
-                "UserID": {
-                    "description": "References",
+                "productId": {
                     "type": "integer"
                 },
-                "channel": {
-                    "$ref": "#/definitions/entities.Channel"
+                "quantity": {
+                    "description": "In the product's stock unit",
+                    "type": "number"
+                }
+            }
+        },
+        "requestModel.Supplier": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
                 },
-                "channelId": {
-                    "description": "Channel Id must be create only since it will interfere with update operations\nUse tx.Exec as raw query to update the channelId as done in channel after-create hook",
-                    "type": "integer"
+                "contactPerson": {
+                    "type": "string"
                 },
-                "createdAt": {
+                "email": {
                     "type": "string"
                 },
-                "createdBy": {
-                    "description": "Transient fields for response mapping, not stored in DB",
+                "gstin": {
                     "type": "string"
                 },
-                "createdById": {
+                "id": {
                     "type": "integer"
                 },
                 "isActive": {
                     "type": "boolean"
                 },
-                "updatedAt": {
+                "name": {
                     "type": "string"
                 },
-                "updatedBy": {
+                "notes": {
                     "type": "string"
                 },
-                "updatedById": {
-                    "type": "integer"
-                },
-                "userChannelId": {
-                    "description": "Refers to channelId",
-                    "type": "integer"
+                "phoneNumber": {
+                    "type": "string"
                 }
             }
         },
-        "entities.UserConfig": {
+        "requestModel.SwitchBranch": {
             "type": "object",
             "properties": {
-                "ID": {
+                "enquiryId": {
                     "type": "integer"
                 },
-                "UserID": {
-                    "description": "References",
+                "studentId": {
                     "type": "integer"
                 },
-                "channelId": {
-                    "description": "Channel Id must be create only since it will interfere with update operations\nUse tx.Exec as raw query to update the channelId as done in channel after-create hook",
+                "toChannel": {
+                    "type": "integer"
+                }
+            }
+        },
+        "requestModel.Task": {
+            "type": "object",
+            "properties": {
+                "assignedToId": {
                     "type": "integer"
                 },
-                "config": {
+                "completedAt": {
                     "type": "string"
                 },
-                "createdAt": {
+                "description": {
                     "type": "string"
                 },
-                "createdBy": {
-                    "description": "Transient fields for response mapping, not stored in DB",
+                "dueDate": {
                     "type": "string"
                 },
-                "createdById": {
+                "id": {
                     "type": "integer"
                 },
                 "isActive": {
                     "type": "boolean"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "priority": {
+                    "type": "integer"
                 },
-                "updatedBy": {
+                "reminderDate": {
                     "type": "string"
                 },
-                "updatedById": {
-                    "type": "integer"
-                }
-            }
-        },
-        "requestModel.BulkMeasurementItem": {
-            "type": "object",
-            "properties": {
-                "dressTypeId": {
-                    "type": "integer"
+                "status": {
+                    "description": "PENDING, IN_PROGRESS, COMPLETED, CANCELLED",
+                    "type": "string"
                 },
-                "values": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "requestModel.BulkMeasurementRequest": {
+        "requestModel.UpdateEnquiryAndCustomer": {
             "type": "object",
             "properties": {
-                "measurements": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/requestModel.BulkMeasurementItem"
-                    }
+                "address": {
+                    "type": "string"
                 },
-                "personId": {
-                    "type": "integer"
-                }
-            }
-        },
-        "requestModel.Category": {
-            "type": "object",
-            "properties": {
-                "id": {
+                "customerId": {
                     "type": "integer"
                 },
-                "isActive": {
+                "customerIsActive": {
+                    "description": "Customer fields",
                     "type": "boolean"
                 },
-                "name": {
+                "email": {
                     "type": "string"
-                }
-            }
-        },
-        "requestModel.Channel": {
-            "type": "object",
-            "properties": {
+                },
+                "firstName": {
+                    "type": "string"
+                },
                 "id": {
+                    "description": "Enquiry fields",
                     "type": "integer"
                 },
                 "isActive": {
                     "type": "boolean"
                 },
-                "name": {
+                "lastName": {
                     "type": "string"
                 },
-                "ownerUserId": {
-                    "type": "integer"
+                "notes": {
+                    "type": "string"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                },
+                "referredBy": {
+                    "type": "string"
+                },
+                "referrerPhoneNumber": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
                 },
                 "status": {
                     "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "whatsappNumber": {
+                    "type": "string"
                 }
             }
         },
-        "requestModel.Customer": {
+        "requestModel.User": {
             "type": "object",
             "properties": {
-                "address": {
+                "department": {
                     "type": "string"
                 },
-                "age": {
-                    "type": "integer"
-                },
                 "email": {
                     "type": "string"
                 },
-                "firstName": {
+                "experience": {
                     "type": "string"
                 },
-                "gender": {
+                "extension": {
+                    "type": "string"
+                },
+                "firstName": {
                     "type": "string"
                 },
                 "id": {
@@ -5102,21 +8577,62 @@ const docTemplate = `{
                 "isActive": {
                     "type": "boolean"
                 },
+                "isLoggedIn": {
+                    "type": "boolean"
+                },
+                "isLoginDisabled": {
+                    "type": "boolean"
+                },
+                "lastLoginTime": {
+                    "type": "string"
+                },
                 "lastName": {
                     "type": "string"
                 },
+                "loginFailureCounter": {
+                    "type": "integer"
+                },
+                "password": {
+                    "type": "string"
+                },
                 "phoneNumber": {
                     "type": "string"
                 },
-                "whatsappNumber": {
+                "resetPasswordString": {
+                    "type": "string"
+                },
+                "role": {
                     "type": "string"
+                },
+                "userChannelDetails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/requestModel.UserChannelDetail"
+                    }
                 }
             }
         },
-        "requestModel.DressType": {
+        "requestModel.UserChannelDetail": {
             "type": "object",
             "properties": {
-                "description": {
+                "channelID": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "requestModel.UserConfig": {
+            "type": "object",
+            "properties": {
+                "config": {
                     "type": "string"
                 },
                 "id": {
@@ -5125,87 +8641,111 @@ const docTemplate = `{
                 "isActive": {
                     "type": "boolean"
                 },
-                "measurements": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
+                "userID": {
+                    "type": "integer"
                 }
             }
         },
-        "requestModel.Enquiry": {
+        "responseModel.AssigneeTaskCount": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
+                "incomplete": {
+                    "type": "integer"
                 },
-                "customerId": {
+                "userId": {
                     "type": "integer"
                 },
-                "email": {
+                "userName": {
                     "type": "string"
-                },
-                "firstName": {
-                    "description": "Customer fields",
+                }
+            }
+        },
+        "responseModel.AuditFields": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "isActive": {
-                    "type": "boolean"
-                },
-                "lastName": {
+                "createdBy": {
                     "type": "string"
                 },
-                "notes": {
+                "updatedAt": {
                     "type": "string"
                 },
-                "phoneNumber": {
+                "updatedBy": {
                     "type": "string"
-                },
-                "referredBy": {
+                }
+            }
+        },
+        "responseModel.COGSReport": {
+            "type": "object",
+            "properties": {
+                "costingMethod": {
                     "type": "string"
                 },
-                "referrerPhoneNumber": {
+                "from": {
                     "type": "string"
                 },
-                "source": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.COGSReportItem"
+                    }
                 },
-                "status": {
+                "to": {
                     "type": "string"
                 },
-                "subject": {
-                    "type": "string"
+                "totalCost": {
+                    "type": "number"
                 },
-                "whatsappNumber": {
-                    "type": "string"
+                "totalWriteOff": {
+                    "type": "number"
                 }
             }
         },
-        "requestModel.EnquiryHistory": {
+        "responseModel.COGSReportItem": {
             "type": "object",
             "properties": {
-                "action": {
-                    "type": "string"
+                "costOfGoods": {
+                    "type": "number"
                 },
-                "callBackDate": {
-                    "type": "string"
+                "productId": {
+                    "type": "integer"
                 },
-                "customerComment": {
+                "productName": {
                     "type": "string"
                 },
-                "employeeComment": {
+                "productSku": {
                     "type": "string"
                 },
-                "employeeId": {
-                    "type": "integer"
+                "quantityConsumed": {
+                    "type": "number"
                 },
-                "enquiryDate": {
+                "quantityWrittenOff": {
+                    "type": "number"
+                },
+                "unit": {
                     "type": "string"
                 },
-                "enquiryId": {
+                "writeOffCost": {
+                    "type": "number"
+                }
+            }
+        },
+        "responseModel.Category": {
+            "type": "object",
+            "properties": {
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
+                },
+                "children": {
+                    "description": "Filled in the category tree",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.Category"
+                    }
+                },
+                "depth": {
                     "type": "integer"
                 },
                 "id": {
@@ -5214,180 +8754,207 @@ const docTemplate = `{
                 "isActive": {
                     "type": "boolean"
                 },
-                "performedAt": {
+                "name": {
                     "type": "string"
                 },
-                "performedById": {
+                "parentId": {
                     "type": "integer"
                 },
-                "responseStatus": {
-                    "type": "string"
-                },
-                "status": {
+                "path": {
+                    "description": "Names from the root, e.g. Fabrics \u003e Silk \u003e Kanchipuram",
                     "type": "string"
                 },
-                "visitingDate": {
-                    "type": "string"
+                "productCount": {
+                    "description": "Count of products in this category",
+                    "type": "integer"
                 }
             }
         },
-        "requestModel.ExpenseDetail": {
+        "responseModel.CategoryAutoComplete": {
             "type": "object",
             "properties": {
-                "expenseId": {
-                    "type": "integer"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "isActive": {
-                    "type": "boolean"
-                },
-                "price": {
-                    "type": "number"
+                "name": {
+                    "type": "string"
                 },
-                "source": {
+                "path": {
                     "type": "string"
                 }
             }
         },
-        "requestModel.ExpenseTracker": {
+        "responseModel.Channel": {
             "type": "object",
             "properties": {
-                "balance": {
-                    "type": "number"
+                "ID": {
+                    "type": "integer"
                 },
-                "billNumber": {
+                "channelId": {
+                    "description": "Channel Id must be create only since it will interfere with update operations\nUse tx.Exec as raw query to update the channelId as done in channel after-create hook",
+                    "type": "integer"
+                },
+                "channelOwnerFirstName": {
                     "type": "string"
                 },
-                "companyName": {
+                "channelOwnerLastName": {
                     "type": "string"
                 },
-                "expenseDetails": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/requestModel.ExpenseDetail"
-                    }
+                "createdAt": {
+                    "type": "string"
                 },
-                "id": {
+                "createdBy": {
+                    "description": "Transient fields for response mapping, not stored in DB",
+                    "type": "string"
+                },
+                "createdById": {
                     "type": "integer"
                 },
+                "email": {
+                    "type": "string"
+                },
+                "gstin": {
+                    "description": "GST registration details, State is used to decide the place of supply",
+                    "type": "string"
+                },
                 "isActive": {
                     "type": "boolean"
                 },
-                "location": {
+                "name": {
                     "type": "string"
                 },
-                "material": {
+                "ownerUserId": {
+                    "description": "Reference",
+                    "type": "integer"
+                },
+                "phoneNumber": {
                     "type": "string"
                 },
-                "notes": {
+                "state": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "status": {
+                    "type": "string"
                 },
-                "purchaseDate": {
+                "updatedAt": {
                     "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                },
+                "updatedById": {
+                    "type": "integer"
                 }
             }
         },
-        "requestModel.Inventory": {
+        "responseModel.ChannelAutoComplete": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
-                },
-                "isActive": {
-                    "type": "boolean"
-                },
-                "lowStockThreshold": {
+                "channelId": {
                     "type": "integer"
                 },
-                "productId": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "requestModel.Login": {
+        "responseModel.CompletionRateStat": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "last30Days": {
+                    "$ref": "#/definitions/responseModel.CompletionRateWindow"
                 },
-                "password": {
-                    "type": "string"
+                "last7Days": {
+                    "$ref": "#/definitions/responseModel.CompletionRateWindow"
                 }
             }
         },
-        "requestModel.MasterConfig": {
+        "responseModel.CompletionRateWindow": {
             "type": "object",
             "properties": {
-                "currentValue": {
-                    "type": "string"
+                "completed": {
+                    "type": "integer"
                 },
-                "defaultValue": {
-                    "type": "string"
+                "percent": {
+                    "type": "number"
                 },
-                "description": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "responseModel.ConsolidatedStock": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.ConsolidatedStockChannel"
+                    }
                 },
-                "format": {
-                    "type": "string"
+                "inTransitQuantity": {
+                    "description": "Dispatched to one of the channels, not received yet",
+                    "type": "number"
                 },
-                "id": {
+                "productId": {
                     "type": "integer"
                 },
-                "isActive": {
-                    "type": "boolean"
-                },
-                "name": {
+                "productName": {
                     "type": "string"
                 },
-                "previousValue": {
+                "productSku": {
                     "type": "string"
                 },
-                "type": {
-                    "type": "string"
+                "totalQuantity": {
+                    "type": "number"
                 },
-                "useDefault": {
-                    "type": "boolean"
+                "unit": {
+                    "type": "string"
                 }
             }
         },
-        "requestModel.Measurement": {
+        "responseModel.ConsolidatedStockChannel": {
             "type": "object",
             "properties": {
-                "dressTypeId": {
-                    "type": "integer"
+                "availableQuantity": {
+                    "type": "number"
                 },
-                "id": {
+                "channelId": {
                     "type": "integer"
                 },
-                "isActive": {
-                    "type": "boolean"
+                "channelName": {
+                    "type": "string"
                 },
-                "personId": {
-                    "type": "integer"
+                "isLowStock": {
+                    "type": "boolean"
                 },
-                "takenById": {
-                    "type": "integer"
+                "lowStockThreshold": {
+                    "type": "number"
                 },
-                "values": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "quantity": {
+                    "type": "number"
                 }
             }
         },
-        "requestModel.MeasurementHistory": {
+        "responseModel.Customer": {
             "type": "object",
             "properties": {
-                "action": {
+                "address": {
                     "type": "string"
                 },
-                "changedValues": {
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "enquiries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.Enquiry"
+                    }
+                },
+                "firstName": {
                     "type": "string"
                 },
                 "id": {
@@ -5396,72 +8963,76 @@ const docTemplate = `{
                 "isActive": {
                     "type": "boolean"
                 },
-                "measurementId": {
-                    "type": "integer"
+                "lastName": {
+                    "type": "string"
                 },
-                "oldValues": {
-                    "description": "JSON string",
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.Order"
+                    }
+                },
+                "persons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.Person"
+                    }
+                },
+                "phoneNumber": {
                     "type": "string"
                 },
-                "performedAt": {
+                "state": {
                     "type": "string"
                 },
-                "performedById": {
-                    "type": "integer"
+                "whatsappNumber": {
+                    "type": "string"
                 }
             }
         },
-        "requestModel.Order": {
+        "responseModel.CustomerAutoComplete": {
             "type": "object",
             "properties": {
-                "additionalCharges": {
-                    "type": "number"
-                },
                 "customerId": {
                     "type": "integer"
                 },
-                "deliveredDate": {
+                "email": {
                     "type": "string"
                 },
-                "expectedDeliveryDate": {
+                "firstName": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "isActive": {
-                    "type": "boolean"
-                },
-                "notes": {
+                "lastName": {
                     "type": "string"
                 },
-                "orderItems": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/requestModel.OrderItem"
-                    }
-                },
-                "orderTakenById": {
-                    "type": "integer"
-                },
-                "status": {
+                "phoneNumber": {
                     "type": "string"
                 }
             }
         },
-        "requestModel.OrderHistory": {
+        "responseModel.DataResponse": {
             "type": "object",
             "properties": {
-                "action": {
+                "data": {},
+                "error": {},
+                "message": {
                     "type": "string"
                 },
-                "changedFields": {
+                "pageMetaData": {},
+                "type": {
                     "type": "string"
+                }
+            }
+        },
+        "responseModel.DressType": {
+            "type": "object",
+            "properties": {
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "deliveredDate": {
+                "description": {
                     "type": "string"
                 },
-                "expectedDeliveryDate": {
+                "hsnCode": {
                     "type": "string"
                 },
                 "id": {
@@ -5470,281 +9041,301 @@ const docTemplate = `{
                 "isActive": {
                     "type": "boolean"
                 },
-                "orderId": {
-                    "type": "integer"
+                "measurementFields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.MeasurementField"
+                    }
                 },
-                "orderItemData": {
-                    "description": "JSON string",
+                "measurements": {
                     "type": "string"
                 },
-                "orderItemId": {
-                    "type": "integer"
-                },
-                "performedAt": {
+                "name": {
                     "type": "string"
                 },
-                "performedById": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "string"
+                "taxRate": {
+                    "type": "number"
                 }
             }
         },
-        "requestModel.OrderItem": {
+        "responseModel.DressTypeComponent": {
             "type": "object",
             "properties": {
-                "additionalCharges": {
-                    "type": "number"
-                },
-                "deliveredDate": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
                 },
                 "dressTypeId": {
                     "type": "integer"
                 },
-                "expectedDeliveryDate": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "isActive": {
-                    "type": "boolean"
-                },
-                "measurementId": {
-                    "type": "integer"
+                "notes": {
+                    "type": "string"
                 },
-                "orderId": {
+                "productId": {
                     "type": "integer"
                 },
-                "personId": {
-                    "type": "integer"
+                "productName": {
+                    "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "productSku": {
+                    "type": "string"
                 },
                 "quantity": {
-                    "type": "integer"
-                },
-                "total": {
                     "type": "number"
+                },
+                "singleLot": {
+                    "type": "boolean"
                 }
             }
         },
-        "requestModel.Person": {
+        "responseModel.Enquiry": {
             "type": "object",
             "properties": {
-                "age": {
-                    "type": "integer"
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
+                },
+                "customer": {
+                    "$ref": "#/definitions/responseModel.Customer"
                 },
                 "customerId": {
                     "type": "integer"
                 },
-                "firstName": {
-                    "type": "string"
-                },
-                "gender": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
                 "isActive": {
                     "type": "boolean"
                 },
-                "lastName": {
+                "notes": {
+                    "type": "string"
+                },
+                "referredBy": {
+                    "type": "string"
+                },
+                "referrerPhoneNumber": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subject": {
                     "type": "string"
                 }
             }
         },
-        "requestModel.Product": {
+        "responseModel.EnquiryConversionStat": {
             "type": "object",
             "properties": {
-                "categoryId": {
+                "enquiriesInPeriod": {
                     "type": "integer"
                 },
-                "costPrice": {
-                    "type": "number"
+                "ordersFromEnquiry": {
+                    "type": "integer"
+                }
+            }
+        },
+        "responseModel.EnquiryHistory": {
+            "type": "object",
+            "properties": {
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "description": {
+                "callBackDate": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "customerComment": {
+                    "type": "string"
                 },
-                "isActive": {
-                    "type": "boolean"
+                "employee": {
+                    "$ref": "#/definitions/responseModel.User"
                 },
-                "lowStockThreshold": {
+                "employeeComment": {
+                    "type": "string"
+                },
+                "employeeId": {
                     "type": "integer"
                 },
-                "name": {
+                "enquiryDate": {
                     "type": "string"
                 },
-                "sellingPrice": {
-                    "type": "number"
+                "enquiryId": {
+                    "type": "integer"
                 },
-                "sku": {
-                    "type": "string"
-                }
-            }
-        },
-        "requestModel.StockMovementRequest": {
-            "type": "object",
-            "required": [
-                "changeType",
-                "productId",
-                "quantity",
-                "reason"
-            ],
-            "properties": {
-                "adminOverride": {
-                    "description": "Allow OUT even if stock insufficient",
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
                     "type": "boolean"
                 },
-                "changeType": {
-                    "description": "IN, OUT, ADJUST",
+                "performedAt": {
                     "type": "string"
                 },
-                "notes": {
-                    "type": "string"
+                "performedBy": {
+                    "$ref": "#/definitions/responseModel.User"
                 },
-                "productId": {
+                "performedById": {
                     "type": "integer"
                 },
-                "quantity": {
-                    "type": "integer"
+                "responseStatus": {
+                    "type": "string"
                 },
-                "reason": {
+                "status": {
+                    "type": "string"
+                },
+                "visitingDate": {
                     "type": "string"
                 }
             }
         },
-        "requestModel.SwitchBranch": {
+        "responseModel.ExpenseDetail": {
             "type": "object",
             "properties": {
-                "enquiryId": {
-                    "type": "integer"
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "studentId": {
+                "expenseId": {
                     "type": "integer"
                 },
-                "toChannel": {
+                "id": {
                     "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "source": {
+                    "type": "string"
                 }
             }
         },
-        "requestModel.Task": {
+        "responseModel.ExpenseTracker": {
             "type": "object",
             "properties": {
-                "assignedToId": {
-                    "type": "integer"
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "completedAt": {
-                    "type": "string"
+                "balance": {
+                    "type": "number"
                 },
-                "description": {
+                "billNumber": {
                     "type": "string"
                 },
-                "dueDate": {
+                "companyName": {
                     "type": "string"
                 },
+                "expenseDetails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.ExpenseDetail"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
                 "isActive": {
                     "type": "boolean"
                 },
-                "priority": {
-                    "type": "integer"
-                },
-                "reminderDate": {
+                "location": {
                     "type": "string"
                 },
-                "status": {
-                    "description": "PENDING, IN_PROGRESS, COMPLETED, CANCELLED",
+                "material": {
                     "type": "string"
                 },
-                "title": {
+                "notes": {
                     "type": "string"
-                }
-            }
-        },
-        "requestModel.UpdateEnquiryAndCustomer": {
-            "type": "object",
-            "properties": {
-                "address": {
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchaseDate": {
                     "type": "string"
                 },
-                "customerId": {
+                "purchaseOrderId": {
                     "type": "integer"
                 },
-                "customerIsActive": {
-                    "description": "Customer fields",
-                    "type": "boolean"
+                "supplierId": {
+                    "type": "integer"
                 },
-                "email": {
+                "supplierName": {
                     "type": "string"
+                }
+            }
+        },
+        "responseModel.Inventory": {
+            "type": "object",
+            "properties": {
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "firstName": {
-                    "type": "string"
+                "availableQuantity": {
+                    "description": "On hand less reserved",
+                    "type": "number"
                 },
                 "id": {
-                    "description": "Enquiry fields",
                     "type": "integer"
                 },
                 "isActive": {
                     "type": "boolean"
                 },
-                "lastName": {
-                    "type": "string"
+                "isLowStock": {
+                    "type": "boolean"
                 },
-                "notes": {
-                    "type": "string"
+                "lowStockThreshold": {
+                    "type": "number"
                 },
-                "phoneNumber": {
-                    "type": "string"
+                "product": {
+                    "description": "Related data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/responseModel.Product"
+                        }
+                    ]
                 },
-                "referredBy": {
-                    "type": "string"
+                "productId": {
+                    "type": "integer"
                 },
-                "referrerPhoneNumber": {
+                "productName": {
                     "type": "string"
                 },
-                "source": {
+                "productSku": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "string"
+                "quantity": {
+                    "type": "number"
                 },
-                "subject": {
+                "reservedQuantity": {
+                    "description": "Held back for confirmed orders",
+                    "type": "number"
+                },
+                "unit": {
+                    "description": "Stock unit of the product",
                     "type": "string"
                 },
-                "whatsappNumber": {
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "requestModel.User": {
+        "responseModel.InventoryLog": {
             "type": "object",
             "properties": {
-                "department": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "experience": {
+                "changeType": {
                     "type": "string"
                 },
-                "extension": {
-                    "type": "string"
+                "enteredQuantity": {
+                    "type": "number"
                 },
-                "firstName": {
+                "enteredUnit": {
                     "type": "string"
                 },
                 "id": {
@@ -5753,46 +9344,84 @@ const docTemplate = `{
                 "isActive": {
                     "type": "boolean"
                 },
-                "isLoggedIn": {
-                    "type": "boolean"
-                },
-                "isLoginDisabled": {
-                    "type": "boolean"
+                "loggedAt": {
+                    "type": "string"
                 },
-                "lastLoginTime": {
+                "loggedByName": {
+                    "description": "User who logged",
                     "type": "string"
                 },
-                "lastName": {
+                "lotCode": {
                     "type": "string"
                 },
-                "loginFailureCounter": {
+                "lotId": {
                     "type": "integer"
                 },
-                "password": {
+                "netChange": {
+                    "description": "e.g. \"0(+20)\" = 0 before, +20 added; \"20(-5)\" = 20 before, -5",
                     "type": "string"
                 },
-                "phoneNumber": {
+                "notes": {
                     "type": "string"
                 },
-                "resetPasswordString": {
+                "orderId": {
+                    "type": "integer"
+                },
+                "orderItemId": {
+                    "type": "integer"
+                },
+                "product": {
+                    "description": "Related data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/responseModel.Product"
+                        }
+                    ]
+                },
+                "productId": {
+                    "type": "integer"
+                },
+                "productName": {
                     "type": "string"
                 },
-                "role": {
+                "productSku": {
                     "type": "string"
                 },
-                "userChannelDetails": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/requestModel.UserChannelDetail"
-                    }
+                "purchaseOrderId": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reversalOfLogId": {
+                    "type": "integer"
+                },
+                "reversedByLogId": {
+                    "type": "integer"
+                },
+                "saleId": {
+                    "type": "integer"
+                },
+                "stockAfter": {
+                    "description": "Stock quantity after this movement",
+                    "type": "number"
+                },
+                "stockTransferId": {
+                    "type": "integer"
+                },
+                "unitCost": {
+                    "type": "number"
                 }
             }
         },
-        "requestModel.UserChannelDetail": {
+        "responseModel.InventoryLot": {
             "type": "object",
             "properties": {
-                "channelID": {
-                    "type": "integer"
+                "expiresAt": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
@@ -5800,134 +9429,211 @@ const docTemplate = `{
                 "isActive": {
                     "type": "boolean"
                 },
-                "userID": {
+                "isExpired": {
+                    "type": "boolean"
+                },
+                "lotCode": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "receivedAt": {
+                    "type": "string"
+                },
+                "remainingQuantity": {
+                    "type": "number"
+                },
+                "supplierId": {
                     "type": "integer"
+                },
+                "supplierName": {
+                    "type": "string"
                 }
             }
         },
-        "requestModel.UserConfig": {
+        "responseModel.InventoryValuation": {
             "type": "object",
             "properties": {
-                "config": {
+                "asOf": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "costingMethod": {
+                    "type": "string"
                 },
-                "isActive": {
-                    "type": "boolean"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.InventoryValuationItem"
+                    }
                 },
-                "userID": {
-                    "type": "integer"
+                "totalValue": {
+                    "type": "number"
                 }
             }
         },
-        "responseModel.AssigneeTaskCount": {
+        "responseModel.InventoryValuationItem": {
             "type": "object",
             "properties": {
-                "incomplete": {
+                "productId": {
                     "type": "integer"
                 },
-                "userId": {
-                    "type": "integer"
+                "productName": {
+                    "type": "string"
                 },
-                "userName": {
+                "productSku": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit": {
                     "type": "string"
+                },
+                "unitCost": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
                 }
             }
         },
-        "responseModel.AuditFields": {
+        "responseModel.LowStockItem": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "availableQuantity": {
+                    "type": "number"
+                },
+                "categoryName": {
+                    "type": "string"
+                },
+                "currentStock": {
+                    "type": "number"
+                },
+                "lowStockThreshold": {
+                    "type": "number"
+                },
+                "parentProductId": {
+                    "description": "Set on variants, items are grouped by it",
+                    "type": "integer"
+                },
+                "parentProductName": {
                     "type": "string"
                 },
-                "createdBy": {
+                "productId": {
+                    "type": "integer"
+                },
+                "productName": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "productSku": {
                     "type": "string"
                 },
-                "updatedBy": {
+                "reservedQuantity": {
+                    "type": "number"
+                },
+                "unit": {
                     "type": "string"
                 }
             }
         },
-        "responseModel.Category": {
+        "responseModel.Measurement": {
             "type": "object",
             "properties": {
                 "auditFields": {
                     "$ref": "#/definitions/responseModel.AuditFields"
                 },
+                "capturedUnit": {
+                    "description": "Unit the values were taken in",
+                    "type": "string"
+                },
+                "dressType": {
+                    "$ref": "#/definitions/responseModel.DressType"
+                },
+                "dressTypeId": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "isActive": {
                     "type": "boolean"
                 },
-                "name": {
-                    "type": "string"
+                "person": {
+                    "$ref": "#/definitions/responseModel.Person"
                 },
-                "productCount": {
-                    "description": "Count of products in this category",
+                "personId": {
                     "type": "integer"
-                }
-            }
-        },
-        "responseModel.CategoryAutoComplete": {
-            "type": "object",
-            "properties": {
-                "id": {
+                },
+                "personName": {
+                    "type": "string"
+                },
+                "takenBy": {
+                    "description": "first_name + last_name",
+                    "type": "string"
+                },
+                "takenById": {
                     "type": "integer"
                 },
-                "name": {
+                "unit": {
+                    "description": "Unit the values are shown in",
                     "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "responseModel.Channel": {
+        "responseModel.MeasurementAnomaly": {
             "type": "object",
             "properties": {
-                "ID": {
-                    "type": "integer"
-                },
-                "channelId": {
-                    "description": "Channel Id must be create only since it will interfere with update operations\nUse tx.Exec as raw query to update the channelId as done in channel after-create hook",
+                "dressTypeId": {
                     "type": "integer"
                 },
-                "channelOwnerFirstName": {
+                "field": {
                     "type": "string"
                 },
-                "channelOwnerLastName": {
+                "kind": {
+                    "description": "JUMP, OUT_OF_RANGE, RULE",
                     "type": "string"
                 },
-                "createdAt": {
-                    "type": "string"
+                "measurementId": {
+                    "type": "integer"
                 },
-                "createdBy": {
-                    "description": "Transient fields for response mapping, not stored in DB",
+                "message": {
                     "type": "string"
                 },
-                "createdById": {
+                "personId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "responseModel.MeasurementBrowse": {
+            "type": "object",
+            "properties": {
+                "customerId": {
                     "type": "integer"
                 },
-                "email": {
+                "dressTypes": {
+                    "description": "CSV of DressType names",
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "isActive": {
                     "type": "boolean"
                 },
-                "name": {
-                    "type": "string"
-                },
-                "ownerUserId": {
-                    "description": "Reference",
+                "personId": {
                     "type": "integer"
                 },
-                "phoneNumber": {
-                    "type": "string"
-                },
-                "status": {
+                "personName": {
                     "type": "string"
                 },
                 "updatedAt": {
@@ -5935,139 +9641,106 @@ const docTemplate = `{
                 },
                 "updatedBy": {
                     "type": "string"
-                },
-                "updatedById": {
-                    "type": "integer"
                 }
             }
         },
-        "responseModel.ChannelAutoComplete": {
+        "responseModel.MeasurementChange": {
             "type": "object",
             "properties": {
-                "channelId": {
-                    "type": "integer"
+                "delta": {
+                    "type": "number"
                 },
-                "name": {
+                "field": {
                     "type": "string"
-                }
-            }
-        },
-        "responseModel.CompletionRateStat": {
-            "type": "object",
-            "properties": {
-                "last30Days": {
-                    "$ref": "#/definitions/responseModel.CompletionRateWindow"
-                },
-                "last7Days": {
-                    "$ref": "#/definitions/responseModel.CompletionRateWindow"
-                }
-            }
-        },
-        "responseModel.CompletionRateWindow": {
-            "type": "object",
-            "properties": {
-                "completed": {
-                    "type": "integer"
                 },
-                "percent": {
+                "from": {
                     "type": "number"
                 },
-                "total": {
-                    "type": "integer"
+                "label": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "ADDED, REMOVED, CHANGED, UNCHANGED",
+                    "type": "string"
+                },
+                "to": {
+                    "type": "number"
                 }
             }
         },
-        "responseModel.Customer": {
+        "responseModel.MeasurementDiff": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "auditFields": {
-                    "$ref": "#/definitions/responseModel.AuditFields"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "enquiries": {
+                "changes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/responseModel.Enquiry"
+                        "$ref": "#/definitions/responseModel.MeasurementChange"
                     }
                 },
-                "firstName": {
-                    "type": "string"
-                },
-                "id": {
+                "fromHistoryId": {
+                    "description": "Not set for the current values",
                     "type": "integer"
                 },
-                "isActive": {
-                    "type": "boolean"
-                },
-                "lastName": {
+                "fromReplacedAt": {
+                    "description": "When the from version was changed",
                     "type": "string"
                 },
-                "orders": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/responseModel.Order"
-                    }
+                "measurementId": {
+                    "type": "integer"
                 },
-                "persons": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/responseModel.Person"
-                    }
+                "toHistoryId": {
+                    "type": "integer"
                 },
-                "phoneNumber": {
+                "toReplacedAt": {
                     "type": "string"
                 },
-                "whatsappNumber": {
+                "unit": {
                     "type": "string"
                 }
             }
         },
-        "responseModel.CustomerAutoComplete": {
+        "responseModel.MeasurementField": {
             "type": "object",
             "properties": {
-                "customerId": {
+                "displayOrder": {
                     "type": "integer"
                 },
-                "email": {
-                    "type": "string"
+                "dressTypeId": {
+                    "type": "integer"
                 },
-                "firstName": {
+                "groupName": {
                     "type": "string"
                 },
-                "lastName": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "phoneNumber": {
+                "key": {
                     "type": "string"
-                }
-            }
-        },
-        "responseModel.DataResponse": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "error": {},
-                "message": {
+                },
+                "label": {
                     "type": "string"
                 },
-                "pageMetaData": {},
-                "type": {
+                "maxValue": {
+                    "type": "number"
+                },
+                "minValue": {
+                    "type": "number"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "unit": {
                     "type": "string"
                 }
             }
         },
-        "responseModel.DressType": {
+        "responseModel.MeasurementFlag": {
             "type": "object",
             "properties": {
                 "auditFields": {
                     "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "description": {
+                "field": {
                     "type": "string"
                 },
                 "id": {
@@ -6076,25 +9749,48 @@ const docTemplate = `{
                 "isActive": {
                     "type": "boolean"
                 },
-                "measurements": {
+                "kind": {
                     "type": "string"
                 },
-                "name": {
+                "measurement": {
+                    "$ref": "#/definitions/responseModel.Measurement"
+                },
+                "measurementId": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "reviewNote": {
+                    "type": "string"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewedBy": {
+                    "description": "first_name + last_name",
+                    "type": "string"
+                },
+                "reviewedById": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "OPEN, REVIEWED, SUPERSEDED",
                     "type": "string"
                 }
             }
         },
-        "responseModel.Enquiry": {
+        "responseModel.MeasurementHistory": {
             "type": "object",
             "properties": {
+                "action": {
+                    "type": "string"
+                },
                 "auditFields": {
                     "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "customer": {
-                    "$ref": "#/definitions/responseModel.Customer"
-                },
-                "customerId": {
-                    "type": "integer"
+                "changedValues": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
@@ -6102,363 +9798,469 @@ const docTemplate = `{
                 "isActive": {
                     "type": "boolean"
                 },
-                "notes": {
-                    "type": "string"
+                "measurement": {
+                    "$ref": "#/definitions/responseModel.Measurement"
                 },
-                "referredBy": {
-                    "type": "string"
+                "measurementId": {
+                    "type": "integer"
                 },
-                "referrerPhoneNumber": {
-                    "type": "string"
+                "oldValues": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "source": {
+                "performedAt": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "string"
+                "performedBy": {
+                    "$ref": "#/definitions/responseModel.User"
                 },
-                "subject": {
-                    "type": "string"
+                "performedById": {
+                    "type": "integer"
                 }
             }
         },
-        "responseModel.EnquiryConversionStat": {
+        "responseModel.MeasurementSnapshot": {
             "type": "object",
             "properties": {
-                "enquiriesInPeriod": {
-                    "type": "integer"
+                "changedSince": {
+                    "description": "The measurement no longer holds these values",
+                    "type": "boolean"
                 },
-                "ordersFromEnquiry": {
-                    "type": "integer"
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.MeasurementField"
+                    }
+                },
+                "measuredAt": {
+                    "description": "When the values were last changed before the snapshot",
+                    "type": "string"
+                },
+                "measurement": {
+                    "$ref": "#/definitions/responseModel.Measurement"
+                },
+                "takenAt": {
+                    "type": "string"
                 }
             }
         },
-        "responseModel.EnquiryHistory": {
+        "responseModel.Order": {
             "type": "object",
             "properties": {
+                "additionalCharges": {
+                    "type": "number"
+                },
                 "auditFields": {
                     "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "callBackDate": {
-                    "type": "string"
-                },
-                "customerComment": {
-                    "type": "string"
+                "balanceDue": {
+                    "description": "order value + tax + additional charges - paid amount",
+                    "type": "number"
                 },
-                "employee": {
-                    "$ref": "#/definitions/responseModel.User"
+                "cgstTotal": {
+                    "description": "sum of cgst amount from order items",
+                    "type": "number"
                 },
-                "employeeComment": {
-                    "type": "string"
+                "customer": {
+                    "$ref": "#/definitions/responseModel.Customer"
                 },
-                "employeeId": {
+                "customerId": {
                     "type": "integer"
                 },
-                "enquiryDate": {
+                "customerName": {
+                    "description": "first_name + last_name",
                     "type": "string"
                 },
-                "enquiryId": {
-                    "type": "integer"
+                "deliveredDate": {
+                    "type": "string"
+                },
+                "expectedDeliveryDate": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "igstTotal": {
+                    "description": "sum of igst amount from order items",
+                    "type": "number"
+                },
+                "invoiceNumber": {
+                    "type": "string"
+                },
+                "invoicedAt": {
+                    "type": "string"
+                },
                 "isActive": {
                     "type": "boolean"
                 },
-                "performedAt": {
+                "notes": {
                     "type": "string"
                 },
-                "performedBy": {
-                    "$ref": "#/definitions/responseModel.User"
+                "orderItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.OrderItem"
+                    }
                 },
-                "performedById": {
+                "orderQuantity": {
+                    "description": "sum of quantity from order items",
                     "type": "integer"
                 },
-                "responseStatus": {
+                "orderTakenBy": {
+                    "description": "first_name + last_name",
                     "type": "string"
                 },
+                "orderTakenById": {
+                    "type": "integer"
+                },
+                "orderValue": {
+                    "description": "sum of total from order items",
+                    "type": "number"
+                },
+                "paidAmount": {
+                    "description": "sum of amount from order payments",
+                    "type": "number"
+                },
+                "sgstTotal": {
+                    "description": "sum of sgst amount from order items",
+                    "type": "number"
+                },
                 "status": {
                     "type": "string"
                 },
-                "visitingDate": {
-                    "type": "string"
+                "taxTotal": {
+                    "description": "cgst + sgst + igst",
+                    "type": "number"
                 }
             }
         },
-        "responseModel.ExpenseDetail": {
+        "responseModel.OrderActivityItem": {
             "type": "object",
             "properties": {
-                "auditFields": {
-                    "$ref": "#/definitions/responseModel.AuditFields"
+                "action": {
+                    "type": "string"
                 },
-                "expenseId": {
-                    "type": "integer"
+                "changedFields": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "isActive": {
-                    "type": "boolean"
+                "orderId": {
+                    "type": "integer"
                 },
-                "price": {
-                    "type": "number"
+                "performedAt": {
+                    "type": "string"
                 },
-                "source": {
+                "performedBy": {
                     "type": "string"
                 }
             }
         },
-        "responseModel.ExpenseTracker": {
+        "responseModel.OrderDashboardList": {
             "type": "object",
             "properties": {
-                "auditFields": {
-                    "$ref": "#/definitions/responseModel.AuditFields"
+                "count": {
+                    "type": "integer"
                 },
-                "balance": {
-                    "type": "number"
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.OrderSummary"
+                    }
+                }
+            }
+        },
+        "responseModel.OrderDashboardResponse": {
+            "type": "object",
+            "properties": {
+                "deliveriesDueThisWeek": {
+                    "description": "by ExpectedDeliveryDate",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/responseModel.OrderDashboardList"
+                        }
+                    ]
                 },
-                "billNumber": {
-                    "type": "string"
+                "orderCountInPeriod": {
+                    "description": "last 7/30 days",
+                    "type": "integer"
                 },
-                "companyName": {
-                    "type": "string"
+                "ordersByStatus": {
+                    "description": "count per status DRAFT → DELIVERED, CANCELLED",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.StatusCountStat"
+                    }
                 },
-                "expenseDetails": {
+                "ordersByTakenBy": {
+                    "description": "count per OrderTakenById",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/responseModel.ExpenseDetail"
+                        "$ref": "#/definitions/responseModel.UserOrderCount"
+                    }
+                },
+                "overdueAtRiskOrders": {
+                    "description": "ExpectedDeliveryDate passed or soon, not DELIVERED",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/responseModel.OrderDashboardList"
+                        }
+                    ]
+                },
+                "recentDeliveries": {
+                    "description": "DeliveredDate last 7/30 days",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/responseModel.OrderDashboardList"
+                        }
+                    ]
+                },
+                "recentOrderActivity": {
+                    "description": "from OrderHistory",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.OrderActivityItem"
                     }
                 },
+                "revenueInPeriod": {
+                    "description": "sum OrderValue + AdditionalCharges in period",
+                    "type": "number"
+                }
+            }
+        },
+        "responseModel.OrderHistory": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "changedFields": {
+                    "type": "string"
+                },
+                "deliveredDate": {
+                    "type": "string"
+                },
+                "expectedDeliveryDate": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "isActive": {
                     "type": "boolean"
                 },
-                "location": {
-                    "type": "string"
+                "order": {
+                    "$ref": "#/definitions/responseModel.Order"
                 },
-                "material": {
+                "orderId": {
+                    "type": "integer"
+                },
+                "orderItemData": {
+                    "description": "JSON string",
                     "type": "string"
                 },
-                "notes": {
+                "orderItemId": {
+                    "type": "integer"
+                },
+                "performedAt": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "performedBy": {
+                    "$ref": "#/definitions/responseModel.User"
                 },
-                "purchaseDate": {
+                "performedById": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "responseModel.Inventory": {
+        "responseModel.OrderItem": {
             "type": "object",
             "properties": {
-                "auditFields": {
-                    "$ref": "#/definitions/responseModel.AuditFields"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "isActive": {
-                    "type": "boolean"
+                "additionalCharges": {
+                    "type": "number"
                 },
-                "isLowStock": {
-                    "type": "boolean"
+                "assignedTailor": {
+                    "description": "first_name + last_name",
+                    "type": "string"
                 },
-                "lowStockThreshold": {
+                "assignedTailorId": {
                     "type": "integer"
                 },
-                "product": {
-                    "description": "Related data",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/responseModel.Product"
-                        }
-                    ]
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "productId": {
-                    "type": "integer"
+                "cgstAmount": {
+                    "type": "number"
                 },
-                "productName": {
+                "cuttingAt": {
                     "type": "string"
                 },
-                "productSku": {
+                "deliveredDate": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "integer"
+                "description": {
+                    "type": "string"
                 },
-                "updatedAt": {
+                "expectedDeliveryDate": {
+                    "type": "string"
+                },
+                "finishingAt": {
                     "type": "string"
-                }
-            }
-        },
-        "responseModel.InventoryLog": {
-            "type": "object",
-            "properties": {
-                "auditFields": {
-                    "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "changeType": {
+                "hsnCode": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "igstAmount": {
+                    "type": "number"
+                },
                 "isActive": {
                     "type": "boolean"
                 },
-                "loggedAt": {
-                    "type": "string"
+                "measurement": {
+                    "$ref": "#/definitions/responseModel.Measurement"
                 },
-                "loggedByName": {
-                    "description": "User who logged",
-                    "type": "string"
+                "measurementId": {
+                    "type": "integer"
                 },
-                "netChange": {
-                    "description": "e.g. \"0(+20)\" = 0 before, +20 added; \"20(-5)\" = 20 before, -5",
-                    "type": "string"
+                "measurementSnapshot": {
+                    "$ref": "#/definitions/responseModel.MeasurementSnapshot"
                 },
-                "notes": {
-                    "type": "string"
+                "order": {
+                    "$ref": "#/definitions/responseModel.Order"
                 },
-                "product": {
-                    "description": "Related data",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/responseModel.Product"
-                        }
-                    ]
+                "orderId": {
+                    "type": "integer"
                 },
-                "productId": {
+                "person": {
+                    "$ref": "#/definitions/responseModel.Person"
+                },
+                "personId": {
                     "type": "integer"
                 },
-                "productName": {
-                    "type": "string"
+                "price": {
+                    "type": "number"
                 },
-                "productSku": {
+                "qcAt": {
                     "type": "string"
                 },
                 "quantity": {
                     "type": "integer"
                 },
-                "reason": {
+                "readyAt": {
                     "type": "string"
                 },
-                "stockAfter": {
-                    "description": "Stock quantity after this movement",
-                    "type": "integer"
-                }
-            }
-        },
-        "responseModel.LowStockItem": {
-            "type": "object",
-            "properties": {
-                "categoryName": {
+                "sgstAmount": {
+                    "type": "number"
+                },
+                "stage": {
                     "type": "string"
                 },
-                "currentStock": {
-                    "type": "integer"
+                "stageUpdatedAt": {
+                    "type": "string"
                 },
-                "lowStockThreshold": {
-                    "type": "integer"
+                "stitchingAt": {
+                    "type": "string"
                 },
-                "productId": {
-                    "type": "integer"
+                "taxRate": {
+                    "type": "number"
                 },
-                "productName": {
-                    "type": "string"
+                "taxableValue": {
+                    "type": "number"
                 },
-                "productSku": {
-                    "type": "string"
+                "total": {
+                    "type": "number"
                 }
             }
         },
-        "responseModel.Measurement": {
+        "responseModel.OrderPayment": {
             "type": "object",
             "properties": {
+                "amount": {
+                    "type": "number"
+                },
                 "auditFields": {
                     "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "dressType": {
-                    "$ref": "#/definitions/responseModel.DressType"
-                },
-                "dressTypeId": {
-                    "type": "integer"
-                },
                 "id": {
                     "type": "integer"
                 },
                 "isActive": {
                     "type": "boolean"
                 },
-                "person": {
-                    "$ref": "#/definitions/responseModel.Person"
+                "mode": {
+                    "type": "string"
                 },
-                "personId": {
+                "orderId": {
                     "type": "integer"
                 },
-                "personName": {
+                "paidAt": {
                     "type": "string"
                 },
-                "takenBy": {
-                    "description": "first_name + last_name",
+                "reference": {
                     "type": "string"
-                },
-                "takenById": {
-                    "type": "integer"
-                },
-                "values": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
                 }
             }
         },
-        "responseModel.MeasurementBrowse": {
+        "responseModel.OrderSummary": {
             "type": "object",
             "properties": {
-                "customerId": {
-                    "type": "integer"
+                "additionalCharges": {
+                    "type": "number"
                 },
-                "dressTypes": {
-                    "description": "CSV of DressType names",
+                "customerName": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "deliveredDate": {
+                    "type": "string"
                 },
-                "isActive": {
-                    "type": "boolean"
+                "expectedDeliveryDate": {
+                    "type": "string"
                 },
-                "personId": {
+                "id": {
                     "type": "integer"
                 },
-                "personName": {
+                "orderTakenBy": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "orderTakenById": {
+                    "type": "integer"
                 },
-                "updatedBy": {
+                "orderValue": {
+                    "type": "number"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "responseModel.MeasurementHistory": {
+        "responseModel.Person": {
             "type": "object",
             "properties": {
-                "action": {
-                    "type": "string"
+                "age": {
+                    "type": "integer"
                 },
-                "auditFields": {
-                    "$ref": "#/definitions/responseModel.AuditFields"
+                "customer": {
+                    "$ref": "#/definitions/responseModel.Customer"
                 },
-                "changedValues": {
+                "customerId": {
+                    "type": "integer"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "gender": {
                     "type": "string"
                 },
                 "id": {
@@ -6467,450 +10269,452 @@ const docTemplate = `{
                 "isActive": {
                     "type": "boolean"
                 },
-                "measurement": {
-                    "$ref": "#/definitions/responseModel.Measurement"
-                },
-                "measurementId": {
-                    "type": "integer"
+                "lastName": {
+                    "type": "string"
                 },
-                "oldValues": {
+                "measurements": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/responseModel.Measurement"
                     }
-                },
-                "performedAt": {
-                    "type": "string"
-                },
-                "performedBy": {
-                    "$ref": "#/definitions/responseModel.User"
-                },
-                "performedById": {
-                    "type": "integer"
                 }
             }
         },
-        "responseModel.Order": {
+        "responseModel.Product": {
             "type": "object",
             "properties": {
-                "additionalCharges": {
-                    "type": "number"
-                },
                 "auditFields": {
                     "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "customer": {
-                    "$ref": "#/definitions/responseModel.Customer"
+                "category": {
+                    "description": "Related data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/responseModel.Category"
+                        }
+                    ]
                 },
-                "customerId": {
+                "categoryId": {
                     "type": "integer"
                 },
-                "customerName": {
-                    "description": "first_name + last_name",
+                "categoryName": {
+                    "description": "Flattened category name",
                     "type": "string"
                 },
-                "deliveredDate": {
+                "costPrice": {
+                    "type": "number"
+                },
+                "currentStock": {
+                    "description": "From inventory",
+                    "type": "number"
+                },
+                "description": {
                     "type": "string"
                 },
-                "expectedDeliveryDate": {
+                "hsnCode": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "inventory": {
+                    "$ref": "#/definitions/responseModel.Inventory"
+                },
                 "isActive": {
                     "type": "boolean"
                 },
-                "notes": {
+                "isLowStock": {
+                    "description": "Stock alert flag",
+                    "type": "boolean"
+                },
+                "name": {
                     "type": "string"
                 },
-                "orderItems": {
+                "options": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/responseModel.OrderItem"
+                        "$ref": "#/definitions/responseModel.ProductOption"
                     }
                 },
-                "orderQuantity": {
-                    "description": "sum of quantity from order items",
+                "parentId": {
+                    "description": "Set on variants",
                     "type": "integer"
                 },
-                "orderTakenBy": {
-                    "description": "first_name + last_name",
+                "parentName": {
                     "type": "string"
                 },
-                "orderTakenById": {
-                    "type": "integer"
-                },
-                "orderValue": {
-                    "description": "sum of total from order items",
+                "sellingPrice": {
                     "type": "number"
                 },
-                "status": {
-                    "type": "string"
-                }
-            }
-        },
-        "responseModel.OrderActivityItem": {
-            "type": "object",
-            "properties": {
-                "action": {
+                "sku": {
                     "type": "string"
                 },
-                "changedFields": {
-                    "type": "string"
+                "taxRate": {
+                    "type": "number"
                 },
-                "id": {
-                    "type": "integer"
+                "unit": {
+                    "type": "string"
                 },
-                "orderId": {
-                    "type": "integer"
+                "unitConversions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.ProductUnitConversion"
+                    }
                 },
-                "performedAt": {
-                    "type": "string"
+                "variantAttributes": {
+                    "description": "Variants of a parent product, its stock is the total of theirs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.ProductAttribute"
+                    }
                 },
-                "performedBy": {
-                    "type": "string"
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.Product"
+                    }
                 }
             }
         },
-        "responseModel.OrderDashboardList": {
+        "responseModel.ProductAttribute": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "orders": {
+                "values": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/responseModel.OrderSummary"
+                        "type": "string"
                     }
                 }
             }
         },
-        "responseModel.OrderDashboardResponse": {
+        "responseModel.ProductAutoComplete": {
             "type": "object",
             "properties": {
-                "deliveriesDueThisWeek": {
-                    "description": "by ExpectedDeliveryDate",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/responseModel.OrderDashboardList"
-                        }
-                    ]
+                "currentStock": {
+                    "type": "number"
                 },
-                "orderCountInPeriod": {
-                    "description": "last 7/30 days",
+                "id": {
                     "type": "integer"
                 },
-                "ordersByStatus": {
-                    "description": "count per status DRAFT → DELIVERED, CANCELLED",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/responseModel.StatusCountStat"
-                    }
+                "isLowStock": {
+                    "type": "boolean"
                 },
-                "ordersByTakenBy": {
-                    "description": "count per OrderTakenById",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/responseModel.UserOrderCount"
-                    }
+                "name": {
+                    "type": "string"
                 },
-                "overdueAtRiskOrders": {
-                    "description": "ExpectedDeliveryDate passed or soon, not DELIVERED",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/responseModel.OrderDashboardList"
-                        }
-                    ]
+                "sku": {
+                    "type": "string"
                 },
-                "recentDeliveries": {
-                    "description": "DeliveredDate last 7/30 days",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/responseModel.OrderDashboardList"
-                        }
-                    ]
+                "unit": {
+                    "type": "string"
                 },
-                "recentOrderActivity": {
-                    "description": "from OrderHistory",
+                "variants": {
+                    "description": "Variants of a parent product, stock is moved on them rather than on the parent",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/responseModel.OrderActivityItem"
+                        "$ref": "#/definitions/responseModel.ProductAutoComplete"
                     }
-                },
-                "revenueInPeriod": {
-                    "description": "sum OrderValue + AdditionalCharges in period",
-                    "type": "number"
                 }
             }
         },
-        "responseModel.OrderHistory": {
+        "responseModel.ProductOption": {
             "type": "object",
             "properties": {
-                "action": {
+                "attribute": {
                     "type": "string"
                 },
-                "changedFields": {
+                "value": {
                     "type": "string"
+                }
+            }
+        },
+        "responseModel.ProductUnitConversion": {
+            "type": "object",
+            "properties": {
+                "factor": {
+                    "type": "number"
                 },
-                "deliveredDate": {
+                "id": {
+                    "type": "integer"
+                },
+                "unit": {
                     "type": "string"
+                }
+            }
+        },
+        "responseModel.PurchaseOrder": {
+            "type": "object",
+            "properties": {
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "expectedDeliveryDate": {
+                "expectedDate": {
                     "type": "string"
                 },
+                "expenses": {
+                    "description": "One per receipt, with the payments made",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.ExpenseTracker"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
                 "isActive": {
                     "type": "boolean"
                 },
-                "order": {
-                    "$ref": "#/definitions/responseModel.Order"
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.PurchaseOrderLine"
+                    }
                 },
-                "orderId": {
-                    "type": "integer"
+                "notes": {
+                    "type": "string"
                 },
-                "orderItemData": {
-                    "description": "JSON string",
+                "orderDate": {
                     "type": "string"
                 },
-                "orderItemId": {
-                    "type": "integer"
+                "orderedValue": {
+                    "description": "Sum of quantity * unit cost",
+                    "type": "number"
                 },
-                "performedAt": {
-                    "type": "string"
+                "receivedValue": {
+                    "description": "Sum of received quantity * unit cost",
+                    "type": "number"
                 },
-                "performedBy": {
-                    "$ref": "#/definitions/responseModel.User"
+                "status": {
+                    "type": "string"
                 },
-                "performedById": {
+                "supplierId": {
                     "type": "integer"
                 },
-                "status": {
+                "supplierName": {
                     "type": "string"
                 }
             }
         },
-        "responseModel.OrderItem": {
+        "responseModel.PurchaseOrderLine": {
             "type": "object",
             "properties": {
-                "additionalCharges": {
-                    "type": "number"
-                },
-                "auditFields": {
-                    "$ref": "#/definitions/responseModel.AuditFields"
-                },
-                "deliveredDate": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "expectedDeliveryDate": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "isActive": {
-                    "type": "boolean"
-                },
-                "measurement": {
-                    "$ref": "#/definitions/responseModel.Measurement"
-                },
-                "measurementId": {
-                    "type": "integer"
+                "lineTotal": {
+                    "type": "number"
                 },
-                "order": {
-                    "$ref": "#/definitions/responseModel.Order"
+                "outstandingQuantity": {
+                    "type": "number"
                 },
-                "orderId": {
+                "productId": {
                     "type": "integer"
                 },
-                "person": {
-                    "$ref": "#/definitions/responseModel.Person"
+                "productName": {
+                    "type": "string"
                 },
-                "personId": {
-                    "type": "integer"
+                "productSku": {
+                    "type": "string"
                 },
-                "price": {
+                "quantity": {
                     "type": "number"
                 },
-                "quantity": {
-                    "type": "integer"
+                "receivedQuantity": {
+                    "type": "number"
                 },
-                "total": {
+                "unitCost": {
                     "type": "number"
                 }
             }
         },
-        "responseModel.OrderSummary": {
+        "responseModel.ReferrerCountStat": {
             "type": "object",
             "properties": {
-                "additionalCharges": {
-                    "type": "number"
+                "enquiriesCount": {
+                    "type": "integer"
                 },
-                "customerName": {
+                "referrer": {
                     "type": "string"
                 },
-                "deliveredDate": {
+                "referrerPhone": {
                     "type": "string"
+                }
+            }
+        },
+        "responseModel.ReorderSuggestion": {
+            "type": "object",
+            "properties": {
+                "availableQuantity": {
+                    "type": "number"
                 },
-                "expectedDeliveryDate": {
+                "averageDailyConsumption": {
+                    "type": "number"
+                },
+                "categoryName": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "consumedQuantity": {
+                    "description": "Booked out over the window",
+                    "type": "number"
                 },
-                "orderTakenBy": {
-                    "type": "string"
+                "currentStock": {
+                    "type": "number"
                 },
-                "orderTakenById": {
+                "inventoryId": {
                     "type": "integer"
                 },
-                "orderValue": {
+                "lowStockAlertedAt": {
+                    "type": "string"
+                },
+                "lowStockThreshold": {
                     "type": "number"
                 },
-                "status": {
-                    "type": "string"
-                }
-            }
-        },
-        "responseModel.Person": {
-            "type": "object",
-            "properties": {
-                "age": {
+                "parentProductId": {
                     "type": "integer"
                 },
-                "customer": {
-                    "$ref": "#/definitions/responseModel.Customer"
+                "parentProductName": {
+                    "type": "string"
                 },
-                "customerId": {
+                "productId": {
                     "type": "integer"
                 },
-                "firstName": {
+                "productName": {
                     "type": "string"
                 },
-                "gender": {
+                "productSku": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "suggestedQuantity": {
+                    "type": "number"
                 },
-                "isActive": {
-                    "type": "boolean"
+                "unit": {
+                    "type": "string"
                 },
-                "lastName": {
+                "windowDays": {
+                    "type": "integer"
+                }
+            }
+        },
+        "responseModel.Response": {
+            "type": "object",
+            "properties": {
+                "error": {},
+                "message": {
                     "type": "string"
                 },
-                "measurements": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/responseModel.Measurement"
-                    }
+                "pageMetaData": {},
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "responseModel.Product": {
+        "responseModel.Sale": {
             "type": "object",
             "properties": {
                 "auditFields": {
                     "$ref": "#/definitions/responseModel.AuditFields"
                 },
-                "category": {
-                    "description": "Related data",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/responseModel.Category"
-                        }
-                    ]
+                "cgstAmount": {
+                    "type": "number"
                 },
-                "categoryId": {
+                "customerId": {
                     "type": "integer"
                 },
-                "categoryName": {
-                    "description": "Flattened category name",
+                "customerName": {
                     "type": "string"
                 },
-                "costPrice": {
+                "discount": {
+                    "description": "Taken off the whole bill",
                     "type": "number"
                 },
-                "currentStock": {
-                    "description": "From inventory",
-                    "type": "integer"
-                },
-                "description": {
-                    "type": "string"
+                "grandTotal": {
+                    "type": "number"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "inventory": {
-                    "$ref": "#/definitions/responseModel.Inventory"
+                "igstAmount": {
+                    "type": "number"
                 },
                 "isActive": {
                     "type": "boolean"
                 },
-                "isLowStock": {
-                    "description": "Stock alert flag",
-                    "type": "boolean"
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.SaleLine"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "paymentMode": {
+                    "type": "string"
+                },
+                "paymentReference": {
+                    "type": "string"
                 },
-                "name": {
+                "receiptNumber": {
                     "type": "string"
                 },
-                "sellingPrice": {
+                "sgstAmount": {
                     "type": "number"
                 },
-                "sku": {
+                "soldAt": {
                     "type": "string"
+                },
+                "subTotal": {
+                    "description": "Lines after their own discounts",
+                    "type": "number"
+                },
+                "taxableValue": {
+                    "type": "number"
                 }
             }
         },
-        "responseModel.ProductAutoComplete": {
+        "responseModel.SaleLine": {
             "type": "object",
             "properties": {
-                "currentStock": {
-                    "type": "integer"
+                "discount": {
+                    "type": "number"
+                },
+                "hsnCode": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "isLowStock": {
-                    "type": "boolean"
+                "productId": {
+                    "type": "integer"
                 },
-                "name": {
+                "productName": {
                     "type": "string"
                 },
-                "sku": {
+                "productSku": {
                     "type": "string"
-                }
-            }
-        },
-        "responseModel.ReferrerCountStat": {
-            "type": "object",
-            "properties": {
-                "enquiriesCount": {
-                    "type": "integer"
                 },
-                "referrer": {
-                    "type": "string"
+                "quantity": {
+                    "type": "number"
                 },
-                "referrerPhone": {
-                    "type": "string"
-                }
-            }
-        },
-        "responseModel.Response": {
-            "type": "object",
-            "properties": {
-                "error": {},
-                "message": {
-                    "type": "string"
+                "sellingPrice": {
+                    "type": "number"
                 },
-                "pageMetaData": {},
-                "type": {
+                "taxAmount": {
+                    "type": "number"
+                },
+                "taxRate": {
+                    "type": "number"
+                },
+                "taxableValue": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "number"
+                },
+                "unit": {
                     "type": "string"
                 }
             }
@@ -6974,6 +10778,14 @@ const docTemplate = `{
                     "description": "delivered orders in period",
                     "type": "number"
                 },
+                "salesCountInPeriod": {
+                    "description": "counter sales in period",
+                    "type": "integer"
+                },
+                "salesRevenueInPeriod": {
+                    "description": "counter sales in period, taxable value after discounts",
+                    "type": "number"
+                },
                 "taskCompletionInPeriod": {
                     "description": "completed vs created in period",
                     "allOf": [
@@ -7002,26 +10814,254 @@ const docTemplate = `{
                 }
             }
         },
+        "responseModel.StockMovementLot": {
+            "type": "object",
+            "properties": {
+                "lotCode": {
+                    "type": "string"
+                },
+                "lotId": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
         "responseModel.StockMovementResponse": {
             "type": "object",
             "properties": {
                 "changeAmount": {
-                    "type": "integer"
+                    "type": "number"
+                },
+                "lots": {
+                    "description": "Lots the stock went into or was taken from",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.StockMovementLot"
+                    }
                 },
                 "message": {
                     "type": "string"
                 },
                 "newStock": {
-                    "type": "integer"
+                    "type": "number"
                 },
                 "previousStock": {
-                    "type": "integer"
+                    "type": "number"
                 },
                 "productId": {
                     "type": "integer"
                 },
+                "replayed": {
+                    "description": "Movement was already recorded with the same idempotency key",
+                    "type": "boolean"
+                },
                 "success": {
                     "type": "boolean"
+                },
+                "unit": {
+                    "description": "Stock unit the figures are in",
+                    "type": "string"
+                }
+            }
+        },
+        "responseModel.StockTake": {
+            "type": "object",
+            "properties": {
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "categoryName": {
+                    "type": "string"
+                },
+                "countedCount": {
+                    "description": "Products counted so far",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.StockTakeLine"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "openedAt": {
+                    "type": "string"
+                },
+                "postedAt": {
+                    "type": "string"
+                },
+                "productCount": {
+                    "description": "Products in the session",
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "responseModel.StockTakeLine": {
+            "type": "object",
+            "properties": {
+                "countedQuantity": {
+                    "description": "nil until counted",
+                    "type": "number"
+                },
+                "currentQuantity": {
+                    "description": "Stock now",
+                    "type": "number"
+                },
+                "expectedQuantity": {
+                    "description": "Stock when the session was opened",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "integer"
+                },
+                "productName": {
+                    "type": "string"
+                },
+                "productSku": {
+                    "type": "string"
+                },
+                "variance": {
+                    "description": "counted - current, or the posted adjustment once posted",
+                    "type": "number"
+                }
+            }
+        },
+        "responseModel.StockTransfer": {
+            "type": "object",
+            "properties": {
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
+                },
+                "dispatchedAt": {
+                    "type": "string"
+                },
+                "fromChannelId": {
+                    "type": "integer"
+                },
+                "fromChannelName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responseModel.StockTransferLine"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "receivedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "toChannelId": {
+                    "type": "integer"
+                },
+                "toChannelName": {
+                    "type": "string"
+                }
+            }
+        },
+        "responseModel.StockTransferLine": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "integer"
+                },
+                "productName": {
+                    "type": "string"
+                },
+                "productSku": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "responseModel.Supplier": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "auditFields": {
+                    "$ref": "#/definitions/responseModel.AuditFields"
+                },
+                "contactPerson": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "gstin": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "outstandingBalance": {
+                    "description": "Still owed to the supplier across all expenses",
+                    "type": "number"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "responseModel.SupplierAutoComplete": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
@@ -7290,6 +11330,8 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "This is the backend for Stitchfolio.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
 }
 
 func init() {
@@ -87,13 +87,13 @@ func (a *App) Migrate(ctx *context.Context, checkErr func(err error)) {
 		// &entities.EmailNotification{},
 		// &entities.EnquiryHistory{},
 		// &entities.Enquiry{},
-		&entities.Expense{},
+		// &entities.Expense{},
 		// &entities.MasterConfig{},
 		// &entities.Measurement{},
 		// &entities.MeasurementHistory{},
 		// &entities.Notification{},
 		// &entities.OrderHistory{},
-		// &entities.Order{},
+		&entities.Order{},
 		// &entities.OrderItem{},
 		// &entities.Person{},
 		// &entities.Task{},
@@ -106,6 +106,24 @@ func (a *App) Migrate(ctx *context.Context, checkErr func(err error)) {
 		// &entities.InventoryLog{},
 		// &entities.Product{},
 		// &entities.Category{},
+		// &entities.OrderPayment{},
+		// &entities.DressTypeComponent{},
+		// &entities.StockTake{},
+		// &entities.StockTakeLine{},
+		// &entities.Supplier{},
+		// &entities.PurchaseOrder{},
+		// &entities.PurchaseOrderLine{},
+		// &entities.ProductUnitConversion{},
+		// &entities.StockTransfer{},
+		// &entities.StockTransferLine{},
+		// &entities.InventoryLot{},
+		// &entities.StockReservation{},
+		// &entities.ProductAttribute{},
+		// &entities.ProductVariantValue{},
+		// &entities.Sale{},
+		// &entities.SaleLine{},
+		// &entities.MeasurementField{},
+		// &entities.MeasurementFlag{},
 	}
 
 	//************************//
@@ -121,5 +139,5 @@ func (a *App) Migrate(ctx *context.Context, checkErr func(err error)) {
 
 	//migrator.Migrate(entityList, checkErr)
 
-	migrator.GenerateAlterMigration(entityList, "008_add_expense_balance")
+	migrator.GenerateAlterMigration(entityList, "035_add_order_invoice_sequence")
 }
@@ -42,6 +42,13 @@ func (a *Task) Start(ctx *context.Context, checkErr func(err error)) {
 
 	// checkErr(err)
 
+	// Run low stock alert task at 8AM IST
+	_, err := a.Cron.AddFunc("0 0 8 * * *", func() {
+		a.LowStockAlertTask(ctx)
+	})
+
+	checkErr(err)
+
 	a.Cron.Start()
 
 	//_log.FromCtx(ctx).Info("Cron jobs started successfully")
@@ -61,6 +68,19 @@ func (a *Task) NotificationRunnerTask(ctx *context.Context) {
 
 }
 
+func (a *Task) LowStockAlertTask(ctx *context.Context) {
+
+	param := tsk.LowStockAlertTaskParam{
+		BaseTaskParam: &task.BaseTaskParam{AbortProceesExecutionOnFailure: true},
+	}
+
+	alertTask := tsk.ProvideLowStockAlertTask(&param, a.BaseService.InventoryService)
+
+	jobRunner := task.ProvideJobRunner(alertTask, *param.BaseTaskParam)
+	jobRunner.CreateAdHocJob(true)
+
+}
+
 func (a *Task) Shutdown(ctx *context.Context, checkErr func(err error)) {
 	// Stop the cron scheduler
 	if a.Cron != nil {
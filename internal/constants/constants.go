@@ -9,3 +9,18 @@ const (
 
 const PASSWORD_RESET_UI_PATH = "reset-password"
 const FORGOT_PASSWORD_UI_PATH = "forgot-password"
+
+// Master Config Names
+const (
+	ORDER_STATUS_TRANSITIONS_CONFIG  = "Order.StatusTransitions"
+	INVENTORY_COSTING_METHOD_CONFIG  = "Inventory.CostingMethod"     // WEIGHTED_AVERAGE (default) or FIFO
+	INVENTORY_REORDER_WINDOW_CONFIG  = "Inventory.ReorderWindowDays" // Days of consumption a reorder covers, 30 by default
+	MEASUREMENT_DISPLAY_UNIT_CONFIG  = "Measurement.DisplayUnit"     // INCH (default) or CM, a user can pick their own with measurementUnit in their config
+	MEASUREMENT_ANOMALY_CHECK_CONFIG = "Measurement.AnomalyCheck"    // JSON of entities.MeasurementAnomalySettings
+)
+
+// Printable document templates
+const (
+	HTML_TEMPLATE_PATH          = "templates/html_templates"
+	ORDER_INVOICE_HTML_TEMPLATE = "orderInvoice.htm"
+)
@@ -60,6 +60,13 @@ var handlerSet = wire.NewSet(
 	handler.ProvideInventoryHandler,
 	handler.ProvideInventoryLogHandler,
 	handler.ProvideDashboardHandler,
+	handler.ProvideOrderPaymentHandler,
+	handler.ProvideInvoiceHandler,
+	handler.ProvideStockTakeHandler,
+	handler.ProvideSupplierHandler,
+	handler.ProvidePurchaseOrderHandler,
+	handler.ProvideStockTransferHandler,
+	handler.ProvideSaleHandler,
 )
 var logSet = wire.NewSet(
 	ProvideNewRelic,
@@ -104,6 +111,15 @@ var svcSet = wire.NewSet(
 	service.ProvideInventoryService,
 	service.ProvideInventoryLogService,
 	service.ProvideDashboardService,
+	service.ProvideOrderPaymentService,
+	service.ProvideInvoiceService,
+	service.ProvideTaxService,
+	service.ProvideStockTakeService,
+	service.ProvideSupplierService,
+	service.ProvidePurchaseOrderService,
+	service.ProvideStockTransferService,
+	service.ProvideStockReservationService,
+	service.ProvideSaleService,
 )
 
 var baseSvc = wire.NewSet(
@@ -135,6 +151,17 @@ var repoSet = wire.NewSet(
 	repository.ProvideInventoryRepository,
 	repository.ProvideInventoryLogRepository,
 	repository.ProvideDashboardRepository,
+	repository.ProvideOrderPaymentRepository,
+	repository.ProvideDressTypeComponentRepository,
+	repository.ProvideMeasurementFieldRepository,
+	repository.ProvideMeasurementFlagRepository,
+	repository.ProvideStockTakeRepository,
+	repository.ProvideSupplierRepository,
+	repository.ProvidePurchaseOrderRepository,
+	repository.ProvideStockTransferRepository,
+	repository.ProvideInventoryLotRepository,
+	repository.ProvideStockReservationRepository,
+	repository.ProvideSaleRepository,
 )
 
 var cronSet = wire.NewSet(
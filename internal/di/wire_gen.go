@@ -56,26 +56,44 @@ func InitApp(ctx *context.Context) (*app.App, error) {
 	adminHandler := handler.ProvideAdminHandler(adminService)
 	customerRepository := repository.ProvideCustomerRepository(gormDAL)
 	personRepository := repository.ProvidePersonRepository(gormDAL)
-	customerService := service.ProvideCustomerService(customerRepository, personRepository, mapperMapper, responseMapper)
+	measurementRepository := repository.ProvideMeasurementRepository(gormDAL)
+	measurementFieldRepository := repository.ProvideMeasurementFieldRepository(gormDAL)
+	measurementHistoryRepository := repository.ProvideMeasurementHistoryRepository(gormDAL)
+	measurementFlagRepository := repository.ProvideMeasurementFlagRepository(gormDAL)
+	orderItemRepository := repository.ProvideOrderItemRepository(gormDAL)
+	measurementService := service.ProvideMeasurementService(measurementRepository, measurementHistoryRepository, measurementFieldRepository, measurementFlagRepository, orderItemRepository, userRepository, masterConfigService, mapperMapper, responseMapper)
+	customerService := service.ProvideCustomerService(customerRepository, personRepository, measurementService, mapperMapper, responseMapper)
 	customerHandler := handler.ProvideCustomerHandler(customerService)
 	enquiryRepository := repository.ProvideEnquiryRepository(gormDAL)
 	enquiryService := service.ProvideEnquiryService(enquiryRepository, customerRepository, mapperMapper, responseMapper)
 	enquiryHandler := handler.ProvideEnquiryHandler(enquiryService)
 	orderRepository := repository.ProvideOrderRepository(gormDAL)
 	orderHistoryRepository := repository.ProvideOrderHistoryRepository(gormDAL)
-	orderService := service.ProvideOrderService(orderRepository, orderHistoryRepository, mapperMapper, responseMapper)
+	taxService := service.ProvideTaxService(channelRepository, customerRepository, measurementRepository)
+	stockReservationRepository := repository.ProvideStockReservationRepository(gormDAL)
+	dressTypeComponentRepository := repository.ProvideDressTypeComponentRepository(gormDAL)
+	inventoryLogRepository := repository.ProvideInventoryLogRepository(gormDAL)
+	inventoryRepository := repository.ProvideInventoryRepository(gormDAL)
+	stockReservationService := service.ProvideStockReservationService(stockReservationRepository, orderRepository, dressTypeComponentRepository, inventoryLogRepository, inventoryRepository)
+	orderPaymentRepository := repository.ProvideOrderPaymentRepository(gormDAL)
+	saleRepository := repository.ProvideSaleRepository(gormDAL)
+	invoiceService := service.ProvideInvoiceService(orderRepository, orderPaymentRepository, channelRepository, saleRepository)
+	orderService := service.ProvideOrderService(orderRepository, orderHistoryRepository, masterConfigService, taxService, stockReservationService, measurementService, invoiceService, mapperMapper, responseMapper)
 	orderHandler := handler.ProvideOrderHandler(orderService)
-	orderItemRepository := repository.ProvideOrderItemRepository(gormDAL)
-	orderItemService := service.ProvideOrderItemService(orderItemRepository, mapperMapper, responseMapper)
+	productRepository := repository.ProvideProductRepository(gormDAL)
+	stockTransferRepository := repository.ProvideStockTransferRepository(gormDAL)
+	inventoryLotRepository := repository.ProvideInventoryLotRepository(gormDAL)
+	notificationRepository := repository.ProvideNotificationRepository(gormDAL)
+	smtpConfig := appConfig.SMTP
+	notificationService := service.ProvideNotificationService(notificationRepository, mapperMapper, smtpConfig, emailService)
+	inventoryService := service.ProvideInventoryService(inventoryRepository, inventoryLogRepository, inventoryLotRepository, productRepository, stockTransferRepository, stockReservationRepository, stockReservationService, channelRepository, masterConfigService, notificationService, mapperMapper, responseMapper)
+	orderItemService := service.ProvideOrderItemService(orderItemRepository, orderRepository, orderHistoryRepository, taxService, inventoryService, dressTypeComponentRepository, stockReservationService, measurementService, masterConfigService, mapperMapper, responseMapper)
 	orderItemHandler := handler.ProvideOrderItemHandler(orderItemService)
-	measurementRepository := repository.ProvideMeasurementRepository(gormDAL)
-	measurementHistoryRepository := repository.ProvideMeasurementHistoryRepository(gormDAL)
-	measurementService := service.ProvideMeasurementService(measurementRepository, measurementHistoryRepository, mapperMapper, responseMapper)
 	measurementHandler := handler.ProvideMeasurementHandler(measurementService)
-	personService := service.ProvidePersonService(personRepository, mapperMapper, responseMapper)
+	personService := service.ProvidePersonService(personRepository, measurementService, mapperMapper, responseMapper)
 	personHandler := handler.ProvidePersonHandler(personService)
 	dressTypeRepository := repository.ProvideDressTypeRepository(gormDAL)
-	dressTypeService := service.ProvideDressTypeService(dressTypeRepository, mapperMapper, responseMapper)
+	dressTypeService := service.ProvideDressTypeService(dressTypeRepository, dressTypeComponentRepository, measurementFieldRepository, productRepository, mapperMapper, responseMapper)
 	dressTypeHandler := handler.ProvideDressTypeHandler(dressTypeService)
 	orderHistoryService := service.ProvideOrderHistoryService(orderHistoryRepository, mapperMapper, responseMapper)
 	orderHistoryHandler := handler.ProvideOrderHistoryHandler(orderHistoryService)
@@ -96,19 +114,31 @@ func InitApp(ctx *context.Context) (*app.App, error) {
 	categoryRepository := repository.ProvideCategoryRepository(gormDAL)
 	categoryService := service.ProvideCategoryService(categoryRepository, mapperMapper, responseMapper)
 	categoryHandler := handler.ProvideCategoryHandler(categoryService)
-	productRepository := repository.ProvideProductRepository(gormDAL)
-	inventoryRepository := repository.ProvideInventoryRepository(gormDAL)
 	productService := service.ProvideProductService(productRepository, inventoryRepository, mapperMapper, responseMapper)
 	productHandler := handler.ProvideProductHandler(productService)
-	inventoryLogRepository := repository.ProvideInventoryLogRepository(gormDAL)
-	inventoryService := service.ProvideInventoryService(inventoryRepository, inventoryLogRepository, productRepository, mapperMapper, responseMapper)
 	inventoryHandler := handler.ProvideInventoryHandler(inventoryService)
-	inventoryLogService := service.ProvideInventoryLogService(inventoryLogRepository, inventoryRepository, responseMapper)
+	inventoryLogService := service.ProvideInventoryLogService(inventoryLogRepository, inventoryRepository, inventoryService, responseMapper)
 	inventoryLogHandler := handler.ProvideInventoryLogHandler(inventoryLogService)
 	dashboardRepository := repository.ProvideDashboardRepository(gormDAL)
 	dashboardService := service.ProvideDashboardService(dashboardRepository)
 	dashboardHandler := handler.ProvideDashboardHandler(dashboardService)
-	baseHandler := base.ProvideBaseHandler(health, userHandler, channelHandler, masterConfigHandler, adminHandler, customerHandler, enquiryHandler, orderHandler, orderItemHandler, measurementHandler, personHandler, dressTypeHandler, orderHistoryHandler, measurementHistoryHandler, enquiryHistoryHandler, expenseTrackerHandler, expenseDetailHandler, taskHandler, categoryHandler, productHandler, inventoryHandler, inventoryLogHandler, dashboardHandler)
+	orderPaymentService := service.ProvideOrderPaymentService(orderPaymentRepository, orderRepository, mapperMapper, responseMapper)
+	orderPaymentHandler := handler.ProvideOrderPaymentHandler(orderPaymentService)
+	invoiceHandler := handler.ProvideInvoiceHandler(invoiceService)
+	stockTakeRepository := repository.ProvideStockTakeRepository(gormDAL)
+	stockTakeService := service.ProvideStockTakeService(stockTakeRepository, inventoryRepository, inventoryService, mapperMapper, responseMapper)
+	stockTakeHandler := handler.ProvideStockTakeHandler(stockTakeService)
+	supplierRepository := repository.ProvideSupplierRepository(gormDAL)
+	supplierService := service.ProvideSupplierService(supplierRepository, mapperMapper, responseMapper)
+	supplierHandler := handler.ProvideSupplierHandler(supplierService)
+	purchaseOrderRepository := repository.ProvidePurchaseOrderRepository(gormDAL)
+	purchaseOrderService := service.ProvidePurchaseOrderService(purchaseOrderRepository, supplierRepository, productRepository, expenseTrackerRepository, expenseDetailRepository, inventoryService, mapperMapper, responseMapper)
+	purchaseOrderHandler := handler.ProvidePurchaseOrderHandler(purchaseOrderService)
+	stockTransferService := service.ProvideStockTransferService(stockTransferRepository, inventoryLogRepository, channelRepository, productRepository, inventoryService, mapperMapper, responseMapper)
+	stockTransferHandler := handler.ProvideStockTransferHandler(stockTransferService)
+	saleService := service.ProvideSaleService(saleRepository, productRepository, customerRepository, inventoryService, taxService, mapperMapper, responseMapper)
+	saleHandler := handler.ProvideSaleHandler(saleService, invoiceService)
+	baseHandler := base.ProvideBaseHandler(health, userHandler, channelHandler, masterConfigHandler, adminHandler, customerHandler, enquiryHandler, orderHandler, orderItemHandler, measurementHandler, personHandler, dressTypeHandler, orderHistoryHandler, measurementHistoryHandler, enquiryHistoryHandler, expenseTrackerHandler, expenseDetailHandler, taskHandler, categoryHandler, productHandler, inventoryHandler, inventoryLogHandler, dashboardHandler, orderPaymentHandler, invoiceHandler, stockTakeHandler, supplierHandler, purchaseOrderHandler, stockTransferHandler, saleHandler)
 	application := ProvideNewRelic(appConfig)
 	serverConfig := appConfig.Server
 	engine := router.InitRouter(baseHandler, application, serverConfig)
@@ -149,27 +179,42 @@ func InitJobService(ctx *context.Context) (*app.Task, error) {
 	masterConfigService := service.ProvideMasterConfigService(masterConfigRepository, mapperMapper, appConfig, responseMapper)
 	customerRepository := repository.ProvideCustomerRepository(gormDAL)
 	personRepository := repository.ProvidePersonRepository(gormDAL)
-	customerService := service.ProvideCustomerService(customerRepository, personRepository, mapperMapper, responseMapper)
+	measurementRepository := repository.ProvideMeasurementRepository(gormDAL)
+	measurementFieldRepository := repository.ProvideMeasurementFieldRepository(gormDAL)
+	measurementHistoryRepository := repository.ProvideMeasurementHistoryRepository(gormDAL)
+	measurementFlagRepository := repository.ProvideMeasurementFlagRepository(gormDAL)
+	orderItemRepository := repository.ProvideOrderItemRepository(gormDAL)
+	measurementService := service.ProvideMeasurementService(measurementRepository, measurementHistoryRepository, measurementFieldRepository, measurementFlagRepository, orderItemRepository, userRepository, masterConfigService, mapperMapper, responseMapper)
+	customerService := service.ProvideCustomerService(customerRepository, personRepository, measurementService, mapperMapper, responseMapper)
 	enquiryRepository := repository.ProvideEnquiryRepository(gormDAL)
 	enquiryService := service.ProvideEnquiryService(enquiryRepository, customerRepository, mapperMapper, responseMapper)
 	orderRepository := repository.ProvideOrderRepository(gormDAL)
 	orderHistoryRepository := repository.ProvideOrderHistoryRepository(gormDAL)
-	orderService := service.ProvideOrderService(orderRepository, orderHistoryRepository, mapperMapper, responseMapper)
-	orderItemRepository := repository.ProvideOrderItemRepository(gormDAL)
-	orderItemService := service.ProvideOrderItemService(orderItemRepository, mapperMapper, responseMapper)
-	measurementRepository := repository.ProvideMeasurementRepository(gormDAL)
-	measurementHistoryRepository := repository.ProvideMeasurementHistoryRepository(gormDAL)
-	measurementService := service.ProvideMeasurementService(measurementRepository, measurementHistoryRepository, mapperMapper, responseMapper)
-	personService := service.ProvidePersonService(personRepository, mapperMapper, responseMapper)
+	taxService := service.ProvideTaxService(channelRepository, customerRepository, measurementRepository)
+	stockReservationRepository := repository.ProvideStockReservationRepository(gormDAL)
+	dressTypeComponentRepository := repository.ProvideDressTypeComponentRepository(gormDAL)
+	inventoryLogRepository := repository.ProvideInventoryLogRepository(gormDAL)
+	inventoryRepository := repository.ProvideInventoryRepository(gormDAL)
+	stockReservationService := service.ProvideStockReservationService(stockReservationRepository, orderRepository, dressTypeComponentRepository, inventoryLogRepository, inventoryRepository)
+	orderPaymentRepository := repository.ProvideOrderPaymentRepository(gormDAL)
+	saleRepository := repository.ProvideSaleRepository(gormDAL)
+	invoiceService := service.ProvideInvoiceService(orderRepository, orderPaymentRepository, channelRepository, saleRepository)
+	orderService := service.ProvideOrderService(orderRepository, orderHistoryRepository, masterConfigService, taxService, stockReservationService, measurementService, invoiceService, mapperMapper, responseMapper)
+	productRepository := repository.ProvideProductRepository(gormDAL)
+	stockTransferRepository := repository.ProvideStockTransferRepository(gormDAL)
+	inventoryLotRepository := repository.ProvideInventoryLotRepository(gormDAL)
+	inventoryService := service.ProvideInventoryService(inventoryRepository, inventoryLogRepository, inventoryLotRepository, productRepository, stockTransferRepository, stockReservationRepository, stockReservationService, channelRepository, masterConfigService, notificationService, mapperMapper, responseMapper)
+	orderItemService := service.ProvideOrderItemService(orderItemRepository, orderRepository, orderHistoryRepository, taxService, inventoryService, dressTypeComponentRepository, stockReservationService, measurementService, masterConfigService, mapperMapper, responseMapper)
+	personService := service.ProvidePersonService(personRepository, measurementService, mapperMapper, responseMapper)
 	dressTypeRepository := repository.ProvideDressTypeRepository(gormDAL)
-	dressTypeService := service.ProvideDressTypeService(dressTypeRepository, mapperMapper, responseMapper)
+	dressTypeService := service.ProvideDressTypeService(dressTypeRepository, dressTypeComponentRepository, measurementFieldRepository, productRepository, mapperMapper, responseMapper)
 	orderHistoryService := service.ProvideOrderHistoryService(orderHistoryRepository, mapperMapper, responseMapper)
 	measurementHistoryService := service.ProvideMeasurementHistoryService(measurementHistoryRepository, mapperMapper, responseMapper)
 	expenseTrackerRepository := repository.ProvideExpenseTrackerRepository(gormDAL)
 	expenseTrackerService := service.ProvideExpenseTrackerService(expenseTrackerRepository, mapperMapper, responseMapper)
 	taskRepository := repository.ProvideTaskRepository(gormDAL)
 	taskService := service.ProvideTaskService(taskRepository, mapperMapper, responseMapper)
-	baseService := base2.ProvideBaseService(userService, notificationService, channelService, masterConfigService, customerService, enquiryService, orderService, orderItemService, measurementService, personService, dressTypeService, orderHistoryService, measurementHistoryService, expenseTrackerService, taskService)
+	baseService := base2.ProvideBaseService(userService, notificationService, channelService, masterConfigService, customerService, enquiryService, orderService, orderItemService, measurementService, personService, dressTypeService, orderHistoryService, measurementHistoryService, expenseTrackerService, taskService, inventoryService)
 	application := ProvideNewRelic(appConfig)
 	cronCron := cron.ProvideCron()
 	task := &app.Task{
@@ -190,7 +235,7 @@ var pkgServiceSet = wire.NewSet(
 	ProvideServiceContainer, wire.FieldsOf(new(*service2.Service), "EmailService"),
 )
 
-var handlerSet = wire.NewSet(base.ProvideHealthHandler, base.ProvideBaseHandler, handler.ProvideUserHandler, handler.ProvideChannelHandler, handler.ProvideMasterConfigHandler, handler.ProvideAdminHandler, handler.ProvideCustomerHandler, handler.ProvideEnquiryHandler, handler.ProvideOrderHandler, handler.ProvideOrderItemHandler, handler.ProvideMeasurementHandler, handler.ProvidePersonHandler, handler.ProvideDressTypeHandler, handler.ProvideOrderHistoryHandler, handler.ProvideMeasurementHistoryHandler, handler.ProvideEnquiryHistoryHandler, handler.ProvideExpenseTrackerHandler, handler.ProvideExpenseDetailHandler, handler.ProvideTaskHandler, handler.ProvideCategoryHandler, handler.ProvideProductHandler, handler.ProvideInventoryHandler, handler.ProvideInventoryLogHandler, handler.ProvideDashboardHandler)
+var handlerSet = wire.NewSet(base.ProvideHealthHandler, base.ProvideBaseHandler, handler.ProvideUserHandler, handler.ProvideChannelHandler, handler.ProvideMasterConfigHandler, handler.ProvideAdminHandler, handler.ProvideCustomerHandler, handler.ProvideEnquiryHandler, handler.ProvideOrderHandler, handler.ProvideOrderItemHandler, handler.ProvideMeasurementHandler, handler.ProvidePersonHandler, handler.ProvideDressTypeHandler, handler.ProvideOrderHistoryHandler, handler.ProvideMeasurementHistoryHandler, handler.ProvideEnquiryHistoryHandler, handler.ProvideExpenseTrackerHandler, handler.ProvideExpenseDetailHandler, handler.ProvideTaskHandler, handler.ProvideCategoryHandler, handler.ProvideProductHandler, handler.ProvideInventoryHandler, handler.ProvideInventoryLogHandler, handler.ProvideDashboardHandler, handler.ProvideOrderPaymentHandler, handler.ProvideInvoiceHandler, handler.ProvideStockTakeHandler, handler.ProvideSupplierHandler, handler.ProvidePurchaseOrderHandler, handler.ProvideStockTransferHandler, handler.ProvideSaleHandler)
 
 var logSet = wire.NewSet(
 	ProvideNewRelic,
@@ -204,10 +249,10 @@ var dbSet = wire.NewSet(
 
 var mapperSet = wire.NewSet(mapper.ProvideMapper, mapper.ProvideResponseMapper)
 
-var svcSet = wire.NewSet(service.ProvideUserService, service.ProvideNotificationService, service.ProvideChannelService, service.ProvideMasterConfigService, service.ProvideAdminService, service.ProvideCustomerService, service.ProvideEnquiryService, service.ProvideOrderService, service.ProvideOrderItemService, service.ProvideMeasurementService, service.ProvidePersonService, service.ProvideDressTypeService, service.ProvideOrderHistoryService, service.ProvideMeasurementHistoryService, service.ProvideEnquiryHistoryService, service.ProvideExpenseTrackerService, service.ProvideExpenseDetailService, service.ProvideTaskService, service.ProvideCategoryService, service.ProvideProductService, service.ProvideInventoryService, service.ProvideInventoryLogService, service.ProvideDashboardService)
+var svcSet = wire.NewSet(service.ProvideUserService, service.ProvideNotificationService, service.ProvideChannelService, service.ProvideMasterConfigService, service.ProvideAdminService, service.ProvideCustomerService, service.ProvideEnquiryService, service.ProvideOrderService, service.ProvideOrderItemService, service.ProvideMeasurementService, service.ProvidePersonService, service.ProvideDressTypeService, service.ProvideOrderHistoryService, service.ProvideMeasurementHistoryService, service.ProvideEnquiryHistoryService, service.ProvideExpenseTrackerService, service.ProvideExpenseDetailService, service.ProvideTaskService, service.ProvideCategoryService, service.ProvideProductService, service.ProvideInventoryService, service.ProvideInventoryLogService, service.ProvideDashboardService, service.ProvideOrderPaymentService, service.ProvideInvoiceService, service.ProvideTaxService, service.ProvideStockTakeService, service.ProvideSupplierService, service.ProvidePurchaseOrderService, service.ProvideStockTransferService, service.ProvideStockReservationService, service.ProvideSaleService)
 
 var baseSvc = wire.NewSet(base2.ProvideBaseService)
 
-var repoSet = wire.NewSet(repository.ProvideGormDAL, repository.ProvideUserRepository, repository.ProvideNotificationRepository, repository.ProvideChannelRepository, repository.ProvideMasterConfigRepository, repository.ProvideAdminRepository, repository.ProvideCustomerRepository, repository.ProvideEnquiryRepository, repository.ProvideOrderRepository, repository.ProvideOrderItemRepository, repository.ProvideMeasurementRepository, repository.ProvidePersonRepository, repository.ProvideDressTypeRepository, repository.ProvideOrderHistoryRepository, repository.ProvideMeasurementHistoryRepository, repository.ProvideEnquiryHistoryRepository, repository.ProvideExpenseTrackerRepository, repository.ProvideExpenseDetailRepository, repository.ProvideTaskRepository, repository.ProvideCategoryRepository, repository.ProvideProductRepository, repository.ProvideInventoryRepository, repository.ProvideInventoryLogRepository, repository.ProvideDashboardRepository)
+var repoSet = wire.NewSet(repository.ProvideGormDAL, repository.ProvideUserRepository, repository.ProvideNotificationRepository, repository.ProvideChannelRepository, repository.ProvideMasterConfigRepository, repository.ProvideAdminRepository, repository.ProvideCustomerRepository, repository.ProvideEnquiryRepository, repository.ProvideOrderRepository, repository.ProvideOrderItemRepository, repository.ProvideMeasurementRepository, repository.ProvidePersonRepository, repository.ProvideDressTypeRepository, repository.ProvideOrderHistoryRepository, repository.ProvideMeasurementHistoryRepository, repository.ProvideEnquiryHistoryRepository, repository.ProvideExpenseTrackerRepository, repository.ProvideExpenseDetailRepository, repository.ProvideTaskRepository, repository.ProvideCategoryRepository, repository.ProvideProductRepository, repository.ProvideInventoryRepository, repository.ProvideInventoryLogRepository, repository.ProvideDashboardRepository, repository.ProvideOrderPaymentRepository, repository.ProvideDressTypeComponentRepository, repository.ProvideMeasurementFieldRepository, repository.ProvideMeasurementFlagRepository, repository.ProvideStockTakeRepository, repository.ProvideSupplierRepository, repository.ProvidePurchaseOrderRepository, repository.ProvideStockTransferRepository, repository.ProvideInventoryLotRepository, repository.ProvideStockReservationRepository, repository.ProvideSaleRepository)
 
 var cronSet = wire.NewSet(cron.ProvideCron)
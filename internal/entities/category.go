@@ -1,14 +1,65 @@
 package entities
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 type Category struct {
 	*Model `mapstructure:",squash"`
 
 	Name string `json:"name" gorm:"not null"`
 
+	// Position in the tree, Path holds the ids from the root down to the category, e.g. /1/5/12/
+	ParentId *uint  `json:"parentId,omitempty"`
+	Path     string `json:"path" gorm:"type:varchar(500);not null;default:''"`
+	Depth    int    `json:"depth" gorm:"not null;default:0"`
+
 	// Relations
+	Parent   *Category `gorm:"foreignKey:ParentId" json:"parent,omitempty"`
 	Products []Product `gorm:"foreignKey:CategoryId;constraint:OnDelete:SET NULL" json:"products,omitempty"`
 }
 
 func (Category) TableNameForQuery() string {
 	return "\"stich\".\"Categories\" E"
 }
+
+// CategoryPath is the path of the category with the id under a parent with parentPath, "" for a root category
+func CategoryPath(parentPath string, id uint) string {
+	if parentPath == "" {
+		parentPath = "/"
+	}
+	return fmt.Sprintf("%s%d/", parentPath, id)
+}
+
+// Contains tells whether other is the category itself or one of its descendants
+func (c *Category) Contains(other *Category) bool {
+	return c.Path != "" && strings.HasPrefix(other.Path, c.Path)
+}
+
+// AncestorIds returns the ids on the path above the category, the root first
+func (c *Category) AncestorIds() []uint {
+	parts := strings.Split(strings.Trim(c.Path, "/"), "/")
+	ids := make([]uint, 0, len(parts))
+	for _, part := range parts[:len(parts)-1] {
+		id, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids
+}
+
+// DisplayPath names the category with its ancestors, e.g. Fabrics > Silk > Kanchipuram
+func (c *Category) DisplayPath(names map[uint]string) string {
+	parts := make([]string, 0, c.Depth+1)
+	for _, id := range c.AncestorIds() {
+		if name, ok := names[id]; ok {
+			parts = append(parts, name)
+		}
+	}
+	parts = append(parts, c.Name)
+	return strings.Join(parts, " > ")
+}
@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CategoryPath(t *testing.T) {
+
+	require.Equal(t, "/1/", CategoryPath("", 1))
+	require.Equal(t, "/1/5/12/", CategoryPath("/1/5/", 12))
+
+	fabrics := &Category{Name: "Fabrics", Path: "/1/"}
+	silk := &Category{Name: "Silk", Path: "/1/5/", Depth: 1}
+	kanchipuram := &Category{Name: "Kanchipuram", Path: "/1/5/12/", Depth: 2}
+	other := &Category{Name: "Buttons", Path: "/15/"}
+
+	require.True(t, fabrics.Contains(kanchipuram))
+	require.True(t, silk.Contains(silk))
+	require.False(t, kanchipuram.Contains(silk))
+	// /1/ is not a prefix match of /15/
+	require.False(t, fabrics.Contains(other))
+	require.False(t, other.Contains(fabrics))
+
+	require.Equal(t, []uint{1, 5}, kanchipuram.AncestorIds())
+	require.Empty(t, fabrics.AncestorIds())
+
+	names := map[uint]string{1: "Fabrics", 5: "Silk"}
+	require.Equal(t, "Fabrics > Silk > Kanchipuram", kanchipuram.DisplayPath(names))
+	require.Equal(t, "Fabrics", fabrics.DisplayPath(names))
+}
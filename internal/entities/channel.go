@@ -16,6 +16,10 @@ type Channel struct {
 	Name   string        `json:"name,omitempty"`
 	Status ChannelStatus `gorm:"default:'ACTIVE';type:text;not null" json:"status,omitempty"`
 
+	// GST registration details, State is used to decide the place of supply
+	GSTIN string `json:"gstin,omitempty"`
+	State string `json:"state,omitempty"`
+
 	//Reference
 	OwnerUserID uint  `json:"ownerUserId,omitempty"`
 	OwnerUser   *User `gorm:"foreignKey:OwnerUserID;references:ID" json:"-"`
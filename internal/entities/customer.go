@@ -9,6 +9,7 @@ type Customer struct {
 	PhoneNumber    string `json:"phoneNumber"`
 	WhatsappNumber string `json:"whatsappNumber"`
 	Address        string `json:"address"`
+	State          string `json:"state"`
 
 	//transient field
 	Source string `json:"source" gorm:"-"`
@@ -5,7 +5,13 @@ type DressType struct {
 
 	Name         string `json:"name"`
 	Description  string `json:"description"`
-	Measurements string `json:"measurements"` //CSV of mesurement types Hip, Waist, Chest
+	Measurements string `json:"measurements"` //CSV of mesurement types Hip, Waist, Chest, kept in step with the labels of MeasurementFields
+
+	HSNCode string  `json:"hsnCode"`
+	TaxRate float64 `json:"taxRate" gorm:"type:decimal(5,2);default:0"` // GST rate in percent
+
+	// Schema the values of its measurements are checked against
+	MeasurementFields []MeasurementField `gorm:"foreignKey:DressTypeId" json:"measurementFields,omitempty"`
 }
 
 func (DressType) TableNameForQuery() string {
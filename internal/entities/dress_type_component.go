@@ -0,0 +1,20 @@
+package entities
+
+// DressTypeComponent is the quantity of a product consumed to stitch one piece of a dress type
+type DressTypeComponent struct {
+	*Model `mapstructure:",squash"`
+
+	DressTypeId uint       `json:"dressTypeId" gorm:"not null"`
+	DressType   *DressType `gorm:"foreignKey:DressTypeId" json:"dressType,omitempty"`
+
+	ProductId uint     `json:"productId" gorm:"not null"`
+	Product   *Product `gorm:"foreignKey:ProductId" json:"product,omitempty"`
+
+	Quantity  float64 `json:"quantity" gorm:"type:decimal(12,3);not null"` // In the product's stock unit
+	SingleLot bool    `json:"singleLot" gorm:"not null;default:false"`     // Cut from one dye lot, other lines are drawn oldest lot first
+	Notes     string  `json:"notes"`
+}
+
+func (DressTypeComponent) TableNameForQuery() string {
+	return "\"stich\".\"DressTypeComponents\" E"
+}
@@ -14,6 +14,10 @@ type Expense struct {
 	Location     *string    `json:"location,omitempty"`
 	Notes        *string    `json:"notes,omitempty"`
 
+	SupplierId      *uint `json:"supplierId,omitempty"`
+	PurchaseOrderId *uint `json:"purchaseOrderId,omitempty"` // Set when the expense was created by receiving a purchase order
+
+	Supplier       *Supplier       `gorm:"foreignKey:SupplierId" json:"supplier,omitempty"`
 	ExpenseDetails []ExpenseDetail `gorm:"foreignKey:ExpenseId" json:"expenseDetails,omitempty"`
 }
 
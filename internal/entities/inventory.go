@@ -1,11 +1,21 @@
 package entities
 
+import "time"
+
+// Inventory is the stock of a product in one channel
 type Inventory struct {
 	*Model `mapstructure:",squash"`
 
-	ProductId         uint `json:"productId" gorm:"unique;not null"`
-	Quantity          int  `json:"quantity" gorm:"not null;default:0"`
-	LowStockThreshold int  `json:"lowStockThreshold" gorm:"default:0"`
+	ProductId         uint    `json:"productId" gorm:"not null"`
+	Quantity          float64 `json:"quantity" gorm:"type:decimal(12,3);not null;default:0"`
+	LowStockThreshold float64 `json:"lowStockThreshold" gorm:"type:decimal(12,3);default:0"`
+
+	// Set when the channel owner has been alerted about low stock, cleared once it is replenished
+	LowStockAlertedAt *time.Time `json:"lowStockAlertedAt,omitempty"`
+
+	// Computed fields (populated by queries)
+	ChannelName      string  `gorm:"->" json:"-"`
+	ReservedQuantity float64 `gorm:"->" json:"-"` // Held back for confirmed orders
 
 	// Relations
 	Product *Product `gorm:"foreignKey:ProductId" json:"product,omitempty"`
@@ -15,7 +25,21 @@ func (Inventory) TableNameForQuery() string {
 	return "\"stich\".\"Inventories\" E"
 }
 
-// IsLowStock checks if current stock is below threshold
+// Available is the stock on hand that is not reserved for an order
+func (i *Inventory) Available() float64 {
+	return RoundQuantity(i.Quantity - i.ReservedQuantity)
+}
+
+// IsLowStock checks if the available stock is below threshold
 func (i *Inventory) IsLowStock() bool {
-	return i.Quantity <= i.LowStockThreshold
+	return i.Available() <= i.LowStockThreshold
+}
+
+// SuggestedReorder is the quantity that refills the stock to the threshold and covers the consumption again
+func (i *Inventory) SuggestedReorder(consumed float64) float64 {
+	suggested := RoundQuantity(i.LowStockThreshold - i.Available() + consumed)
+	if suggested < 0 {
+		return 0
+	}
+	return suggested
 }
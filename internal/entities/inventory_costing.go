@@ -0,0 +1,131 @@
+package entities
+
+type CostingMethod string
+
+const (
+	CostingMethodWEIGHTED_AVERAGE CostingMethod = "WEIGHTED_AVERAGE"
+	CostingMethodFIFO             CostingMethod = "FIFO"
+)
+
+func (m CostingMethod) IsValid() bool {
+	return m == CostingMethodWEIGHTED_AVERAGE || m == CostingMethodFIFO
+}
+
+type costLayer struct {
+	quantity float64
+	unitCost float64
+}
+
+// CostLedger replays the stock movements of a product and keeps the quantity and value on hand
+type CostLedger struct {
+	method   CostingMethod
+	quantity float64
+	value    float64
+	layers   []costLayer // FIFO only, oldest first
+	lastCost float64
+}
+
+// NewCostLedger starts an empty ledger, fallbackCost values stock before any cost is known
+func NewCostLedger(method CostingMethod, fallbackCost float64) *CostLedger {
+	if !method.IsValid() {
+		method = CostingMethodWEIGHTED_AVERAGE
+	}
+	return &CostLedger{method: method, lastCost: fallbackCost}
+}
+
+// Apply books a movement and returns the cost of the stock it took out
+func (l *CostLedger) Apply(log *InventoryLog) float64 {
+	change := log.CalculateNetChange()
+	switch {
+	case change > 0:
+		unitCost := l.UnitCost()
+		if log.UnitCost != nil {
+			unitCost = *log.UnitCost
+		}
+		l.add(change, unitCost)
+		return 0
+	case change < 0:
+		return l.remove(-change)
+	default:
+		return 0
+	}
+}
+
+func (l *CostLedger) Quantity() float64 {
+	return l.quantity
+}
+
+func (l *CostLedger) Value() float64 {
+	return roundAmount(l.value)
+}
+
+// UnitCost is the average cost of the stock on hand, or the last known cost when there is none
+func (l *CostLedger) UnitCost() float64 {
+	if l.quantity <= 0 {
+		return l.lastCost
+	}
+	return l.value / l.quantity
+}
+
+func (l *CostLedger) add(quantity float64, unitCost float64) {
+	l.lastCost = unitCost
+
+	// Units already taken out while stock was short were costed then
+	if l.quantity < 0 {
+		covered := min(quantity, -l.quantity)
+		l.quantity = RoundQuantity(l.quantity + covered)
+		quantity = RoundQuantity(quantity - covered)
+	}
+	if quantity == 0 {
+		return
+	}
+
+	l.quantity = RoundQuantity(l.quantity + quantity)
+	l.value += quantity * unitCost
+	if l.method == CostingMethodFIFO {
+		l.layers = append(l.layers, costLayer{quantity: quantity, unitCost: unitCost})
+	}
+}
+
+func (l *CostLedger) remove(quantity float64) float64 {
+	cost := 0.0
+	available := max(l.quantity, 0)
+	taken := min(quantity, available)
+
+	if taken > 0 {
+		if l.method == CostingMethodFIFO {
+			cost = l.takeLayers(taken)
+		} else {
+			cost = taken * l.UnitCost()
+			l.lastCost = l.UnitCost()
+		}
+		l.quantity = RoundQuantity(l.quantity - taken)
+		l.value -= cost
+		if l.quantity == 0 {
+			l.value = 0
+		}
+	}
+
+	if shortfall := RoundQuantity(quantity - taken); shortfall > 0 {
+		cost += shortfall * l.lastCost
+		l.quantity = RoundQuantity(l.quantity - shortfall)
+	}
+	return cost
+}
+
+// takeLayers consumes the oldest layers first and returns their cost
+func (l *CostLedger) takeLayers(quantity float64) float64 {
+	cost := 0.0
+	for quantity > 0 && len(l.layers) > 0 {
+		layer := &l.layers[0]
+		taken := min(quantity, layer.quantity)
+		cost += taken * layer.unitCost
+		l.lastCost = layer.unitCost
+		layer.quantity = RoundQuantity(layer.quantity - taken)
+		quantity = RoundQuantity(quantity - taken)
+		if layer.quantity == 0 {
+			l.layers = l.layers[1:]
+		}
+	}
+	return cost
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func stockIn(quantity float64, unitCost float64) *InventoryLog {
+	return &InventoryLog{ChangeType: InventoryLogChangeTypeIN, Quantity: quantity, UnitCost: &unitCost}
+}
+
+func stockOut(quantity float64) *InventoryLog {
+	return &InventoryLog{ChangeType: InventoryLogChangeTypeOUT, Quantity: quantity}
+}
+
+func Test_CostLedgerWeightedAverage(t *testing.T) {
+
+	ledger := NewCostLedger(CostingMethodWEIGHTED_AVERAGE, 0)
+	ledger.Apply(stockIn(10, 10))
+	ledger.Apply(stockIn(10, 20))
+	require.Equal(t, 300.0, ledger.Value())
+
+	require.InDelta(t, 75.0, ledger.Apply(stockOut(5)), 0.001)
+	require.Equal(t, 15.0, ledger.Quantity())
+	require.Equal(t, 225.0, ledger.Value())
+
+	// Positive adjustments come in at the average cost
+	ledger.Apply(&InventoryLog{ChangeType: InventoryLogChangeTypeADJUST, Quantity: 5})
+	require.Equal(t, 300.0, ledger.Value())
+}
+
+func Test_CostLedgerFIFO(t *testing.T) {
+
+	ledger := NewCostLedger(CostingMethodFIFO, 0)
+	ledger.Apply(stockIn(10, 10))
+	ledger.Apply(stockIn(10, 20))
+
+	require.InDelta(t, 140.0, ledger.Apply(stockOut(12)), 0.001)
+	require.Equal(t, 8.0, ledger.Quantity())
+	require.Equal(t, 160.0, ledger.Value())
+
+	// Taking out more than is on hand costs the shortfall at the last cost,
+	// the next receipt covers the shortfall before adding stock
+	require.InDelta(t, 200.0, ledger.Apply(stockOut(10)), 0.001)
+	require.Equal(t, -2.0, ledger.Quantity())
+	require.Equal(t, 0.0, ledger.Value())
+
+	ledger.Apply(stockIn(5, 30))
+	require.Equal(t, 3.0, ledger.Quantity())
+	require.Equal(t, 90.0, ledger.Value())
+}
+
+func Test_CostLedgerFractionalQuantities(t *testing.T) {
+
+	ledger := NewCostLedger(CostingMethodWEIGHTED_AVERAGE, 0)
+	ledger.Apply(stockIn(40, 5))
+
+	// Cutting 2.75 m three times leaves a 31.75 m remnant
+	for i := 0; i < 3; i++ {
+		require.InDelta(t, 13.75, ledger.Apply(stockOut(2.75)), 0.001)
+	}
+	require.Equal(t, 31.75, ledger.Quantity())
+	require.Equal(t, 158.75, ledger.Value())
+}
@@ -15,21 +15,71 @@ type InventoryLog struct {
 
 	ProductId  uint                   `json:"productId" gorm:"not null"`
 	ChangeType InventoryLogChangeType `json:"changeType" gorm:"type:varchar(20);not null"`
-	Quantity   int                    `json:"quantity" gorm:"not null"`
+	Quantity   float64                `json:"quantity" gorm:"type:decimal(12,3);not null"` // In the product's stock unit
 	Reason     string                 `json:"reason" gorm:"not null"`
 	Notes      string                 `json:"notes"`
 	LoggedAt   time.Time              `json:"loggedAt" gorm:"not null"`
 
+	// Quantity and unit as entered, set when the movement was given in another unit than the stock unit
+	EnteredQuantity *float64      `json:"enteredQuantity,omitempty" gorm:"type:decimal(12,3)"`
+	EnteredUnit     UnitOfMeasure `json:"enteredUnit,omitempty" gorm:"type:varchar(20)"`
+
+	// Cost of one unit of stock received, set on IN movements and used for valuation
+	UnitCost *float64 `json:"unitCost,omitempty"`
+
+	// Set when the stock was consumed for an order item
+	OrderItemId *uint `json:"orderItemId,omitempty"`
+
+	// Set when the stock was received against a purchase order
+	PurchaseOrderId *uint `json:"purchaseOrderId,omitempty"`
+
+	// Set on the OUT and IN movements of a transfer between channels
+	StockTransferId *uint `json:"stockTransferId,omitempty"`
+
+	// Set when the stock was sold over the counter
+	SaleId *uint `json:"saleId,omitempty"`
+
+	// A mistaken movement is undone by a reversing entry, the two point at each other
+	ReversalOfLogId *uint `json:"reversalOfLogId,omitempty" gorm:"uniqueIndex"` // Set on the reversing entry
+	ReversedByLogId *uint `json:"reversedByLogId,omitempty"`                    // Set on the entry that was reversed
+
+	// Lot the stock went into or was taken from, a movement drawing from several lots
+	// is logged once per lot
+	LotId *uint `json:"lotId,omitempty" gorm:"uniqueIndex:idx_stich_InventoryLogs_channel_id_idempotency_key_lot_id,priority:3,where:lot_id IS NOT NULL"`
+
+	// Client supplied key, unique per channel, a retried movement with the same key is not booked again.
+	// Every log of a movement drawn from several lots carries the key, one without a lot is unique on the key alone.
+	IdempotencyKey *string `json:"idempotencyKey,omitempty" gorm:"uniqueIndex:idx_stich_InventoryLogs_channel_id_idempotency_key_lot_id,priority:2,where:lot_id IS NOT NULL;uniqueIndex:idx_stich_InventoryLogs_channel_id_idempotency_key,priority:2,where:lot_id IS NULL"`
+
 	// Relations
-	Product *Product `gorm:"foreignKey:ProductId" json:"product,omitempty"`
+	Product   *Product      `gorm:"foreignKey:ProductId" json:"product,omitempty"`
+	OrderItem *OrderItem    `gorm:"foreignKey:OrderItemId" json:"orderItem,omitempty"`
+	Lot       *InventoryLot `gorm:"foreignKey:LotId" json:"lot,omitempty"`
 }
 
 func (InventoryLog) TableNameForQuery() string {
 	return "\"stich\".\"InventoryLogs\" E"
 }
 
+// IsReversed reports whether the movement has been undone by a reversing entry
+func (il *InventoryLog) IsReversed() bool {
+	return il.ReversedByLogId != nil
+}
+
+// Reversal returns the change type and quantity of the entry that undoes the movement
+func (il *InventoryLog) Reversal() (InventoryLogChangeType, float64) {
+	switch il.ChangeType {
+	case InventoryLogChangeTypeIN:
+		return InventoryLogChangeTypeOUT, il.Quantity
+	case InventoryLogChangeTypeOUT:
+		return InventoryLogChangeTypeIN, il.Quantity
+	default:
+		return il.ChangeType, -il.Quantity
+	}
+}
+
 // CalculateNetChange returns the net change in quantity based on change type
-func (il *InventoryLog) CalculateNetChange() int {
+func (il *InventoryLog) CalculateNetChange() float64 {
 	switch il.ChangeType {
 	case InventoryLogChangeTypeIN:
 		return il.Quantity
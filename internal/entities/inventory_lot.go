@@ -0,0 +1,62 @@
+package entities
+
+import "time"
+
+// InventoryLot is a batch of a product received in one go, e.g. a roll of fabric from one dye lot
+type InventoryLot struct {
+	*Model `mapstructure:",squash"`
+
+	ProductId         uint       `json:"productId" gorm:"not null"`
+	SupplierId        *uint      `json:"supplierId,omitempty"`
+	LotCode           string     `json:"lotCode"` // Dye-lot code printed on the roll, empty when not known
+	ReceivedAt        time.Time  `json:"receivedAt" gorm:"not null"`
+	Quantity          float64    `json:"quantity" gorm:"type:decimal(12,3);not null"`          // Received, in the product's stock unit
+	RemainingQuantity float64    `json:"remainingQuantity" gorm:"type:decimal(12,3);not null"` // Still in stock
+	ExpiresAt         *time.Time `json:"expiresAt,omitempty"`
+
+	// Relations
+	Product  *Product  `gorm:"foreignKey:ProductId" json:"product,omitempty"`
+	Supplier *Supplier `gorm:"foreignKey:SupplierId" json:"supplier,omitempty"`
+}
+
+func (InventoryLot) TableNameForQuery() string {
+	return "\"stich\".\"InventoryLots\" E"
+}
+
+// LotPick is the quantity taken from one lot by an outgoing movement
+type LotPick struct {
+	Lot      *InventoryLot
+	Quantity float64
+}
+
+// PickLots chooses the lots, oldest first, an outgoing quantity is taken from
+func PickLots(lots []InventoryLot, quantity float64, singleLot bool) (picks []LotPick, remainder float64, ok bool) {
+	quantity = RoundQuantity(quantity)
+
+	if singleLot {
+		for i := range lots {
+			if lots[i].RemainingQuantity >= quantity {
+				return []LotPick{{Lot: &lots[i], Quantity: quantity}}, 0, true
+			}
+		}
+		return nil, quantity, false
+	}
+
+	remainder = quantity
+	for i := range lots {
+		if remainder <= 0 {
+			break
+		}
+		if lots[i].RemainingQuantity <= 0 {
+			continue
+		}
+
+		take := lots[i].RemainingQuantity
+		if take > remainder {
+			take = remainder
+		}
+		picks = append(picks, LotPick{Lot: &lots[i], Quantity: take})
+		remainder = RoundQuantity(remainder - take)
+	}
+	return picks, remainder, true
+}
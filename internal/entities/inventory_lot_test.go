@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PickLots(t *testing.T) {
+
+	lots := func() []InventoryLot {
+		return []InventoryLot{
+			{Model: &Model{ID: 1}, LotCode: "DL-01", RemainingQuantity: 5},
+			{Model: &Model{ID: 2}, LotCode: "DL-02", RemainingQuantity: 0},
+			{Model: &Model{ID: 3}, LotCode: "DL-03", RemainingQuantity: 12.5},
+		}
+	}
+
+	// FIFO takes the oldest lot first and skips empty ones
+	picks, remainder, ok := PickLots(lots(), 8, false)
+	require.True(t, ok)
+	require.Equal(t, 0.0, remainder)
+	require.Len(t, picks, 2)
+	require.Equal(t, uint(1), picks[0].Lot.ID)
+	require.Equal(t, 5.0, picks[0].Quantity)
+	require.Equal(t, uint(3), picks[1].Lot.ID)
+	require.Equal(t, 3.0, picks[1].Quantity)
+
+	// More than the lots hold leaves a remainder
+	picks, remainder, ok = PickLots(lots(), 20, false)
+	require.True(t, ok)
+	require.Len(t, picks, 2)
+	require.Equal(t, 2.5, remainder)
+
+	// A single lot has to hold the whole quantity
+	picks, remainder, ok = PickLots(lots(), 8, true)
+	require.True(t, ok)
+	require.Equal(t, 0.0, remainder)
+	require.Len(t, picks, 1)
+	require.Equal(t, "DL-03", picks[0].Lot.LotCode)
+
+	_, _, ok = PickLots(lots(), 13, true)
+	require.False(t, ok)
+}
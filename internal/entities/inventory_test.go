@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InventoryAvailableAndReorder(t *testing.T) {
+
+	inventory := Inventory{Quantity: 12, ReservedQuantity: 7.5, LowStockThreshold: 5}
+
+	require.Equal(t, 4.5, inventory.Available())
+	require.True(t, inventory.IsLowStock())
+
+	// Back up to the threshold plus another window of consumption
+	require.Equal(t, 20.5, inventory.SuggestedReorder(20))
+	require.Equal(t, 0.5, inventory.SuggestedReorder(0))
+
+	inventory.ReservedQuantity = 0
+	require.False(t, inventory.IsLowStock())
+	require.Equal(t, 0.0, inventory.SuggestedReorder(0))
+}
+
+func Test_InventoryLogReversal(t *testing.T) {
+
+	for _, log := range []InventoryLog{
+		{ChangeType: InventoryLogChangeTypeIN, Quantity: 12.5},
+		{ChangeType: InventoryLogChangeTypeOUT, Quantity: 3},
+		{ChangeType: InventoryLogChangeTypeADJUST, Quantity: -2},
+	} {
+		changeType, quantity := log.Reversal()
+		reversal := InventoryLog{ChangeType: changeType, Quantity: quantity}
+		require.Equal(t, 0.0, log.CalculateNetChange()+reversal.CalculateNetChange())
+		require.True(t, reversal.Quantity > 0 || reversal.ChangeType == InventoryLogChangeTypeADJUST)
+	}
+}
@@ -1,11 +1,19 @@
 package entities
 
-import entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+
+	entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
+)
 
 type Measurement struct {
 	*Model `mapstructure:",squash"`
 
-	Value entitiy_types.JSON `gorm:"type:jsonb" json:"values"`
+	Value entitiy_types.JSON `gorm:"type:jsonb" json:"values"`                             // In MeasurementStorageUnit
+	Unit  MeasurementUnit    `gorm:"type:varchar(10);not null;default:'INCH'" json:"unit"` // Unit the values were taken in
 
 	PersonId uint    `json:"personId"`
 	Person   *Person `gorm:"foreignKey:PersonId" json:"person"`
@@ -21,3 +29,163 @@ func (Measurement) TableNameForQuery() string {
 	return "\"stich\".\"Measurements\" E"
 
 }
+
+type MeasurementChangeStatus string
+
+const (
+	MeasurementChangeAdded     MeasurementChangeStatus = "ADDED"
+	MeasurementChangeRemoved   MeasurementChangeStatus = "REMOVED"
+	MeasurementChangeChanged   MeasurementChangeStatus = "CHANGED"
+	MeasurementChangeUnchanged MeasurementChangeStatus = "UNCHANGED"
+)
+
+// MeasurementChange is how one value differs between two versions of a measurement
+type MeasurementChange struct {
+	Field  string                  `json:"field"`
+	From   *float64                `json:"from"`
+	To     *float64                `json:"to"`
+	Delta  *float64                `json:"delta,omitempty"` // To less From, when both are given
+	Status MeasurementChangeStatus `json:"status"`
+}
+
+// DiffMeasurementValues compares two versions of the values of a measurement field by field
+func DiffMeasurementValues(from, to entitiy_types.JSON) ([]MeasurementChange, error) {
+	fromValues, err := numericMeasurementValues(from)
+	if err != nil {
+		return nil, err
+	}
+	toValues, err := numericMeasurementValues(to)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(fromValues)+len(toValues))
+	for key := range fromValues {
+		keys = append(keys, key)
+	}
+	for key := range toValues {
+		if _, ok := fromValues[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	changes := make([]MeasurementChange, 0, len(keys))
+	for _, key := range keys {
+		change := MeasurementChange{Field: key}
+		if value, ok := fromValues[key]; ok {
+			change.From = &value
+		}
+		if value, ok := toValues[key]; ok {
+			change.To = &value
+		}
+
+		switch {
+		case change.From == nil:
+			change.Status = MeasurementChangeAdded
+		case change.To == nil:
+			change.Status = MeasurementChangeRemoved
+		default:
+			delta := roundMeasurement(*change.To-*change.From, MeasurementStorageDecimals)
+			change.Delta = &delta
+			change.Status = MeasurementChangeChanged
+			if delta == 0 {
+				change.Status = MeasurementChangeUnchanged
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// numericMeasurementValues reads the values given as numbers or numeric strings
+func numericMeasurementValues(values entitiy_types.JSON) (map[string]float64, error) {
+	numbers := make(map[string]float64)
+	raw := bytes.TrimSpace(values)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return numbers, nil
+	}
+
+	var given map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&given); err != nil {
+		return nil, err
+	}
+
+	for key, value := range given {
+		if number, present, err := measurementValue(value); err == nil && present {
+			numbers[key] = number
+		}
+	}
+	return numbers, nil
+}
+
+// MeasurementSnapshot is a measurement and its dress type schema frozen on an order item
+type MeasurementSnapshot struct {
+	MeasurementId uint                       `json:"measurementId"`
+	PersonId      uint                       `json:"personId"`
+	DressTypeId   uint                       `json:"dressTypeId"`
+	Values        entitiy_types.JSON         `json:"values"` // In MeasurementStorageUnit
+	Unit          MeasurementUnit            `json:"unit"`   // Unit the values were taken in
+	MeasuredAt    *time.Time                 `json:"measuredAt,omitempty"`
+	Fields        []MeasurementSnapshotField `json:"fields"`
+}
+
+// MeasurementSnapshotField is a field of the schema as it was when the snapshot was taken
+type MeasurementSnapshotField struct {
+	Key          string          `json:"key"`
+	Label        string          `json:"label"`
+	Unit         MeasurementUnit `json:"unit"`
+	MinValue     *float64        `json:"minValue,omitempty"`
+	MaxValue     *float64        `json:"maxValue,omitempty"`
+	Required     bool            `json:"required"`
+	GroupName    string          `json:"groupName"`
+	DisplayOrder int             `json:"displayOrder"`
+}
+
+func NewMeasurementSnapshot(measurement Measurement, fields []MeasurementField) MeasurementSnapshot {
+	snapshot := MeasurementSnapshot{
+		PersonId:    measurement.PersonId,
+		DressTypeId: measurement.DressTypeId,
+		Values:      measurement.Value,
+		Unit:        measurement.Unit,
+		Fields:      make([]MeasurementSnapshotField, 0, len(fields)),
+	}
+	if measurement.Model != nil {
+		snapshot.MeasurementId = measurement.ID
+		snapshot.MeasuredAt = measurement.UpdatedAt
+	}
+
+	for _, field := range fields {
+		snapshot.Fields = append(snapshot.Fields, MeasurementSnapshotField{
+			Key:          field.Key,
+			Label:        field.Label,
+			Unit:         field.Unit,
+			MinValue:     field.MinValue,
+			MaxValue:     field.MaxValue,
+			Required:     field.Required,
+			GroupName:    field.GroupName,
+			DisplayOrder: field.DisplayOrder,
+		})
+	}
+	return snapshot
+}
+
+// ChangedSince reports whether the measurement no longer holds the values of the snapshot
+func (s MeasurementSnapshot) ChangedSince(current Measurement) (bool, error) {
+	if current.Model != nil && current.ID != s.MeasurementId {
+		return true, nil
+	}
+
+	changes, err := DiffMeasurementValues(s.Values, current.Value)
+	if err != nil {
+		return false, err
+	}
+	for _, change := range changes {
+		if change.Status != MeasurementChangeUnchanged {
+			return true, nil
+		}
+	}
+	return false, nil
+}
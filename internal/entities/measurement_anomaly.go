@@ -0,0 +1,127 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
+)
+
+type MeasurementAnomalyKind string
+
+const (
+	MeasurementAnomalyJump       MeasurementAnomalyKind = "JUMP"         // Changed more than expected since the previous measurement
+	MeasurementAnomalyOutOfRange MeasurementAnomalyKind = "OUT_OF_RANGE" // Far from what the channel usually measures for the dress type
+	MeasurementAnomalyRule       MeasurementAnomalyKind = "RULE"         // Does not fit with another field, e.g. sleeve shorter than shoulder
+)
+
+// MeasurementAnomaly is a value that looks like a data-entry mistake
+type MeasurementAnomaly struct {
+	Field   string                 `json:"field"`
+	Kind    MeasurementAnomalyKind `json:"kind"`
+	Message string                 `json:"message"`
+}
+
+// MeasurementRule expects the value of Field to be at least the value of AtLeast
+type MeasurementRule struct {
+	Field   string `json:"field"`
+	AtLeast string `json:"atLeast"`
+}
+
+// MeasurementAnomalySettings are read from the Measurement.AnomalyCheck master config
+type MeasurementAnomalySettings struct {
+	JumpThreshold   float64           `json:"jumpThreshold"`   // Largest change from the previous value, in MeasurementStorageUnit
+	RangeDeviations float64           `json:"rangeDeviations"` // Standard deviations a value may be from the channel's mean
+	MinSamples      int               `json:"minSamples"`      // Values of a field needed before its range is checked
+	Rules           []MeasurementRule `json:"rules"`
+}
+
+var DefaultMeasurementAnomalySettings = MeasurementAnomalySettings{
+	JumpThreshold:   3,
+	RangeDeviations: 3,
+	MinSamples:      10,
+	Rules: []MeasurementRule{
+		{Field: "sleeve_length", AtLeast: "shoulder_width"},
+		{Field: "sleeve", AtLeast: "shoulder"},
+	},
+}
+
+// MeasurementFieldStats is how a field of a dress type is measured across the channel, in MeasurementStorageUnit
+type MeasurementFieldStats struct {
+	Field   string  `json:"field"`
+	Mean    float64 `json:"mean"`
+	StdDev  float64 `json:"stdDev"`
+	Samples int     `json:"samples"`
+}
+
+// DetectMeasurementAnomalies looks for values that are likely data-entry mistakes
+func DetectMeasurementAnomalies(values, previous entitiy_types.JSON, stats []MeasurementFieldStats, settings MeasurementAnomalySettings, unit MeasurementUnit) ([]MeasurementAnomaly, error) {
+	current, err := numericMeasurementValues(values)
+	if err != nil {
+		return nil, err
+	}
+	before, err := numericMeasurementValues(previous)
+	if err != nil {
+		return nil, err
+	}
+
+	statsByField := make(map[string]MeasurementFieldStats, len(stats))
+	for _, fieldStats := range stats {
+		statsByField[fieldStats.Field] = fieldStats
+	}
+
+	show := func(value float64) string {
+		return strconv.FormatFloat(roundMeasurement(ConvertMeasurement(value, MeasurementStorageUnit, unit), MeasurementDisplayDecimals), 'f', -1, 64)
+	}
+
+	keys := make([]string, 0, len(current))
+	for key := range current {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	anomalies := make([]MeasurementAnomaly, 0)
+	for _, key := range keys {
+		value := current[key]
+
+		if old, ok := before[key]; ok && settings.JumpThreshold > 0 {
+			change := value - old
+			if change < 0 {
+				change = -change
+			}
+			if roundMeasurement(change, MeasurementStorageDecimals) > settings.JumpThreshold {
+				anomalies = append(anomalies, MeasurementAnomaly{
+					Field:   key,
+					Kind:    MeasurementAnomalyJump,
+					Message: fmt.Sprintf("changed by %s %s since the previous measurement, from %s to %s %s", show(change), unit.Symbol(), show(old), show(value), unit.Symbol()),
+				})
+			}
+		}
+
+		if fieldStats, ok := statsByField[key]; ok && fieldStats.Samples >= settings.MinSamples && fieldStats.StdDev > 0 && settings.RangeDeviations > 0 {
+			low := fieldStats.Mean - settings.RangeDeviations*fieldStats.StdDev
+			high := fieldStats.Mean + settings.RangeDeviations*fieldStats.StdDev
+			if value < low || value > high {
+				anomalies = append(anomalies, MeasurementAnomaly{
+					Field:   key,
+					Kind:    MeasurementAnomalyOutOfRange,
+					Message: fmt.Sprintf("%s %s is outside the usual %s to %s %s for this dress type", show(value), unit.Symbol(), show(low), show(high), unit.Symbol()),
+				})
+			}
+		}
+
+		for _, rule := range settings.Rules {
+			other, ok := current[rule.AtLeast]
+			if rule.Field != key || !ok || value >= other {
+				continue
+			}
+			anomalies = append(anomalies, MeasurementAnomaly{
+				Field:   key,
+				Kind:    MeasurementAnomalyRule,
+				Message: fmt.Sprintf("is shorter than %s, %s against %s %s", rule.AtLeast, show(value), show(other), unit.Symbol()),
+			})
+		}
+	}
+	return anomalies, nil
+}
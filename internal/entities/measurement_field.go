@@ -0,0 +1,307 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
+)
+
+type MeasurementUnit string
+
+const (
+	MeasurementUnitINCH MeasurementUnit = "INCH"
+	MeasurementUnitCM   MeasurementUnit = "CM"
+)
+
+func (u MeasurementUnit) IsValid() bool {
+	switch u {
+	case MeasurementUnitINCH, MeasurementUnitCM:
+		return true
+	}
+	return false
+}
+
+// Symbol is the short form of the unit shown next to a value
+func (u MeasurementUnit) Symbol() string {
+	if u == MeasurementUnitCM {
+		return "cm"
+	}
+	return "in"
+}
+
+// MeasurementStorageUnit is the unit measurement values are kept in, whatever unit they were taken in
+const MeasurementStorageUnit = MeasurementUnitINCH
+
+const centimetresPerInch = 2.54
+
+// Values are stored with enough decimals to give back what was entered in either unit
+const (
+	MeasurementStorageDecimals = 4
+	MeasurementDisplayDecimals = 2
+)
+
+// ConvertMeasurement converts a value between inches and centimetres
+func ConvertMeasurement(value float64, from, to MeasurementUnit) float64 {
+	switch {
+	case from == MeasurementUnitCM && to == MeasurementUnitINCH:
+		return value / centimetresPerInch
+	case from == MeasurementUnitINCH && to == MeasurementUnitCM:
+		return value * centimetresPerInch
+	}
+	return value
+}
+
+// ConvertMeasurementValues converts the numeric values of a measurement between units
+func ConvertMeasurementValues(values entitiy_types.JSON, from, to MeasurementUnit, decimals int) (entitiy_types.JSON, error) {
+	raw := bytes.TrimSpace(values)
+	if len(raw) == 0 || raw[0] != '{' {
+		return values, nil
+	}
+
+	var given map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &given); err != nil {
+		return nil, err
+	}
+
+	converted := make(map[string]json.RawMessage, len(given))
+	for key, rawValue := range given {
+		var value interface{}
+		decoder := json.NewDecoder(bytes.NewReader(rawValue))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		number, present, err := measurementValue(value)
+		if err != nil || !present {
+			converted[key] = rawValue
+			continue
+		}
+		converted[key] = json.RawMessage(strconv.FormatFloat(roundMeasurement(ConvertMeasurement(number, from, to), decimals), 'f', -1, 64))
+	}
+
+	result, err := json.Marshal(converted)
+	if err != nil {
+		return nil, err
+	}
+	return entitiy_types.JSON(result), nil
+}
+
+func roundMeasurement(value float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return math.Round(value*scale) / scale
+}
+
+// ParseMeasurementUnit accepts the unit in any case, an empty value is returned as is
+func ParseMeasurementUnit(value string) MeasurementUnit {
+	return MeasurementUnit(strings.ToUpper(strings.TrimSpace(value)))
+}
+
+// MeasurementField is one measurement in the schema of a dress type
+type MeasurementField struct {
+	*Model `mapstructure:",squash"`
+
+	DressTypeId uint       `json:"dressTypeId" gorm:"not null"`
+	DressType   *DressType `gorm:"foreignKey:DressTypeId" json:"dressType,omitempty"`
+
+	Key       string          `json:"key" gorm:"type:varchar(50);not null"` // waist, shoulder_width
+	Label     string          `json:"label" gorm:"not null"`
+	Unit      MeasurementUnit `json:"unit" gorm:"type:varchar(10);not null"`
+	MinValue  *float64        `json:"minValue,omitempty" gorm:"type:decimal(8,2)"`
+	MaxValue  *float64        `json:"maxValue,omitempty" gorm:"type:decimal(8,2)"`
+	Required  bool            `json:"required" gorm:"not null;default:false"`
+	GroupName string          `json:"groupName"` // Fields are shown under their group, e.g. Upper body
+
+	DisplayOrder int `json:"displayOrder" gorm:"not null;default:0"`
+}
+
+func (MeasurementField) TableNameForQuery() string {
+	return "\"stich\".\"MeasurementFields\" E"
+}
+
+var measurementFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+var nonKeyCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+// IsValidMeasurementFieldKey allows lower case letters, digits and underscores, starting with a letter
+func IsValidMeasurementFieldKey(key string) bool {
+	return len(key) <= 50 && measurementFieldKeyPattern.MatchString(key)
+}
+
+// MeasurementFieldKey turns a label into a key, "Shoulder Width" becomes shoulder_width
+func MeasurementFieldKey(label string) string {
+	return strings.Trim(nonKeyCharacters.ReplaceAllString(strings.ToLower(label), "_"), "_")
+}
+
+// MeasurementFieldError is a problem with one value of a measurement
+type MeasurementFieldError struct {
+	DressTypeId uint   `json:"dressTypeId,omitempty"`
+	Field       string `json:"field"`
+	Message     string `json:"message"`
+}
+
+type MeasurementFieldErrors []MeasurementFieldError
+
+func (e MeasurementFieldErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldError := range e {
+		if fieldError.Field == "" {
+			messages = append(messages, fieldError.Message)
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", fieldError.Field, fieldError.Message))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ValidateMeasurementValues checks the values of a measurement against the schema of its dress type
+func ValidateMeasurementValues(fields []MeasurementField, values entitiy_types.JSON, unit MeasurementUnit) MeasurementFieldErrors {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	given := make(map[string]interface{})
+	if raw := bytes.TrimSpace(values); len(raw) > 0 && !bytes.Equal(raw, []byte("null")) {
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&given); err != nil {
+			return MeasurementFieldErrors{{Message: "Values must be an object of measurement keys and numbers"}}
+		}
+	}
+
+	var fieldErrors MeasurementFieldErrors
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field.Key] = true
+
+		value, present, err := measurementValue(given[field.Key])
+		if err != nil {
+			fieldErrors = append(fieldErrors, MeasurementFieldError{Field: field.Key, Message: err.Error()})
+			continue
+		}
+		if !present {
+			if field.Required {
+				fieldErrors = append(fieldErrors, MeasurementFieldError{Field: field.Key, Message: "is required"})
+			}
+			continue
+		}
+
+		if message := field.checkBounds(value, unit); message != "" {
+			fieldErrors = append(fieldErrors, MeasurementFieldError{Field: field.Key, Message: message})
+		}
+	}
+
+	unknown := make([]string, 0)
+	for key := range given {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		message := "is not a measurement of this dress type"
+		if suggestion := closestMeasurementKey(key, fields); suggestion != "" {
+			message = fmt.Sprintf("is not a measurement of this dress type, did you mean %s?", suggestion)
+		}
+		fieldErrors = append(fieldErrors, MeasurementFieldError{Field: key, Message: message})
+	}
+
+	return fieldErrors
+}
+
+// measurementValue reads a value given as a number or a numeric string
+func measurementValue(value interface{}) (float64, bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, false, nil
+	case json.Number:
+		number, err := v.Float64()
+		if err != nil {
+			return 0, false, fmt.Errorf("must be a number")
+		}
+		return number, true, nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return 0, false, nil
+		}
+		number, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("must be a number")
+		}
+		return number, true, nil
+	}
+	return 0, false, fmt.Errorf("must be a number")
+}
+
+// checkBounds compares a value with the bounds of the field, both in the unit the value was taken in
+func (f MeasurementField) checkBounds(value float64, unit MeasurementUnit) string {
+	bound := func(limit *float64) (float64, string) {
+		converted := roundMeasurement(ConvertMeasurement(*limit, f.Unit, unit), MeasurementDisplayDecimals)
+		return converted, strconv.FormatFloat(converted, 'f', -1, 64)
+	}
+
+	if value <= 0 {
+		return "must be greater than 0"
+	}
+	if f.MinValue != nil && f.MaxValue != nil {
+		minValue, minText := bound(f.MinValue)
+		maxValue, maxText := bound(f.MaxValue)
+		if value < minValue || value > maxValue {
+			return fmt.Sprintf("must be between %s and %s %s", minText, maxText, unit.Symbol())
+		}
+		return ""
+	}
+	if f.MinValue != nil {
+		if minValue, minText := bound(f.MinValue); value < minValue {
+			return fmt.Sprintf("must be at least %s %s", minText, unit.Symbol())
+		}
+	}
+	if f.MaxValue != nil {
+		if maxValue, maxText := bound(f.MaxValue); value > maxValue {
+			return fmt.Sprintf("must be at most %s %s", maxText, unit.Symbol())
+		}
+	}
+	return ""
+}
+
+// closestMeasurementKey suggests the field a mistyped key was meant for
+func closestMeasurementKey(key string, fields []MeasurementField) string {
+	lower := strings.ToLower(key)
+	best, bestDistance := "", 3
+	for _, field := range fields {
+		if strings.EqualFold(field.Label, key) {
+			return field.Key
+		}
+		if distance := editDistance(lower, field.Key); distance < bestDistance {
+			best, bestDistance = field.Key, distance
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,120 @@
+package entities
+
+import (
+	"testing"
+
+	entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidateMeasurementValues(t *testing.T) {
+
+	minWaist, maxWaist := 20.0, 60.0
+	fields := []MeasurementField{
+		{Key: "waist", Label: "Waist", Unit: MeasurementUnitINCH, MinValue: &minWaist, MaxValue: &maxWaist, Required: true},
+		{Key: "shoulder_width", Label: "Shoulder Width", Unit: MeasurementUnitINCH},
+	}
+
+	require.Empty(t, ValidateMeasurementValues(fields, entitiy_types.JSON(`{"waist": 32, "shoulder_width": "15.5"}`), MeasurementUnitINCH))
+	require.Empty(t, ValidateMeasurementValues(fields, entitiy_types.JSON(`{"waist": "32", "shoulder_width": ""}`), MeasurementUnitINCH))
+	// Dress types without a schema take any values
+	require.Empty(t, ValidateMeasurementValues(nil, entitiy_types.JSON(`{"anything": "goes"}`), MeasurementUnitINCH))
+
+	fieldErrors := ValidateMeasurementValues(fields, entitiy_types.JSON(`{"wasit": 32, "shoulder_width": "wide"}`), MeasurementUnitINCH)
+	require.Equal(t, MeasurementFieldErrors{
+		{Field: "waist", Message: "is required"},
+		{Field: "shoulder_width", Message: "must be a number"},
+		{Field: "wasit", Message: "is not a measurement of this dress type, did you mean waist?"},
+	}, fieldErrors)
+
+	fieldErrors = ValidateMeasurementValues(fields, entitiy_types.JSON(`{"waist": 340, "Shoulder Width": 0}`), MeasurementUnitINCH)
+	require.Equal(t, "waist: must be between 20 and 60 in; Shoulder Width: is not a measurement of this dress type, did you mean shoulder_width?", fieldErrors.Error())
+
+	// Bounds are shown in the unit the values were taken in
+	require.Empty(t, ValidateMeasurementValues(fields, entitiy_types.JSON(`{"waist": 81.5}`), MeasurementUnitCM))
+	fieldErrors = ValidateMeasurementValues(fields, entitiy_types.JSON(`{"waist": 32}`), MeasurementUnitCM)
+	require.Equal(t, "waist: must be between 50.8 and 152.4 cm", fieldErrors.Error())
+
+	require.Len(t, ValidateMeasurementValues(fields, entitiy_types.JSON(`[32]`), MeasurementUnitINCH), 1)
+	require.Equal(t, "shoulder_width", MeasurementFieldKey(" Shoulder Width "))
+	require.True(t, IsValidMeasurementFieldKey("sleeve_length_2"))
+	require.False(t, IsValidMeasurementFieldKey("2_sleeve"))
+}
+
+func Test_ConvertMeasurementValues(t *testing.T) {
+
+	stored, err := ConvertMeasurementValues(entitiy_types.JSON(`{"waist": 81, "hip": "96.5", "notes": "loose fit"}`), MeasurementUnitCM, MeasurementStorageUnit, MeasurementStorageDecimals)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"waist": 31.8898, "hip": 37.9921, "notes": "loose fit"}`, string(stored))
+
+	// What was entered comes back when shown in the same unit
+	shown, err := ConvertMeasurementValues(stored, MeasurementStorageUnit, MeasurementUnitCM, MeasurementDisplayDecimals)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"waist": 81, "hip": 96.5, "notes": "loose fit"}`, string(shown))
+
+	shown, err = ConvertMeasurementValues(entitiy_types.JSON(`{"waist": 32}`), MeasurementUnitINCH, MeasurementUnitINCH, MeasurementDisplayDecimals)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"waist": 32}`, string(shown))
+
+	empty, err := ConvertMeasurementValues(nil, MeasurementUnitINCH, MeasurementUnitCM, MeasurementDisplayDecimals)
+	require.NoError(t, err)
+	require.Empty(t, empty)
+}
+
+func Test_DiffMeasurementValues(t *testing.T) {
+
+	changes, err := DiffMeasurementValues(
+		entitiy_types.JSON(`{"waist": 32, "hip": "38", "chest": 40, "notes": "loose"}`),
+		entitiy_types.JSON(`{"waist": 33.5, "hip": 38, "sleeve": 24}`),
+	)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+
+	require.Equal(t, "chest", changes[0].Field)
+	require.Equal(t, MeasurementChangeRemoved, changes[0].Status)
+	require.Nil(t, changes[0].To)
+
+	require.Equal(t, "hip", changes[1].Field)
+	require.Equal(t, MeasurementChangeUnchanged, changes[1].Status)
+
+	require.Equal(t, "sleeve", changes[2].Field)
+	require.Equal(t, MeasurementChangeAdded, changes[2].Status)
+	require.Nil(t, changes[2].Delta)
+
+	require.Equal(t, "waist", changes[3].Field)
+	require.Equal(t, MeasurementChangeChanged, changes[3].Status)
+	require.Equal(t, 1.5, *changes[3].Delta)
+
+	changes, err = DiffMeasurementValues(nil, entitiy_types.JSON(`{"waist": 32}`))
+	require.NoError(t, err)
+	require.Equal(t, MeasurementChangeAdded, changes[0].Status)
+}
+
+func Test_DetectMeasurementAnomalies(t *testing.T) {
+
+	previous := entitiy_types.JSON(`{"waist": 32, "chest": 38}`)
+	values := entitiy_types.JSON(`{"waist": 36, "chest": "40", "sleeve_length": 6, "shoulder_width": 15, "hip": 80}`)
+	stats := []MeasurementFieldStats{
+		{Field: "hip", Mean: 40, StdDev: 3, Samples: 25},
+		{Field: "chest", Mean: 38, StdDev: 2, Samples: 25},
+		{Field: "sleeve_length", Mean: 22, StdDev: 4, Samples: 4}, // Too few to have a range
+	}
+
+	anomalies, err := DetectMeasurementAnomalies(values, previous, stats, DefaultMeasurementAnomalySettings, MeasurementUnitINCH)
+	require.NoError(t, err)
+	require.Equal(t, []MeasurementAnomaly{
+		{Field: "hip", Kind: MeasurementAnomalyOutOfRange, Message: "80 in is outside the usual 31 to 49 in for this dress type"},
+		{Field: "sleeve_length", Kind: MeasurementAnomalyRule, Message: "is shorter than shoulder_width, 6 against 15 in"},
+		{Field: "waist", Kind: MeasurementAnomalyJump, Message: "changed by 4 in since the previous measurement, from 32 to 36 in"},
+	}, anomalies)
+
+	// Messages are in the unit the measurement was taken in
+	anomalies, err = DetectMeasurementAnomalies(entitiy_types.JSON(`{"waist": 36}`), previous, nil, DefaultMeasurementAnomalySettings, MeasurementUnitCM)
+	require.NoError(t, err)
+	require.Equal(t, "changed by 10.16 cm since the previous measurement, from 81.28 to 91.44 cm", anomalies[0].Message)
+
+	// A change up to the threshold is not flagged
+	anomalies, err = DetectMeasurementAnomalies(entitiy_types.JSON(`{"waist": 35}`), previous, nil, DefaultMeasurementAnomalySettings, MeasurementUnitINCH)
+	require.NoError(t, err)
+	require.Empty(t, anomalies)
+}
@@ -0,0 +1,41 @@
+package entities
+
+import "time"
+
+type MeasurementFlagStatus string
+
+const (
+	MeasurementFlagOpen       MeasurementFlagStatus = "OPEN"
+	MeasurementFlagReviewed   MeasurementFlagStatus = "REVIEWED"
+	MeasurementFlagSuperseded MeasurementFlagStatus = "SUPERSEDED" // The measurement was saved again before the flag was reviewed
+)
+
+func (s MeasurementFlagStatus) IsValid() bool {
+	switch s {
+	case MeasurementFlagOpen, MeasurementFlagReviewed, MeasurementFlagSuperseded:
+		return true
+	}
+	return false
+}
+
+// MeasurementFlag keeps an anomaly found when a measurement was saved, for review
+type MeasurementFlag struct {
+	*Model `mapstructure:",squash"`
+
+	MeasurementId uint         `json:"measurementId" gorm:"not null"`
+	Measurement   *Measurement `gorm:"foreignKey:MeasurementId" json:"measurement,omitempty"`
+
+	Field   string                 `json:"field" gorm:"type:varchar(50);not null"`
+	Kind    MeasurementAnomalyKind `json:"kind" gorm:"type:varchar(20);not null"`
+	Message string                 `json:"message" gorm:"not null"`
+	Status  MeasurementFlagStatus  `json:"status" gorm:"type:varchar(20);not null;default:'OPEN'"`
+
+	ReviewedById *uint      `json:"reviewedById,omitempty"`
+	ReviewedBy   *User      `gorm:"foreignKey:ReviewedById" json:"reviewedBy,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewedAt,omitempty"`
+	ReviewNote   string     `json:"reviewNote"`
+}
+
+func (MeasurementFlag) TableNameForQuery() string {
+	return "\"stich\".\"MeasurementFlags\" E"
+}
@@ -1,6 +1,9 @@
 package entities
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type OrderStatus string
 
@@ -17,6 +20,57 @@ const (
 	CANCELLED           OrderStatus = "CANCELLED"
 )
 
+// OrderStatusTransitions maps a status to the statuses an order is allowed to move to from it.
+type OrderStatusTransitions map[OrderStatus][]OrderStatus
+
+// DefaultOrderStatusTransitions is used when the channel has no Order.StatusTransitions master config
+var DefaultOrderStatusTransitions = OrderStatusTransitions{
+	DRAFT:               {CONFIRMED, CANCELLED},
+	CONFIRMED:           {DESIGN_CONFIRMED, RAW_MATERIAL_SOURCE, CUTTING, CANCELLED},
+	DESIGN_CONFIRMED:    {RAW_MATERIAL_SOURCE, CUTTING, CANCELLED},
+	RAW_MATERIAL_SOURCE: {CUTTING, CANCELLED},
+	CUTTING:             {STITCHING, CANCELLED},
+	STITCHING:           {FINISHING, CANCELLED},
+	FINISHING:           {READY_FOR_DELIVERY, STITCHING, CANCELLED},
+	READY_FOR_DELIVERY:  {DELIVERED, FINISHING},
+	DELIVERED:           {},
+	CANCELLED:           {},
+}
+
+// Allows reports whether moving from one status to another is part of the transition graph
+func (t OrderStatusTransitions) Allows(from, to OrderStatus) bool {
+	for _, next := range t[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid checks if the status is one of the known order statuses
+func (s OrderStatus) IsValid() bool {
+	_, ok := DefaultOrderStatusTransitions[s]
+	return ok
+}
+
+// ReservesStock reports whether an order in this status holds its materials back from stock
+func (s OrderStatus) ReservesStock() bool {
+	switch s {
+	case DRAFT, DELIVERED, CANCELLED:
+		return false
+	}
+	return s.IsValid()
+}
+
+// CanBeInvoiced reports whether an invoice may be issued for an order in this status
+func (s OrderStatus) CanBeInvoiced() bool {
+	switch s {
+	case DRAFT, CANCELLED:
+		return false
+	}
+	return s.IsValid()
+}
+
 type Order struct {
 	*Model `mapstructure:",squash"`
 
@@ -29,6 +83,11 @@ type Order struct {
 	ExpectedDeliveryDate *time.Time `json:"expectedDeliveryDate,omitempty"`
 	DeliveredDate        *time.Time `json:"deliveredDate,omitempty"`
 
+	// Set when the invoice is issued, on delivery or on request, from the channel's invoice sequence
+	InvoiceSequence *uint      `gorm:"uniqueIndex:idx_stich_Orders_channel_id_invoice_sequence,priority:2" json:"-"`
+	InvoiceNumber   string     `gorm:"type:varchar(20)" json:"invoiceNumber,omitempty"`
+	InvoicedAt      *time.Time `json:"invoicedAt,omitempty"`
+
 	CustomerId *uint     `json:"customerId"`
 	Customer   *Customer `gorm:"foreignKey:CustomerId" json:"customer"`
 
@@ -40,8 +99,26 @@ type Order struct {
 	// Transient/Calculated fields (populated via SQL subqueries, not stored in DB)
 	OrderQuantity int     `gorm:"->" json:"-"`
 	OrderValue    float64 `gorm:"->" json:"-"`
+	CGSTTotal     float64 `gorm:"->" json:"-"`
+	SGSTTotal     float64 `gorm:"->" json:"-"`
+	IGSTTotal     float64 `gorm:"->" json:"-"`
+	TaxTotal      float64 `gorm:"->" json:"-"`
+	PaidAmount    float64 `gorm:"->" json:"-"`
+	BalanceDue    float64 `gorm:"->" json:"-"`
 }
 
 func (Order) TableNameForQuery() string {
 	return "\"stich\".\"Orders\" E"
 }
+
+// KeepInvoice carries the issued invoice number and date over from the stored order
+func (o *Order) KeepInvoice(stored Order) {
+	o.InvoiceSequence = stored.InvoiceSequence
+	o.InvoiceNumber = stored.InvoiceNumber
+	o.InvoicedAt = stored.InvoicedAt
+}
+
+// InvoiceNumber formats the number printed on the invoice with the given place in the channel's sequence
+func InvoiceNumber(sequence uint) string {
+	return fmt.Sprintf("INV-%06d", sequence)
+}
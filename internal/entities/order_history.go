@@ -19,6 +19,8 @@ const (
 	OrderChangeFieldStatus               string = "status"
 	OrderChangeFieldExpectedDeliveryDate string = "expectedDeliveryDate"
 	OrderChangeFieldDeliveredDate        string = "deliveredDate"
+	OrderChangeFieldItemStage            string = "itemStage"
+	OrderChangeFieldAssignedTailor       string = "assignedTailorId"
 )
 
 type OrderHistory struct {
@@ -29,6 +31,9 @@ type OrderHistory struct {
 	// Comma-separated list of changed fields (e.g., "status,expectedDeliveryDate")
 	ChangedFields string `json:"changedFields,omitempty"`
 
+	// Reason given by the user for a status transition
+	Reason string `json:"reason,omitempty"`
+
 	Status               *OrderStatus `gorm:"type:text" json:"status,omitempty"`
 	ExpectedDeliveryDate *time.Time   `json:"expectedDeliveryDate,omitempty"`
 	DeliveredDate        *time.Time   `json:"deliveredDate,omitempty"`
@@ -1,6 +1,84 @@
 package entities
 
-import "time"
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"time"
+
+	entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
+)
+
+type OrderItemStage string
+
+const (
+	STAGE_CUTTING   OrderItemStage = "CUTTING"
+	STAGE_STITCHING OrderItemStage = "STITCHING"
+	STAGE_FINISHING OrderItemStage = "FINISHING"
+	STAGE_QC        OrderItemStage = "QC"
+	STAGE_READY     OrderItemStage = "READY"
+)
+
+// OrderItemStages lists the production stages in the order an item goes through them
+var OrderItemStages = []OrderItemStage{STAGE_CUTTING, STAGE_STITCHING, STAGE_FINISHING, STAGE_QC, STAGE_READY}
+
+// orderStatusByStage is the order status an order is in while its least-advanced item is at the stage
+var orderStatusByStage = map[OrderItemStage]OrderStatus{
+	STAGE_CUTTING:   CUTTING,
+	STAGE_STITCHING: STITCHING,
+	STAGE_FINISHING: FINISHING,
+	STAGE_QC:        FINISHING,
+	STAGE_READY:     READY_FOR_DELIVERY,
+}
+
+// rank is the position of the stage in the production line, -1 when the item has not started
+func (s OrderItemStage) rank() int {
+	for i, stage := range OrderItemStages {
+		if stage == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsValid checks if the stage is one of the known production stages
+func (s OrderItemStage) IsValid() bool {
+	return s.rank() >= 0
+}
+
+// CanMoveTo allows moving forward one stage at a time, or back to any earlier stage for rework
+func (s OrderItemStage) CanMoveTo(to OrderItemStage) bool {
+	if !to.IsValid() || to == s {
+		return false
+	}
+	return to.rank() <= s.rank()+1
+}
+
+// OrderStatus is the order status that corresponds to the stage
+func (s OrderItemStage) OrderStatus() OrderStatus {
+	return orderStatusByStage[s]
+}
+
+// DeriveOrderStatus returns the status of an order from its least-advanced active item
+func DeriveOrderStatus(items []OrderItem) (OrderStatus, bool) {
+	leastAdvanced := -1
+	for _, item := range items {
+		if item.Model != nil && !item.IsActive {
+			continue
+		}
+		rank := item.Stage.rank()
+		if rank < 0 {
+			return "", false
+		}
+		if leastAdvanced < 0 || rank < leastAdvanced {
+			leastAdvanced = rank
+		}
+	}
+	if leastAdvanced < 0 {
+		return "", false
+	}
+	return OrderItemStages[leastAdvanced].OrderStatus(), true
+}
 
 type OrderItem struct {
 	*Model `mapstructure:",squash"`
@@ -11,6 +89,26 @@ type OrderItem struct {
 	Total             float64 `json:"total"`
 	AdditionalCharges float64 `json:"additionalCharges"`
 
+	// GST, computed from the dress type rate and the place of supply
+	HSNCode      string  `json:"hsnCode"`
+	TaxRate      float64 `json:"taxRate" gorm:"type:decimal(5,2);default:0"`
+	TaxableValue float64 `json:"taxableValue"`
+	CGSTAmount   float64 `json:"cgstAmount"`
+	SGSTAmount   float64 `json:"sgstAmount"`
+	IGSTAmount   float64 `json:"igstAmount"`
+
+	// Production stage, moved through the stage endpoint
+	Stage          OrderItemStage `gorm:"type:text" json:"stage,omitempty"`
+	StageUpdatedAt *time.Time     `json:"stageUpdatedAt,omitempty"`
+	CuttingAt      *time.Time     `json:"cuttingAt,omitempty"`
+	StitchingAt    *time.Time     `json:"stitchingAt,omitempty"`
+	FinishingAt    *time.Time     `json:"finishingAt,omitempty"`
+	QCAt           *time.Time     `json:"qcAt,omitempty"`
+	ReadyAt        *time.Time     `json:"readyAt,omitempty"`
+
+	AssignedTailorId *uint `json:"assignedTailorId,omitempty"`
+	AssignedTailor   *User `gorm:"foreignKey:AssignedTailorId" json:"assignedTailor,omitempty"`
+
 	ExpectedDeliveryDate *time.Time `json:"expectedDeliveryDate,omitempty"`
 	DeliveredDate        *time.Time `json:"deliveredDate,omitempty"`
 
@@ -20,6 +118,10 @@ type OrderItem struct {
 	MeasurementId *uint        `json:"measurementId,omitempty"`
 	Measurement   *Measurement `gorm:"foreignKey:MeasurementId" json:"measurement,omitempty"`
 
+	// Measurement and dress type schema as they were when the order was confirmed, see MeasurementSnapshot
+	MeasurementSnapshot   entitiy_types.JSON `gorm:"type:jsonb" json:"measurementSnapshot,omitempty"`
+	MeasurementSnapshotAt *time.Time         `json:"measurementSnapshotAt,omitempty"`
+
 	OrderId uint   `json:"orderId"`
 	Order   *Order `gorm:"foreignKey:OrderId" json:"order"`
 }
@@ -27,3 +129,93 @@ type OrderItem struct {
 func (OrderItem) TableNameForQuery() string {
 	return "\"stich\".\"OrderItems\" E"
 }
+
+// StageColumn is the column holding the time the item entered the stage
+func (s OrderItemStage) StageColumn() string {
+	switch s {
+	case STAGE_CUTTING:
+		return "cutting_at"
+	case STAGE_STITCHING:
+		return "stitching_at"
+	case STAGE_FINISHING:
+		return "finishing_at"
+	case STAGE_QC:
+		return "qc_at"
+	case STAGE_READY:
+		return "ready_at"
+	}
+	return ""
+}
+
+// KeepProduction carries the production stage and its timestamps over from the stored item
+func (oi *OrderItem) KeepProduction(stored OrderItem) {
+	oi.Stage = stored.Stage
+	oi.StageUpdatedAt = stored.StageUpdatedAt
+	oi.CuttingAt = stored.CuttingAt
+	oi.StitchingAt = stored.StitchingAt
+	oi.FinishingAt = stored.FinishingAt
+	oi.QCAt = stored.QCAt
+	oi.ReadyAt = stored.ReadyAt
+}
+
+// KeepMeasurementSnapshot carries the measurement snapshot over from the stored item
+func (oi *OrderItem) KeepMeasurementSnapshot(stored OrderItem) {
+	oi.MeasurementSnapshot = stored.MeasurementSnapshot
+	oi.MeasurementSnapshotAt = stored.MeasurementSnapshotAt
+}
+
+// GetMeasurementSnapshot reads the snapshot of the item, nil when none was taken
+func (oi *OrderItem) GetMeasurementSnapshot() (*MeasurementSnapshot, error) {
+	if len(oi.MeasurementSnapshot) == 0 {
+		return nil, nil
+	}
+	var snapshot MeasurementSnapshot
+	if err := json.Unmarshal(oi.MeasurementSnapshot, &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// NeedsMeasurementSnapshot reports whether the item's measurement is not the one in its snapshot
+func (oi *OrderItem) NeedsMeasurementSnapshot() bool {
+	if oi.MeasurementId == nil {
+		return false
+	}
+	snapshot, err := oi.GetMeasurementSnapshot()
+	return err != nil || snapshot == nil || snapshot.MeasurementId != *oi.MeasurementId
+}
+
+// ApplyTax computes GST on the item total
+func (oi *OrderItem) ApplyTax(hsnCode string, rate float64, interState bool) {
+	oi.HSNCode = hsnCode
+	oi.TaxRate = rate
+	oi.TaxableValue = oi.Total
+	oi.CGSTAmount, oi.SGSTAmount, oi.IGSTAmount = 0, 0, 0
+
+	tax := roundAmount(oi.TaxableValue * rate / 100)
+	if interState {
+		oi.IGSTAmount = tax
+		return
+	}
+	oi.CGSTAmount = roundAmount(tax / 2)
+	oi.SGSTAmount = roundAmount(tax - oi.CGSTAmount)
+}
+
+// TaxAmount is the total GST charged on the item
+func (oi *OrderItem) TaxAmount() float64 {
+	return oi.CGSTAmount + oi.SGSTAmount + oi.IGSTAmount
+}
+
+// IsInterStateSupply reports whether the place of supply differs from the supplier's state
+func IsInterStateSupply(supplierState, placeOfSupply string) bool {
+	supplierState = strings.TrimSpace(supplierState)
+	placeOfSupply = strings.TrimSpace(placeOfSupply)
+	if supplierState == "" || placeOfSupply == "" {
+		return false
+	}
+	return !strings.EqualFold(supplierState, placeOfSupply)
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
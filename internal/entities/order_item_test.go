@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+
+	entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OrderItemApplyTax(t *testing.T) {
+
+	item := OrderItem{Total: 1000.25}
+	item.ApplyTax("6211", 5, false)
+
+	require.Equal(t, 1000.25, item.TaxableValue)
+	require.Equal(t, 25.01, item.CGSTAmount)
+	require.Equal(t, 25.0, item.SGSTAmount)
+	require.Equal(t, 0.0, item.IGSTAmount)
+	require.InDelta(t, 50.01, item.TaxAmount(), 0.001)
+
+	item.ApplyTax("6211", 5, true)
+	require.Equal(t, 0.0, item.CGSTAmount)
+	require.Equal(t, 0.0, item.SGSTAmount)
+	require.Equal(t, 50.01, item.IGSTAmount)
+
+	require.False(t, IsInterStateSupply("Tamil Nadu", " tamil nadu"))
+	require.False(t, IsInterStateSupply("Tamil Nadu", ""))
+	require.True(t, IsInterStateSupply("Tamil Nadu", "Kerala"))
+}
+
+func Test_OrderItemStages(t *testing.T) {
+
+	require.True(t, OrderItemStage("").CanMoveTo(STAGE_CUTTING))
+	require.False(t, OrderItemStage("").CanMoveTo(STAGE_STITCHING))
+	require.True(t, STAGE_STITCHING.CanMoveTo(STAGE_FINISHING))
+	require.False(t, STAGE_STITCHING.CanMoveTo(STAGE_QC))
+	require.True(t, STAGE_QC.CanMoveTo(STAGE_STITCHING))
+	require.False(t, STAGE_READY.CanMoveTo(STAGE_READY))
+
+	items := []OrderItem{
+		{Model: &Model{IsActive: true}, Stage: STAGE_READY},
+		{Model: &Model{IsActive: true}, Stage: STAGE_QC},
+		{Model: &Model{IsActive: false}, Stage: STAGE_CUTTING},
+	}
+	status, ok := DeriveOrderStatus(items)
+	require.True(t, ok)
+	require.Equal(t, FINISHING, status)
+
+	items[1].Stage = STAGE_READY
+	status, ok = DeriveOrderStatus(items)
+	require.True(t, ok)
+	require.Equal(t, READY_FOR_DELIVERY, status)
+
+	items = append(items, OrderItem{Model: &Model{IsActive: true}})
+	_, ok = DeriveOrderStatus(items)
+	require.False(t, ok)
+}
+
+func Test_OrderItemMeasurementSnapshot(t *testing.T) {
+
+	measurementId := uint(7)
+	measurement := Measurement{Model: &Model{ID: measurementId}, Value: entitiy_types.JSON(`{"waist": 32, "length": "40"}`), Unit: MeasurementUnitCM, DressTypeId: 3}
+	item := OrderItem{MeasurementId: &measurementId}
+	require.True(t, item.NeedsMeasurementSnapshot())
+
+	snapshot, err := json.Marshal(NewMeasurementSnapshot(measurement, []MeasurementField{{Key: "waist", Label: "Waist", Unit: MeasurementUnitINCH}}))
+	require.NoError(t, err)
+	item.MeasurementSnapshot = entitiy_types.JSON(snapshot)
+	require.False(t, item.NeedsMeasurementSnapshot())
+
+	stored, err := item.GetMeasurementSnapshot()
+	require.NoError(t, err)
+	require.Equal(t, MeasurementUnitCM, stored.Unit)
+	require.Equal(t, "waist", stored.Fields[0].Key)
+
+	changed, err := stored.ChangedSince(Measurement{Model: &Model{ID: measurementId}, Value: entitiy_types.JSON(`{"waist": "32", "length": 40}`)})
+	require.NoError(t, err)
+	require.False(t, changed)
+	changed, _ = stored.ChangedSince(Measurement{Model: &Model{ID: measurementId}, Value: entitiy_types.JSON(`{"waist": 33, "length": 40}`)})
+	require.True(t, changed)
+	changed, _ = stored.ChangedSince(Measurement{Model: &Model{ID: 8}, Value: measurement.Value})
+	require.True(t, changed)
+
+	// Moving the item to another measurement needs a new snapshot
+	otherId := uint(8)
+	item.MeasurementId = &otherId
+	require.True(t, item.NeedsMeasurementSnapshot())
+}
@@ -0,0 +1,37 @@
+package entities
+
+import "time"
+
+type OrderPaymentMode string
+
+const (
+	OrderPaymentModeCASH OrderPaymentMode = "CASH"
+	OrderPaymentModeUPI  OrderPaymentMode = "UPI"
+	OrderPaymentModeCARD OrderPaymentMode = "CARD"
+	OrderPaymentModeBANK OrderPaymentMode = "BANK"
+)
+
+// IsValid checks if the mode is one of the supported payment modes
+func (m OrderPaymentMode) IsValid() bool {
+	switch m {
+	case OrderPaymentModeCASH, OrderPaymentModeUPI, OrderPaymentModeCARD, OrderPaymentModeBANK:
+		return true
+	}
+	return false
+}
+
+type OrderPayment struct {
+	*Model `mapstructure:",squash"`
+
+	Amount    float64          `json:"amount" gorm:"not null"`
+	Mode      OrderPaymentMode `json:"mode" gorm:"type:varchar(20);not null"`
+	Reference string           `json:"reference"`
+	PaidAt    time.Time        `json:"paidAt" gorm:"not null"`
+
+	OrderId uint   `json:"orderId" gorm:"not null"`
+	Order   *Order `gorm:"foreignKey:OrderId" json:"order,omitempty"`
+}
+
+func (OrderPayment) TableNameForQuery() string {
+	return "\"stich\".\"OrderPayments\" E"
+}
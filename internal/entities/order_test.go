@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OrderStatusTransitions(t *testing.T) {
+
+	transitions := DefaultOrderStatusTransitions
+
+	require.True(t, transitions.Allows(DRAFT, CONFIRMED))
+	require.True(t, transitions.Allows(READY_FOR_DELIVERY, DELIVERED))
+	require.False(t, transitions.Allows(DRAFT, DELIVERED))
+	require.False(t, transitions.Allows(DELIVERED, CANCELLED))
+	require.False(t, transitions.Allows(CANCELLED, DRAFT))
+
+	require.True(t, CUTTING.IsValid())
+	require.False(t, OrderStatus("SHIPPED").IsValid())
+
+	require.True(t, CONFIRMED.ReservesStock())
+	require.True(t, FINISHING.ReservesStock())
+	require.False(t, DRAFT.ReservesStock())
+	require.False(t, CANCELLED.ReservesStock())
+	require.False(t, OrderStatus("SHIPPED").ReservesStock())
+}
@@ -9,12 +9,51 @@ type Product struct {
 	Description  string  `json:"description" gorm:"type:text"`
 	CostPrice    float64 `json:"costPrice" gorm:"type:decimal(10,2);not null"`
 	SellingPrice float64 `json:"sellingPrice" gorm:"type:decimal(10,2);not null"`
+	HSNCode      string  `json:"hsnCode"`
+	TaxRate      float64 `json:"taxRate" gorm:"type:decimal(5,2);default:0"` // GST rate in percent
+
+	// Unit the stock of the product is kept in, movements in other units are converted to it
+	Unit UnitOfMeasure `json:"unit" gorm:"type:varchar(20);not null;default:'PIECE'"`
+
+	// Set on variants, which take their details from the parent and keep their own SKU and stock
+	ParentId *uint `json:"parentId,omitempty"`
 
 	// Relations
 	Category  *Category  `gorm:"foreignKey:CategoryId" json:"category,omitempty"`
 	Inventory *Inventory `gorm:"foreignKey:ProductId" json:"inventory,omitempty"`
+
+	UnitConversions []ProductUnitConversion `gorm:"foreignKey:ProductId" json:"unitConversions,omitempty"`
+
+	Parent        *Product              `gorm:"foreignKey:ParentId" json:"parent,omitempty"`
+	Variants      []Product             `gorm:"foreignKey:ParentId" json:"variants,omitempty"`
+	Attributes    []ProductAttribute    `gorm:"foreignKey:ProductId" json:"attributes,omitempty"`
+	VariantValues []ProductVariantValue `gorm:"foreignKey:ProductId" json:"variantValues,omitempty"`
 }
 
 func (Product) TableNameForQuery() string {
 	return "\"stich\".\"Products\" E"
 }
+
+// StockUnit is the unit the stock is kept in, pieces for products saved before units were introduced
+func (p *Product) StockUnit() UnitOfMeasure {
+	if p.Unit == "" {
+		return UnitOfMeasurePIECE
+	}
+	return p.Unit
+}
+
+// ToStockUnit converts a quantity given in unit to the product's stock unit
+func (p *Product) ToStockUnit(quantity float64, unit UnitOfMeasure) (float64, bool) {
+	if unit == "" || unit == p.StockUnit() {
+		return RoundQuantity(quantity), true
+	}
+	for _, conversion := range p.UnitConversions {
+		if conversion.Model != nil && !conversion.IsActive {
+			continue
+		}
+		if conversion.Unit == unit {
+			return RoundQuantity(quantity * conversion.Factor), true
+		}
+	}
+	return 0, false
+}
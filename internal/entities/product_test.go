@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProductToStockUnit(t *testing.T) {
+
+	fabric := Product{
+		Unit: UnitOfMeasureMETER,
+		UnitConversions: []ProductUnitConversion{
+			{Model: &Model{IsActive: true}, Unit: UnitOfMeasureROLL, Factor: 40},
+			{Model: &Model{IsActive: false}, Unit: UnitOfMeasureKG, Factor: 8},
+		},
+	}
+
+	quantity, ok := fabric.ToStockUnit(1, UnitOfMeasureROLL)
+	require.True(t, ok)
+	require.Equal(t, 40.0, quantity)
+
+	quantity, ok = fabric.ToStockUnit(2.75, "")
+	require.True(t, ok)
+	require.Equal(t, 2.75, quantity)
+
+	// Inactive and missing conversions are rejected
+	_, ok = fabric.ToStockUnit(1, UnitOfMeasureKG)
+	require.False(t, ok)
+	_, ok = fabric.ToStockUnit(1, UnitOfMeasurePIECE)
+	require.False(t, ok)
+
+	// Products saved before units were introduced are kept in pieces
+	require.Equal(t, UnitOfMeasurePIECE, (&Product{}).StockUnit())
+}
+
+func Test_ProductVariants(t *testing.T) {
+
+	combinations := VariantCombinations([][]string{{"Red", "Blue"}, {"S", "M", "L"}})
+	require.Len(t, combinations, 6)
+	require.Equal(t, []string{"Red", "S"}, combinations[0])
+	require.Equal(t, []string{"Red", "M"}, combinations[1])
+	require.Equal(t, []string{"Blue", "L"}, combinations[5])
+	require.Nil(t, VariantCombinations(nil))
+
+	require.Equal(t, "SAREE-RED-XL", VariantSKU("SAREE", []string{"Red", "XL"}))
+	require.Equal(t, "SAREE-SKYBLUE-32", VariantSKU("SAREE", []string{"Sky blue", "32\""}))
+	require.Equal(t, "Saree - Red / XL", VariantName("Saree", []string{"Red", "XL"}))
+	require.Equal(t, VariantKey([]string{"Red", "XL"}), VariantKey([]string{"red", "xl"}))
+
+	variant := Product{VariantValues: []ProductVariantValue{
+		{Model: &Model{IsActive: true}, AttributeId: 2, Value: "XL", Attribute: &ProductAttribute{Position: 1}},
+		{Model: &Model{IsActive: true}, AttributeId: 1, Value: "Red", Attribute: &ProductAttribute{Position: 0}},
+	}}
+	values := variant.OptionValues()
+	require.Equal(t, "Red", values[0].Value)
+	require.Equal(t, "XL", values[1].Value)
+}
@@ -0,0 +1,107 @@
+package entities
+
+import (
+	"sort"
+	"strings"
+)
+
+// ProductAttribute is one axis of a parent product's variant matrix, e.g. Colour or Size
+type ProductAttribute struct {
+	*Model `mapstructure:",squash"`
+
+	ProductId uint   `json:"productId" gorm:"not null"`
+	Name      string `json:"name" gorm:"type:varchar(50);not null"`
+	Position  int    `json:"position" gorm:"not null;default:0"`
+}
+
+// ProductVariantValue is the value a variant takes for one attribute of its parent, e.g. Colour = Red
+type ProductVariantValue struct {
+	*Model `mapstructure:",squash"`
+
+	ProductId   uint   `json:"productId" gorm:"not null"` // The variant
+	AttributeId uint   `json:"attributeId" gorm:"not null"`
+	Value       string `json:"value" gorm:"type:varchar(50);not null"`
+
+	Attribute *ProductAttribute `gorm:"foreignKey:AttributeId" json:"attribute,omitempty"`
+}
+
+// HasVariants tells whether the product is the parent of a variant matrix, its stock is kept on the variants
+func (p *Product) HasVariants() bool {
+	return len(p.Attributes) > 0
+}
+
+// IsVariant tells whether the product is a variant of a parent product
+func (p *Product) IsVariant() bool {
+	return p.ParentId != nil
+}
+
+// OptionValues returns the variant's values ordered by the position of their attribute
+func (p *Product) OptionValues() []ProductVariantValue {
+	values := make([]ProductVariantValue, 0, len(p.VariantValues))
+	for _, value := range p.VariantValues {
+		if value.Model != nil && !value.IsActive {
+			continue
+		}
+		values = append(values, value)
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		if values[i].Attribute == nil || values[j].Attribute == nil {
+			return values[i].AttributeId < values[j].AttributeId
+		}
+		return values[i].Attribute.Position < values[j].Attribute.Position
+	})
+	return values
+}
+
+// VariantCombinations returns every combination of one value per attribute, the last attribute varying fastest
+func VariantCombinations(values [][]string) [][]string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	combinations := [][]string{{}}
+	for _, options := range values {
+		next := make([][]string, 0, len(combinations)*len(options))
+		for _, combination := range combinations {
+			for _, option := range options {
+				extended := append(append(make([]string, 0, len(combination)+1), combination...), option)
+				next = append(next, extended)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// VariantKey identifies a combination of values regardless of case, used to find variants that already exist
+func VariantKey(values []string) string {
+	return strings.ToLower(strings.Join(values, "\x00"))
+}
+
+// VariantSKU appends a code for each value to the parent's SKU, SILK-SAREE with Red and XL gives SILK-SAREE-RED-XL
+func VariantSKU(parentSKU string, values []string) string {
+	var sku strings.Builder
+	sku.WriteString(parentSKU)
+	for _, value := range values {
+		code := strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+				return r
+			case r >= 'a' && r <= 'z':
+				return r - 'a' + 'A'
+			}
+			return -1
+		}, value)
+		if code == "" {
+			continue
+		}
+		sku.WriteString("-")
+		sku.WriteString(code)
+	}
+	return sku.String()
+}
+
+// VariantName is the parent's name followed by the values, e.g. Silk Saree - Red / XL
+func VariantName(parentName string, values []string) string {
+	return parentName + " - " + strings.Join(values, " / ")
+}
@@ -0,0 +1,109 @@
+package entities
+
+import "time"
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusORDERED            PurchaseOrderStatus = "ORDERED"
+	PurchaseOrderStatusPARTIALLY_RECEIVED PurchaseOrderStatus = "PARTIALLY_RECEIVED"
+	PurchaseOrderStatusRECEIVED           PurchaseOrderStatus = "RECEIVED"
+	PurchaseOrderStatusCANCELLED          PurchaseOrderStatus = "CANCELLED"
+)
+
+type PurchaseOrder struct {
+	*Model `mapstructure:",squash"`
+
+	SupplierId   uint                `json:"supplierId" gorm:"not null"`
+	Status       PurchaseOrderStatus `json:"status" gorm:"type:varchar(30);not null"`
+	OrderDate    *time.Time          `json:"orderDate,omitempty"`
+	ExpectedDate *time.Time          `json:"expectedDate,omitempty"`
+	Notes        string              `json:"notes"`
+
+	// Relations
+	Supplier *Supplier           `gorm:"foreignKey:SupplierId" json:"supplier,omitempty"`
+	Lines    []PurchaseOrderLine `gorm:"foreignKey:PurchaseOrderId" json:"lines,omitempty"`
+	Expenses []Expense           `gorm:"foreignKey:PurchaseOrderId" json:"expenses,omitempty"` // One per receipt
+}
+
+func (PurchaseOrder) TableNameForQuery() string {
+	return "\"stich\".\"PurchaseOrders\" E"
+}
+
+// IsOpen reports whether stock can still be received against the purchase order
+func (po *PurchaseOrder) IsOpen() bool {
+	return po.Status == PurchaseOrderStatusORDERED || po.Status == PurchaseOrderStatusPARTIALLY_RECEIVED
+}
+
+// ReceiptStatus works out the status from the received quantities of the active lines
+func (po *PurchaseOrder) ReceiptStatus() PurchaseOrderStatus {
+	received, outstanding := false, false
+	for _, line := range po.Lines {
+		if line.Model != nil && !line.IsActive {
+			continue
+		}
+		if line.ReceivedQuantity > 0 {
+			received = true
+		}
+		if line.OutstandingQuantity() > 0 {
+			outstanding = true
+		}
+	}
+
+	switch {
+	case !outstanding:
+		return PurchaseOrderStatusRECEIVED
+	case received:
+		return PurchaseOrderStatusPARTIALLY_RECEIVED
+	default:
+		return PurchaseOrderStatusORDERED
+	}
+}
+
+func (po *PurchaseOrder) OrderedValue() float64 {
+	total := 0.0
+	for i := range po.Lines {
+		total += po.Lines[i].LineTotal()
+	}
+	return roundAmount(total)
+}
+
+func (po *PurchaseOrder) ReceivedValue() float64 {
+	total := 0.0
+	for i := range po.Lines {
+		total += po.Lines[i].ReceivedValue()
+	}
+	return roundAmount(total)
+}
+
+type PurchaseOrderLine struct {
+	*Model `mapstructure:",squash"`
+
+	PurchaseOrderId  uint    `json:"purchaseOrderId" gorm:"not null"`
+	ProductId        uint    `json:"productId" gorm:"not null"`
+	Quantity         float64 `json:"quantity" gorm:"type:decimal(12,3);not null"` // In the product's stock unit
+	ReceivedQuantity float64 `json:"receivedQuantity" gorm:"type:decimal(12,3);not null;default:0"`
+	UnitCost         float64 `json:"unitCost"`
+
+	// Relations
+	Product *Product `gorm:"foreignKey:ProductId" json:"product,omitempty"`
+}
+
+func (PurchaseOrderLine) TableNameForQuery() string {
+	return "\"stich\".\"PurchaseOrderLines\" E"
+}
+
+func (l *PurchaseOrderLine) OutstandingQuantity() float64 {
+	if l.ReceivedQuantity >= l.Quantity {
+		return 0
+	}
+	return RoundQuantity(l.Quantity - l.ReceivedQuantity)
+}
+
+func (l *PurchaseOrderLine) LineTotal() float64 {
+	return roundAmount(l.Quantity * l.UnitCost)
+}
+
+func (l *PurchaseOrderLine) ReceivedValue() float64 {
+	return roundAmount(l.ReceivedQuantity * l.UnitCost)
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PurchaseOrderReceiptStatus(t *testing.T) {
+
+	po := PurchaseOrder{
+		Lines: []PurchaseOrderLine{
+			{Model: &Model{IsActive: true}, Quantity: 10, UnitCost: 12.5},
+			{Model: &Model{IsActive: true}, Quantity: 4, UnitCost: 100},
+			{Model: &Model{IsActive: false}, Quantity: 50},
+		},
+	}
+	require.Equal(t, PurchaseOrderStatusORDERED, po.ReceiptStatus())
+	require.Equal(t, 525.0, po.OrderedValue())
+
+	po.Lines[0].ReceivedQuantity = 6
+	require.Equal(t, PurchaseOrderStatusPARTIALLY_RECEIVED, po.ReceiptStatus())
+	require.Equal(t, 4.0, po.Lines[0].OutstandingQuantity())
+	require.Equal(t, 75.0, po.ReceivedValue())
+
+	po.Lines[0].ReceivedQuantity = 10
+	po.Lines[1].ReceivedQuantity = 4
+	require.Equal(t, PurchaseOrderStatusRECEIVED, po.ReceiptStatus())
+}
@@ -0,0 +1,138 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Sale is a counter sale of ready-made products
+type Sale struct {
+	*Model `mapstructure:",squash"`
+
+	CustomerId       *uint            `json:"customerId,omitempty"`
+	SoldAt           time.Time        `json:"soldAt" gorm:"not null"`
+	PaymentMode      OrderPaymentMode `json:"paymentMode" gorm:"type:varchar(20);not null"`
+	PaymentReference string           `json:"paymentReference"`
+	Notes            string           `json:"notes"`
+
+	// Discount taken off the whole bill, spread over the lines in proportion to their value
+	Discount float64 `json:"discount" gorm:"type:decimal(10,2);not null;default:0"`
+
+	// Totals of the lines, worked out by ApplyTotals
+	SubTotal     float64 `json:"subTotal" gorm:"type:decimal(12,2);not null;default:0"` // Lines after their own discounts
+	TaxableValue float64 `json:"taxableValue" gorm:"type:decimal(12,2);not null;default:0"`
+	CGSTAmount   float64 `json:"cgstAmount" gorm:"type:decimal(12,2);not null;default:0"`
+	SGSTAmount   float64 `json:"sgstAmount" gorm:"type:decimal(12,2);not null;default:0"`
+	IGSTAmount   float64 `json:"igstAmount" gorm:"type:decimal(12,2);not null;default:0"`
+	GrandTotal   float64 `json:"grandTotal" gorm:"type:decimal(12,2);not null;default:0"`
+
+	// Relations
+	Customer *Customer  `gorm:"foreignKey:CustomerId" json:"customer,omitempty"`
+	Lines    []SaleLine `gorm:"foreignKey:SaleId" json:"lines,omitempty"`
+}
+
+func (Sale) TableNameForQuery() string {
+	return "\"stich\".\"Sales\" E"
+}
+
+// ReceiptNumber is the number printed on the receipt
+func (s *Sale) ReceiptNumber() string {
+	return fmt.Sprintf("SAL-%06d", s.ID)
+}
+
+// TaxAmount is the total GST charged on the sale
+func (s *Sale) TaxAmount() float64 {
+	return roundAmount(s.CGSTAmount + s.SGSTAmount + s.IGSTAmount)
+}
+
+// ApplyTotals spreads the bill discount over the lines and computes the GST and totals
+func (s *Sale) ApplyTotals(interState bool) {
+	s.SubTotal = 0
+	for i := range s.Lines {
+		s.SubTotal += s.Lines[i].NetValue()
+	}
+	s.SubTotal = roundAmount(s.SubTotal)
+
+	remaining := s.Discount
+	s.TaxableValue, s.CGSTAmount, s.SGSTAmount, s.IGSTAmount = 0, 0, 0, 0
+	for i := range s.Lines {
+		line := &s.Lines[i]
+
+		share := remaining
+		if i < len(s.Lines)-1 && s.SubTotal > 0 {
+			share = roundAmount(s.Discount * line.NetValue() / s.SubTotal)
+		}
+		remaining = roundAmount(remaining - share)
+
+		line.applyTax(roundAmount(line.NetValue()-share), interState)
+		s.TaxableValue += line.TaxableValue
+		s.CGSTAmount += line.CGSTAmount
+		s.SGSTAmount += line.SGSTAmount
+		s.IGSTAmount += line.IGSTAmount
+	}
+
+	s.TaxableValue = roundAmount(s.TaxableValue)
+	s.CGSTAmount = roundAmount(s.CGSTAmount)
+	s.SGSTAmount = roundAmount(s.SGSTAmount)
+	s.IGSTAmount = roundAmount(s.IGSTAmount)
+	s.GrandTotal = roundAmount(s.TaxableValue + s.TaxAmount())
+}
+
+type SaleLine struct {
+	*Model `mapstructure:",squash"`
+
+	SaleId       uint    `json:"saleId" gorm:"not null"`
+	ProductId    uint    `json:"productId" gorm:"not null"`
+	Quantity     float64 `json:"quantity" gorm:"type:decimal(12,3);not null"` // In the product's stock unit
+	SellingPrice float64 `json:"sellingPrice" gorm:"type:decimal(10,2);not null"`
+	Discount     float64 `json:"discount" gorm:"type:decimal(10,2);not null;default:0"` // Taken off this line only
+
+	// GST, computed from the product's rate and the place of supply on what is left after the discounts
+	HSNCode      string  `json:"hsnCode"`
+	TaxRate      float64 `json:"taxRate" gorm:"type:decimal(5,2);default:0"`
+	TaxableValue float64 `json:"taxableValue" gorm:"type:decimal(12,2);not null;default:0"`
+	CGSTAmount   float64 `json:"cgstAmount" gorm:"type:decimal(12,2);not null;default:0"`
+	SGSTAmount   float64 `json:"sgstAmount" gorm:"type:decimal(12,2);not null;default:0"`
+	IGSTAmount   float64 `json:"igstAmount" gorm:"type:decimal(12,2);not null;default:0"`
+
+	// Relations
+	Product *Product `gorm:"foreignKey:ProductId" json:"product,omitempty"`
+}
+
+func (SaleLine) TableNameForQuery() string {
+	return "\"stich\".\"SaleLines\" E"
+}
+
+// GrossValue is the quantity at the selling price, before any discount
+func (l *SaleLine) GrossValue() float64 {
+	return roundAmount(l.Quantity * l.SellingPrice)
+}
+
+// NetValue is the line after its own discount
+func (l *SaleLine) NetValue() float64 {
+	return roundAmount(l.GrossValue() - l.Discount)
+}
+
+// TaxAmount is the total GST charged on the line
+func (l *SaleLine) TaxAmount() float64 {
+	return roundAmount(l.CGSTAmount + l.SGSTAmount + l.IGSTAmount)
+}
+
+// Total is the taxable value of the line with its GST
+func (l *SaleLine) Total() float64 {
+	return roundAmount(l.TaxableValue + l.TaxAmount())
+}
+
+// applyTax charges GST on the line the same way as an order item
+func (l *SaleLine) applyTax(taxableValue float64, interState bool) {
+	l.TaxableValue = taxableValue
+	l.CGSTAmount, l.SGSTAmount, l.IGSTAmount = 0, 0, 0
+
+	tax := roundAmount(taxableValue * l.TaxRate / 100)
+	if interState {
+		l.IGSTAmount = tax
+		return
+	}
+	l.CGSTAmount = roundAmount(tax / 2)
+	l.SGSTAmount = roundAmount(tax - l.CGSTAmount)
+}
@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SaleTotals(t *testing.T) {
+
+	sale := Sale{
+		Discount: 100,
+		Lines: []SaleLine{
+			{Quantity: 2, SellingPrice: 450, Discount: 50, TaxRate: 12},
+			{Quantity: 1.5, SellingPrice: 200, TaxRate: 5},
+		},
+	}
+
+	sale.ApplyTotals(false)
+	require.Equal(t, 1150.0, sale.SubTotal)
+	// The bill discount is shared 850:300
+	require.Equal(t, 776.09, sale.Lines[0].TaxableValue)
+	require.Equal(t, 273.91, sale.Lines[1].TaxableValue)
+	require.Equal(t, 1050.0, sale.TaxableValue)
+	require.Equal(t, 46.57, sale.Lines[0].CGSTAmount)
+	require.Equal(t, 46.56, sale.Lines[0].SGSTAmount)
+	require.Equal(t, 0.0, sale.IGSTAmount)
+	require.Equal(t, 1050+sale.TaxAmount(), sale.GrandTotal)
+
+	sale.ApplyTotals(true)
+	require.Equal(t, 0.0, sale.CGSTAmount)
+	require.Equal(t, 93.13, sale.Lines[0].IGSTAmount)
+	require.Equal(t, 13.7, sale.Lines[1].IGSTAmount)
+	require.Equal(t, 1156.83, sale.GrandTotal)
+	require.Equal(t, "SAL-000042", (&Sale{Model: &Model{ID: 42}}).ReceiptNumber())
+}
@@ -0,0 +1,27 @@
+package entities
+
+type StockReservationStatus string
+
+const (
+	RESERVATION_RESERVED StockReservationStatus = "RESERVED"
+	RESERVATION_RELEASED StockReservationStatus = "RELEASED"
+	RESERVATION_CONSUMED StockReservationStatus = "CONSUMED"
+)
+
+// StockReservation holds back the material an order item needs from the stock of its channel
+type StockReservation struct {
+	*Model `mapstructure:",squash"`
+
+	ProductId   uint                   `json:"productId" gorm:"not null"`
+	OrderId     uint                   `json:"orderId" gorm:"not null"`
+	OrderItemId uint                   `json:"orderItemId" gorm:"not null"`
+	Quantity    float64                `json:"quantity" gorm:"type:decimal(12,3);not null"` // In the product's stock unit
+	Status      StockReservationStatus `json:"status" gorm:"type:varchar(20);not null;default:'RESERVED'"`
+
+	// Relations
+	Product *Product `gorm:"foreignKey:ProductId" json:"product,omitempty"`
+}
+
+func (StockReservation) TableNameForQuery() string {
+	return "\"stich\".\"StockReservations\" E"
+}
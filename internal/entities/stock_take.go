@@ -0,0 +1,58 @@
+package entities
+
+import "time"
+
+type StockTakeStatus string
+
+const (
+	StockTakeStatusOPEN      StockTakeStatus = "OPEN"
+	StockTakeStatusPOSTED    StockTakeStatus = "POSTED"
+	StockTakeStatusCANCELLED StockTakeStatus = "CANCELLED"
+)
+
+// StockTake is a physical count session for a category, or for all products when CategoryId is nil
+type StockTake struct {
+	*Model `mapstructure:",squash"`
+
+	Status     StockTakeStatus `json:"status" gorm:"type:varchar(20);not null"`
+	CategoryId *uint           `json:"categoryId,omitempty"`
+	Notes      string          `json:"notes"`
+	Reason     string          `json:"reason"` // Given when the variances are posted
+	OpenedAt   time.Time       `json:"openedAt" gorm:"not null"`
+	PostedAt   *time.Time      `json:"postedAt,omitempty"`
+
+	// Relations
+	Category *Category       `gorm:"foreignKey:CategoryId" json:"category,omitempty"`
+	Lines    []StockTakeLine `gorm:"foreignKey:StockTakeId" json:"lines,omitempty"`
+}
+
+func (StockTake) TableNameForQuery() string {
+	return "\"stich\".\"StockTakes\" E"
+}
+
+// StockTakeLine holds the count of one product in a stock take
+type StockTakeLine struct {
+	*Model `mapstructure:",squash"`
+
+	StockTakeId      uint     `json:"stockTakeId" gorm:"not null"`
+	ProductId        uint     `json:"productId" gorm:"not null"`
+	ExpectedQuantity float64  `json:"expectedQuantity" gorm:"type:decimal(12,3)"`
+	CountedQuantity  *float64 `json:"countedQuantity,omitempty" gorm:"type:decimal(12,3)"`
+	PostedVariance   *float64 `json:"postedVariance,omitempty" gorm:"type:decimal(12,3)"`
+
+	// Relations
+	Product *Product `gorm:"foreignKey:ProductId" json:"product,omitempty"`
+}
+
+func (StockTakeLine) TableNameForQuery() string {
+	return "\"stich\".\"StockTakeLines\" E"
+}
+
+// Variance is the counted quantity minus the given stock, nil until the product is counted
+func (l *StockTakeLine) Variance(stock float64) *float64 {
+	if l.CountedQuantity == nil {
+		return nil
+	}
+	variance := RoundQuantity(*l.CountedQuantity - stock)
+	return &variance
+}
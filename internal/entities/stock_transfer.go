@@ -0,0 +1,48 @@
+package entities
+
+import "time"
+
+type StockTransferStatus string
+
+const (
+	StockTransferStatusDRAFT      StockTransferStatus = "DRAFT"
+	StockTransferStatusDISPATCHED StockTransferStatus = "DISPATCHED"
+	StockTransferStatusRECEIVED   StockTransferStatus = "RECEIVED"
+	StockTransferStatusCANCELLED  StockTransferStatus = "CANCELLED"
+)
+
+// StockTransfer moves stock from one channel (branch) to another
+type StockTransfer struct {
+	*Model `mapstructure:",squash"`
+
+	FromChannelId uint                `json:"fromChannelId" gorm:"not null"`
+	ToChannelId   uint                `json:"toChannelId" gorm:"not null"`
+	Status        StockTransferStatus `json:"status" gorm:"type:varchar(20);not null"`
+	Notes         string              `json:"notes"`
+	DispatchedAt  *time.Time          `json:"dispatchedAt,omitempty"`
+	ReceivedAt    *time.Time          `json:"receivedAt,omitempty"`
+
+	// Relations
+	FromChannel *Channel            `gorm:"foreignKey:FromChannelId" json:"fromChannel,omitempty"`
+	ToChannel   *Channel            `gorm:"foreignKey:ToChannelId" json:"toChannel,omitempty"`
+	Lines       []StockTransferLine `gorm:"foreignKey:StockTransferId" json:"lines,omitempty"`
+}
+
+func (StockTransfer) TableNameForQuery() string {
+	return "\"stich\".\"StockTransfers\" E"
+}
+
+type StockTransferLine struct {
+	*Model `mapstructure:",squash"`
+
+	StockTransferId uint    `json:"stockTransferId" gorm:"not null"`
+	ProductId       uint    `json:"productId" gorm:"not null"`
+	Quantity        float64 `json:"quantity" gorm:"type:decimal(12,3);not null"` // In the product's stock unit
+
+	// Relations
+	Product *Product `gorm:"foreignKey:ProductId" json:"product,omitempty"`
+}
+
+func (StockTransferLine) TableNameForQuery() string {
+	return "\"stich\".\"StockTransferLines\" E"
+}
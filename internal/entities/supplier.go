@@ -0,0 +1,20 @@
+package entities
+
+type Supplier struct {
+	*Model `mapstructure:",squash"`
+
+	Name          string `json:"name" gorm:"not null"`
+	ContactPerson string `json:"contactPerson"`
+	PhoneNumber   string `json:"phoneNumber"`
+	Email         string `json:"email"`
+	Address       string `json:"address"`
+	GSTIN         string `json:"gstin"`
+	Notes         string `json:"notes"`
+
+	// Computed fields (populated by queries)
+	OutstandingBalance float64 `gorm:"->" json:"-"` // Sum of the balances of the supplier's expenses
+}
+
+func (Supplier) TableNameForQuery() string {
+	return "\"stich\".\"Suppliers\" E"
+}
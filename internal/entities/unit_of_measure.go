@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+type UnitOfMeasure string
+
+const (
+	UnitOfMeasurePIECE UnitOfMeasure = "PIECE"
+	UnitOfMeasureMETER UnitOfMeasure = "METER"
+	UnitOfMeasureKG    UnitOfMeasure = "KG"
+	UnitOfMeasureROLL  UnitOfMeasure = "ROLL"
+)
+
+func (u UnitOfMeasure) IsValid() bool {
+	switch u {
+	case UnitOfMeasurePIECE, UnitOfMeasureMETER, UnitOfMeasureKG, UnitOfMeasureROLL:
+		return true
+	}
+	return false
+}
+
+// ParseUnitOfMeasure accepts the unit in any case, an empty value is returned as is
+func ParseUnitOfMeasure(value string) UnitOfMeasure {
+	return UnitOfMeasure(strings.ToUpper(strings.TrimSpace(value)))
+}
+
+// ProductUnitConversion lets stock of a product be moved in another unit, e.g. 1 ROLL = 40 METER
+type ProductUnitConversion struct {
+	*Model `mapstructure:",squash"`
+
+	ProductId uint          `json:"productId" gorm:"not null"`
+	Unit      UnitOfMeasure `json:"unit" gorm:"type:varchar(20);not null"`
+	Factor    float64       `json:"factor" gorm:"type:decimal(12,3);not null"`
+}
+
+func (ProductUnitConversion) TableNameForQuery() string {
+	return "\"stich\".\"ProductUnitConversions\" E"
+}
+
+// QuantityPrecision is the number of decimals stock quantities are kept to
+const QuantityPrecision = 3
+
+// RoundQuantity rounds a stock quantity to the stored precision
+func RoundQuantity(quantity float64) float64 {
+	scale := math.Pow10(QuantityPrecision)
+	return math.Round(quantity*scale) / scale
+}
+
+// FormatQuantity prints a quantity without trailing zeros, 40 or 2.75
+func FormatQuantity(quantity float64) string {
+	return strconv.FormatFloat(RoundQuantity(quantity), 'f', -1, 64)
+}
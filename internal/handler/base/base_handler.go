@@ -19,13 +19,20 @@ type BaseHandler struct {
 	MeasurementHistoryHandler *handler.MeasurementHistoryHandler
 	EnquiryHistoryHandler     *handler.EnquiryHistoryHandler
 	ExpenseTrackerHandler     *handler.ExpenseTrackerHandler
-	ExpenseDetailHandler     *handler.ExpenseDetailHandler
+	ExpenseDetailHandler      *handler.ExpenseDetailHandler
 	TaskHandler               *handler.TaskHandler
 	CategoryHandler           *handler.CategoryHandler
 	ProductHandler            *handler.ProductHandler
 	InventoryHandler          *handler.InventoryHandler
 	InventoryLogHandler       *handler.InventoryLogHandler
 	DashboardHandler          *handler.DashboardHandler
+	OrderPaymentHandler       *handler.OrderPaymentHandler
+	InvoiceHandler            *handler.InvoiceHandler
+	StockTakeHandler          *handler.StockTakeHandler
+	SupplierHandler           *handler.SupplierHandler
+	PurchaseOrderHandler      *handler.PurchaseOrderHandler
+	StockTransferHandler      *handler.StockTransferHandler
+	SaleHandler               *handler.SaleHandler
 }
 
 func ProvideBaseHandler(health Health,
@@ -51,6 +58,13 @@ func ProvideBaseHandler(health Health,
 	inventoryHandler *handler.InventoryHandler,
 	inventoryLogHandler *handler.InventoryLogHandler,
 	dashboardHandler *handler.DashboardHandler,
+	orderPaymentHandler *handler.OrderPaymentHandler,
+	invoiceHandler *handler.InvoiceHandler,
+	stockTakeHandler *handler.StockTakeHandler,
+	supplierHandler *handler.SupplierHandler,
+	purchaseOrderHandler *handler.PurchaseOrderHandler,
+	stockTransferHandler *handler.StockTransferHandler,
+	saleHandler *handler.SaleHandler,
 ) BaseHandler {
 	return BaseHandler{
 		HealthHandler:             health,
@@ -69,12 +83,19 @@ func ProvideBaseHandler(health Health,
 		MeasurementHistoryHandler: measurementHistoryHandler,
 		EnquiryHistoryHandler:     enquiryHistoryHandler,
 		ExpenseTrackerHandler:     expenseTrackerHandler,
-		ExpenseDetailHandler:     expenseDetailHandler,
+		ExpenseDetailHandler:      expenseDetailHandler,
 		TaskHandler:               taskHandler,
 		CategoryHandler:           categoryHandler,
 		ProductHandler:            productHandler,
 		InventoryHandler:          inventoryHandler,
 		InventoryLogHandler:       inventoryLogHandler,
 		DashboardHandler:          dashboardHandler,
+		OrderPaymentHandler:       orderPaymentHandler,
+		InvoiceHandler:            invoiceHandler,
+		StockTakeHandler:          stockTakeHandler,
+		SupplierHandler:           supplierHandler,
+		PurchaseOrderHandler:      purchaseOrderHandler,
+		StockTransferHandler:      stockTransferHandler,
+		SaleHandler:               saleHandler,
 	}
 }
@@ -168,3 +168,65 @@ func (h CategoryHandler) AutocompleteCategory(ctx *gin.Context) {
 
 	h.dataResp.DefaultSuccessResponse(categories).FormatAndSend(&context, ctx, http.StatusOK)
 }
+
+//	@Summary		Get category tree
+//	@Description	Get the categories as a tree, root categories with their subcategories nested under them
+//	@Tags			Category
+//	@Accept			json
+//	@Success		200	{object}	responseModel.Category
+//	@Failure		400	{object}	responseModel.DataResponse
+//	@Router			/category/tree [get]
+func (h CategoryHandler) GetTree(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	tree, errr := h.categorySvc.GetTree(&context)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(tree).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+//	@Summary		Move Category
+//	@Description	Moves a category with its subcategories under another parent, or to the root when no parent is given
+//	@Tags			Category
+//	@Accept			json
+//	@Success		202		{object}	responseModel.Response
+//	@Failure		400		{object}	responseModel.Response
+//	@Param			move	body		requestModel.CategoryMove	true	"new parent"
+//	@Param			id		path		int							true	"Category id"
+//	@Router			/category/{id}/move [put]
+func (h CategoryHandler) Move(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var move requestModel.CategoryMove
+	err := ctx.Bind(&move)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.categorySvc.Move(&context, uint(id), move)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Move success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// categoryIdQuery reads the optional categoryId filter, which also matches the category's descendants
+func categoryIdQuery(ctx *gin.Context) (*uint, *errs.XError) {
+	s := ctx.Query("categoryId")
+	if s == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "categoryId must be a number", err)
+	}
+	categoryId := uint(id)
+	return &categoryId, nil
+}
@@ -156,3 +156,113 @@ func (h DressTypeHandler) Delete(ctx *gin.Context) {
 
 	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
 }
+
+// Get DressType bill of materials
+//
+//	@Summary		Get DressType bill of materials
+//	@Description	Get the products consumed to stitch one piece of a DressType
+//	@Tags			DressType
+//	@Accept			json
+//	@Success		200	{object}	responseModel.DressTypeComponent
+//	@Failure		400	{object}	responseModel.DataResponse
+//	@Param			id	path		int	true	"DressType id"
+//	@Router			/dress-type/{id}/bill-of-materials [get]
+func (h DressTypeHandler) GetBillOfMaterials(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	components, errr := h.dressTypeSvc.GetBillOfMaterials(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(components).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Update DressType bill of materials
+//
+//	@Summary		Update DressType bill of materials
+//	@Description	Replaces the products consumed to stitch one piece of a DressType
+//	@Tags			DressType
+//	@Accept			json
+//	@Success		202			{object}	responseModel.Response
+//	@Failure		400			{object}	responseModel.Response
+//	@Param			components	body		[]requestModel.DressTypeComponent	true	"bill of materials"
+//	@Param			id			path		int									true	"DressType id"
+//	@Router			/dress-type/{id}/bill-of-materials [put]
+func (h DressTypeHandler) UpdateBillOfMaterials(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var components []requesModel.DressTypeComponent
+	err := ctx.Bind(&components)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.dressTypeSvc.UpdateBillOfMaterials(&context, uint(id), components)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Get DressType measurement schema
+//
+//	@Summary		Get DressType measurement schema
+//	@Description	Get the measurements taken for a DressType, in the order they are shown
+//	@Tags			DressType
+//	@Accept			json
+//	@Success		200	{object}	responseModel.MeasurementField
+//	@Failure		400	{object}	responseModel.DataResponse
+//	@Param			id	path		int	true	"DressType id"
+//	@Router			/dress-type/{id}/measurement-schema [get]
+func (h DressTypeHandler) GetMeasurementSchema(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	fields, errr := h.dressTypeSvc.GetMeasurementSchema(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(fields).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Update DressType measurement schema
+//
+//	@Summary		Update DressType measurement schema
+//	@Description	Replaces the measurements taken for a DressType, their units, bounds and grouping
+//	@Tags			DressType
+//	@Accept			json
+//	@Success		202		{object}	responseModel.Response
+//	@Failure		400		{object}	responseModel.Response
+//	@Param			fields	body		[]requestModel.MeasurementField	true	"measurement schema"
+//	@Param			id		path		int								true	"DressType id"
+//	@Router			/dress-type/{id}/measurement-schema [put]
+func (h DressTypeHandler) UpdateMeasurementSchema(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var fields []requesModel.MeasurementField
+	err := ctx.Bind(&fields)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.dressTypeSvc.UpdateMeasurementSchema(&context, uint(id), fields)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
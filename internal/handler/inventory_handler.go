@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
@@ -125,11 +126,18 @@ func (h InventoryHandler) UpdateThreshold(ctx *gin.Context) {
 //	@Accept			json
 //	@Success		200	{object}	responseModel.LowStockItem
 //	@Failure		400	{object}	responseModel.DataResponse
+//	@Param			categoryId	query		int		false	"Category, its subcategories are included"
 //	@Router			/inventory/low-stock [get]
 func (h InventoryHandler) GetLowStockItems(ctx *gin.Context) {
 	context := util.CopyContextFromGin(ctx)
 
-	items, errr := h.inventorySvc.GetLowStockItems(&context)
+	categoryId, errr := categoryIdQuery(ctx)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	items, errr := h.inventorySvc.GetLowStockItems(&context, categoryId)
 	if errr != nil {
 		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
 		return
@@ -138,6 +146,88 @@ func (h InventoryHandler) GetLowStockItems(ctx *gin.Context) {
 	h.dataResp.DefaultSuccessResponse(items).FormatAndSend(&context, ctx, http.StatusOK)
 }
 
+//	@Summary		Get reorder suggestions
+//	@Description	Get the low stock items with a suggested reorder quantity based on the consumption over the reorder window
+//	@Tags			Inventory
+//	@Accept			json
+//	@Success		200	{object}	responseModel.ReorderSuggestion
+//	@Failure		400	{object}	responseModel.DataResponse
+//	@Param			categoryId	query		int		false	"Category, its subcategories are included"
+//	@Router			/inventory/reorder-suggestions [get]
+func (h InventoryHandler) GetReorderSuggestions(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	categoryId, errr := categoryIdQuery(ctx)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	suggestions, errr := h.inventorySvc.GetReorderSuggestions(&context, categoryId)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(suggestions).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+//	@Summary		Get lots of a product
+//	@Description	Get the lots of a product in the current channel, oldest first, which is the order outgoing stock is taken from them
+//	@Tags			Inventory
+//	@Accept			json
+//	@Success		200				{object}	responseModel.InventoryLot
+//	@Failure		400				{object}	responseModel.DataResponse
+//	@Param			productId		path		int		true	"Product id"
+//	@Param			includeEmpty	query		bool	false	"Include lots that are used up"
+//	@Router			/inventory/product/{productId}/lots [get]
+func (h InventoryHandler) GetLots(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	productId, _ := strconv.Atoi(ctx.Param("productId"))
+	includeEmpty := ctx.Query("includeEmpty") == "true"
+
+	lots, errr := h.inventorySvc.GetLots(&context, uint(productId), includeEmpty)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(lots).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+//	@Summary		Get consolidated stock
+//	@Description	Get the stock of each product in every channel the user can access, with the total and the quantity in transit
+//	@Tags			Inventory
+//	@Accept			json
+//	@Success		200			{object}	responseModel.ConsolidatedStock
+//	@Failure		400			{object}	responseModel.DataResponse
+//	@Param			productId	query		int	false	"Product id"
+//	@Router			/inventory/consolidated [get]
+func (h InventoryHandler) GetConsolidated(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	var productId *uint
+	if s := ctx.Query("productId"); s != "" {
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			x := errs.NewXError(errs.INVALID_REQUEST, "productId must be a number", err)
+			h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+			return
+		}
+		pid := uint(id)
+		productId = &pid
+	}
+
+	stock, errr := h.inventorySvc.GetConsolidated(&context, productId)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(stock).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
 //	@Summary		Record stock movement
 //	@Description	Record a stock IN, OUT, or ADJUST movement
 //	@Tags			Inventory
@@ -145,7 +235,8 @@ func (h InventoryHandler) GetLowStockItems(ctx *gin.Context) {
 //	@Success		201			{object}	responseModel.StockMovementResponse
 //	@Failure		400			{object}	responseModel.DataResponse
 //	@Failure		500			{object}	responseModel.DataResponse
-//	@Param			movement	body		requestModel.StockMovementRequest	true	"stock movement"
+//	@Param			movement		body		requestModel.StockMovementRequest	true	"stock movement"
+//	@Param			Idempotency-Key	header		string								false	"retries with the same key are booked once"
 //	@Router			/inventory/movement [post]
 func (h InventoryHandler) RecordStockMovement(ctx *gin.Context) {
 	context := util.CopyContextFromGin(ctx)
@@ -157,6 +248,10 @@ func (h InventoryHandler) RecordStockMovement(ctx *gin.Context) {
 		return
 	}
 
+	if key := ctx.GetHeader("Idempotency-Key"); key != "" {
+		movement.IdempotencyKey = key
+	}
+
 	response, errr := h.inventorySvc.RecordStockMovement(&context, movement)
 	if errr != nil {
 		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusInternalServerError)
@@ -165,3 +260,71 @@ func (h InventoryHandler) RecordStockMovement(ctx *gin.Context) {
 
 	h.dataResp.DefaultSuccessResponse(response).FormatAndSend(&context, ctx, http.StatusCreated)
 }
+
+//	@Summary		Get inventory valuation
+//	@Description	Get the quantity and value of stock on hand at the end of a day, using the channel's costing method
+//	@Tags			Inventory
+//	@Accept			json
+//	@Success		200		{object}	responseModel.InventoryValuation
+//	@Failure		400		{object}	responseModel.DataResponse
+//	@Param			asOf		query		string	false	"As of date (YYYY-MM-DD), defaults to today"
+//	@Param			categoryId	query		int		false	"Category, its subcategories are included"
+//	@Router			/inventory/valuation [get]
+func (h InventoryHandler) GetValuation(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	var asOf *time.Time
+	if s := ctx.Query("asOf"); s != "" {
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			x := errs.NewXError(errs.INVALID_REQUEST, "asOf must be a date in YYYY-MM-DD format", err)
+			h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+			return
+		}
+		asOf = &t
+	}
+
+	categoryId, errr := categoryIdQuery(ctx)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	valuation, errr := h.inventorySvc.GetValuation(&context, asOf, categoryId)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(valuation).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+//	@Summary		Get cost of goods report
+//	@Description	Get the cost of stock consumed and written off in a date range, using the channel's costing method
+//	@Tags			Inventory
+//	@Accept			json
+//	@Success		200		{object}	responseModel.COGSReport
+//	@Failure		400		{object}	responseModel.DataResponse
+//	@Param			from	query		string	false	"From date (YYYY-MM-DD), defaults to the start of the month"
+//	@Param			to			query		string	false	"To date (YYYY-MM-DD), defaults to today"
+//	@Param			categoryId	query		int		false	"Category, its subcategories are included"
+//	@Router			/inventory/cogs [get]
+func (h InventoryHandler) GetCOGSReport(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	from, to := parseDateRange(ctx, "from", "to")
+
+	categoryId, errr := categoryIdQuery(ctx)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	report, errr := h.inventorySvc.GetCOGSReport(&context, from, to, categoryId)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(report).FormatAndSend(&context, ctx, http.StatusOK)
+}
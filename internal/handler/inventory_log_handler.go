@@ -5,7 +5,9 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
 	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
 	"github.com/loop-kar/pixie/response"
 	"github.com/loop-kar/pixie/util"
 )
@@ -132,3 +134,34 @@ func (h InventoryLogHandler) GetByDateRange(ctx *gin.Context) {
 
 	h.dataResp.DefaultSuccessResponse(logs).FormatAndSend(&context, ctx, http.StatusOK)
 }
+
+//	@Summary		Reverse an Inventory Log
+//	@Description	Undoes a movement recorded by mistake with a linked reversing entry and restores the stock
+//	@Tags			InventoryLog
+//	@Accept			json
+//	@Success		202			{object}	responseModel.InventoryLog
+//	@Failure		400			{object}	responseModel.Response
+//	@Param			id			path		int									true	"Inventory Log id"
+//	@Param			reversal	body		requestModel.InventoryLogReversal	true	"reversal"
+//	@Router			/inventory-log/{id}/reverse [post]
+func (h InventoryLogHandler) Reverse(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var reversal requestModel.InventoryLogReversal
+	err := ctx.Bind(&reversal)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	log, errr := h.inventoryLogSvc.Reverse(&context, uint(id), reversal)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(log).FormatAndSend(&context, ctx, http.StatusAccepted)
+}
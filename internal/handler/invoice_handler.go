@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type InvoiceHandler struct {
+	invoiceSvc service.InvoiceService
+	resp       response.Response
+}
+
+func ProvideInvoiceHandler(svc service.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{invoiceSvc: svc}
+}
+
+// Get Order Invoice
+//
+//	@Summary		Get Order invoice
+//	@Description	Renders the issued invoice of an Order with its items, payments and balance
+//	@Tags			Order
+//	@Produce		html,application/pdf
+//	@Success		200		{file}		file
+//	@Failure		400		{object}	responseModel.Response
+//	@Param			id		path		int		true	"Order id"
+//	@Param			format	query		string	false	"html (default) or pdf"
+//	@Router			/order/{id}/invoice [get]
+func (h InvoiceHandler) GetOrderInvoice(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	format := ctx.Query("format")
+
+	invoice, errr := h.invoiceSvc.GetOrderInvoice(&context, uint(id), format)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", invoice.FileName))
+	ctx.Data(http.StatusOK, invoice.ContentType, invoice.Content)
+}
+
+// Issue Order Invoice
+//
+//	@Summary		Issue Order invoice
+//	@Description	Gives the Order the next invoice number of the channel, orders are also invoiced when delivered
+//	@Tags			Order
+//	@Success		202	{object}	responseModel.Response
+//	@Failure		400	{object}	responseModel.Response
+//	@Param			id	path		int	true	"Order id"
+//	@Router			/order/{id}/invoice [post]
+func (h InvoiceHandler) IssueOrderInvoice(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.invoiceSvc.IssueOrderInvoice(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Invoice issued").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
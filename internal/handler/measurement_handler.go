@@ -1,10 +1,15 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/imkarthi24/sf-backend/internal/entities"
 	requesModel "github.com/imkarthi24/sf-backend/internal/model/request"
 	"github.com/imkarthi24/sf-backend/internal/service"
 	"github.com/loop-kar/pixie/errs"
@@ -22,13 +27,23 @@ func ProvideMeasurementHandler(svc service.MeasurementService) *MeasurementHandl
 	return &MeasurementHandler{measurementSvc: svc}
 }
 
+// sendSaveFailure answers values that do not fit the dress type's schema with the error of each field
+func (h MeasurementHandler) sendSaveFailure(reqCtx *context.Context, ctx *gin.Context, errr *errs.XError) {
+	var fieldErrors entities.MeasurementFieldErrors
+	if errors.As(errr.Err, &fieldErrors) {
+		h.dataResp.FailureResponse(fieldErrors, errs.VALIDATION).FormatAndSend(reqCtx, ctx, http.StatusBadRequest)
+		return
+	}
+	h.resp.DefaultFailureResponse(errr).FormatAndSend(reqCtx, ctx, http.StatusInternalServerError)
+}
+
 // Save Measurement
 //
 //	@Summary		Save Measurement
-//	@Description	Saves an instance of Measurement
+//	@Description	Saves an instance of Measurement, values that look like mistakes are returned as warnings and flagged for review
 //	@Tags			Measurement
 //	@Accept			json
-//	@Success		201			{object}	responseModel.Response
+//	@Success		201			{object}	responseModel.MeasurementAnomaly
 //	@Failure		400			{object}	responseModel.Response
 //	@Failure		501			{object}	responseModel.Response
 //	@Param			measurement	body		requestModel.Measurement	true	"measurement"
@@ -43,22 +58,22 @@ func (h MeasurementHandler) SaveMeasurement(ctx *gin.Context) {
 		return
 	}
 
-	errr := h.measurementSvc.SaveMeasurement(&context, measurement)
+	warnings, errr := h.measurementSvc.SaveMeasurement(&context, measurement)
 	if errr != nil {
-		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusInternalServerError)
+		h.sendSaveFailure(&context, ctx, errr)
 		return
 	}
 
-	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+	h.dataResp.DefaultSuccessResponse(warnings).FormatAndSend(&context, ctx, http.StatusCreated)
 }
 
 // Save Bulk Measurements
 //
 //	@Summary		Save Bulk Measurements
-//	@Description	Saves multiple measurements for multiple persons in bulk
+//	@Description	Saves multiple measurements for multiple persons in bulk, values that look like mistakes are returned as warnings and flagged for review
 //	@Tags			Measurement
 //	@Accept			json
-//	@Success		201				{object}	responseModel.Response
+//	@Success		201				{object}	responseModel.MeasurementAnomaly
 //	@Failure		400				{object}	responseModel.Response
 //	@Failure		501				{object}	responseModel.Response
 //	@Param			measurements	body		[]requestModel.BulkMeasurementRequest	true	"Array of bulk measurement requests"
@@ -73,22 +88,22 @@ func (h MeasurementHandler) SaveBulkMeasurements(ctx *gin.Context) {
 		return
 	}
 
-	errr := h.measurementSvc.SaveBulkMeasurements(&context, bulkRequests)
+	warnings, errr := h.measurementSvc.SaveBulkMeasurements(&context, bulkRequests)
 	if errr != nil {
-		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusInternalServerError)
+		h.sendSaveFailure(&context, ctx, errr)
 		return
 	}
 
-	h.resp.SuccessResponse("Bulk save success").FormatAndSend(&context, ctx, http.StatusCreated)
+	h.dataResp.DefaultSuccessResponse(warnings).FormatAndSend(&context, ctx, http.StatusCreated)
 }
 
 // Update Measurement
 //
 //	@Summary		Update Measurement
-//	@Description	Updates a single measurement by its ID
+//	@Description	Updates a single measurement by its ID, values that look like mistakes are returned as warnings and flagged for review
 //	@Tags			Measurement
 //	@Accept			json
-//	@Success		200			{object}	responseModel.Response
+//	@Success		200			{object}	responseModel.MeasurementAnomaly
 //	@Failure		400			{object}	responseModel.Response
 //	@Failure		501			{object}	responseModel.Response
 //	@Param			id			path		int							true	"Measurement id"
@@ -105,22 +120,22 @@ func (h MeasurementHandler) UpdateMeasurement(ctx *gin.Context) {
 	}
 
 	id, _ := strconv.Atoi(ctx.Param("id"))
-	errr := h.measurementSvc.UpdateMeasurement(&context, measurement, uint(id))
+	warnings, errr := h.measurementSvc.UpdateMeasurement(&context, measurement, uint(id))
 	if errr != nil {
-		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusInternalServerError)
+		h.sendSaveFailure(&context, ctx, errr)
 		return
 	}
 
-	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusOK)
+	h.dataResp.DefaultSuccessResponse(warnings).FormatAndSend(&context, ctx, http.StatusOK)
 }
 
 // Bulk Update Measurements
 //
 //	@Summary		Bulk Update Measurements
-//	@Description	Updates an array of measurements by their IDs
+//	@Description	Updates an array of measurements by their IDs, values that look like mistakes are returned as warnings and flagged for review
 //	@Tags			Measurement
 //	@Accept			json
-//	@Success		200				{object}	responseModel.Response
+//	@Success		200				{object}	responseModel.MeasurementAnomaly
 //	@Failure		400				{object}	responseModel.Response
 //	@Failure		501				{object}	responseModel.Response
 //	@Param			measurements	body		[]requestModel.Measurement	true	"Array of measurements to update"
@@ -135,31 +150,32 @@ func (h MeasurementHandler) BulkUpdateMeasurements(ctx *gin.Context) {
 		return
 	}
 
-	errr := h.measurementSvc.BulkUpdateMeasurements(&context, measurements)
+	warnings, errr := h.measurementSvc.BulkUpdateMeasurements(&context, measurements)
 	if errr != nil {
-		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusInternalServerError)
+		h.sendSaveFailure(&context, ctx, errr)
 		return
 	}
 
-	h.resp.SuccessResponse("Bulk update success").FormatAndSend(&context, ctx, http.StatusOK)
+	h.dataResp.DefaultSuccessResponse(warnings).FormatAndSend(&context, ctx, http.StatusOK)
 }
 
 // Get Measurement
 //
 //	@Summary		Get a specific Measurement
-//	@Description	Get an instance of Measurement
+//	@Description	Get an instance of Measurement, its values are shown in the unit asked for or the user's display unit
 //	@Tags			Measurement
 //	@Accept			json
-//	@Success		200	{object}	responseModel.Measurement
-//	@Failure		400	{object}	responseModel.DataResponse
-//	@Param			id	path		int	true	"Measurement id"
+//	@Success		200		{object}	responseModel.Measurement
+//	@Failure		400		{object}	responseModel.DataResponse
+//	@Param			id		path		int		true	"Measurement id"
+//	@Param			unit	query		string	false	"INCH or CM"
 //	@Router			/measurement/{id} [get]
 func (h MeasurementHandler) Get(ctx *gin.Context) {
 	context := util.CopyContextFromGin(ctx)
 
 	id, _ := strconv.Atoi(ctx.Param("id"))
 
-	measurement, errr := h.measurementSvc.Get(&context, uint(id))
+	measurement, errr := h.measurementSvc.Get(&context, uint(id), ctx.Query("unit"))
 	if errr != nil {
 		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
 		return
@@ -216,3 +232,152 @@ func (h MeasurementHandler) Delete(ctx *gin.Context) {
 
 	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
 }
+
+// Compare Measurement versions
+//
+//	@Summary		Compare Measurement versions
+//	@Description	Compares two versions of a Measurement field by field. A version is a history entry, the values before that change, or current.
+//	@Tags			Measurement
+//	@Accept			json
+//	@Success		200		{object}	responseModel.MeasurementDiff
+//	@Failure		400		{object}	responseModel.DataResponse
+//	@Param			id		path		int		true	"Measurement id"
+//	@Param			from	query		string	true	"History id or current"
+//	@Param			to		query		string	false	"History id or current, current by default"
+//	@Param			unit	query		string	false	"INCH or CM"
+//	@Router			/measurement/{id}/diff [get]
+func (h MeasurementHandler) Diff(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	from := ctx.Query("from")
+	if from == "" {
+		x := errs.NewXError(errs.INVALID_REQUEST, "from must be a history id or current", nil)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+	fromHistoryId, errr := measurementVersion("from", from)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+	toHistoryId, errr := measurementVersion("to", ctx.Query("to"))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	diff, errr := h.measurementSvc.Diff(&context, uint(id), fromHistoryId, toHistoryId, ctx.Query("unit"))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(diff).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Restore Measurement
+//
+//	@Summary		Restore Measurement
+//	@Description	Puts back the values a Measurement had before a change in its history, the replaced values are kept in a new history entry. Values that look like mistakes are returned as warnings and flagged for review
+//	@Tags			Measurement
+//	@Accept			json
+//	@Success		202			{object}	responseModel.MeasurementAnomaly
+//	@Failure		400			{object}	responseModel.Response
+//	@Param			id			path		int	true	"Measurement id"
+//	@Param			historyId	path		int	true	"Measurement history id"
+//	@Router			/measurement/{id}/restore/{historyId} [post]
+func (h MeasurementHandler) Restore(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	historyId, _ := strconv.Atoi(ctx.Param("historyId"))
+
+	warnings, errr := h.measurementSvc.Restore(&context, uint(id), uint(historyId))
+	if errr != nil {
+		h.sendSaveFailure(&context, ctx, errr)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(warnings).FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Get flagged Measurements
+//
+//	@Summary		Get flagged Measurements
+//	@Description	Lists the values that looked like mistakes when measurements were saved, for review
+//	@Tags			Measurement
+//	@Accept			json
+//	@Success		200			{object}	responseModel.MeasurementFlag
+//	@Failure		400			{object}	responseModel.DataResponse
+//	@Param			status		query		string	false	"OPEN (default), REVIEWED or SUPERSEDED"
+//	@Param			personId	query		int		false	"Person id"
+//	@Router			/measurement-flag [get]
+func (h MeasurementHandler) GetFlagged(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	var personId *uint
+	if s := ctx.Query("personId"); s != "" {
+		id, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			x := errs.NewXError(errs.INVALID_REQUEST, "personId must be a number", err)
+			h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+			return
+		}
+		value := uint(id)
+		personId = &value
+	}
+
+	flags, errr := h.measurementSvc.GetFlagged(&context, ctx.Query("status"), personId)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(flags).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Review Measurement flag
+//
+//	@Summary		Review Measurement flag
+//	@Description	Closes an open flag once the measurement was checked with the person
+//	@Tags			Measurement
+//	@Accept			json
+//	@Success		202		{object}	responseModel.Response
+//	@Failure		400		{object}	responseModel.Response
+//	@Param			id		path		int									true	"Measurement flag id"
+//	@Param			review	body		requestModel.MeasurementFlagReview	true	"review"
+//	@Router			/measurement-flag/{id}/review [put]
+func (h MeasurementHandler) ReviewFlag(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var review requesModel.MeasurementFlagReview
+	err := ctx.Bind(&review)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.measurementSvc.ReviewFlag(&context, uint(id), review)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Review success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// measurementVersion reads a version given as a history id, an empty value or current is the current values
+func measurementVersion(name string, value string) (*uint, *errs.XError) {
+	if value == "" || strings.EqualFold(value, "current") {
+		return nil, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, fmt.Sprintf("%s must be a history id or current", name), err)
+	}
+	historyId := uint(id)
+	return &historyId, nil
+}
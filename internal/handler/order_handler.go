@@ -157,3 +157,34 @@ func (h OrderHandler) Delete(ctx *gin.Context) {
 
 	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
 }
+
+// Transition Order status
+//
+//	@Summary		Transition Order status
+//	@Description	Moves an Order to the given status if the channel's transition rules allow it
+//	@Tags			Order
+//	@Accept			json
+//	@Success		202		{object}	responseModel.Response
+//	@Failure		400		{object}	responseModel.Response
+//	@Param			status	body		requestModel.Status	true	"target status and optional reason"
+//	@Param			id		path		int					true	"Order id"
+//	@Router			/order/{id}/transition [post]
+func (h OrderHandler) TransitionOrder(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var status requesModel.Status
+	err := ctx.Bind(&status)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.orderSvc.TransitionOrder(&context, uint(id), status)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Status update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
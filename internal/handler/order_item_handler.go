@@ -156,3 +156,34 @@ func (h OrderItemHandler) Delete(ctx *gin.Context) {
 
 	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
 }
+
+// Move OrderItem stage
+//
+//	@Summary		Move OrderItem stage
+//	@Description	Moves an OrderItem to a production stage and derives the Order status from its items
+//	@Tags			OrderItem
+//	@Accept			json
+//	@Success		202		{object}	responseModel.Response
+//	@Failure		400		{object}	responseModel.Response
+//	@Param			stage	body		requestModel.OrderItemStage	true	"target stage, optional reason and tailor"
+//	@Param			id		path		int							true	"OrderItem id"
+//	@Router			/order-item/{id}/stage [post]
+func (h OrderItemHandler) MoveStage(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var stage requesModel.OrderItemStage
+	err := ctx.Bind(&stage)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.orderItemSvc.MoveStage(&context, uint(id), stage)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Stage update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type OrderPaymentHandler struct {
+	orderPaymentSvc service.OrderPaymentService
+	resp            response.Response
+	dataResp        response.DataResponse
+}
+
+func ProvideOrderPaymentHandler(svc service.OrderPaymentService) *OrderPaymentHandler {
+	return &OrderPaymentHandler{orderPaymentSvc: svc}
+}
+
+// Save OrderPayment
+//
+//	@Summary		Save OrderPayment
+//	@Description	Records a payment (advance or balance) against an order
+//	@Tags			OrderPayment
+//	@Accept			json
+//	@Success		201		{object}	responseModel.Response
+//	@Failure		400		{object}	responseModel.Response
+//	@Param			id		path		int							true	"Order id"
+//	@Param			body	body		requestModel.OrderPayment	true	"order payment"
+//	@Router			/order/{id}/payment [post]
+func (h *OrderPaymentHandler) Save(ctx *gin.Context) {
+	c := util.CopyContextFromGin(ctx)
+	orderId, _ := strconv.Atoi(ctx.Param("id"))
+	var req requestModel.OrderPayment
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.resp.DefaultFailureResponse(errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)).FormatAndSend(&c, ctx, http.StatusBadRequest)
+		return
+	}
+	if err := h.orderPaymentSvc.Save(&c, req, uint(orderId)); err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&c, ctx, http.StatusInternalServerError)
+		return
+	}
+	h.resp.SuccessResponse("Save success").FormatAndSend(&c, ctx, http.StatusCreated)
+}
+
+// Update OrderPayment
+//
+//	@Summary		Update OrderPayment
+//	@Description	Updates a payment recorded against an order
+//	@Tags			OrderPayment
+//	@Accept			json
+//	@Success		202			{object}	responseModel.Response
+//	@Failure		400			{object}	responseModel.Response
+//	@Param			id			path		int							true	"Order id"
+//	@Param			paymentId	path		int							true	"OrderPayment id"
+//	@Param			body		body		requestModel.OrderPayment	true	"order payment"
+//	@Router			/order/{id}/payment/{paymentId} [put]
+func (h *OrderPaymentHandler) Update(ctx *gin.Context) {
+	c := util.CopyContextFromGin(ctx)
+	orderId, _ := strconv.Atoi(ctx.Param("id"))
+	id, _ := strconv.Atoi(ctx.Param("paymentId"))
+	var req requestModel.OrderPayment
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.resp.DefaultFailureResponse(errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)).FormatAndSend(&c, ctx, http.StatusBadRequest)
+		return
+	}
+	if err := h.orderPaymentSvc.Update(&c, req, uint(orderId), uint(id)); err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&c, ctx, http.StatusInternalServerError)
+		return
+	}
+	h.resp.SuccessResponse("Update success").FormatAndSend(&c, ctx, http.StatusAccepted)
+}
+
+// Get OrderPayment
+//
+//	@Summary		Get OrderPayment
+//	@Description	Get a payment recorded against an order
+//	@Tags			OrderPayment
+//	@Accept			json
+//	@Success		200			{object}	responseModel.OrderPayment
+//	@Failure		400			{object}	responseModel.DataResponse
+//	@Param			id			path		int	true	"Order id"
+//	@Param			paymentId	path		int	true	"OrderPayment id"
+//	@Router			/order/{id}/payment/{paymentId} [get]
+func (h *OrderPaymentHandler) Get(ctx *gin.Context) {
+	c := util.CopyContextFromGin(ctx)
+	orderId, _ := strconv.Atoi(ctx.Param("id"))
+	id, _ := strconv.Atoi(ctx.Param("paymentId"))
+	payment, err := h.orderPaymentSvc.Get(&c, uint(orderId), uint(id))
+	if err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&c, ctx, http.StatusBadRequest)
+		return
+	}
+	h.dataResp.DefaultSuccessResponse(payment).FormatAndSend(&c, ctx, http.StatusOK)
+}
+
+// GetByOrderId returns all payments for an order
+//
+//	@Summary		Get payments by order id
+//	@Description	Get all payments recorded against an order
+//	@Tags			OrderPayment
+//	@Accept			json
+//	@Success		200	{object}	[]responseModel.OrderPayment
+//	@Failure		400	{object}	responseModel.DataResponse
+//	@Param			id	path		int	true	"Order id"
+//	@Router			/order/{id}/payment [get]
+func (h *OrderPaymentHandler) GetByOrderId(ctx *gin.Context) {
+	c := util.CopyContextFromGin(ctx)
+	orderId, _ := strconv.Atoi(ctx.Param("id"))
+	payments, err := h.orderPaymentSvc.GetByOrderId(&c, uint(orderId))
+	if err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&c, ctx, http.StatusBadRequest)
+		return
+	}
+	h.dataResp.DefaultSuccessResponse(payments).FormatAndSend(&c, ctx, http.StatusOK)
+}
+
+// Delete OrderPayment
+//
+//	@Summary		Delete OrderPayment
+//	@Description	Deletes a payment recorded against an order
+//	@Tags			OrderPayment
+//	@Accept			json
+//	@Success		200			{object}	responseModel.Response
+//	@Failure		400			{object}	responseModel.Response
+//	@Param			id			path		int	true	"Order id"
+//	@Param			paymentId	path		int	true	"OrderPayment id"
+//	@Router			/order/{id}/payment/{paymentId} [delete]
+func (h *OrderPaymentHandler) Delete(ctx *gin.Context) {
+	c := util.CopyContextFromGin(ctx)
+	orderId, _ := strconv.Atoi(ctx.Param("id"))
+	id, _ := strconv.Atoi(ctx.Param("paymentId"))
+	if err := h.orderPaymentSvc.Delete(&c, uint(orderId), uint(id)); err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&c, ctx, http.StatusBadRequest)
+		return
+	}
+	h.resp.SuccessResponse("Delete success").FormatAndSend(&c, ctx, http.StatusOK)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -108,7 +109,8 @@ func (h ProductHandler) Get(ctx *gin.Context) {
 //	@Accept			json
 //	@Success		200		{object}	responseModel.Product
 //	@Failure		400		{object}	responseModel.DataResponse
-//	@Param			search	query		string	false	"search"
+//	@Param			search		query		string	false	"search"
+//	@Param			categoryId	query		int		false	"Category, its subcategories are included"
 //	@Router			/product [get]
 func (h ProductHandler) GetAllProducts(ctx *gin.Context) {
 	context := util.CopyContextFromGin(ctx)
@@ -116,7 +118,13 @@ func (h ProductHandler) GetAllProducts(ctx *gin.Context) {
 	search := ctx.Query("search")
 	search = util.EncloseWithSingleQuote(search)
 
-	products, errr := h.productSvc.GetAll(&context, search)
+	categoryId, errr := categoryIdQuery(ctx)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	products, errr := h.productSvc.GetAll(&context, search, categoryId)
 	if errr != nil {
 		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
 		return
@@ -202,11 +210,18 @@ func (h ProductHandler) GetBySKU(ctx *gin.Context) {
 //	@Accept			json
 //	@Success		200	{object}	responseModel.Product
 //	@Failure		400	{object}	responseModel.DataResponse
+//	@Param			categoryId	query		int		false	"Category, its subcategories are included"
 //	@Router			/product/low-stock [get]
 func (h ProductHandler) GetLowStockProducts(ctx *gin.Context) {
 	context := util.CopyContextFromGin(ctx)
 
-	products, errr := h.productSvc.GetLowStockProducts(&context)
+	categoryId, errr := categoryIdQuery(ctx)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	products, errr := h.productSvc.GetLowStockProducts(&context, categoryId)
 	if errr != nil {
 		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
 		return
@@ -214,3 +229,52 @@ func (h ProductHandler) GetLowStockProducts(ctx *gin.Context) {
 
 	h.dataResp.DefaultSuccessResponse(products).FormatAndSend(&context, ctx, http.StatusOK)
 }
+
+//	@Summary		Scan product
+//	@Description	Resolves a scanned barcode or QR code to the product and its current inventory
+//	@Tags			Product
+//	@Accept			json
+//	@Success		200		{object}	responseModel.Product
+//	@Failure		400		{object}	responseModel.DataResponse
+//	@Param			code	path		string	true	"Scanned code"
+//	@Router			/product/scan/{code} [get]
+func (h ProductHandler) Scan(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	product, errr := h.productSvc.Scan(&context, ctx.Param("code"))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(product).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+//	@Summary		Print product labels
+//	@Description	Renders a PDF sheet of Code128 or QR labels for the SKUs of the given products
+//	@Tags			Product
+//	@Accept			json
+//	@Produce		application/pdf
+//	@Success		200		{file}		file
+//	@Failure		400		{object}	responseModel.Response
+//	@Param			labels	body		requestModel.ProductLabels	true	"labels"
+//	@Router			/product/labels [post]
+func (h ProductHandler) GetLabels(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var request requestModel.ProductLabels
+	err := ctx.Bind(&request)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	labels, errr := h.productSvc.GetLabels(&context, request)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", labels.FileName))
+	ctx.Data(http.StatusOK, labels.ContentType, labels.Content)
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type PurchaseOrderHandler struct {
+	purchaseOrderSvc service.PurchaseOrderService
+	resp             response.Response
+	dataResp         response.DataResponse
+}
+
+func ProvidePurchaseOrderHandler(svc service.PurchaseOrderService) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{purchaseOrderSvc: svc}
+}
+
+// Save PurchaseOrder
+//
+//	@Summary		Save PurchaseOrder
+//	@Description	Saves a PurchaseOrder with its lines
+//	@Tags			PurchaseOrder
+//	@Accept			json
+//	@Success		201				{object}	responseModel.Response
+//	@Failure		400				{object}	responseModel.Response
+//	@Param			purchaseOrder	body		requestModel.PurchaseOrder	true	"purchaseOrder"
+//	@Router			/purchase-order [post]
+func (h PurchaseOrderHandler) SavePurchaseOrder(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var purchaseOrder requestModel.PurchaseOrder
+	err := ctx.Bind(&purchaseOrder)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	errr := h.purchaseOrderSvc.SavePurchaseOrder(&context, purchaseOrder)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// Update PurchaseOrder
+//
+//	@Summary		Update PurchaseOrder
+//	@Description	Replaces the details and lines of a PurchaseOrder that has not been received yet
+//	@Tags			PurchaseOrder
+//	@Accept			json
+//	@Success		202				{object}	responseModel.Response
+//	@Failure		400				{object}	responseModel.Response
+//	@Param			purchaseOrder	body		requestModel.PurchaseOrder	true	"purchaseOrder"
+//	@Param			id				path		int							true	"PurchaseOrder id"
+//	@Router			/purchase-order/{id} [put]
+func (h PurchaseOrderHandler) UpdatePurchaseOrder(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var purchaseOrder requestModel.PurchaseOrder
+	err := ctx.Bind(&purchaseOrder)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.purchaseOrderSvc.UpdatePurchaseOrder(&context, purchaseOrder, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Get PurchaseOrder
+//
+//	@Summary		Get a specific PurchaseOrder
+//	@Description	Get a PurchaseOrder with its lines and the expenses created by its receipts
+//	@Tags			PurchaseOrder
+//	@Accept			json
+//	@Success		200	{object}	responseModel.PurchaseOrder
+//	@Failure		400	{object}	responseModel.DataResponse
+//	@Param			id	path		int	true	"PurchaseOrder id"
+//	@Router			/purchase-order/{id} [get]
+func (h PurchaseOrderHandler) Get(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	purchaseOrder, errr := h.purchaseOrderSvc.Get(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(purchaseOrder).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Get all PurchaseOrders
+//
+//	@Summary		Get all PurchaseOrders
+//	@Description	Get all PurchaseOrders, optionally for one supplier or status
+//	@Tags			PurchaseOrder
+//	@Accept			json
+//	@Success		200			{object}	responseModel.PurchaseOrder
+//	@Failure		400			{object}	responseModel.DataResponse
+//	@Param			supplierId	query		int		false	"supplier id"
+//	@Param			status		query		string	false	"ORDERED, PARTIALLY_RECEIVED, RECEIVED or CANCELLED"
+//	@Router			/purchase-order [get]
+func (h PurchaseOrderHandler) GetAllPurchaseOrders(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	var supplierId *uint
+	if idStr := ctx.Query("supplierId"); idStr != "" {
+		id, err := strconv.ParseUint(idStr, 10, 32)
+		if err == nil {
+			uid := uint(id)
+			supplierId = &uid
+		}
+	}
+
+	purchaseOrders, errr := h.purchaseOrderSvc.GetAll(&context, supplierId, ctx.Query("status"))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(purchaseOrders).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Receive PurchaseOrder
+//
+//	@Summary		Receive PurchaseOrder
+//	@Description	Books received quantities as IN stock movements and creates an expense for the receipt against the supplier
+//	@Tags			PurchaseOrder
+//	@Accept			json
+//	@Success		202		{object}	responseModel.PurchaseOrder
+//	@Failure		400		{object}	responseModel.Response
+//	@Param			receipt	body		requestModel.PurchaseOrderReceipt	true	"receipt"
+//	@Param			id		path		int									true	"PurchaseOrder id"
+//	@Router			/purchase-order/{id}/receive [post]
+func (h PurchaseOrderHandler) Receive(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var receipt requestModel.PurchaseOrderReceipt
+	err := ctx.Bind(&receipt)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	purchaseOrder, errr := h.purchaseOrderSvc.Receive(&context, uint(id), receipt)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(purchaseOrder).FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Cancel PurchaseOrder
+//
+//	@Summary		Cancel PurchaseOrder
+//	@Description	Cancels the outstanding quantities of a PurchaseOrder, received stock is kept
+//	@Tags			PurchaseOrder
+//	@Accept			json
+//	@Success		202	{object}	responseModel.Response
+//	@Failure		400	{object}	responseModel.Response
+//	@Param			id	path		int	true	"PurchaseOrder id"
+//	@Router			/purchase-order/{id}/cancel [post]
+func (h PurchaseOrderHandler) Cancel(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.purchaseOrderSvc.Cancel(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Cancel Success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type SaleHandler struct {
+	saleSvc    service.SaleService
+	invoiceSvc service.InvoiceService
+	resp       response.Response
+	dataResp   response.DataResponse
+}
+
+func ProvideSaleHandler(svc service.SaleService, invoiceSvc service.InvoiceService) *SaleHandler {
+	return &SaleHandler{saleSvc: svc, invoiceSvc: invoiceSvc}
+}
+
+// Save Sale
+//
+//	@Summary		Save Sale
+//	@Description	Posts a counter sale, the stock of its lines is taken out of the channel's inventory
+//	@Tags			Sale
+//	@Accept			json
+//	@Success		201		{object}	responseModel.Sale
+//	@Failure		400		{object}	responseModel.Response
+//	@Param			sale	body		requestModel.Sale	true	"sale"
+//	@Router			/sale [post]
+func (h SaleHandler) SaveSale(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var sale requestModel.Sale
+	err := ctx.Bind(&sale)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	saved, errr := h.saleSvc.SaveSale(&context, sale)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(saved).FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// Get Sale
+//
+//	@Summary		Get a specific Sale
+//	@Description	Get a counter sale with its lines and totals
+//	@Tags			Sale
+//	@Accept			json
+//	@Success		200	{object}	responseModel.Sale
+//	@Failure		400	{object}	responseModel.DataResponse
+//	@Param			id	path		int	true	"Sale id"
+//	@Router			/sale/{id} [get]
+func (h SaleHandler) Get(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	sale, errr := h.saleSvc.Get(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(sale).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Get all Sales
+//
+//	@Summary		Get all Sales
+//	@Description	Get the counter sales of the channel, optionally in a date range or for one customer
+//	@Tags			Sale
+//	@Accept			json
+//	@Success		200			{object}	responseModel.Sale
+//	@Failure		400			{object}	responseModel.DataResponse
+//	@Param			from		query		string	false	"From date (YYYY-MM-DD)"
+//	@Param			to			query		string	false	"To date (YYYY-MM-DD)"
+//	@Param			customerId	query		int		false	"Customer id"
+//	@Router			/sale [get]
+func (h SaleHandler) GetAllSales(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	from, to := parseDateRange(ctx, "from", "to")
+
+	var customerId *uint
+	if s := ctx.Query("customerId"); s != "" {
+		id, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			x := errs.NewXError(errs.INVALID_REQUEST, "customerId must be a number", err)
+			h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+			return
+		}
+		value := uint(id)
+		customerId = &value
+	}
+
+	sales, errr := h.saleSvc.GetAll(&context, customerId, from, to)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(sales).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Get Sale Receipt
+//
+//	@Summary		Get Sale receipt
+//	@Description	Renders the printable PDF receipt of a counter sale
+//	@Tags			Sale
+//	@Produce		application/pdf
+//	@Success		200	{file}		file
+//	@Failure		400	{object}	responseModel.Response
+//	@Param			id	path		int	true	"Sale id"
+//	@Router			/sale/{id}/receipt [get]
+func (h SaleHandler) GetReceipt(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	receipt, errr := h.invoiceSvc.GetSaleReceipt(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", receipt.FileName))
+	ctx.Data(http.StatusOK, receipt.ContentType, receipt.Content)
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type StockTakeHandler struct {
+	stockTakeSvc service.StockTakeService
+	resp         response.Response
+	dataResp     response.DataResponse
+}
+
+func ProvideStockTakeHandler(svc service.StockTakeService) *StockTakeHandler {
+	return &StockTakeHandler{stockTakeSvc: svc}
+}
+
+// Open StockTake
+//
+//	@Summary		Open StockTake
+//	@Description	Opens a count session for a category, or for all products when no category is given
+//	@Tags			StockTake
+//	@Accept			json
+//	@Success		201			{object}	responseModel.StockTake
+//	@Failure		400			{object}	responseModel.Response
+//	@Param			stockTake	body		requestModel.StockTake	true	"stockTake"
+//	@Router			/stock-take [post]
+func (h StockTakeHandler) Open(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var stockTake requestModel.StockTake
+	err := ctx.Bind(&stockTake)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	res, errr := h.stockTakeSvc.Open(&context, stockTake)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(res).FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// Get StockTake
+//
+//	@Summary		Get a specific StockTake
+//	@Description	Get a StockTake with the expected, current and counted quantity and the variance of every product
+//	@Tags			StockTake
+//	@Accept			json
+//	@Success		200	{object}	responseModel.StockTake
+//	@Failure		400	{object}	responseModel.DataResponse
+//	@Param			id	path		int	true	"StockTake id"
+//	@Router			/stock-take/{id} [get]
+func (h StockTakeHandler) Get(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	stockTake, errr := h.stockTakeSvc.Get(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(stockTake).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Get all StockTakes
+//
+//	@Summary		Get all StockTakes
+//	@Description	Get all count sessions, latest first
+//	@Tags			StockTake
+//	@Accept			json
+//	@Success		200	{object}	responseModel.StockTake
+//	@Failure		400	{object}	responseModel.DataResponse
+//	@Router			/stock-take [get]
+func (h StockTakeHandler) GetAll(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	stockTakes, errr := h.stockTakeSvc.GetAll(&context)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(stockTakes).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Submit StockTake counts
+//
+//	@Summary		Submit StockTake counts
+//	@Description	Records counted quantities of products in an open StockTake
+//	@Tags			StockTake
+//	@Accept			json
+//	@Success		202		{object}	responseModel.Response
+//	@Failure		400		{object}	responseModel.Response
+//	@Param			counts	body		[]requestModel.StockTakeCount	true	"counts"
+//	@Param			id		path		int								true	"StockTake id"
+//	@Router			/stock-take/{id}/count [put]
+func (h StockTakeHandler) SubmitCounts(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var counts []requestModel.StockTakeCount
+	err := ctx.Bind(&counts)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.stockTakeSvc.SubmitCounts(&context, uint(id), counts)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Post StockTake
+//
+//	@Summary		Post StockTake
+//	@Description	Books the variance of every counted product as an ADJUST stock movement and closes the StockTake
+//	@Tags			StockTake
+//	@Accept			json
+//	@Success		202		{object}	responseModel.StockTake
+//	@Failure		400		{object}	responseModel.Response
+//	@Param			post	body		requestModel.StockTakePost	true	"reason for the adjustments"
+//	@Param			id		path		int							true	"StockTake id"
+//	@Router			/stock-take/{id}/post [post]
+func (h StockTakeHandler) Post(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var post requestModel.StockTakePost
+	err := ctx.Bind(&post)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	res, errr := h.stockTakeSvc.Post(&context, uint(id), post)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(res).FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Cancel StockTake
+//
+//	@Summary		Cancel StockTake
+//	@Description	Cancels an open StockTake without adjusting stock
+//	@Tags			StockTake
+//	@Accept			json
+//	@Success		200	{object}	responseModel.Response
+//	@Failure		400	{object}	responseModel.Response
+//	@Param			id	path		int	true	"StockTake id"
+//	@Router			/stock-take/{id} [delete]
+func (h StockTakeHandler) Cancel(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	err := h.stockTakeSvc.Cancel(&context, uint(id))
+	if err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Cancel Success").FormatAndSend(&context, ctx, http.StatusOK)
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type StockTransferHandler struct {
+	stockTransferSvc service.StockTransferService
+	resp             response.Response
+	dataResp         response.DataResponse
+}
+
+func ProvideStockTransferHandler(svc service.StockTransferService) *StockTransferHandler {
+	return &StockTransferHandler{stockTransferSvc: svc}
+}
+
+// Save StockTransfer
+//
+//	@Summary		Save StockTransfer
+//	@Description	Drafts a transfer of stock from the current channel to another channel
+//	@Tags			StockTransfer
+//	@Accept			json
+//	@Success		201				{object}	responseModel.Response
+//	@Failure		400				{object}	responseModel.Response
+//	@Param			stockTransfer	body		requestModel.StockTransfer	true	"stockTransfer"
+//	@Router			/stock-transfer [post]
+func (h StockTransferHandler) SaveStockTransfer(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var stockTransfer requestModel.StockTransfer
+	err := ctx.Bind(&stockTransfer)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	errr := h.stockTransferSvc.SaveStockTransfer(&context, stockTransfer)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// Update StockTransfer
+//
+//	@Summary		Update StockTransfer
+//	@Description	Replaces the destination and lines of a StockTransfer that is still a draft
+//	@Tags			StockTransfer
+//	@Accept			json
+//	@Success		202				{object}	responseModel.Response
+//	@Failure		400				{object}	responseModel.Response
+//	@Param			stockTransfer	body		requestModel.StockTransfer	true	"stockTransfer"
+//	@Param			id				path		int							true	"StockTransfer id"
+//	@Router			/stock-transfer/{id} [put]
+func (h StockTransferHandler) UpdateStockTransfer(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var stockTransfer requestModel.StockTransfer
+	err := ctx.Bind(&stockTransfer)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.stockTransferSvc.UpdateStockTransfer(&context, stockTransfer, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Get StockTransfer
+//
+//	@Summary		Get a specific StockTransfer
+//	@Description	Get a StockTransfer sent from or to the current channel, with its lines
+//	@Tags			StockTransfer
+//	@Accept			json
+//	@Success		200	{object}	responseModel.StockTransfer
+//	@Failure		400	{object}	responseModel.DataResponse
+//	@Param			id	path		int	true	"StockTransfer id"
+//	@Router			/stock-transfer/{id} [get]
+func (h StockTransferHandler) Get(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	stockTransfer, errr := h.stockTransferSvc.Get(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(stockTransfer).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Get all StockTransfers
+//
+//	@Summary		Get all StockTransfers
+//	@Description	Get all StockTransfers sent from or to the current channel, optionally for one status
+//	@Tags			StockTransfer
+//	@Accept			json
+//	@Success		200		{object}	responseModel.StockTransfer
+//	@Failure		400		{object}	responseModel.DataResponse
+//	@Param			status	query		string	false	"DRAFT, DISPATCHED, RECEIVED or CANCELLED"
+//	@Router			/stock-transfer [get]
+func (h StockTransferHandler) GetAllStockTransfers(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	stockTransfers, errr := h.stockTransferSvc.GetAll(&context, ctx.Query("status"))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(stockTransfers).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Dispatch StockTransfer
+//
+//	@Summary		Dispatch StockTransfer
+//	@Description	Books the lines as OUT stock movements in the sending channel, the stock is in transit until received
+//	@Tags			StockTransfer
+//	@Accept			json
+//	@Success		202	{object}	responseModel.StockTransfer
+//	@Failure		400	{object}	responseModel.Response
+//	@Param			id	path		int	true	"StockTransfer id"
+//	@Router			/stock-transfer/{id}/dispatch [post]
+func (h StockTransferHandler) Dispatch(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	stockTransfer, errr := h.stockTransferSvc.Dispatch(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(stockTransfer).FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Receive StockTransfer
+//
+//	@Summary		Receive StockTransfer
+//	@Description	Books the lines of a dispatched StockTransfer as IN stock movements in the receiving channel
+//	@Tags			StockTransfer
+//	@Accept			json
+//	@Success		202	{object}	responseModel.StockTransfer
+//	@Failure		400	{object}	responseModel.Response
+//	@Param			id	path		int	true	"StockTransfer id"
+//	@Router			/stock-transfer/{id}/receive [post]
+func (h StockTransferHandler) Receive(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	stockTransfer, errr := h.stockTransferSvc.Receive(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(stockTransfer).FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Cancel StockTransfer
+//
+//	@Summary		Cancel StockTransfer
+//	@Description	Cancels a StockTransfer that has not been dispatched
+//	@Tags			StockTransfer
+//	@Accept			json
+//	@Success		202	{object}	responseModel.Response
+//	@Failure		400	{object}	responseModel.Response
+//	@Param			id	path		int	true	"StockTransfer id"
+//	@Router			/stock-transfer/{id}/cancel [post]
+func (h StockTransferHandler) Cancel(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.stockTransferSvc.Cancel(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Cancel Success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type SupplierHandler struct {
+	supplierSvc service.SupplierService
+	resp        response.Response
+	dataResp    response.DataResponse
+}
+
+func ProvideSupplierHandler(svc service.SupplierService) *SupplierHandler {
+	return &SupplierHandler{supplierSvc: svc}
+}
+
+// Save Supplier
+//
+//	@Summary		Save Supplier
+//	@Description	Saves an instance of Supplier
+//	@Tags			Supplier
+//	@Accept			json
+//	@Success		201			{object}	responseModel.Response
+//	@Failure		400			{object}	responseModel.Response
+//	@Failure		500			{object}	responseModel.Response
+//	@Param			supplier	body		requestModel.Supplier	true	"supplier"
+//	@Router			/supplier [post]
+func (h SupplierHandler) SaveSupplier(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var supplier requestModel.Supplier
+	err := ctx.Bind(&supplier)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	errr := h.supplierSvc.SaveSupplier(&context, supplier)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusInternalServerError)
+		return
+	}
+
+	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// Update Supplier
+//
+//	@Summary		Update Supplier
+//	@Description	Updates an instance of Supplier
+//	@Tags			Supplier
+//	@Accept			json
+//	@Success		202			{object}	responseModel.Response
+//	@Failure		400			{object}	responseModel.Response
+//	@Failure		500			{object}	responseModel.Response
+//	@Param			supplier	body		requestModel.Supplier	true	"supplier"
+//	@Param			id			path		int						true	"Supplier id"
+//	@Router			/supplier/{id} [put]
+func (h SupplierHandler) UpdateSupplier(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var supplier requestModel.Supplier
+	err := ctx.Bind(&supplier)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.supplierSvc.UpdateSupplier(&context, supplier, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusInternalServerError)
+		return
+	}
+
+	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Get a specific Supplier
+//
+//	@Summary		Get a specific Supplier
+//	@Description	Get an instance of Supplier
+//	@Tags			Supplier
+//	@Accept			json
+//	@Success		200	{object}	responseModel.Supplier
+//	@Failure		400	{object}	responseModel.DataResponse
+//	@Param			id	path		int	true	"Supplier id"
+//	@Router			/supplier/{id} [get]
+func (h SupplierHandler) Get(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	supplier, errr := h.supplierSvc.Get(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(supplier).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Get all active suppliers
+//
+//	@Summary		Get all active suppliers
+//	@Description	Get all active suppliers
+//	@Tags			Supplier
+//	@Accept			json
+//	@Success		200		{object}	responseModel.Supplier
+//	@Failure		400		{object}	responseModel.DataResponse
+//	@Param			search	query		string	false	"search"
+//	@Router			/supplier [get]
+func (h SupplierHandler) GetAllSuppliers(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	search := ctx.Query("search")
+	search = util.EncloseWithSingleQuote(search)
+
+	suppliers, errr := h.supplierSvc.GetAll(&context, search)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(suppliers).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Delete Supplier
+//
+//	@Summary		Delete Supplier
+//	@Description	Deletes an instance of Supplier
+//	@Tags			Supplier
+//	@Accept			json
+//	@Success		200	{object}	responseModel.Response
+//	@Failure		400	{object}	responseModel.Response
+//	@Param			id	path		int	true	"supplier id"
+//	@Router			/supplier/{id} [delete]
+func (h SupplierHandler) Delete(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	err := h.supplierSvc.Delete(&context, uint(id))
+	if err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Autocomplete for suppliers
+//
+//	@Summary		Autocomplete for suppliers
+//	@Description	Autocomplete for suppliers
+//	@Tags			Supplier
+//	@Accept			json
+//	@Success		200		{object}	responseModel.SupplierAutoComplete
+//	@Failure		400		{object}	responseModel.DataResponse
+//	@Param			search	query		string	false	"search"
+//	@Router			/supplier/autocomplete [get]
+func (h SupplierHandler) AutocompleteSupplier(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	search := ctx.Query("search")
+	search = util.EncloseWithSingleQuote(search)
+
+	suppliers, errr := h.supplierSvc.AutocompleteSupplier(&context, search)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(suppliers).FormatAndSend(&context, ctx, http.StatusOK)
+}
@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"strings"
 	"time"
 
 	"github.com/imkarthi24/sf-backend/internal/entities"
@@ -20,6 +21,9 @@ type Mapper interface {
 	Customer(e requestModel.Customer) (*entities.Customer, error)
 	Person(e requestModel.Person) (*entities.Person, error)
 	DressType(e requestModel.DressType) (*entities.DressType, error)
+	DressTypeComponents(dressTypeId uint, items []requestModel.DressTypeComponent) ([]entities.DressTypeComponent, error)
+	MeasurementFields(dressTypeId uint, items []requestModel.MeasurementField) ([]entities.MeasurementField, error)
+	StockTake(e requestModel.StockTake) (*entities.StockTake, error)
 	Measurement(e requestModel.Measurement) (*entities.Measurement, error)
 	Order(e requestModel.Order) (*entities.Order, error)
 	OrderItem(e requestModel.OrderItem) (*entities.OrderItem, error)
@@ -34,6 +38,11 @@ type Mapper interface {
 	Product(e requestModel.Product) (*entities.Product, error)
 	Inventory(e requestModel.Inventory) (*entities.Inventory, error)
 	InventoryLog(e requestModel.InventoryLog) (*entities.InventoryLog, error)
+	OrderPayment(e requestModel.OrderPayment) (*entities.OrderPayment, error)
+	Supplier(e requestModel.Supplier) (*entities.Supplier, error)
+	PurchaseOrder(e requestModel.PurchaseOrder) (*entities.PurchaseOrder, error)
+	StockTransfer(e requestModel.StockTransfer) (*entities.StockTransfer, error)
+	Sale(e requestModel.Sale) (*entities.Sale, error)
 }
 
 type mapper struct{}
@@ -78,6 +87,8 @@ func (*mapper) Channel(chnl requestModel.Channel) (*entities.Channel, error) {
 		Name:        chnl.Name,
 		Status:      entities.ChannelStatus(chnl.Status),
 		OwnerUserID: chnl.OwnerUserId,
+		GSTIN:       chnl.GSTIN,
+		State:       chnl.State,
 	}, nil
 }
 
@@ -201,6 +212,7 @@ func (m *mapper) Customer(e requestModel.Customer) (*entities.Customer, error) {
 		PhoneNumber:    e.PhoneNumber,
 		WhatsappNumber: e.WhatsappNumber,
 		Address:        e.Address,
+		State:          e.State,
 	}, nil
 }
 
@@ -226,9 +238,57 @@ func (m *mapper) DressType(e requestModel.DressType) (*entities.DressType, error
 		Name:         e.Name,
 		Description:  e.Description,
 		Measurements: e.Measurements,
+		HSNCode:      e.HSNCode,
+		TaxRate:      e.TaxRate,
 	}, nil
 }
 
+func (m *mapper) DressTypeComponents(dressTypeId uint, items []requestModel.DressTypeComponent) ([]entities.DressTypeComponent, error) {
+	components := make([]entities.DressTypeComponent, 0, len(items))
+	for _, item := range items {
+		components = append(components, entities.DressTypeComponent{
+			Model:       &entities.Model{IsActive: true},
+			DressTypeId: dressTypeId,
+			ProductId:   item.ProductId,
+			Quantity:    entities.RoundQuantity(item.Quantity),
+			SingleLot:   item.SingleLot,
+			Notes:       item.Notes,
+		})
+	}
+	return components, nil
+}
+
+func (m *mapper) MeasurementFields(dressTypeId uint, items []requestModel.MeasurementField) ([]entities.MeasurementField, error) {
+	fields := make([]entities.MeasurementField, 0, len(items))
+	for i, item := range items {
+		key := strings.TrimSpace(item.Key)
+		if key == "" {
+			key = entities.MeasurementFieldKey(item.Label)
+		}
+		unit := entities.ParseMeasurementUnit(item.Unit)
+		if unit == "" {
+			unit = entities.MeasurementUnitINCH
+		}
+		displayOrder := item.DisplayOrder
+		if displayOrder == 0 {
+			displayOrder = i + 1
+		}
+		fields = append(fields, entities.MeasurementField{
+			Model:        &entities.Model{IsActive: true},
+			DressTypeId:  dressTypeId,
+			Key:          key,
+			Label:        strings.TrimSpace(item.Label),
+			Unit:         unit,
+			MinValue:     item.MinValue,
+			MaxValue:     item.MaxValue,
+			Required:     item.Required,
+			GroupName:    strings.TrimSpace(item.GroupName),
+			DisplayOrder: displayOrder,
+		})
+	}
+	return fields, nil
+}
+
 func (m *mapper) Measurement(e requestModel.Measurement) (*entities.Measurement, error) {
 	// Convert values JSON
 	var values entitiy_types.JSON
@@ -249,6 +309,7 @@ func (m *mapper) Measurement(e requestModel.Measurement) (*entities.Measurement,
 	return &entities.Measurement{
 		Model:       &entities.Model{ID: e.ID, IsActive: e.IsActive},
 		Value:       values,
+		Unit:        entities.ParseMeasurementUnit(e.Unit),
 		PersonId:    personId,
 		DressTypeId: dressTypeId,
 		TakenById:   e.TakenById,
@@ -322,6 +383,7 @@ func (m *mapper) OrderItem(e requestModel.OrderItem) (*entities.OrderItem, error
 		DeliveredDate:        deliveredDate,
 		PersonId:             e.PersonId,
 		MeasurementId:        e.MeasurementId,
+		AssignedTailorId:     e.AssignedTailorId,
 		OrderId:              e.OrderId,
 	}, nil
 }
@@ -381,6 +443,7 @@ func (m *mapper) OrderHistory(e requestModel.OrderHistory) (*entities.OrderHisto
 		Model:                &entities.Model{ID: e.ID, IsActive: e.IsActive},
 		Action:               entities.OrderHistoryAction(e.Action),
 		ChangedFields:        e.ChangedFields,
+		Reason:               e.Reason,
 		Status:               status,
 		ExpectedDeliveryDate: expectedDeliveryDate,
 		DeliveredDate:        deliveredDate,
@@ -447,6 +510,7 @@ func (m *mapper) ExpenseTracker(e requestModel.ExpenseTracker) (*entities.Expens
 		Balance:        e.Balance,
 		Location:       e.Location,
 		Notes:          e.Notes,
+		SupplierId:     e.SupplierId,
 		ExpenseDetails: expenseDetails,
 	}, nil
 }
@@ -530,8 +594,9 @@ func (m *mapper) Task(e requestModel.Task) (*entities.Task, error) {
 
 func (m *mapper) Category(e requestModel.Category) (*entities.Category, error) {
 	return &entities.Category{
-		Model: &entities.Model{ID: e.ID, IsActive: e.IsActive},
-		Name:  e.Name,
+		Model:    &entities.Model{ID: e.ID, IsActive: e.IsActive},
+		Name:     e.Name,
+		ParentId: e.ParentId,
 	}, nil
 }
 
@@ -544,6 +609,9 @@ func (m *mapper) Product(e requestModel.Product) (*entities.Product, error) {
 		Description:  e.Description,
 		CostPrice:    e.CostPrice,
 		SellingPrice: e.SellingPrice,
+		HSNCode:      e.HSNCode,
+		TaxRate:      e.TaxRate,
+		Unit:         entities.ParseUnitOfMeasure(e.Unit),
 	}, nil
 }
 
@@ -571,9 +639,143 @@ func (m *mapper) InventoryLog(e requestModel.InventoryLog) (*entities.InventoryL
 		Model:      &entities.Model{ID: e.ID, IsActive: e.IsActive},
 		ProductId:  e.ProductId,
 		ChangeType: entities.InventoryLogChangeType(e.ChangeType),
-		Quantity:   e.Quantity,
+		Quantity:   entities.RoundQuantity(e.Quantity),
 		Reason:     e.Reason,
 		Notes:      e.Notes,
 		LoggedAt:   loggedAt,
 	}, nil
 }
+
+func (m *mapper) OrderPayment(e requestModel.OrderPayment) (*entities.OrderPayment, error) {
+	var isActive bool = true
+	if e.IsActive != nil {
+		isActive = *e.IsActive
+	}
+
+	paidAt := util.GetLocalTime()
+	if e.PaidAt != "" {
+		date, err := util.GenerateDateTimeFromString(&e.PaidAt)
+		if err != nil {
+			return nil, err
+		}
+		paidAt = *date
+	}
+
+	return &entities.OrderPayment{
+		Model:     &entities.Model{ID: e.ID, IsActive: isActive},
+		Amount:    e.Amount,
+		Mode:      entities.OrderPaymentMode(e.Mode),
+		Reference: e.Reference,
+		PaidAt:    paidAt,
+		OrderId:   e.OrderId,
+	}, nil
+}
+
+func (m *mapper) StockTake(e requestModel.StockTake) (*entities.StockTake, error) {
+	return &entities.StockTake{
+		Model:      &entities.Model{IsActive: true},
+		Status:     entities.StockTakeStatusOPEN,
+		CategoryId: e.CategoryId,
+		Notes:      e.Notes,
+		OpenedAt:   util.GetLocalTime(),
+	}, nil
+}
+
+func (m *mapper) Supplier(e requestModel.Supplier) (*entities.Supplier, error) {
+	return &entities.Supplier{
+		Model:         &entities.Model{ID: e.ID, IsActive: e.IsActive},
+		Name:          e.Name,
+		ContactPerson: e.ContactPerson,
+		PhoneNumber:   e.PhoneNumber,
+		Email:         e.Email,
+		Address:       e.Address,
+		GSTIN:         e.GSTIN,
+		Notes:         e.Notes,
+	}, nil
+}
+
+func (m *mapper) PurchaseOrder(e requestModel.PurchaseOrder) (*entities.PurchaseOrder, error) {
+	var orderDate, expectedDate *time.Time
+	if e.OrderDate != nil {
+		date, err := util.GenerateDateTimeFromString(e.OrderDate)
+		if err != nil {
+			return nil, err
+		}
+		orderDate = date
+	}
+	if e.ExpectedDate != nil {
+		date, err := util.GenerateDateTimeFromString(e.ExpectedDate)
+		if err != nil {
+			return nil, err
+		}
+		expectedDate = date
+	}
+
+	lines := make([]entities.PurchaseOrderLine, 0, len(e.Lines))
+	for _, line := range e.Lines {
+		lines = append(lines, entities.PurchaseOrderLine{
+			Model:     &entities.Model{IsActive: true},
+			ProductId: line.ProductId,
+			Quantity:  entities.RoundQuantity(line.Quantity),
+			UnitCost:  line.UnitCost,
+		})
+	}
+
+	return &entities.PurchaseOrder{
+		Model:        &entities.Model{ID: e.ID, IsActive: true},
+		SupplierId:   e.SupplierId,
+		Status:       entities.PurchaseOrderStatusORDERED,
+		OrderDate:    orderDate,
+		ExpectedDate: expectedDate,
+		Notes:        e.Notes,
+		Lines:        lines,
+	}, nil
+}
+
+func (m *mapper) StockTransfer(e requestModel.StockTransfer) (*entities.StockTransfer, error) {
+	lines := make([]entities.StockTransferLine, 0, len(e.Lines))
+	for _, line := range e.Lines {
+		lines = append(lines, entities.StockTransferLine{
+			Model:     &entities.Model{IsActive: true},
+			ProductId: line.ProductId,
+			Quantity:  entities.RoundQuantity(line.Quantity),
+		})
+	}
+
+	return &entities.StockTransfer{
+		Model:       &entities.Model{IsActive: true},
+		ToChannelId: e.ToChannelId,
+		Status:      entities.StockTransferStatusDRAFT,
+		Notes:       e.Notes,
+		Lines:       lines,
+	}, nil
+}
+
+// Sale leaves the selling price of a line at 0 when it is not given, the service takes it from the product
+func (m *mapper) Sale(e requestModel.Sale) (*entities.Sale, error) {
+	lines := make([]entities.SaleLine, 0, len(e.Lines))
+	for _, line := range e.Lines {
+		var sellingPrice float64
+		if line.SellingPrice != nil {
+			sellingPrice = *line.SellingPrice
+		}
+		lines = append(lines, entities.SaleLine{
+			Model:        &entities.Model{IsActive: true},
+			ProductId:    line.ProductId,
+			Quantity:     entities.RoundQuantity(line.Quantity),
+			SellingPrice: sellingPrice,
+			Discount:     line.Discount,
+		})
+	}
+
+	return &entities.Sale{
+		Model:            &entities.Model{IsActive: true},
+		CustomerId:       e.CustomerId,
+		SoldAt:           util.GetLocalTime(),
+		PaymentMode:      entities.OrderPaymentMode(e.PaymentMode),
+		PaymentReference: e.PaymentReference,
+		Discount:         e.Discount,
+		Notes:            e.Notes,
+		Lines:            lines,
+	}, nil
+}
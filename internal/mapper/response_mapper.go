@@ -34,8 +34,13 @@ type ResponseMapper interface {
 	Persons(items []entities.Person) ([]responseModel.Person, error)
 	DressType(e *entities.DressType) (*responseModel.DressType, error)
 	DressTypes(items []entities.DressType) ([]responseModel.DressType, error)
+	DressTypeComponents(items []entities.DressTypeComponent) ([]responseModel.DressTypeComponent, error)
+	MeasurementFields(items []entities.MeasurementField) ([]responseModel.MeasurementField, error)
+	StockTake(e *entities.StockTake) (*responseModel.StockTake, error)
+	StockTakes(items []entities.StockTake) ([]responseModel.StockTake, error)
 	Measurement(e *entities.Measurement) (*responseModel.Measurement, error)
 	Measurements(items []entities.Measurement) ([]responseModel.Measurement, error)
+	MeasurementFlags(items []entities.MeasurementFlag) ([]responseModel.MeasurementFlag, error)
 	Order(e *entities.Order) (*responseModel.Order, error)
 	Orders(items []entities.Order) ([]responseModel.Order, error)
 	OrderItem(e *entities.OrderItem) (*responseModel.OrderItem, error)
@@ -56,8 +61,20 @@ type ResponseMapper interface {
 	Products(items []entities.Product) ([]responseModel.Product, error)
 	Inventory(e *entities.Inventory) (*responseModel.Inventory, error)
 	Inventories(items []entities.Inventory) ([]responseModel.Inventory, error)
+	InventoryLot(e *entities.InventoryLot) (*responseModel.InventoryLot, error)
+	InventoryLots(items []entities.InventoryLot) ([]responseModel.InventoryLot, error)
 	InventoryLog(e *entities.InventoryLog) (*responseModel.InventoryLog, error)
 	InventoryLogs(items []entities.InventoryLog) ([]responseModel.InventoryLog, error)
+	OrderPayment(e *entities.OrderPayment) (*responseModel.OrderPayment, error)
+	OrderPayments(items []entities.OrderPayment) ([]responseModel.OrderPayment, error)
+	Supplier(e *entities.Supplier) (*responseModel.Supplier, error)
+	Suppliers(items []entities.Supplier) ([]responseModel.Supplier, error)
+	PurchaseOrder(e *entities.PurchaseOrder) (*responseModel.PurchaseOrder, error)
+	PurchaseOrders(items []entities.PurchaseOrder) ([]responseModel.PurchaseOrder, error)
+	StockTransfer(e *entities.StockTransfer) (*responseModel.StockTransfer, error)
+	StockTransfers(items []entities.StockTransfer) ([]responseModel.StockTransfer, error)
+	Sale(e *entities.Sale) (*responseModel.Sale, error)
+	Sales(items []entities.Sale) ([]responseModel.Sale, error)
 }
 
 func ProvideResponseMapper() ResponseMapper {
@@ -291,6 +308,7 @@ func (m *responseMapper) Customer(e *entities.Customer) (*responseModel.Customer
 		PhoneNumber:    e.PhoneNumber,
 		WhatsappNumber: e.WhatsappNumber,
 		Address:        e.Address,
+		State:          e.State,
 		Persons:        persons,
 		Enquiries:      enquiries,
 		Orders:         orders,
@@ -343,16 +361,43 @@ func (m *responseMapper) DressType(e *entities.DressType) (*responseModel.DressT
 		return nil, nil
 	}
 
+	fields, err := m.MeasurementFields(e.MeasurementFields)
+	if err != nil {
+		return nil, err
+	}
+
 	return &responseModel.DressType{
-		ID:           e.ID,
-		IsActive:     e.IsActive,
-		Name:         e.Name,
-		Description:  e.Description,
-		Measurements: e.Measurements,
-		AuditFields:  responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedBy: e.CreatedBy, UpdatedBy: e.UpdatedBy},
+		ID:                e.ID,
+		IsActive:          e.IsActive,
+		Name:              e.Name,
+		Description:       e.Description,
+		Measurements:      e.Measurements,
+		HSNCode:           e.HSNCode,
+		TaxRate:           e.TaxRate,
+		MeasurementFields: fields,
+		AuditFields:       responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedBy: e.CreatedBy, UpdatedBy: e.UpdatedBy},
 	}, nil
 }
 
+func (m *responseMapper) MeasurementFields(items []entities.MeasurementField) ([]responseModel.MeasurementField, error) {
+	result := make([]responseModel.MeasurementField, 0, len(items))
+	for _, item := range items {
+		result = append(result, responseModel.MeasurementField{
+			ID:           item.ID,
+			DressTypeId:  item.DressTypeId,
+			Key:          item.Key,
+			Label:        item.Label,
+			Unit:         string(item.Unit),
+			MinValue:     item.MinValue,
+			MaxValue:     item.MaxValue,
+			Required:     item.Required,
+			GroupName:    item.GroupName,
+			DisplayOrder: item.DisplayOrder,
+		})
+	}
+	return result, nil
+}
+
 func (m *responseMapper) DressTypes(items []entities.DressType) ([]responseModel.DressType, error) {
 	result := make([]responseModel.DressType, 0)
 	for _, item := range items {
@@ -365,6 +410,29 @@ func (m *responseMapper) DressTypes(items []entities.DressType) ([]responseModel
 	return result, nil
 }
 
+func (m *responseMapper) DressTypeComponents(items []entities.DressTypeComponent) ([]responseModel.DressTypeComponent, error) {
+	result := make([]responseModel.DressTypeComponent, 0, len(items))
+	for _, item := range items {
+		var productName, productSKU string
+		if item.Product != nil {
+			productName = item.Product.Name
+			productSKU = item.Product.SKU
+		}
+		result = append(result, responseModel.DressTypeComponent{
+			ID:          item.ID,
+			DressTypeId: item.DressTypeId,
+			ProductId:   item.ProductId,
+			ProductName: productName,
+			ProductSKU:  productSKU,
+			Quantity:    item.Quantity,
+			SingleLot:   item.SingleLot,
+			Notes:       item.Notes,
+			AuditFields: responseModel.AuditFields{CreatedAt: item.CreatedAt, UpdatedAt: item.UpdatedAt, CreatedBy: item.CreatedBy, UpdatedBy: item.UpdatedBy},
+		})
+	}
+	return result, nil
+}
+
 func (m *responseMapper) Customers(items []entities.Customer) ([]responseModel.Customer, error) {
 	result := make([]responseModel.Customer, 0)
 	for _, item := range items {
@@ -403,17 +471,19 @@ func (m *responseMapper) Measurement(e *entities.Measurement) (*responseModel.Me
 	}
 
 	return &responseModel.Measurement{
-		ID:          e.ID,
-		IsActive:    e.IsActive,
-		Values:      e.Value,
-		PersonId:    &e.PersonId,
-		Person:      person,
-		PersonName:  personName,
-		DressTypeId: &e.DressTypeId,
-		DressType:   dressType,
-		TakenById:   e.TakenById,
-		TakenBy:     takenBy,
-		AuditFields: responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedBy: e.CreatedBy, UpdatedBy: e.UpdatedBy},
+		ID:           e.ID,
+		IsActive:     e.IsActive,
+		Values:       e.Value,
+		Unit:         string(entities.MeasurementStorageUnit),
+		CapturedUnit: string(e.Unit),
+		PersonId:     &e.PersonId,
+		Person:       person,
+		PersonName:   personName,
+		DressTypeId:  &e.DressTypeId,
+		DressType:    dressType,
+		TakenById:    e.TakenById,
+		TakenBy:      takenBy,
+		AuditFields:  responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedBy: e.CreatedBy, UpdatedBy: e.UpdatedBy},
 	}, nil
 }
 
@@ -429,6 +499,38 @@ func (m *responseMapper) Measurements(items []entities.Measurement) ([]responseM
 	return result, nil
 }
 
+func (m *responseMapper) MeasurementFlags(items []entities.MeasurementFlag) ([]responseModel.MeasurementFlag, error) {
+	result := make([]responseModel.MeasurementFlag, 0, len(items))
+	for _, item := range items {
+		measurement, err := m.Measurement(item.Measurement)
+		if err != nil {
+			return nil, err
+		}
+
+		var reviewedBy string
+		if item.ReviewedBy != nil {
+			reviewedBy = item.ReviewedBy.FirstName + " " + item.ReviewedBy.LastName
+		}
+
+		result = append(result, responseModel.MeasurementFlag{
+			ID:            item.ID,
+			IsActive:      item.IsActive,
+			MeasurementId: item.MeasurementId,
+			Measurement:   measurement,
+			Field:         item.Field,
+			Kind:          string(item.Kind),
+			Message:       item.Message,
+			Status:        string(item.Status),
+			ReviewedById:  item.ReviewedById,
+			ReviewedBy:    reviewedBy,
+			ReviewedAt:    item.ReviewedAt,
+			ReviewNote:    item.ReviewNote,
+			AuditFields:   responseModel.AuditFields{CreatedAt: item.CreatedAt, UpdatedAt: item.UpdatedAt, CreatedBy: item.CreatedBy, UpdatedBy: item.UpdatedBy},
+		})
+	}
+	return result, nil
+}
+
 func (m *responseMapper) Order(e *entities.Order) (*responseModel.Order, error) {
 	if e == nil {
 		return nil, nil
@@ -471,12 +573,20 @@ func (m *responseMapper) Order(e *entities.Order) (*responseModel.Order, error)
 		AdditionalCharges:    e.AdditionalCharges,
 		ExpectedDeliveryDate: e.ExpectedDeliveryDate,
 		DeliveredDate:        e.DeliveredDate,
+		InvoiceNumber:        e.InvoiceNumber,
+		InvoicedAt:           e.InvoicedAt,
 		CustomerId:           e.CustomerId,
 		CustomerName:         customerName,
 		OrderTakenById:       e.OrderTakenById,
 		OrderTakenBy:         orderTakenBy,
 		OrderQuantity:        orderQuantity,
 		OrderValue:           orderValue,
+		CGSTTotal:            e.CGSTTotal,
+		SGSTTotal:            e.SGSTTotal,
+		IGSTTotal:            e.IGSTTotal,
+		TaxTotal:             e.TaxTotal,
+		PaidAmount:           e.PaidAmount,
+		BalanceDue:           e.BalanceDue,
 		AuditFields:          responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedBy: e.CreatedBy, UpdatedBy: e.UpdatedBy},
 		OrderItems:           orderItems,
 	}, nil
@@ -514,6 +624,16 @@ func (m *responseMapper) OrderItem(e *entities.OrderItem) (*responseModel.OrderI
 		return nil, err
 	}
 
+	measurementSnapshot, err := m.measurementSnapshot(e)
+	if err != nil {
+		return nil, err
+	}
+
+	var assignedTailor string
+	if e.AssignedTailor != nil {
+		assignedTailor = e.AssignedTailor.FirstName + " " + e.AssignedTailor.LastName
+	}
+
 	return &responseModel.OrderItem{
 		ID:                   e.ID,
 		IsActive:             e.IsActive,
@@ -522,18 +642,80 @@ func (m *responseMapper) OrderItem(e *entities.OrderItem) (*responseModel.OrderI
 		Price:                e.Price,
 		Total:                e.Total,
 		AdditionalCharges:    e.AdditionalCharges,
+		HSNCode:              e.HSNCode,
+		TaxRate:              e.TaxRate,
+		TaxableValue:         e.TaxableValue,
+		CGSTAmount:           e.CGSTAmount,
+		SGSTAmount:           e.SGSTAmount,
+		IGSTAmount:           e.IGSTAmount,
+		Stage:                string(e.Stage),
+		StageUpdatedAt:       e.StageUpdatedAt,
+		CuttingAt:            e.CuttingAt,
+		StitchingAt:          e.StitchingAt,
+		FinishingAt:          e.FinishingAt,
+		QCAt:                 e.QCAt,
+		ReadyAt:              e.ReadyAt,
+		AssignedTailorId:     e.AssignedTailorId,
+		AssignedTailor:       assignedTailor,
 		ExpectedDeliveryDate: e.ExpectedDeliveryDate,
 		DeliveredDate:        e.DeliveredDate,
 		PersonId:             e.PersonId,
 		Person:               person,
 		MeasurementId:        e.MeasurementId,
 		Measurement:          measurement,
+		MeasurementSnapshot:  measurementSnapshot,
 		OrderId:              e.OrderId,
 		Order:                order,
 		AuditFields:          responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedBy: e.CreatedBy, UpdatedBy: e.UpdatedBy},
 	}, nil
 }
 
+// measurementSnapshot maps the measurement snapshot of an order item
+func (m *responseMapper) measurementSnapshot(e *entities.OrderItem) (*responseModel.MeasurementSnapshot, error) {
+	snapshot, err := e.GetMeasurementSnapshot()
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+
+	changedSince := false
+	if e.Measurement != nil && len(e.Measurement.Value) > 0 {
+		changedSince, err = snapshot.ChangedSince(*e.Measurement)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields := make([]responseModel.MeasurementField, 0, len(snapshot.Fields))
+	for _, field := range snapshot.Fields {
+		fields = append(fields, responseModel.MeasurementField{
+			DressTypeId:  snapshot.DressTypeId,
+			Key:          field.Key,
+			Label:        field.Label,
+			Unit:         string(field.Unit),
+			MinValue:     field.MinValue,
+			MaxValue:     field.MaxValue,
+			Required:     field.Required,
+			GroupName:    field.GroupName,
+			DisplayOrder: field.DisplayOrder,
+		})
+	}
+
+	return &responseModel.MeasurementSnapshot{
+		Measurement: responseModel.Measurement{
+			ID:           snapshot.MeasurementId,
+			Values:       snapshot.Values,
+			Unit:         string(entities.MeasurementStorageUnit),
+			CapturedUnit: string(snapshot.Unit),
+			PersonId:     &snapshot.PersonId,
+			DressTypeId:  &snapshot.DressTypeId,
+		},
+		Fields:       fields,
+		MeasuredAt:   snapshot.MeasuredAt,
+		TakenAt:      e.MeasurementSnapshotAt,
+		ChangedSince: changedSince,
+	}, nil
+}
+
 func (m *responseMapper) OrderItems(items []entities.OrderItem) ([]responseModel.OrderItem, error) {
 	result := make([]responseModel.OrderItem, 0)
 	for _, item := range items {
@@ -577,6 +759,7 @@ func (m *responseMapper) OrderHistory(e *entities.OrderHistory) (*responseModel.
 		IsActive:             e.IsActive,
 		Action:               string(e.Action),
 		ChangedFields:        e.ChangedFields,
+		Reason:               e.Reason,
 		Status:               status,
 		ExpectedDeliveryDate: e.ExpectedDeliveryDate,
 		DeliveredDate:        e.DeliveredDate,
@@ -652,6 +835,11 @@ func (m *responseMapper) ExpenseTracker(e *entities.Expense) (*responseModel.Exp
 		return nil, err
 	}
 
+	var supplierName string
+	if e.Supplier != nil {
+		supplierName = e.Supplier.Name
+	}
+
 	return &responseModel.ExpenseTracker{
 		ID:             e.ID,
 		IsActive:       e.IsActive,
@@ -665,6 +853,10 @@ func (m *responseMapper) ExpenseTracker(e *entities.Expense) (*responseModel.Exp
 		Notes:          e.Notes,
 		ExpenseDetails: expenseDetails,
 		AuditFields:    responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedBy: e.CreatedBy, UpdatedBy: e.UpdatedBy},
+
+		SupplierId:      e.SupplierId,
+		SupplierName:    supplierName,
+		PurchaseOrderId: e.PurchaseOrderId,
 	}, nil
 }
 
@@ -753,6 +945,8 @@ func (m *responseMapper) Category(e *entities.Category) (*responseModel.Category
 		ID:           e.ID,
 		IsActive:     e.IsActive,
 		Name:         e.Name,
+		ParentId:     e.ParentId,
+		Depth:        e.Depth,
 		ProductCount: productCount,
 		AuditFields: responseModel.AuditFields{
 			CreatedAt: e.CreatedAt,
@@ -792,7 +986,7 @@ func (m *responseMapper) Product(e *entities.Product) (*responseModel.Product, e
 	}
 
 	var inventory *responseModel.Inventory
-	var currentStock int
+	var currentStock float64
 	var isLowStock bool
 	if e.Inventory != nil {
 		inv, err := m.Inventory(e.Inventory)
@@ -804,6 +998,26 @@ func (m *responseMapper) Product(e *entities.Product) (*responseModel.Product, e
 		isLowStock = e.Inventory.IsLowStock()
 	}
 
+	// A parent holds no stock of its own, it is the total of its variants
+	var variants []responseModel.Product
+	if e.HasVariants() {
+		mappedVariants, err := m.Products(e.Variants)
+		if err != nil {
+			return nil, err
+		}
+		variants = mappedVariants
+		currentStock = 0
+		for _, variant := range variants {
+			currentStock = entities.RoundQuantity(currentStock + variant.CurrentStock)
+			isLowStock = isLowStock || variant.IsLowStock
+		}
+	}
+
+	var parentName string
+	if e.Parent != nil {
+		parentName = e.Parent.Name
+	}
+
 	return &responseModel.Product{
 		ID:           e.ID,
 		IsActive:     e.IsActive,
@@ -813,11 +1027,22 @@ func (m *responseMapper) Product(e *entities.Product) (*responseModel.Product, e
 		Description:  e.Description,
 		CostPrice:    e.CostPrice,
 		SellingPrice: e.SellingPrice,
+		HSNCode:      e.HSNCode,
+		TaxRate:      e.TaxRate,
 		Category:     category,
 		Inventory:    inventory,
 		CurrentStock: currentStock,
 		IsLowStock:   isLowStock,
 		CategoryName: categoryName,
+
+		Unit:            string(e.StockUnit()),
+		UnitConversions: m.productUnitConversions(e.UnitConversions),
+
+		VariantAttributes: m.productAttributes(e),
+		Variants:          variants,
+		ParentId:          e.ParentId,
+		ParentName:        parentName,
+		Options:           m.productOptions(e.OptionValues()),
 		AuditFields: responseModel.AuditFields{
 			CreatedAt: e.CreatedAt,
 			UpdatedAt: e.UpdatedAt,
@@ -827,6 +1052,76 @@ func (m *responseMapper) Product(e *entities.Product) (*responseModel.Product, e
 	}, nil
 }
 
+// productAttributes lists the attributes of a parent with the values its variants take, in the order they were given
+func (m *responseMapper) productAttributes(e *entities.Product) []responseModel.ProductAttribute {
+	if !e.HasVariants() {
+		return nil
+	}
+
+	attributes := make([]entities.ProductAttribute, len(e.Attributes))
+	copy(attributes, e.Attributes)
+	sort.SliceStable(attributes, func(i, j int) bool { return attributes[i].Position < attributes[j].Position })
+
+	values := make(map[uint][]string)
+	seen := make(map[uint]map[string]bool)
+	for _, variant := range e.Variants {
+		for _, value := range variant.OptionValues() {
+			if seen[value.AttributeId] == nil {
+				seen[value.AttributeId] = make(map[string]bool)
+			}
+			if seen[value.AttributeId][value.Value] {
+				continue
+			}
+			seen[value.AttributeId][value.Value] = true
+			values[value.AttributeId] = append(values[value.AttributeId], value.Value)
+		}
+	}
+
+	result := make([]responseModel.ProductAttribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		result = append(result, responseModel.ProductAttribute{
+			Name:   attribute.Name,
+			Values: values[attribute.ID],
+		})
+	}
+	return result
+}
+
+func (m *responseMapper) productOptions(values []entities.ProductVariantValue) []responseModel.ProductOption {
+	if len(values) == 0 {
+		return nil
+	}
+
+	result := make([]responseModel.ProductOption, 0, len(values))
+	for _, value := range values {
+		attribute := ""
+		if value.Attribute != nil {
+			attribute = value.Attribute.Name
+		}
+		result = append(result, responseModel.ProductOption{Attribute: attribute, Value: value.Value})
+	}
+	return result
+}
+
+func (m *responseMapper) productUnitConversions(items []entities.ProductUnitConversion) []responseModel.ProductUnitConversion {
+	result := make([]responseModel.ProductUnitConversion, 0, len(items))
+	for _, item := range items {
+		if item.Model != nil && !item.IsActive {
+			continue
+		}
+		var id uint
+		if item.Model != nil {
+			id = item.ID
+		}
+		result = append(result, responseModel.ProductUnitConversion{
+			ID:     id,
+			Unit:   string(item.Unit),
+			Factor: item.Factor,
+		})
+	}
+	return result
+}
+
 func (m *responseMapper) Products(items []entities.Product) ([]responseModel.Product, error) {
 	result := make([]responseModel.Product, 0)
 	for _, item := range items {
@@ -847,6 +1142,7 @@ func (m *responseMapper) Inventory(e *entities.Inventory) (*responseModel.Invent
 	var product *responseModel.Product
 	var productName string
 	var productSKU string
+	var unit string
 	if e.Product != nil {
 		prod, err := m.Product(e.Product)
 		if err != nil {
@@ -855,6 +1151,7 @@ func (m *responseMapper) Inventory(e *entities.Inventory) (*responseModel.Invent
 		product = prod
 		productName = e.Product.Name
 		productSKU = e.Product.SKU
+		unit = string(e.Product.StockUnit())
 	}
 
 	isLowStock := e.IsLowStock()
@@ -864,10 +1161,13 @@ func (m *responseMapper) Inventory(e *entities.Inventory) (*responseModel.Invent
 		IsActive:          e.IsActive,
 		ProductId:         e.ProductId,
 		Quantity:          e.Quantity,
+		ReservedQuantity:  e.ReservedQuantity,
+		AvailableQuantity: e.Available(),
 		LowStockThreshold: e.LowStockThreshold,
 		Product:           product,
 		ProductName:       productName,
 		ProductSKU:        productSKU,
+		Unit:              unit,
 		IsLowStock:        isLowStock,
 		AuditFields: responseModel.AuditFields{
 			CreatedAt: e.CreatedAt,
@@ -878,6 +1178,43 @@ func (m *responseMapper) Inventory(e *entities.Inventory) (*responseModel.Invent
 	}, nil
 }
 
+func (m *responseMapper) InventoryLot(e *entities.InventoryLot) (*responseModel.InventoryLot, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var supplierName string
+	if e.Supplier != nil {
+		supplierName = e.Supplier.Name
+	}
+
+	return &responseModel.InventoryLot{
+		ID:                e.ID,
+		IsActive:          e.IsActive,
+		ProductId:         e.ProductId,
+		SupplierId:        e.SupplierId,
+		SupplierName:      supplierName,
+		LotCode:           e.LotCode,
+		ReceivedAt:        e.ReceivedAt,
+		Quantity:          e.Quantity,
+		RemainingQuantity: e.RemainingQuantity,
+		ExpiresAt:         e.ExpiresAt,
+		IsExpired:         e.ExpiresAt != nil && e.ExpiresAt.Before(time.Now()),
+	}, nil
+}
+
+func (m *responseMapper) InventoryLots(items []entities.InventoryLot) ([]responseModel.InventoryLot, error) {
+	result := make([]responseModel.InventoryLot, 0)
+	for _, item := range items {
+		mappedItem, err := m.InventoryLot(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
+
 func (m *responseMapper) Inventories(items []entities.Inventory) ([]responseModel.Inventory, error) {
 	result := make([]responseModel.Inventory, 0)
 	for _, item := range items {
@@ -892,15 +1229,19 @@ func (m *responseMapper) Inventories(items []entities.Inventory) ([]responseMode
 
 // netChangeString formats quantity before and delta as "quantityBefore(+delta)" or "quantityBefore(-delta)".
 // If stockAfter is nil (single log without context), returns just "(+delta)" or "(-delta)".
-func netChangeString(delta int, stockAfter *int) string {
+func netChangeString(delta float64, stockAfter *float64) string {
+	sign := "+"
+	if delta < 0 {
+		sign = ""
+	}
 	if stockAfter == nil {
-		return fmt.Sprintf("(%+d)", delta)
+		return fmt.Sprintf("(%s%s)", sign, entities.FormatQuantity(delta))
 	}
-	qtyBefore := *stockAfter - delta
-	return fmt.Sprintf("%d(%+d)", qtyBefore, delta)
+	qtyBefore := entities.RoundQuantity(*stockAfter - delta)
+	return fmt.Sprintf("%s(%s%s)", entities.FormatQuantity(qtyBefore), sign, entities.FormatQuantity(delta))
 }
 
-func (m *responseMapper) inventoryLogWithStock(e *entities.InventoryLog, stockAfter *int) (*responseModel.InventoryLog, error) {
+func (m *responseMapper) inventoryLogWithStock(e *entities.InventoryLog, stockAfter *float64) (*responseModel.InventoryLog, error) {
 	if e == nil {
 		return nil, nil
 	}
@@ -918,8 +1259,18 @@ func (m *responseMapper) inventoryLogWithStock(e *entities.InventoryLog, stockAf
 		productSKU = e.Product.SKU
 	}
 
+	var orderId *uint
+	if e.OrderItem != nil {
+		orderId = &e.OrderItem.OrderId
+	}
+
+	var lotCode string
+	if e.Lot != nil {
+		lotCode = e.Lot.LotCode
+	}
+
 	delta := e.CalculateNetChange()
-	stockAfterVal := 0
+	stockAfterVal := 0.0
 	if stockAfter != nil {
 		stockAfterVal = *stockAfter
 	}
@@ -933,11 +1284,25 @@ func (m *responseMapper) inventoryLogWithStock(e *entities.InventoryLog, stockAf
 		Reason:      e.Reason,
 		Notes:       e.Notes,
 		LoggedAt:    e.LoggedAt,
+		OrderItemId: e.OrderItemId,
+		OrderId:     orderId,
 		Product:     product,
 		ProductName: productName,
 		ProductSKU:  productSKU,
 		NetChange:   netChangeString(delta, stockAfter),
 		StockAfter:  stockAfterVal,
+
+		PurchaseOrderId: e.PurchaseOrderId,
+		StockTransferId: e.StockTransferId,
+		SaleId:          e.SaleId,
+		ReversalOfLogId: e.ReversalOfLogId,
+		ReversedByLogId: e.ReversedByLogId,
+		UnitCost:        e.UnitCost,
+		EnteredQuantity: e.EnteredQuantity,
+		EnteredUnit:     string(e.EnteredUnit),
+		LotId:           e.LotId,
+		LotCode:         lotCode,
+
 		AuditFields: responseModel.AuditFields{
 			CreatedAt: e.CreatedAt,
 			UpdatedAt: e.UpdatedAt,
@@ -967,18 +1332,22 @@ func (m *responseMapper) InventoryLogs(items []entities.InventoryLog) ([]respons
 		}
 	}
 
-	var stockAfterByID map[uint]int
+	var stockAfterByID map[uint]float64
 	if sameProduct {
 		// Chronological order (oldest first) to compute running stock
 		sorted := make([]entities.InventoryLog, len(items))
 		copy(sorted, items)
 		sort.Slice(sorted, func(i, j int) bool {
+			if sorted[i].LoggedAt.Equal(sorted[j].LoggedAt) {
+				// Logs of one movement drawn from several lots share the time
+				return sorted[i].ID < sorted[j].ID
+			}
 			return sorted[i].LoggedAt.Before(sorted[j].LoggedAt)
 		})
-		stockAfterByID = make(map[uint]int, len(sorted))
-		runningStock := 0
+		stockAfterByID = make(map[uint]float64, len(sorted))
+		runningStock := 0.0
 		for i := range sorted {
-			runningStock += sorted[i].CalculateNetChange()
+			runningStock = entities.RoundQuantity(runningStock + sorted[i].CalculateNetChange())
 			stockAfterByID[sorted[i].ID] = runningStock
 		}
 	}
@@ -986,7 +1355,7 @@ func (m *responseMapper) InventoryLogs(items []entities.InventoryLog) ([]respons
 	result := make([]responseModel.InventoryLog, 0, len(items))
 	for i := range items {
 		item := &items[i]
-		var sa *int
+		var sa *float64
 		if stockAfterByID != nil {
 			if v, ok := stockAfterByID[item.ID]; ok {
 				sa = &v
@@ -1000,3 +1369,342 @@ func (m *responseMapper) InventoryLogs(items []entities.InventoryLog) ([]respons
 	}
 	return result, nil
 }
+
+func (m *responseMapper) OrderPayment(e *entities.OrderPayment) (*responseModel.OrderPayment, error) {
+	if e == nil {
+		return nil, nil
+	}
+	return &responseModel.OrderPayment{
+		ID:          e.ID,
+		IsActive:    e.IsActive,
+		Amount:      e.Amount,
+		Mode:        string(e.Mode),
+		Reference:   e.Reference,
+		PaidAt:      e.PaidAt,
+		OrderId:     e.OrderId,
+		AuditFields: responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedBy: e.CreatedBy, UpdatedBy: e.UpdatedBy},
+	}, nil
+}
+
+func (m *responseMapper) OrderPayments(items []entities.OrderPayment) ([]responseModel.OrderPayment, error) {
+	result := make([]responseModel.OrderPayment, 0, len(items))
+	for i := range items {
+		mapped, err := m.OrderPayment(&items[i])
+		if err != nil {
+			return nil, err
+		}
+		if mapped != nil {
+			result = append(result, *mapped)
+		}
+	}
+	return result, nil
+}
+
+func (m *responseMapper) StockTake(e *entities.StockTake) (*responseModel.StockTake, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var categoryName string
+	if e.Category != nil {
+		categoryName = e.Category.Name
+	}
+
+	countedCount := 0
+	lines := make([]responseModel.StockTakeLine, 0, len(e.Lines))
+	for i := range e.Lines {
+		line := &e.Lines[i]
+		if line.CountedQuantity != nil {
+			countedCount++
+		}
+
+		var productName, productSKU string
+		var currentQuantity float64
+		if line.Product != nil {
+			productName = line.Product.Name
+			productSKU = line.Product.SKU
+			if line.Product.Inventory != nil {
+				currentQuantity = line.Product.Inventory.Quantity
+			}
+		}
+
+		variance := line.PostedVariance
+		if variance == nil {
+			variance = line.Variance(currentQuantity)
+		}
+
+		lines = append(lines, responseModel.StockTakeLine{
+			ID:               line.ID,
+			ProductId:        line.ProductId,
+			ProductName:      productName,
+			ProductSKU:       productSKU,
+			ExpectedQuantity: line.ExpectedQuantity,
+			CurrentQuantity:  currentQuantity,
+			CountedQuantity:  line.CountedQuantity,
+			Variance:         variance,
+		})
+	}
+
+	return &responseModel.StockTake{
+		ID:           e.ID,
+		IsActive:     e.IsActive,
+		Status:       string(e.Status),
+		CategoryId:   e.CategoryId,
+		CategoryName: categoryName,
+		Notes:        e.Notes,
+		Reason:       e.Reason,
+		OpenedAt:     e.OpenedAt,
+		PostedAt:     e.PostedAt,
+		ProductCount: len(e.Lines),
+		CountedCount: countedCount,
+		AuditFields:  responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedBy: e.CreatedBy, UpdatedBy: e.UpdatedBy},
+		Lines:        lines,
+	}, nil
+}
+
+func (m *responseMapper) StockTakes(items []entities.StockTake) ([]responseModel.StockTake, error) {
+	result := make([]responseModel.StockTake, 0, len(items))
+	for i := range items {
+		mapped, err := m.StockTake(&items[i])
+		if err != nil {
+			return nil, err
+		}
+		// Lines are only returned when a single session is fetched
+		mapped.Lines = nil
+		result = append(result, *mapped)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) Supplier(e *entities.Supplier) (*responseModel.Supplier, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	return &responseModel.Supplier{
+		ID:                 e.ID,
+		IsActive:           e.IsActive,
+		Name:               e.Name,
+		ContactPerson:      e.ContactPerson,
+		PhoneNumber:        e.PhoneNumber,
+		Email:              e.Email,
+		Address:            e.Address,
+		GSTIN:              e.GSTIN,
+		Notes:              e.Notes,
+		OutstandingBalance: e.OutstandingBalance,
+		AuditFields:        responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedBy: e.CreatedBy, UpdatedBy: e.UpdatedBy},
+	}, nil
+}
+
+func (m *responseMapper) Suppliers(items []entities.Supplier) ([]responseModel.Supplier, error) {
+	result := make([]responseModel.Supplier, 0, len(items))
+	for i := range items {
+		mapped, err := m.Supplier(&items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mapped)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) PurchaseOrder(e *entities.PurchaseOrder) (*responseModel.PurchaseOrder, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var supplierName string
+	if e.Supplier != nil {
+		supplierName = e.Supplier.Name
+	}
+
+	lines := make([]responseModel.PurchaseOrderLine, 0, len(e.Lines))
+	for i := range e.Lines {
+		line := &e.Lines[i]
+
+		var productName, productSKU string
+		if line.Product != nil {
+			productName = line.Product.Name
+			productSKU = line.Product.SKU
+		}
+
+		lines = append(lines, responseModel.PurchaseOrderLine{
+			ID:                  line.ID,
+			ProductId:           line.ProductId,
+			ProductName:         productName,
+			ProductSKU:          productSKU,
+			Quantity:            line.Quantity,
+			ReceivedQuantity:    line.ReceivedQuantity,
+			OutstandingQuantity: line.OutstandingQuantity(),
+			UnitCost:            line.UnitCost,
+			LineTotal:           line.LineTotal(),
+		})
+	}
+
+	expenses, err := m.ExpenseTrackers(e.Expenses)
+	if err != nil {
+		return nil, err
+	}
+
+	return &responseModel.PurchaseOrder{
+		ID:            e.ID,
+		IsActive:      e.IsActive,
+		SupplierId:    e.SupplierId,
+		SupplierName:  supplierName,
+		Status:        string(e.Status),
+		OrderDate:     e.OrderDate,
+		ExpectedDate:  e.ExpectedDate,
+		Notes:         e.Notes,
+		OrderedValue:  e.OrderedValue(),
+		ReceivedValue: e.ReceivedValue(),
+		Lines:         lines,
+		Expenses:      expenses,
+		AuditFields:   responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedBy: e.CreatedBy, UpdatedBy: e.UpdatedBy},
+	}, nil
+}
+
+func (m *responseMapper) PurchaseOrders(items []entities.PurchaseOrder) ([]responseModel.PurchaseOrder, error) {
+	result := make([]responseModel.PurchaseOrder, 0, len(items))
+	for i := range items {
+		mapped, err := m.PurchaseOrder(&items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mapped)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) StockTransfer(e *entities.StockTransfer) (*responseModel.StockTransfer, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var fromChannelName, toChannelName string
+	if e.FromChannel != nil {
+		fromChannelName = e.FromChannel.Name
+	}
+	if e.ToChannel != nil {
+		toChannelName = e.ToChannel.Name
+	}
+
+	lines := make([]responseModel.StockTransferLine, 0, len(e.Lines))
+	for i := range e.Lines {
+		line := &e.Lines[i]
+
+		var productName, productSKU, unit string
+		if line.Product != nil {
+			productName = line.Product.Name
+			productSKU = line.Product.SKU
+			unit = string(line.Product.StockUnit())
+		}
+
+		lines = append(lines, responseModel.StockTransferLine{
+			ID:          line.ID,
+			ProductId:   line.ProductId,
+			ProductName: productName,
+			ProductSKU:  productSKU,
+			Unit:        unit,
+			Quantity:    line.Quantity,
+		})
+	}
+
+	return &responseModel.StockTransfer{
+		ID:              e.ID,
+		IsActive:        e.IsActive,
+		FromChannelId:   e.FromChannelId,
+		FromChannelName: fromChannelName,
+		ToChannelId:     e.ToChannelId,
+		ToChannelName:   toChannelName,
+		Status:          string(e.Status),
+		Notes:           e.Notes,
+		DispatchedAt:    e.DispatchedAt,
+		ReceivedAt:      e.ReceivedAt,
+		Lines:           lines,
+		AuditFields:     responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedBy: e.CreatedBy, UpdatedBy: e.UpdatedBy},
+	}, nil
+}
+
+func (m *responseMapper) StockTransfers(items []entities.StockTransfer) ([]responseModel.StockTransfer, error) {
+	result := make([]responseModel.StockTransfer, 0, len(items))
+	for i := range items {
+		mapped, err := m.StockTransfer(&items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mapped)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) Sale(e *entities.Sale) (*responseModel.Sale, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var customerName string
+	if e.Customer != nil {
+		customerName = e.Customer.FirstName + " " + e.Customer.LastName
+	}
+
+	lines := make([]responseModel.SaleLine, 0, len(e.Lines))
+	for i := range e.Lines {
+		line := &e.Lines[i]
+
+		var productName, productSKU, unit string
+		if line.Product != nil {
+			productName = line.Product.Name
+			productSKU = line.Product.SKU
+			unit = string(line.Product.StockUnit())
+		}
+
+		lines = append(lines, responseModel.SaleLine{
+			ID:           line.ID,
+			ProductId:    line.ProductId,
+			ProductName:  productName,
+			ProductSKU:   productSKU,
+			Unit:         unit,
+			Quantity:     line.Quantity,
+			SellingPrice: line.SellingPrice,
+			Discount:     line.Discount,
+			HSNCode:      line.HSNCode,
+			TaxRate:      line.TaxRate,
+			TaxableValue: line.TaxableValue,
+			TaxAmount:    line.TaxAmount(),
+			Total:        line.Total(),
+		})
+	}
+
+	return &responseModel.Sale{
+		ID:               e.ID,
+		IsActive:         e.IsActive,
+		ReceiptNumber:    e.ReceiptNumber(),
+		CustomerId:       e.CustomerId,
+		CustomerName:     customerName,
+		SoldAt:           e.SoldAt,
+		PaymentMode:      string(e.PaymentMode),
+		PaymentReference: e.PaymentReference,
+		Notes:            e.Notes,
+		SubTotal:         e.SubTotal,
+		Discount:         e.Discount,
+		TaxableValue:     e.TaxableValue,
+		CGSTAmount:       e.CGSTAmount,
+		SGSTAmount:       e.SGSTAmount,
+		IGSTAmount:       e.IGSTAmount,
+		GrandTotal:       e.GrandTotal,
+		Lines:            lines,
+		AuditFields:      responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedBy: e.CreatedBy, UpdatedBy: e.UpdatedBy},
+	}, nil
+}
+
+func (m *responseMapper) Sales(items []entities.Sale) ([]responseModel.Sale, error) {
+	result := make([]responseModel.Sale, 0, len(items))
+	for i := range items {
+		mapped, err := m.Sale(&items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mapped)
+	}
+	return result, nil
+}
@@ -4,4 +4,10 @@ type Category struct {
 	ID       uint   `json:"id,omitempty"`
 	IsActive bool   `json:"isActive,omitempty"`
 	Name     string `json:"name,omitempty"`
+	ParentId *uint  `json:"parentId,omitempty"` // Only used on create, use move to re-parent
+}
+
+// CategoryMove re-parents a category with its subcategories, no parent makes it a root
+type CategoryMove struct {
+	ParentId *uint `json:"parentId"`
 }
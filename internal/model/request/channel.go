@@ -6,4 +6,6 @@ type Channel struct {
 	Name        string `json:"name,omitempty"`
 	Status      string `json:"status,omitempty"`
 	OwnerUserId uint   `json:"ownerUserId,omitempty"`
+	GSTIN       string `json:"gstin,omitempty"`
+	State       string `json:"state,omitempty"`
 }
@@ -10,6 +10,7 @@ type Customer struct {
 	PhoneNumber    string `json:"phoneNumber,omitempty"`
 	WhatsappNumber string `json:"whatsappNumber,omitempty"`
 	Address        string `json:"address,omitempty"`
+	State          string `json:"state,omitempty"`
 	Age            int    `json:"age,omitempty"`
 	Gender         string `json:"gender,omitempty"`
 }
@@ -7,4 +7,30 @@ type DressType struct {
 	Name         string `json:"name,omitempty"`
 	Description  string `json:"description,omitempty"`
 	Measurements string `json:"measurements,omitempty"`
+
+	HSNCode string  `json:"hsnCode,omitempty"`
+	TaxRate float64 `json:"taxRate,omitempty"`
+
+	// Replaces the measurement schema when given, otherwise a schema is made from Measurements
+	MeasurementFields []MeasurementField `json:"measurementFields,omitempty"`
+}
+
+// MeasurementField is one measurement in the schema of a dress type, the key is made from the label when not given
+type MeasurementField struct {
+	Key          string   `json:"key,omitempty"`
+	Label        string   `json:"label" binding:"required"`
+	Unit         string   `json:"unit,omitempty"` // INCH, CM; defaults to INCH
+	MinValue     *float64 `json:"minValue,omitempty"`
+	MaxValue     *float64 `json:"maxValue,omitempty"`
+	Required     bool     `json:"required,omitempty"`
+	GroupName    string   `json:"groupName,omitempty"`
+	DisplayOrder int      `json:"displayOrder,omitempty"` // Defaults to the position in the list
+}
+
+// DressTypeComponent is a bill of materials line, quantity is per piece
+type DressTypeComponent struct {
+	ProductId uint    `json:"productId" binding:"required"`
+	Quantity  float64 `json:"quantity" binding:"required"` // In the product's stock unit
+	SingleLot bool    `json:"singleLot,omitempty"`         // Cut from one dye lot
+	Notes     string  `json:"notes,omitempty"`
 }
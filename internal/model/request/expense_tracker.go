@@ -12,6 +12,7 @@ type ExpenseTracker struct {
 	Balance      float64 `json:"balance,omitempty"`
 	Location     *string `json:"location,omitempty"`
 	Notes        *string `json:"notes,omitempty"`
+	SupplierId   *uint   `json:"supplierId,omitempty"`
 
 	ExpenseDetails []ExpenseDetail `json:"expenseDetails,omitempty"`
 }
@@ -1,22 +1,54 @@
 package requestModel
 
+import "time"
+
 type InventoryLog struct {
-	ID         uint   `json:"id,omitempty"`
-	IsActive   bool   `json:"isActive,omitempty"`
-	ProductId  uint   `json:"productId,omitempty"`
-	ChangeType string `json:"changeType,omitempty"` // IN, OUT, ADJUST
-	Quantity   int    `json:"quantity,omitempty"`
-	Reason     string `json:"reason,omitempty"`
-	Notes      string `json:"notes,omitempty"`
-	LoggedAt   string `json:"loggedAt,omitempty"` // ISO datetime string
+	ID         uint    `json:"id,omitempty"`
+	IsActive   bool    `json:"isActive,omitempty"`
+	ProductId  uint    `json:"productId,omitempty"`
+	ChangeType string  `json:"changeType,omitempty"` // IN, OUT, ADJUST
+	Quantity   float64 `json:"quantity,omitempty"`
+	Reason     string  `json:"reason,omitempty"`
+	Notes      string  `json:"notes,omitempty"`
+	LoggedAt   string  `json:"loggedAt,omitempty"` // ISO datetime string
+}
+
+// InventoryLogReversal undoes a movement that was recorded by mistake
+type InventoryLogReversal struct {
+	Reason string `json:"reason" binding:"required"`
 }
 
 // StockMovementRequest is used for manual stock adjustments
 type StockMovementRequest struct {
-	ProductId     uint   `json:"productId" binding:"required"`
-	ChangeType    string `json:"changeType" binding:"required"` // IN, OUT, ADJUST
-	Quantity      int    `json:"quantity" binding:"required"`
-	Reason        string `json:"reason" binding:"required"`
-	Notes         string `json:"notes,omitempty"`
-	AdminOverride bool   `json:"adminOverride,omitempty"` // Allow OUT even if stock insufficient
+	ProductId     uint    `json:"productId" binding:"required"`
+	ChangeType    string  `json:"changeType" binding:"required"` // IN, OUT, ADJUST
+	Quantity      float64 `json:"quantity" binding:"required"`
+	Reason        string  `json:"reason" binding:"required"`
+	Notes         string  `json:"notes,omitempty"`
+	AdminOverride bool    `json:"adminOverride,omitempty"` // Allow OUT even if stock insufficient
+	OrderItemId   *uint   `json:"orderItemId,omitempty"`   // Order item the stock is consumed for
+
+	// Unit the quantity is given in, converted to the product's stock unit (1 ROLL = 40 METER); defaults to the stock unit
+	Unit string `json:"unit,omitempty"`
+
+	UnitCost *float64 `json:"unitCost,omitempty"` // IN only, per unit the quantity is given in, defaults to the product's cost price
+
+	// Incoming stock opens a lot with these details
+	LotCode    string  `json:"lotCode,omitempty"`    // Dye-lot code printed on the roll
+	SupplierId *uint   `json:"supplierId,omitempty"` // Supplier the lot came from
+	ExpiresAt  *string `json:"expiresAt,omitempty"`
+
+	// Outgoing stock is taken from the oldest lots first unless a lot is given. SingleLot takes the
+	// whole quantity from one lot, so that all the fabric for a garment has the same dye lot.
+	// A positive ADJUST with a lot puts the stock back into that lot.
+	LotId     *uint `json:"lotId,omitempty"`
+	SingleLot bool  `json:"singleLot,omitempty"`
+
+	PurchaseOrderId *uint      `json:"-"` // Set by purchase order receipts only
+	StockTransferId *uint      `json:"-"` // Set by stock transfers only
+	SaleId          *uint      `json:"-"` // Set by counter sales only
+	ReceivedAt      *time.Time `json:"-"` // Received date of the lot when it is not today, set by purchase order receipts and stock transfers
+	LotExpiresAt    *time.Time `json:"-"` // Expiry carried over from the sending channel's lot, set by stock transfers
+
+	IdempotencyKey string `json:"idempotencyKey,omitempty"` // Taken from the Idempotency-Key header when present
 }
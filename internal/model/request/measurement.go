@@ -9,6 +9,7 @@ type Measurement struct {
 	IsActive bool `json:"isActive,omitempty"`
 
 	Values entitiy_types.JSON `json:"values,omitempty"`
+	Unit   string             `json:"unit,omitempty"` // INCH, CM; defaults to the display unit of the user
 
 	PersonId    *uint `json:"personId,omitempty"`
 	DressTypeId *uint `json:"dressTypeId,omitempty"`
@@ -18,9 +19,15 @@ type Measurement struct {
 type BulkMeasurementItem struct {
 	DressTypeId uint               `json:"dressTypeId"`
 	Values      entitiy_types.JSON `json:"values"`
+	Unit        string             `json:"unit,omitempty"` // INCH, CM; defaults to the display unit of the user
 }
 
 type BulkMeasurementRequest struct {
 	PersonId     uint                  `json:"personId"`
 	Measurements []BulkMeasurementItem `json:"measurements"`
 }
+
+// MeasurementFlagReview closes a flag once the measurement was checked with the person
+type MeasurementFlagReview struct {
+	Note string `json:"note" binding:"required"`
+}
@@ -32,9 +32,17 @@ type OrderItem struct {
 	ExpectedDeliveryDate *string `json:"expectedDeliveryDate,omitempty"`
 	DeliveredDate        *string `json:"deliveredDate,omitempty"`
 
-	PersonId      *uint `json:"personId,omitempty"`
-	MeasurementId *uint `json:"measurementId,omitempty"`
-	DressTypeId   *uint `json:"dressTypeId,omitempty"`
+	PersonId         *uint `json:"personId,omitempty"`
+	MeasurementId    *uint `json:"measurementId,omitempty"`
+	DressTypeId      *uint `json:"dressTypeId,omitempty"`
+	AssignedTailorId *uint `json:"assignedTailorId,omitempty"`
 
 	OrderId uint `json:"orderId,omitempty"`
 }
+
+// OrderItemStage moves an order item to a production stage
+type OrderItemStage struct {
+	Stage            string `json:"stage,omitempty" binding:"required"`
+	Reason           string `json:"reason,omitempty"`
+	AssignedTailorId *uint  `json:"assignedTailorId,omitempty"`
+}
@@ -5,6 +5,7 @@ type OrderHistory struct {
 	IsActive             bool    `json:"isActive,omitempty"`
 	Action               string  `json:"action,omitempty"`
 	ChangedFields        string  `json:"changedFields,omitempty"`
+	Reason               string  `json:"reason,omitempty"`
 	Status               *string `json:"status,omitempty"`
 	ExpectedDeliveryDate *string `json:"expectedDeliveryDate,omitempty"`
 	DeliveredDate        *string `json:"deliveredDate,omitempty"`
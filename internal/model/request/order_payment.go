@@ -0,0 +1,11 @@
+package requestModel
+
+type OrderPayment struct {
+	ID        uint    `json:"id,omitempty"`
+	IsActive  *bool   `json:"isActive,omitempty"`
+	Amount    float64 `json:"amount" binding:"required"`
+	Mode      string  `json:"mode" binding:"required"` // CASH, UPI, CARD, BANK
+	Reference string  `json:"reference,omitempty"`
+	PaidAt    string  `json:"paidAt,omitempty"` // ISO datetime string
+	OrderId   uint    `json:"orderId,omitempty"`
+}
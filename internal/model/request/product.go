@@ -9,5 +9,47 @@ type Product struct {
 	Description       string  `json:"description,omitempty"`
 	CostPrice         float64 `json:"costPrice,omitempty"`
 	SellingPrice      float64 `json:"sellingPrice,omitempty"`
-	LowStockThreshold int     `json:"lowStockThreshold,omitempty"`
+	HSNCode           string  `json:"hsnCode,omitempty"`
+	TaxRate           float64 `json:"taxRate,omitempty"`
+	LowStockThreshold float64 `json:"lowStockThreshold,omitempty"`
+
+	Unit            string                  `json:"unit,omitempty"` // PIECE, METER, KG, ROLL; defaults to PIECE
+	UnitConversions []ProductUnitConversion `json:"unitConversions,omitempty"`
+
+	// Makes the product a parent with a variant for every combination of the values.
+	// On update values can be added to the attributes, the missing variants are created.
+	VariantAttributes []ProductAttribute `json:"variantAttributes,omitempty"`
+}
+
+// ProductAttribute is an axis of the variant matrix, e.g. Colour with Red, Blue and Green
+type ProductAttribute struct {
+	Name   string   `json:"name" binding:"required"`
+	Values []string `json:"values" binding:"required"`
+}
+
+// ProductUnitConversion is how many stock units one unit holds, e.g. 1 ROLL = 40 METER
+type ProductUnitConversion struct {
+	Unit   string  `json:"unit" binding:"required"`
+	Factor float64 `json:"factor" binding:"required"`
+}
+
+// ProductLabels asks for a printable sheet of SKU labels, each product is repeated Copies times
+type ProductLabels struct {
+	ProductIds []uint            `json:"productIds" binding:"required"`
+	Copies     int               `json:"copies,omitempty"`    // defaults to 1
+	Symbology  string            `json:"symbology,omitempty"` // CODE128 or QR; defaults to CODE128
+	Layout     string            `json:"layout,omitempty"`    // A4-24, A4-40 or A4-65; defaults to A4-24
+	Custom     *LabelSheetLayout `json:"customLayout,omitempty"`
+}
+
+// LabelSheetLayout is a custom label sheet on A4, sizes are in millimetres
+type LabelSheetLayout struct {
+	Columns     int     `json:"columns" binding:"required"`
+	Rows        int     `json:"rows" binding:"required"`
+	LabelWidth  float64 `json:"labelWidth" binding:"required"`
+	LabelHeight float64 `json:"labelHeight" binding:"required"`
+	MarginLeft  float64 `json:"marginLeft,omitempty"`
+	MarginTop   float64 `json:"marginTop,omitempty"`
+	GapX        float64 `json:"gapX,omitempty"`
+	GapY        float64 `json:"gapY,omitempty"`
 }
@@ -0,0 +1,39 @@
+package requestModel
+
+type PurchaseOrder struct {
+	ID           uint    `json:"id,omitempty"`
+	SupplierId   uint    `json:"supplierId" binding:"required"`
+	OrderDate    *string `json:"orderDate,omitempty"`
+	ExpectedDate *string `json:"expectedDate,omitempty"`
+	Notes        string  `json:"notes,omitempty"`
+
+	Lines []PurchaseOrderLine `json:"lines"`
+}
+
+type PurchaseOrderLine struct {
+	ProductId uint    `json:"productId" binding:"required"`
+	Quantity  float64 `json:"quantity"` // In the product's stock unit
+	UnitCost  float64 `json:"unitCost"`
+}
+
+// PurchaseOrderReceipt records goods arriving against a purchase order, in full or in part
+type PurchaseOrderReceipt struct {
+	BillNumber   string  `json:"billNumber,omitempty"`
+	ReceivedDate *string `json:"receivedDate,omitempty"` // Defaults to now
+	Notes        string  `json:"notes,omitempty"`
+
+	Lines []PurchaseOrderReceiptLine `json:"lines"`
+
+	// Optional payment made to the supplier on receipt, recorded as an expense detail
+	AmountPaid    float64 `json:"amountPaid,omitempty"`
+	PaymentSource string  `json:"paymentSource,omitempty"`
+}
+
+type PurchaseOrderReceiptLine struct {
+	LineId   uint    `json:"lineId" binding:"required"`
+	Quantity float64 `json:"quantity"`
+
+	// Lot the received stock is booked as
+	LotCode   string  `json:"lotCode,omitempty"`
+	ExpiresAt *string `json:"expiresAt,omitempty"`
+}
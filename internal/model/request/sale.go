@@ -0,0 +1,19 @@
+package requestModel
+
+// Sale is a counter sale of ready-made products, it is posted as soon as it is saved
+type Sale struct {
+	CustomerId       *uint   `json:"customerId,omitempty"`
+	PaymentMode      string  `json:"paymentMode" binding:"required"` // CASH, UPI, CARD or BANK
+	PaymentReference string  `json:"paymentReference,omitempty"`
+	Discount         float64 `json:"discount,omitempty"` // Taken off the whole bill
+	Notes            string  `json:"notes,omitempty"`
+
+	Lines []SaleLine `json:"lines"`
+}
+
+type SaleLine struct {
+	ProductId    uint     `json:"productId" binding:"required"`
+	Quantity     float64  `json:"quantity"`               // In the product's stock unit
+	SellingPrice *float64 `json:"sellingPrice,omitempty"` // Defaults to the product's selling price
+	Discount     float64  `json:"discount,omitempty"`     // Taken off this line only
+}
@@ -0,0 +1,15 @@
+package requestModel
+
+type StockTake struct {
+	CategoryId *uint  `json:"categoryId,omitempty"` // Count all products when not given
+	Notes      string `json:"notes,omitempty"`
+}
+
+type StockTakeCount struct {
+	ProductId       uint    `json:"productId" binding:"required"`
+	CountedQuantity float64 `json:"countedQuantity"`
+}
+
+type StockTakePost struct {
+	Reason string `json:"reason" binding:"required"`
+}
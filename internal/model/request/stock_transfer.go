@@ -0,0 +1,14 @@
+package requestModel
+
+// StockTransfer sends stock from the current channel to another channel
+type StockTransfer struct {
+	ToChannelId uint   `json:"toChannelId" binding:"required"`
+	Notes       string `json:"notes,omitempty"`
+
+	Lines []StockTransferLine `json:"lines"`
+}
+
+type StockTransferLine struct {
+	ProductId uint    `json:"productId" binding:"required"`
+	Quantity  float64 `json:"quantity"` // In the product's stock unit
+}
@@ -0,0 +1,13 @@
+package requestModel
+
+type Supplier struct {
+	ID            uint   `json:"id,omitempty"`
+	IsActive      bool   `json:"isActive,omitempty"`
+	Name          string `json:"name,omitempty"`
+	ContactPerson string `json:"contactPerson,omitempty"`
+	PhoneNumber   string `json:"phoneNumber,omitempty"`
+	Email         string `json:"email,omitempty"`
+	Address       string `json:"address,omitempty"`
+	GSTIN         string `json:"gstin,omitempty"`
+	Notes         string `json:"notes,omitempty"`
+}
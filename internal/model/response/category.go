@@ -4,13 +4,19 @@ type Category struct {
 	ID       uint   `json:"id,omitempty"`
 	IsActive bool   `json:"isActive,omitempty"`
 	Name     string `json:"name,omitempty"`
+	ParentId *uint  `json:"parentId,omitempty"`
+	Depth    int    `json:"depth"`
+	Path     string `json:"path,omitempty"` // Names from the root, e.g. Fabrics > Silk > Kanchipuram
 
 	AuditFields `json:"auditFields,omitempty"`
 
 	ProductCount int `json:"productCount,omitempty"` // Count of products in this category
+
+	Children []Category `json:"children,omitempty"` // Filled in the category tree
 }
 
 type CategoryAutoComplete struct {
 	ID   uint   `json:"id,omitempty"`
 	Name string `json:"name,omitempty"`
+	Path string `json:"path,omitempty"`
 }
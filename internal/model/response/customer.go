@@ -10,6 +10,7 @@ type Customer struct {
 	PhoneNumber    string `json:"phoneNumber,omitempty"`
 	WhatsappNumber string `json:"whatsappNumber,omitempty"`
 	Address        string `json:"address,omitempty"`
+	State          string `json:"state,omitempty"`
 
 	AuditFields `json:"auditFields,omitempty"`
 
@@ -118,6 +118,8 @@ type OrderActivityItem struct {
 // Aggregates; support date range and ChannelId for revenue, expenses, new customers, task completion.
 type StatsDashboardResponse struct {
 	RevenueInPeriod       float64             `json:"revenueInPeriod"`       // delivered orders in period
+	SalesRevenueInPeriod  float64             `json:"salesRevenueInPeriod"`  // counter sales in period, taxable value after discounts
+	SalesCountInPeriod    int                 `json:"salesCountInPeriod"`    // counter sales in period
 	OrderPipelineValue   float64             `json:"orderPipelineValue"`   // sum value for orders not CANCELLED/DELIVERED
 	EnquiriesByStatus    []StatusCountStat   `json:"enquiriesByStatus"`    // new / accepted / callback / closed
 	EnquiryOrderConversion *EnquiryConversionStat `json:"enquiryOrderConversion,omitempty"` // orders linked to customers who had enquiries in period
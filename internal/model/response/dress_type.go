@@ -8,5 +8,36 @@ type DressType struct {
 	Description  string `json:"description,omitempty"`
 	Measurements string `json:"measurements,omitempty"`
 
+	HSNCode string  `json:"hsnCode,omitempty"`
+	TaxRate float64 `json:"taxRate,omitempty"`
+
+	MeasurementFields []MeasurementField `json:"measurementFields,omitempty"`
+
+	AuditFields `json:"auditFields,omitempty"`
+}
+
+type MeasurementField struct {
+	ID           uint     `json:"id,omitempty"`
+	DressTypeId  uint     `json:"dressTypeId,omitempty"`
+	Key          string   `json:"key"`
+	Label        string   `json:"label"`
+	Unit         string   `json:"unit"`
+	MinValue     *float64 `json:"minValue,omitempty"`
+	MaxValue     *float64 `json:"maxValue,omitempty"`
+	Required     bool     `json:"required"`
+	GroupName    string   `json:"groupName,omitempty"`
+	DisplayOrder int      `json:"displayOrder"`
+}
+
+type DressTypeComponent struct {
+	ID          uint    `json:"id,omitempty"`
+	DressTypeId uint    `json:"dressTypeId,omitempty"`
+	ProductId   uint    `json:"productId,omitempty"`
+	ProductName string  `json:"productName,omitempty"`
+	ProductSKU  string  `json:"productSku,omitempty"`
+	Quantity    float64 `json:"quantity,omitempty"`
+	SingleLot   bool    `json:"singleLot,omitempty"`
+	Notes       string  `json:"notes,omitempty"`
+
 	AuditFields `json:"auditFields,omitempty"`
 }
@@ -15,6 +15,10 @@ type ExpenseTracker struct {
 	Location     *string    `json:"location,omitempty"`
 	Notes        *string    `json:"notes,omitempty"`
 
+	SupplierId      *uint  `json:"supplierId,omitempty"`
+	SupplierName    string `json:"supplierName,omitempty"`
+	PurchaseOrderId *uint  `json:"purchaseOrderId,omitempty"`
+
 	ExpenseDetails []ExpenseDetail `json:"expenseDetails,omitempty"`
 
 	AuditFields `json:"auditFields,omitempty"`
@@ -4,3 +4,10 @@ type FileResponse struct {
 	FileUrl  string `json:"fileUrl,omitempty"`
 	FileName string `json:"fileName,omitempty"`
 }
+
+// FileContent holds a generated document that is streamed back as-is
+type FileContent struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
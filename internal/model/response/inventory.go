@@ -6,8 +6,10 @@ type Inventory struct {
 	ID                uint      `json:"id,omitempty"`
 	IsActive          bool      `json:"isActive,omitempty"`
 	ProductId         uint      `json:"productId,omitempty"`
-	Quantity          int       `json:"quantity,omitempty"`
-	LowStockThreshold int       `json:"lowStockThreshold,omitempty"`
+	Quantity          float64   `json:"quantity,omitempty"`
+	ReservedQuantity  float64   `json:"reservedQuantity"`  // Held back for confirmed orders
+	AvailableQuantity float64   `json:"availableQuantity"` // On hand less reserved
+	LowStockThreshold float64   `json:"lowStockThreshold,omitempty"`
 	UpdatedAt         time.Time `json:"updatedAt,omitempty"`
 
 	AuditFields `json:"auditFields,omitempty"`
@@ -16,14 +18,121 @@ type Inventory struct {
 	Product     *Product `json:"product,omitempty"`
 	ProductName string   `json:"productName,omitempty"`
 	ProductSKU  string   `json:"productSku,omitempty"`
+	Unit        string   `json:"unit,omitempty"` // Stock unit of the product
 	IsLowStock  bool     `json:"isLowStock,omitempty"`
 }
 
+// ConsolidatedStock is the stock of a product across the channels the user can access
+type ConsolidatedStock struct {
+	ProductId         uint                       `json:"productId"`
+	ProductName       string                     `json:"productName"`
+	ProductSKU        string                     `json:"productSku"`
+	Unit              string                     `json:"unit"`
+	TotalQuantity     float64                    `json:"totalQuantity"`
+	InTransitQuantity float64                    `json:"inTransitQuantity"` // Dispatched to one of the channels, not received yet
+	Channels          []ConsolidatedStockChannel `json:"channels"`
+}
+
+type ConsolidatedStockChannel struct {
+	ChannelId         uint    `json:"channelId"`
+	ChannelName       string  `json:"channelName"`
+	Quantity          float64 `json:"quantity"`
+	AvailableQuantity float64 `json:"availableQuantity"`
+	LowStockThreshold float64 `json:"lowStockThreshold"`
+	IsLowStock        bool    `json:"isLowStock"`
+}
+
+type InventoryLot struct {
+	ID                uint       `json:"id,omitempty"`
+	IsActive          bool       `json:"isActive,omitempty"`
+	ProductId         uint       `json:"productId,omitempty"`
+	SupplierId        *uint      `json:"supplierId,omitempty"`
+	SupplierName      string     `json:"supplierName,omitempty"`
+	LotCode           string     `json:"lotCode,omitempty"`
+	ReceivedAt        time.Time  `json:"receivedAt"`
+	Quantity          float64    `json:"quantity"`
+	RemainingQuantity float64    `json:"remainingQuantity"`
+	ExpiresAt         *time.Time `json:"expiresAt,omitempty"`
+	IsExpired         bool       `json:"isExpired,omitempty"`
+}
+
 type LowStockItem struct {
-	ProductId         uint   `json:"productId"`
-	ProductName       string `json:"productName"`
-	ProductSKU        string `json:"productSku"`
-	CurrentStock      int    `json:"currentStock"`
-	LowStockThreshold int    `json:"lowStockThreshold"`
-	CategoryName      string `json:"categoryName,omitempty"`
+	ProductId         uint    `json:"productId"`
+	ProductName       string  `json:"productName"`
+	ProductSKU        string  `json:"productSku"`
+	CurrentStock      float64 `json:"currentStock"`
+	ReservedQuantity  float64 `json:"reservedQuantity"`
+	AvailableQuantity float64 `json:"availableQuantity"`
+	LowStockThreshold float64 `json:"lowStockThreshold"`
+	Unit              string  `json:"unit"`
+	CategoryName      string  `json:"categoryName,omitempty"`
+	ParentProductId   *uint   `json:"parentProductId,omitempty"` // Set on variants, items are grouped by it
+	ParentProductName string  `json:"parentProductName,omitempty"`
+}
+
+// ReorderSuggestion is a low stock item with the quantity to order to cover the coming window
+type ReorderSuggestion struct {
+	InventoryId             uint       `json:"inventoryId"`
+	ProductId               uint       `json:"productId"`
+	ProductName             string     `json:"productName"`
+	ProductSKU              string     `json:"productSku"`
+	Unit                    string     `json:"unit"`
+	CategoryName            string     `json:"categoryName,omitempty"`
+	CurrentStock            float64    `json:"currentStock"`
+	AvailableQuantity       float64    `json:"availableQuantity"`
+	LowStockThreshold       float64    `json:"lowStockThreshold"`
+	WindowDays              int        `json:"windowDays"`
+	ConsumedQuantity        float64    `json:"consumedQuantity"` // Booked out over the window
+	AverageDailyConsumption float64    `json:"averageDailyConsumption"`
+	SuggestedQuantity       float64    `json:"suggestedQuantity"`
+	LowStockAlertedAt       *time.Time `json:"lowStockAlertedAt,omitempty"`
+	ParentProductId         *uint      `json:"parentProductId,omitempty"`
+	ParentProductName       string     `json:"parentProductName,omitempty"`
+}
+
+// LowStockAlert is the set of suggestions a channel owner is emailed about
+type LowStockAlert struct {
+	ChannelId   uint                `json:"channelId"`
+	ChannelName string              `json:"channelName"`
+	OwnerUserId uint                `json:"ownerUserId"`
+	OwnerEmail  string              `json:"ownerEmail"`
+	Suggestions []ReorderSuggestion `json:"suggestions"`
+}
+
+type InventoryValuation struct {
+	AsOf          time.Time                `json:"asOf"`
+	CostingMethod string                   `json:"costingMethod"`
+	TotalValue    float64                  `json:"totalValue"`
+	Items         []InventoryValuationItem `json:"items"`
+}
+
+type InventoryValuationItem struct {
+	ProductId   uint    `json:"productId"`
+	ProductName string  `json:"productName"`
+	ProductSKU  string  `json:"productSku"`
+	Unit        string  `json:"unit"`
+	Quantity    float64 `json:"quantity"`
+	UnitCost    float64 `json:"unitCost"`
+	Value       float64 `json:"value"`
+}
+
+// COGSReport is the cost of stock taken out in a period
+type COGSReport struct {
+	From          time.Time        `json:"from"`
+	To            time.Time        `json:"to"`
+	CostingMethod string           `json:"costingMethod"`
+	TotalCost     float64          `json:"totalCost"`
+	TotalWriteOff float64          `json:"totalWriteOff"`
+	Items         []COGSReportItem `json:"items"`
+}
+
+type COGSReportItem struct {
+	ProductId          uint    `json:"productId"`
+	ProductName        string  `json:"productName"`
+	ProductSKU         string  `json:"productSku"`
+	Unit               string  `json:"unit"`
+	QuantityConsumed   float64 `json:"quantityConsumed"`
+	CostOfGoods        float64 `json:"costOfGoods"`
+	QuantityWrittenOff float64 `json:"quantityWrittenOff"`
+	WriteOffCost       float64 `json:"writeOffCost"`
 }
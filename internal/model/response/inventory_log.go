@@ -7,11 +7,29 @@ type InventoryLog struct {
 	IsActive   bool      `json:"isActive,omitempty"`
 	ProductId  uint      `json:"productId,omitempty"`
 	ChangeType string    `json:"changeType,omitempty"`
-	Quantity   int       `json:"quantity,omitempty"`
+	Quantity   float64   `json:"quantity,omitempty"`
 	Reason     string    `json:"reason,omitempty"`
 	Notes      string    `json:"notes,omitempty"`
 	LoggedAt   time.Time `json:"loggedAt,omitempty"`
 
+	EnteredQuantity *float64 `json:"enteredQuantity,omitempty"`
+	EnteredUnit     string   `json:"enteredUnit,omitempty"`
+
+	UnitCost *float64 `json:"unitCost,omitempty"`
+
+	OrderItemId *uint `json:"orderItemId,omitempty"`
+	OrderId     *uint `json:"orderId,omitempty"`
+
+	PurchaseOrderId *uint `json:"purchaseOrderId,omitempty"`
+	StockTransferId *uint `json:"stockTransferId,omitempty"`
+	SaleId          *uint `json:"saleId,omitempty"`
+
+	ReversalOfLogId *uint `json:"reversalOfLogId,omitempty"`
+	ReversedByLogId *uint `json:"reversedByLogId,omitempty"`
+
+	LotId   *uint  `json:"lotId,omitempty"`
+	LotCode string `json:"lotCode,omitempty"`
+
 	AuditFields `json:"auditFields,omitempty"`
 
 	// Related data
@@ -19,15 +37,25 @@ type InventoryLog struct {
 	ProductName  string   `json:"productName,omitempty"`
 	ProductSKU   string   `json:"productSku,omitempty"`
 	NetChange    string   `json:"netChange,omitempty"`    // e.g. "0(+20)" = 0 before, +20 added; "20(-5)" = 20 before, -5
-	StockAfter   int      `json:"stockAfter,omitempty"`   // Stock quantity after this movement
+	StockAfter   float64  `json:"stockAfter,omitempty"`   // Stock quantity after this movement
 	LoggedByName string   `json:"loggedByName,omitempty"` // User who logged
 }
 
 type StockMovementResponse struct {
-	Success       bool   `json:"success"`
-	Message       string `json:"message"`
-	ProductId     uint   `json:"productId"`
-	PreviousStock int    `json:"previousStock"`
-	NewStock      int    `json:"newStock"`
-	ChangeAmount  int    `json:"changeAmount"`
+	Success       bool    `json:"success"`
+	Message       string  `json:"message"`
+	ProductId     uint    `json:"productId"`
+	PreviousStock float64 `json:"previousStock"`
+	NewStock      float64 `json:"newStock"`
+	ChangeAmount  float64 `json:"changeAmount"`
+	Unit          string  `json:"unit"`               // Stock unit the figures are in
+	Replayed      bool    `json:"replayed,omitempty"` // Movement was already recorded with the same idempotency key
+
+	Lots []StockMovementLot `json:"lots,omitempty"` // Lots the stock went into or was taken from
+}
+
+type StockMovementLot struct {
+	LotId    uint    `json:"lotId"`
+	LotCode  string  `json:"lotCode,omitempty"`
+	Quantity float64 `json:"quantity"`
 }
@@ -1,6 +1,8 @@
 package responseModel
 
 import (
+	"time"
+
 	entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
 )
 
@@ -8,7 +10,9 @@ type Measurement struct {
 	ID       uint `json:"id,omitempty"`
 	IsActive bool `json:"isActive,omitempty"`
 
-	Values entitiy_types.JSON `json:"values,omitempty"`
+	Values       entitiy_types.JSON `json:"values,omitempty"`
+	Unit         string             `json:"unit,omitempty"`         // Unit the values are shown in
+	CapturedUnit string             `json:"capturedUnit,omitempty"` // Unit the values were taken in
 
 	PersonId   *uint   `json:"personId,omitempty"`
 	Person     *Person `json:"person,omitempty"`
@@ -23,6 +27,15 @@ type Measurement struct {
 	AuditFields `json:"auditFields,omitempty"`
 }
 
+// MeasurementSnapshot is the measurement an order item was confirmed with
+type MeasurementSnapshot struct {
+	Measurement  Measurement        `json:"measurement"`
+	Fields       []MeasurementField `json:"fields"`
+	MeasuredAt   *time.Time         `json:"measuredAt,omitempty"` // When the values were last changed before the snapshot
+	TakenAt      *time.Time         `json:"takenAt,omitempty"`
+	ChangedSince bool               `json:"changedSince"` // The measurement no longer holds these values
+}
+
 type MeasurementBrowse struct {
 	ID         uint   `json:"id,omitempty"`
 	IsActive   bool   `json:"isActive,omitempty"`
@@ -33,3 +46,55 @@ type MeasurementBrowse struct {
 	UpdatedAt  string `json:"updatedAt,omitempty"`
 	UpdatedBy  string `json:"updatedBy,omitempty"`
 }
+
+// MeasurementDiff compares two versions of a measurement
+type MeasurementDiff struct {
+	MeasurementId uint `json:"measurementId"`
+
+	FromHistoryId  *uint      `json:"fromHistoryId,omitempty"`  // Not set for the current values
+	FromReplacedAt *time.Time `json:"fromReplacedAt,omitempty"` // When the from version was changed
+	ToHistoryId    *uint      `json:"toHistoryId,omitempty"`
+	ToReplacedAt   *time.Time `json:"toReplacedAt,omitempty"`
+
+	Unit    string              `json:"unit"`
+	Changes []MeasurementChange `json:"changes"`
+}
+
+type MeasurementChange struct {
+	Field  string   `json:"field"`
+	Label  string   `json:"label,omitempty"`
+	From   *float64 `json:"from"`
+	To     *float64 `json:"to"`
+	Delta  *float64 `json:"delta,omitempty"`
+	Status string   `json:"status"` // ADDED, REMOVED, CHANGED, UNCHANGED
+}
+
+// MeasurementAnomaly warns about a saved value that looks like a data-entry mistake
+type MeasurementAnomaly struct {
+	MeasurementId uint   `json:"measurementId"`
+	PersonId      uint   `json:"personId,omitempty"`
+	DressTypeId   uint   `json:"dressTypeId,omitempty"`
+	Field         string `json:"field"`
+	Kind          string `json:"kind"` // JUMP, OUT_OF_RANGE, RULE
+	Message       string `json:"message"`
+}
+
+type MeasurementFlag struct {
+	ID       uint `json:"id,omitempty"`
+	IsActive bool `json:"isActive,omitempty"`
+
+	MeasurementId uint         `json:"measurementId"`
+	Measurement   *Measurement `json:"measurement,omitempty"`
+
+	Field   string `json:"field"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	Status  string `json:"status"` // OPEN, REVIEWED, SUPERSEDED
+
+	ReviewedById *uint      `json:"reviewedById,omitempty"`
+	ReviewedBy   string     `json:"reviewedBy,omitempty"` // first_name + last_name
+	ReviewedAt   *time.Time `json:"reviewedAt,omitempty"`
+	ReviewNote   string     `json:"reviewNote,omitempty"`
+
+	AuditFields `json:"auditFields,omitempty"`
+}
@@ -15,6 +15,9 @@ type Order struct {
 	ExpectedDeliveryDate *time.Time `json:"expectedDeliveryDate,omitempty"`
 	DeliveredDate        *time.Time `json:"deliveredDate,omitempty"`
 
+	InvoiceNumber string     `json:"invoiceNumber,omitempty"`
+	InvoicedAt    *time.Time `json:"invoicedAt,omitempty"`
+
 	CustomerId   *uint     `json:"customerId,omitempty"`
 	Customer     *Customer `json:"customer,omitempty"`
 	CustomerName string    `json:"customerName,omitempty"` // first_name + last_name
@@ -24,6 +27,12 @@ type Order struct {
 
 	OrderQuantity int     `json:"orderQuantity,omitempty"` // sum of quantity from order items
 	OrderValue    float64 `json:"orderValue,omitempty"`    // sum of total from order items
+	PaidAmount    float64 `json:"paidAmount"`              // sum of amount from order payments
+	CGSTTotal     float64 `json:"cgstTotal"`               // sum of cgst amount from order items
+	SGSTTotal     float64 `json:"sgstTotal"`               // sum of sgst amount from order items
+	IGSTTotal     float64 `json:"igstTotal"`               // sum of igst amount from order items
+	TaxTotal      float64 `json:"taxTotal"`                // cgst + sgst + igst
+	BalanceDue    float64 `json:"balanceDue"`              // order value + tax + additional charges - paid amount
 
 	AuditFields `json:"auditFields,omitempty"`
 
@@ -40,6 +49,24 @@ type OrderItem struct {
 	Total             float64 `json:"total,omitempty"`
 	AdditionalCharges float64 `json:"additionalCharges,omitempty"`
 
+	HSNCode      string  `json:"hsnCode,omitempty"`
+	TaxRate      float64 `json:"taxRate"`
+	TaxableValue float64 `json:"taxableValue"`
+	CGSTAmount   float64 `json:"cgstAmount"`
+	SGSTAmount   float64 `json:"sgstAmount"`
+	IGSTAmount   float64 `json:"igstAmount"`
+
+	Stage          string     `json:"stage,omitempty"`
+	StageUpdatedAt *time.Time `json:"stageUpdatedAt,omitempty"`
+	CuttingAt      *time.Time `json:"cuttingAt,omitempty"`
+	StitchingAt    *time.Time `json:"stitchingAt,omitempty"`
+	FinishingAt    *time.Time `json:"finishingAt,omitempty"`
+	QCAt           *time.Time `json:"qcAt,omitempty"`
+	ReadyAt        *time.Time `json:"readyAt,omitempty"`
+
+	AssignedTailorId *uint  `json:"assignedTailorId,omitempty"`
+	AssignedTailor   string `json:"assignedTailor,omitempty"` // first_name + last_name
+
 	ExpectedDeliveryDate *time.Time `json:"expectedDeliveryDate,omitempty"`
 	DeliveredDate        *time.Time `json:"deliveredDate,omitempty"`
 
@@ -48,6 +75,8 @@ type OrderItem struct {
 	MeasurementId *uint        `json:"measurementId,omitempty"`
 	Measurement   *Measurement `json:"measurement,omitempty"`
 
+	MeasurementSnapshot *MeasurementSnapshot `json:"measurementSnapshot,omitempty"`
+
 	OrderId uint   `json:"orderId,omitempty"`
 	Order   *Order `json:"order,omitempty"`
 
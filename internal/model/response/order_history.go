@@ -7,6 +7,7 @@ type OrderHistory struct {
 	IsActive             bool       `json:"isActive,omitempty"`
 	Action               string     `json:"action,omitempty"`
 	ChangedFields        string     `json:"changedFields,omitempty"`
+	Reason               string     `json:"reason,omitempty"`
 	Status               *string    `json:"status,omitempty"`
 	ExpectedDeliveryDate *time.Time `json:"expectedDeliveryDate,omitempty"`
 	DeliveredDate        *time.Time `json:"deliveredDate,omitempty"`
@@ -0,0 +1,15 @@
+package responseModel
+
+import "time"
+
+type OrderPayment struct {
+	ID        uint      `json:"id,omitempty"`
+	IsActive  bool      `json:"isActive,omitempty"`
+	Amount    float64   `json:"amount,omitempty"`
+	Mode      string    `json:"mode,omitempty"`
+	Reference string    `json:"reference,omitempty"`
+	PaidAt    time.Time `json:"paidAt,omitempty"`
+	OrderId   uint      `json:"orderId,omitempty"`
+
+	AuditFields `json:"auditFields,omitempty"`
+}
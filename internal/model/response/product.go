@@ -9,21 +9,56 @@ type Product struct {
 	Description  string  `json:"description,omitempty"`
 	CostPrice    float64 `json:"costPrice,omitempty"`
 	SellingPrice float64 `json:"sellingPrice,omitempty"`
+	HSNCode      string  `json:"hsnCode,omitempty"`
+	TaxRate      float64 `json:"taxRate,omitempty"`
+
+	Unit            string                  `json:"unit,omitempty"`
+	UnitConversions []ProductUnitConversion `json:"unitConversions,omitempty"`
 
 	AuditFields `json:"auditFields,omitempty"`
 
 	// Related data
 	Category     *Category  `json:"category,omitempty"`
 	Inventory    *Inventory `json:"inventory,omitempty"`
-	CurrentStock int        `json:"currentStock,omitempty"` // From inventory
+	CurrentStock float64    `json:"currentStock,omitempty"` // From inventory
 	IsLowStock   bool       `json:"isLowStock,omitempty"`   // Stock alert flag
 	CategoryName string     `json:"categoryName,omitempty"` // Flattened category name
+
+	// Variants of a parent product, its stock is the total of theirs
+	VariantAttributes []ProductAttribute `json:"variantAttributes,omitempty"`
+	Variants          []Product          `json:"variants,omitempty"`
+
+	// Set on variants
+	ParentId   *uint           `json:"parentId,omitempty"`
+	ParentName string          `json:"parentName,omitempty"`
+	Options    []ProductOption `json:"options,omitempty"`
+}
+
+type ProductAttribute struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// ProductOption is the value a variant takes for an attribute, e.g. Colour: Red
+type ProductOption struct {
+	Attribute string `json:"attribute"`
+	Value     string `json:"value"`
 }
 
 type ProductAutoComplete struct {
-	ID           uint   `json:"id,omitempty"`
-	Name         string `json:"name,omitempty"`
-	SKU          string `json:"sku,omitempty"`
-	CurrentStock int    `json:"currentStock,omitempty"`
-	IsLowStock   bool   `json:"isLowStock,omitempty"`
+	ID           uint    `json:"id,omitempty"`
+	Name         string  `json:"name,omitempty"`
+	SKU          string  `json:"sku,omitempty"`
+	Unit         string  `json:"unit,omitempty"`
+	CurrentStock float64 `json:"currentStock,omitempty"`
+	IsLowStock   bool    `json:"isLowStock,omitempty"`
+
+	// Variants of a parent product, stock is moved on them rather than on the parent
+	Variants []ProductAutoComplete `json:"variants,omitempty"`
+}
+
+type ProductUnitConversion struct {
+	ID     uint    `json:"id,omitempty"`
+	Unit   string  `json:"unit"`
+	Factor float64 `json:"factor"`
 }
@@ -0,0 +1,34 @@
+package responseModel
+
+import "time"
+
+type PurchaseOrder struct {
+	ID           uint       `json:"id,omitempty"`
+	IsActive     bool       `json:"isActive,omitempty"`
+	SupplierId   uint       `json:"supplierId,omitempty"`
+	SupplierName string     `json:"supplierName,omitempty"`
+	Status       string     `json:"status,omitempty"`
+	OrderDate    *time.Time `json:"orderDate,omitempty"`
+	ExpectedDate *time.Time `json:"expectedDate,omitempty"`
+	Notes        string     `json:"notes,omitempty"`
+
+	OrderedValue  float64 `json:"orderedValue"`  // Sum of quantity * unit cost
+	ReceivedValue float64 `json:"receivedValue"` // Sum of received quantity * unit cost
+
+	Lines    []PurchaseOrderLine `json:"lines,omitempty"`
+	Expenses []ExpenseTracker    `json:"expenses,omitempty"` // One per receipt, with the payments made
+
+	AuditFields `json:"auditFields,omitempty"`
+}
+
+type PurchaseOrderLine struct {
+	ID                  uint    `json:"id,omitempty"`
+	ProductId           uint    `json:"productId,omitempty"`
+	ProductName         string  `json:"productName,omitempty"`
+	ProductSKU          string  `json:"productSku,omitempty"`
+	Quantity            float64 `json:"quantity"`
+	ReceivedQuantity    float64 `json:"receivedQuantity"`
+	OutstandingQuantity float64 `json:"outstandingQuantity"`
+	UnitCost            float64 `json:"unitCost"`
+	LineTotal           float64 `json:"lineTotal"`
+}
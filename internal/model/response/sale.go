@@ -0,0 +1,43 @@
+package responseModel
+
+import "time"
+
+type Sale struct {
+	ID               uint      `json:"id,omitempty"`
+	IsActive         bool      `json:"isActive,omitempty"`
+	ReceiptNumber    string    `json:"receiptNumber,omitempty"`
+	CustomerId       *uint     `json:"customerId,omitempty"`
+	CustomerName     string    `json:"customerName,omitempty"`
+	SoldAt           time.Time `json:"soldAt"`
+	PaymentMode      string    `json:"paymentMode,omitempty"`
+	PaymentReference string    `json:"paymentReference,omitempty"`
+	Notes            string    `json:"notes,omitempty"`
+
+	SubTotal     float64 `json:"subTotal"` // Lines after their own discounts
+	Discount     float64 `json:"discount"` // Taken off the whole bill
+	TaxableValue float64 `json:"taxableValue"`
+	CGSTAmount   float64 `json:"cgstAmount"`
+	SGSTAmount   float64 `json:"sgstAmount"`
+	IGSTAmount   float64 `json:"igstAmount"`
+	GrandTotal   float64 `json:"grandTotal"`
+
+	Lines []SaleLine `json:"lines,omitempty"`
+
+	AuditFields `json:"auditFields,omitempty"`
+}
+
+type SaleLine struct {
+	ID           uint    `json:"id,omitempty"`
+	ProductId    uint    `json:"productId,omitempty"`
+	ProductName  string  `json:"productName,omitempty"`
+	ProductSKU   string  `json:"productSku,omitempty"`
+	Unit         string  `json:"unit,omitempty"`
+	Quantity     float64 `json:"quantity"`
+	SellingPrice float64 `json:"sellingPrice"`
+	Discount     float64 `json:"discount"`
+	HSNCode      string  `json:"hsnCode,omitempty"`
+	TaxRate      float64 `json:"taxRate"`
+	TaxableValue float64 `json:"taxableValue"`
+	TaxAmount    float64 `json:"taxAmount"`
+	Total        float64 `json:"total"`
+}
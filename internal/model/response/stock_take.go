@@ -0,0 +1,33 @@
+package responseModel
+
+import "time"
+
+type StockTake struct {
+	ID           uint       `json:"id,omitempty"`
+	IsActive     bool       `json:"isActive,omitempty"`
+	Status       string     `json:"status,omitempty"`
+	CategoryId   *uint      `json:"categoryId,omitempty"`
+	CategoryName string     `json:"categoryName,omitempty"`
+	Notes        string     `json:"notes,omitempty"`
+	Reason       string     `json:"reason,omitempty"`
+	OpenedAt     time.Time  `json:"openedAt,omitempty"`
+	PostedAt     *time.Time `json:"postedAt,omitempty"`
+
+	ProductCount int `json:"productCount"` // Products in the session
+	CountedCount int `json:"countedCount"` // Products counted so far
+
+	AuditFields `json:"auditFields,omitempty"`
+
+	Lines []StockTakeLine `json:"lines,omitempty"`
+}
+
+type StockTakeLine struct {
+	ID               uint     `json:"id,omitempty"`
+	ProductId        uint     `json:"productId,omitempty"`
+	ProductName      string   `json:"productName,omitempty"`
+	ProductSKU       string   `json:"productSku,omitempty"`
+	ExpectedQuantity float64  `json:"expectedQuantity"` // Stock when the session was opened
+	CurrentQuantity  float64  `json:"currentQuantity"`  // Stock now
+	CountedQuantity  *float64 `json:"countedQuantity"`  // nil until counted
+	Variance         *float64 `json:"variance"`         // counted - current, or the posted adjustment once posted
+}
@@ -0,0 +1,29 @@
+package responseModel
+
+import "time"
+
+type StockTransfer struct {
+	ID              uint       `json:"id,omitempty"`
+	IsActive        bool       `json:"isActive,omitempty"`
+	FromChannelId   uint       `json:"fromChannelId,omitempty"`
+	FromChannelName string     `json:"fromChannelName,omitempty"`
+	ToChannelId     uint       `json:"toChannelId,omitempty"`
+	ToChannelName   string     `json:"toChannelName,omitempty"`
+	Status          string     `json:"status,omitempty"`
+	Notes           string     `json:"notes,omitempty"`
+	DispatchedAt    *time.Time `json:"dispatchedAt,omitempty"`
+	ReceivedAt      *time.Time `json:"receivedAt,omitempty"`
+
+	Lines []StockTransferLine `json:"lines,omitempty"`
+
+	AuditFields `json:"auditFields,omitempty"`
+}
+
+type StockTransferLine struct {
+	ID          uint    `json:"id,omitempty"`
+	ProductId   uint    `json:"productId,omitempty"`
+	ProductName string  `json:"productName,omitempty"`
+	ProductSKU  string  `json:"productSku,omitempty"`
+	Unit        string  `json:"unit,omitempty"`
+	Quantity    float64 `json:"quantity"`
+}
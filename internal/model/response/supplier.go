@@ -0,0 +1,22 @@
+package responseModel
+
+type Supplier struct {
+	ID            uint   `json:"id,omitempty"`
+	IsActive      bool   `json:"isActive,omitempty"`
+	Name          string `json:"name,omitempty"`
+	ContactPerson string `json:"contactPerson,omitempty"`
+	PhoneNumber   string `json:"phoneNumber,omitempty"`
+	Email         string `json:"email,omitempty"`
+	Address       string `json:"address,omitempty"`
+	GSTIN         string `json:"gstin,omitempty"`
+	Notes         string `json:"notes,omitempty"`
+
+	OutstandingBalance float64 `json:"outstandingBalance"` // Still owed to the supplier across all expenses
+
+	AuditFields `json:"auditFields,omitempty"`
+}
+
+type SupplierAutoComplete struct {
+	ID   uint   `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
 	"github.com/loop-kar/pixie/db"
 	"github.com/loop-kar/pixie/errs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CategoryRepository interface {
@@ -16,6 +19,13 @@ type CategoryRepository interface {
 	GetAll(*context.Context, string) ([]entities.Category, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
 	AutocompleteCategory(*context.Context, string) ([]entities.Category, *errs.XError)
+	GetByIds(*context.Context, []uint) ([]entities.Category, *errs.XError)
+	GetTree(*context.Context) ([]entities.Category, *errs.XError)
+	Lock(*context.Context, uint) *errs.XError
+	LockTree(*context.Context) *errs.XError
+	SetPosition(*context.Context, uint, *uint, string, int) *errs.XError
+	MoveSubtree(*context.Context, string, string, int) *errs.XError
+	CountChildren(*context.Context, uint) (int64, *errs.XError)
 }
 
 type categoryRepository struct {
@@ -78,10 +88,108 @@ func (cr *categoryRepository) AutocompleteCategory(ctx *context.Context, search
 	res := cr.WithDB(ctx).
 		Scopes(scopes.Channel(), scopes.IsActive()).
 		Scopes(scopes.ILike(search, "name")).
-		Select("id", "name").
+		Select("id", "name", "path", "depth").
 		Find(&categories)
 	if res.Error != nil {
 		return nil, errs.NewXError(errs.DATABASE, "Unable to find categories for autocomplete", res.Error)
 	}
 	return categories, nil
 }
+
+func (cr *categoryRepository) GetByIds(ctx *context.Context, ids []uint) ([]entities.Category, *errs.XError) {
+	var categories []entities.Category
+	if len(ids) == 0 {
+		return categories, nil
+	}
+	res := cr.WithDB(ctx).Model(&entities.Category{}).
+		Where("id IN ?", ids).
+		Find(&categories)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find categories", res.Error)
+	}
+	return categories, nil
+}
+
+// GetTree returns all active categories of the channel ordered so that parents come before their children
+func (cr *categoryRepository) GetTree(ctx *context.Context) ([]entities.Category, *errs.XError) {
+	var categories []entities.Category
+	res := cr.WithDB(ctx).Model(&entities.Category{}).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Order("depth ASC, name ASC").
+		Find(&categories)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find categories", res.Error)
+	}
+	return categories, nil
+}
+
+// Lock holds the category row until the transaction ends, a category created under it waits for moves of it
+func (cr *categoryRepository) Lock(ctx *context.Context, id uint) *errs.XError {
+	var category entities.Category
+	res := cr.WithDB(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&category, id)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to find category", res.Error)
+	}
+	return nil
+}
+
+// LockTree locks every category of the channel in id order until the transaction ends
+func (cr *categoryRepository) LockTree(ctx *context.Context) *errs.XError {
+	var categories []entities.Category
+	res := cr.WithDB(ctx).Model(&entities.Category{}).
+		Scopes(scopes.Channel()).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Order("id ASC").
+		Find(&categories)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to lock categories", res.Error)
+	}
+	return nil
+}
+
+// SetPosition places the category under the parent with the given path and depth, without touching its descendants
+func (cr *categoryRepository) SetPosition(ctx *context.Context, id uint, parentId *uint, path string, depth int) *errs.XError {
+	res := cr.WithDB(ctx).Model(&entities.Category{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"parent_id":  parentId,
+			"path":       path,
+			"depth":      depth,
+			"updated_at": time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update category position", res.Error)
+	}
+	return nil
+}
+
+// MoveSubtree rewrites the paths starting with oldPath to start with newPath and shifts their depth
+func (cr *categoryRepository) MoveSubtree(ctx *context.Context, oldPath string, newPath string, depthChange int) *errs.XError {
+	res := cr.WithDB(ctx).Model(&entities.Category{}).
+		Where("path LIKE ?", oldPath+"%").
+		Updates(map[string]interface{}{
+			"path":       gorm.Expr("? || SUBSTRING(path FROM ?)", newPath, len(oldPath)+1),
+			"depth":      gorm.Expr("depth + ?", depthChange),
+			"updated_at": time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to move categories", res.Error)
+	}
+	return nil
+}
+
+func (cr *categoryRepository) CountChildren(ctx *context.Context, id uint) (int64, *errs.XError) {
+	var count int64
+	res := cr.WithDB(ctx).Model(&entities.Category{}).
+		Scopes(scopes.IsActive()).
+		Where("parent_id = ?", id).
+		Count(&count)
+	if res.Error != nil {
+		return 0, errs.NewXError(errs.DATABASE, "Unable to count subcategories", res.Error)
+	}
+	return count, nil
+}
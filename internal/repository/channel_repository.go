@@ -7,6 +7,7 @@ import (
 	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
 	"github.com/loop-kar/pixie/db"
 	"github.com/loop-kar/pixie/errs"
+	"gorm.io/gorm/clause"
 )
 
 type ChannelRepository interface {
@@ -16,6 +17,8 @@ type ChannelRepository interface {
 	Delete(*context.Context, uint) *errs.XError
 	GetAllChannels(*context.Context, string) ([]entities.Channel, *errs.XError)
 	ChannelAutoComplete(*context.Context, string) ([]entities.Channel, *errs.XError)
+	GetActive(*context.Context) ([]entities.Channel, *errs.XError)
+	Lock(*context.Context, uint) *errs.XError
 }
 
 type channelRepository struct {
@@ -101,3 +104,33 @@ func (ur *channelRepository) ChannelAutoComplete(ctx *context.Context, autoCompN
 
 	return *channels, nil
 }
+
+// GetActive returns every active channel with its owner, for jobs that run outside a user session
+func (ur *channelRepository) GetActive(ctx *context.Context) ([]entities.Channel, *errs.XError) {
+	var channels []entities.Channel
+
+	res := ur.WithDB(ctx).
+		Preload("OwnerUser").
+		Scopes(scopes.IsActive()).
+		Order("id ASC").
+		Find(&channels)
+
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to fetch active channels", res.Error)
+	}
+
+	return channels, nil
+}
+
+// Lock takes a row lock on the channel until the transaction ends, invoices of the channel are numbered one at a time
+func (ur *channelRepository) Lock(ctx *context.Context, id uint) *errs.XError {
+	var channel entities.Channel
+	res := ur.WithDB(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&channel, id)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to find channel", res.Error)
+	}
+	return nil
+}
@@ -411,6 +411,22 @@ func (dr *dashboardRepository) GetStatsDashboard(ctx *context.Context, from, to
 		resp.RevenueInPeriod += o.OrderValue + o.AdditionalCharges
 	}
 
+	// 1a. Counter sales in period, excluding GST like the order revenue. Sales are timestamped,
+	// so the whole of the to day is counted.
+	var sales struct {
+		Revenue float64
+		Count   int64
+	}
+	res = dr.WithDB(ctx).Model(&entities.Sale{}).Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("sold_at >= ? AND sold_at < ?", from, to.AddDate(0, 0, 1)).
+		Select("COALESCE(SUM(taxable_value), 0) as revenue", "COUNT(*) as count").
+		Scan(&sales)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "stats sales revenue", res.Error)
+	}
+	resp.SalesRevenueInPeriod = sales.Revenue
+	resp.SalesCountInPeriod = int(sales.Count)
+
 	// 2. Order pipeline value (not CANCELLED/DELIVERED)
 	var pipelineOrders []entities.Order
 	res = dr.WithDB(ctx).Model(&entities.Order{}).
@@ -512,8 +528,10 @@ func (dr *dashboardRepository) GetStatsDashboard(ctx *context.Context, from, to
 	// 8. Low-stock items
 	var lowStock []entities.Inventory
 	res = dr.WithDB(ctx).Model(&entities.Inventory{}).Scopes(scopes.Channel(), scopes.IsActive()).
-		Where("quantity <= low_stock_threshold").
+		Scopes(scopes.WithReservedQuantity(), scopes.LowAvailableStock()).
+		Scopes(scopes.GroupedByParentProduct()).
 		Preload("Product").Preload("Product.Category").
+		Preload("Product.Parent", scopes.SelectFields("name")).
 		Find(&lowStock)
 	if res.Error != nil {
 		return nil, errs.NewXError(errs.DATABASE, "stats low stock", res.Error)
@@ -523,20 +541,33 @@ func (dr *dashboardRepository) GetStatsDashboard(ctx *context.Context, from, to
 		name := ""
 		sku := ""
 		categoryName := ""
+		parentName := ""
+		var parentId *uint
+		unit := string(entities.UnitOfMeasurePIECE)
 		if i.Product != nil {
 			name = i.Product.Name
 			sku = i.Product.SKU
+			unit = string(i.Product.StockUnit())
 			if i.Product.Category != nil {
 				categoryName = i.Product.Category.Name
 			}
+			parentId = i.Product.ParentId
+			if i.Product.Parent != nil {
+				parentName = i.Product.Parent.Name
+			}
 		}
 		resp.LowStockItems = append(resp.LowStockItems, responseModel.LowStockItem{
 			ProductId:         i.ProductId,
 			ProductName:       name,
 			ProductSKU:        sku,
 			CurrentStock:      i.Quantity,
+			ReservedQuantity:  i.ReservedQuantity,
+			AvailableQuantity: i.Available(),
 			LowStockThreshold: i.LowStockThreshold,
+			Unit:              unit,
 			CategoryName:      categoryName,
+			ParentProductId:   parentId,
+			ParentProductName: parentName,
 		})
 	}
 
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type DressTypeComponentRepository interface {
+	GetByDressTypeId(*context.Context, uint) ([]entities.DressTypeComponent, *errs.XError)
+	ReplaceForDressType(*context.Context, uint, []entities.DressTypeComponent) *errs.XError
+}
+
+type dressTypeComponentRepository struct {
+	GormDAL
+}
+
+func ProvideDressTypeComponentRepository(customDB GormDAL) DressTypeComponentRepository {
+	return &dressTypeComponentRepository{GormDAL: customDB}
+}
+
+func (dcr *dressTypeComponentRepository) GetByDressTypeId(ctx *context.Context, dressTypeId uint) ([]entities.DressTypeComponent, *errs.XError) {
+	var components []entities.DressTypeComponent
+	res := dcr.WithDB(ctx).Model(&entities.DressTypeComponent{}).
+		Scopes(scopes.IsActive()).
+		Where("dress_type_id = ?", dressTypeId).
+		Preload("Product", scopes.SelectFields("name", "sku", "unit")).
+		Order("id ASC").
+		Find(&components)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find bill of materials", res.Error)
+	}
+	return components, nil
+}
+
+// ReplaceForDressType deactivates the current bill of materials and saves the given lines in its place
+func (dcr *dressTypeComponentRepository) ReplaceForDressType(ctx *context.Context, dressTypeId uint, components []entities.DressTypeComponent) *errs.XError {
+	res := dcr.WithDB(ctx).Model(&entities.DressTypeComponent{}).
+		Where("dress_type_id = ? AND is_active = ?", dressTypeId, true).
+		Update("is_active", false)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update bill of materials", res.Error)
+	}
+
+	if len(components) == 0 {
+		return nil
+	}
+
+	res = dcr.WithDB(ctx).Create(&components)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save bill of materials", res.Error)
+	}
+	return nil
+}
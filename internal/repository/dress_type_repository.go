@@ -42,6 +42,7 @@ func (dtr *dressTypeRepository) Get(ctx *context.Context, id uint) (*entities.Dr
 	res := dtr.WithDB(ctx).
 		Model(dressType).
 		Scopes(scopes.WithAuditInfo()).
+		Preload("MeasurementFields", scopes.OrderedMeasurementFields()).
 		Find(&dressType, id)
 	if res.Error != nil {
 		return nil, errs.NewXError(errs.DATABASE, "Unable to find dress type", res.Error)
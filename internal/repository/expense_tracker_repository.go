@@ -46,6 +46,7 @@ func (etr *expenseTrackerRepository) Get(ctx *context.Context, id uint) (*entiti
 	res := etr.WithDB(ctx).Model(expenseTracker).
 		Scopes(scopes.WithAuditInfo()).
 		Scopes(scopes.Channel(), scopes.IsActive()).
+		Preload("Supplier", scopes.SelectFields("name")).
 		Preload("ExpenseDetails").
 		Find(&expenseTracker, id)
 	if res.Error != nil {
@@ -69,6 +70,7 @@ func (etr *expenseTrackerRepository) GetAll(ctx *context.Context, search string)
 		Scopes(scopes.GetExpenseTrackers_Filter(filter)).
 		Scopes(db.Paginate(ctx)).
 		Scopes(scopes.WithAuditInfo()).
+		Preload("Supplier", scopes.SelectFields("name")).
 		Preload("ExpenseDetails").
 		Find(&expenseTrackers)
 	if res.Error != nil {
@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
 	"github.com/loop-kar/pixie/db"
 	"github.com/loop-kar/pixie/errs"
+	"gorm.io/gorm/clause"
 )
 
 type InventoryLogRepository interface {
@@ -16,6 +18,13 @@ type InventoryLogRepository interface {
 	GetByProductId(*context.Context, uint) ([]entities.InventoryLog, *errs.XError)
 	GetByChangeType(*context.Context, entities.InventoryLogChangeType) ([]entities.InventoryLog, *errs.XError)
 	GetByDateRange(*context.Context, string, string) ([]entities.InventoryLog, *errs.XError)
+	GetByIdempotencyKey(*context.Context, string) ([]entities.InventoryLog, *errs.XError)
+	GetByStockTransferId(*context.Context, uint, entities.InventoryLogChangeType) ([]entities.InventoryLog, *errs.XError)
+	GetLoggedBefore(*context.Context, time.Time, *uint) ([]entities.InventoryLog, *errs.XError)
+	GetConsumedQuantities(*context.Context, time.Time) (map[uint]float64, *errs.XError)
+	GetBookedOutByOrderItems(*context.Context, []uint) (map[uint]map[uint]float64, *errs.XError)
+	Lock(*context.Context, uint) *errs.XError
+	MarkReversed(*context.Context, uint, uint) *errs.XError
 }
 
 type inventoryLogRepository struct {
@@ -39,6 +48,8 @@ func (ilr *inventoryLogRepository) Get(ctx *context.Context, id uint) (*entities
 	res := ilr.WithDB(ctx).
 		Preload("Product").
 		Preload("Product.Category").
+		Preload("OrderItem", scopes.SelectFields("order_id")).
+		Preload("Lot", scopes.SelectFields("lot_code")).
 		Find(&log, id)
 	if res.Error != nil {
 		return nil, errs.NewXError(errs.DATABASE, "Unable to find inventory log", res.Error)
@@ -53,6 +64,8 @@ func (ilr *inventoryLogRepository) GetAll(ctx *context.Context, search string) (
 		Scopes(db.Paginate(ctx)).
 		Preload("Product").
 		Preload("Product.Category").
+		Preload("OrderItem", scopes.SelectFields("order_id")).
+		Preload("Lot", scopes.SelectFields("lot_code")).
 		Order("logged_at DESC").
 		Find(&logs)
 	if res.Error != nil {
@@ -68,6 +81,8 @@ func (ilr *inventoryLogRepository) GetByProductId(ctx *context.Context, productI
 		Scopes(scopes.WithAuditInfo()).
 		Where("product_id = ?", productId).
 		Preload("Product").
+		Preload("OrderItem", scopes.SelectFields("order_id")).
+		Preload("Lot", scopes.SelectFields("lot_code")).
 		Order("logged_at DESC").
 		Find(&logs)
 	if res.Error != nil {
@@ -83,6 +98,8 @@ func (ilr *inventoryLogRepository) GetByChangeType(ctx *context.Context, changeT
 		Scopes(scopes.WithAuditInfo()).
 		Where("change_type = ?", changeType).
 		Preload("Product").
+		Preload("OrderItem", scopes.SelectFields("order_id")).
+		Preload("Lot", scopes.SelectFields("lot_code")).
 		Order("logged_at DESC").
 		Find(&logs)
 	if res.Error != nil {
@@ -97,6 +114,8 @@ func (ilr *inventoryLogRepository) GetByDateRange(ctx *context.Context, startDat
 		Scopes(scopes.Channel(), scopes.IsActive()).
 		Scopes(scopes.WithAuditInfo()).
 		Preload("Product").
+		Preload("OrderItem", scopes.SelectFields("order_id")).
+		Preload("Lot", scopes.SelectFields("lot_code")).
 		Order("logged_at DESC")
 
 	if startDate != "" {
@@ -112,3 +131,136 @@ func (ilr *inventoryLogRepository) GetByDateRange(ctx *context.Context, startDat
 	}
 	return logs, nil
 }
+
+// GetByIdempotencyKey returns the logs booked with the key, one per lot of the movement, empty when none
+func (ilr *inventoryLogRepository) GetByIdempotencyKey(ctx *context.Context, key string) ([]entities.InventoryLog, *errs.XError) {
+	var logs []entities.InventoryLog
+	res := ilr.WithDB(ctx).Model(entities.InventoryLog{}).
+		Scopes(scopes.Channel()).
+		Where("idempotency_key = ?", key).
+		Preload("Lot", scopes.SelectFields("lot_code")).
+		Order("id ASC").
+		Find(&logs)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find inventory log", res.Error)
+	}
+	return logs, nil
+}
+
+// GetByStockTransferId returns the movements of one direction of a stock transfer in either channel
+func (ilr *inventoryLogRepository) GetByStockTransferId(ctx *context.Context, transferId uint, changeType entities.InventoryLogChangeType) ([]entities.InventoryLog, *errs.XError) {
+	var logs []entities.InventoryLog
+	res := ilr.WithDB(ctx).Model(entities.InventoryLog{}).
+		Scopes(scopes.IsActive()).
+		Where("stock_transfer_id = ? AND change_type = ?", transferId, changeType).
+		Preload("Lot").
+		Order("id ASC").
+		Find(&logs)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find inventory logs of stock transfer", res.Error)
+	}
+	return logs, nil
+}
+
+// GetLoggedBefore returns the movements logged before the given time in the order they were logged
+func (ilr *inventoryLogRepository) GetLoggedBefore(ctx *context.Context, before time.Time, categoryId *uint) ([]entities.InventoryLog, *errs.XError) {
+	var logs []entities.InventoryLog
+	res := ilr.WithDB(ctx).Model(entities.InventoryLog{}).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Scopes(scopes.ProductInCategoryTree(categoryId, `"stich"."InventoryLogs".product_id`)).
+		Where("logged_at < ?", before).
+		Preload("Product", scopes.SelectFields("name", "sku", "cost_price", "unit")).
+		Order("product_id ASC, logged_at ASC, id ASC").
+		Find(&logs)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find inventory logs for valuation", res.Error)
+	}
+	return logs, nil
+}
+
+// GetConsumedQuantities sums the stock booked out per product since the given time, transfers excluded
+func (ilr *inventoryLogRepository) GetConsumedQuantities(ctx *context.Context, since time.Time) (map[uint]float64, *errs.XError) {
+	var rows []struct {
+		ProductId uint
+		Consumed  float64
+	}
+	res := ilr.WithDB(ctx).Model(entities.InventoryLog{}).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("change_type = ? AND stock_transfer_id IS NULL AND logged_at >= ?", entities.InventoryLogChangeTypeOUT, since).
+		Select("product_id, COALESCE(SUM(quantity), 0) AS consumed").
+		Group("product_id").
+		Scan(&rows)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find consumed quantities", res.Error)
+	}
+
+	consumed := make(map[uint]float64, len(rows))
+	for _, row := range rows {
+		consumed[row.ProductId] = row.Consumed
+	}
+	return consumed, nil
+}
+
+// GetBookedOutByOrderItems sums the stock booked out per order item and product, net of reversals
+func (ilr *inventoryLogRepository) GetBookedOutByOrderItems(ctx *context.Context, orderItemIds []uint) (map[uint]map[uint]float64, *errs.XError) {
+	bookedOut := make(map[uint]map[uint]float64)
+	if len(orderItemIds) == 0 {
+		return bookedOut, nil
+	}
+
+	var rows []struct {
+		OrderItemId uint
+		ProductId   uint
+		BookedOut   float64
+	}
+	res := ilr.WithDB(ctx).Model(entities.InventoryLog{}).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("order_item_id IN ?", orderItemIds).
+		Select(`order_item_id, product_id, COALESCE(SUM(CASE
+			WHEN change_type = ? THEN quantity
+			WHEN change_type = ? AND reversal_of_log_id IS NOT NULL THEN -quantity
+			ELSE 0 END), 0) AS booked_out`, entities.InventoryLogChangeTypeOUT, entities.InventoryLogChangeTypeIN).
+		Group("order_item_id, product_id").
+		Scan(&rows)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find booked out quantities", res.Error)
+	}
+
+	for _, row := range rows {
+		if bookedOut[row.OrderItemId] == nil {
+			bookedOut[row.OrderItemId] = make(map[uint]float64)
+		}
+		bookedOut[row.OrderItemId][row.ProductId] = row.BookedOut
+	}
+	return bookedOut, nil
+}
+
+// Lock takes a row lock on the log until the transaction ends, so it cannot be reversed twice
+func (ilr *inventoryLogRepository) Lock(ctx *context.Context, id uint) *errs.XError {
+	var log entities.InventoryLog
+	res := ilr.WithDB(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&log, id)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to find inventory log", res.Error)
+	}
+	return nil
+}
+
+// MarkReversed links the log to the entry reversing it, a log already reversed is left as it is
+func (ilr *inventoryLogRepository) MarkReversed(ctx *context.Context, id uint, reversalId uint) *errs.XError {
+	res := ilr.WithDB(ctx).Model(&entities.InventoryLog{}).
+		Where("id = ? AND reversed_by_log_id IS NULL", id).
+		Updates(map[string]interface{}{
+			"reversed_by_log_id": reversalId,
+			"updated_at":         time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update inventory log", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return errs.NewXError(errs.VALIDATION, "Stock movement has already been reversed", nil)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/errs"
+	"gorm.io/gorm"
+)
+
+type InventoryLotRepository interface {
+	Create(*context.Context, *entities.InventoryLot) *errs.XError
+	Get(*context.Context, uint) (*entities.InventoryLot, *errs.XError)
+	GetByProductId(*context.Context, uint, bool) ([]entities.InventoryLot, *errs.XError)
+	GetAvailable(*context.Context, uint) ([]entities.InventoryLot, *errs.XError)
+	AdjustRemaining(*context.Context, uint, float64) *errs.XError
+}
+
+type inventoryLotRepository struct {
+	GormDAL
+}
+
+func ProvideInventoryLotRepository(customDB GormDAL) InventoryLotRepository {
+	return &inventoryLotRepository{GormDAL: customDB}
+}
+
+func (lr *inventoryLotRepository) Create(ctx *context.Context, lot *entities.InventoryLot) *errs.XError {
+	res := lr.WithDB(ctx).Create(&lot)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to create inventory lot", res.Error)
+	}
+	return nil
+}
+
+func (lr *inventoryLotRepository) Get(ctx *context.Context, id uint) (*entities.InventoryLot, *errs.XError) {
+	lot := entities.InventoryLot{}
+	res := lr.WithDB(ctx).Model(&entities.InventoryLot{}).
+		Scopes(scopes.Channel()).
+		Preload("Supplier", scopes.SelectFields("name")).
+		Find(&lot, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find inventory lot", res.Error)
+	}
+	return &lot, nil
+}
+
+// GetByProductId returns the channel's lots of a product oldest first, used up lots only when includeEmpty is set
+func (lr *inventoryLotRepository) GetByProductId(ctx *context.Context, productId uint, includeEmpty bool) ([]entities.InventoryLot, *errs.XError) {
+	var lots []entities.InventoryLot
+	query := lr.WithDB(ctx).Model(&entities.InventoryLot{}).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("product_id = ?", productId)
+	if !includeEmpty {
+		query = query.Where("remaining_quantity > 0")
+	}
+
+	res := query.
+		Preload("Supplier", scopes.SelectFields("name")).
+		Order("received_at ASC, id ASC").
+		Find(&lots)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find inventory lots", res.Error)
+	}
+	return lots, nil
+}
+
+// GetAvailable returns the lots of a product that still hold stock, oldest first
+func (lr *inventoryLotRepository) GetAvailable(ctx *context.Context, productId uint) ([]entities.InventoryLot, *errs.XError) {
+	var lots []entities.InventoryLot
+	res := lr.WithDB(ctx).Model(&entities.InventoryLot{}).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("product_id = ? AND remaining_quantity > 0", productId).
+		Order("received_at ASC, id ASC").
+		Find(&lots)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find inventory lots", res.Error)
+	}
+	return lots, nil
+}
+
+// AdjustRemaining adds the change to the lot's remaining quantity
+func (lr *inventoryLotRepository) AdjustRemaining(ctx *context.Context, id uint, change float64) *errs.XError {
+	res := lr.WithDB(ctx).Model(&entities.InventoryLot{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"remaining_quantity": gorm.Expr("remaining_quantity + ?", change),
+			"updated_at":         time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update inventory lot", res.Error)
+	}
+	return nil
+}
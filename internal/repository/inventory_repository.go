@@ -8,6 +8,8 @@ import (
 	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
 	"github.com/loop-kar/pixie/db"
 	"github.com/loop-kar/pixie/errs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type InventoryRepository interface {
@@ -16,9 +18,15 @@ type InventoryRepository interface {
 	Get(*context.Context, uint) (*entities.Inventory, *errs.XError)
 	GetAll(*context.Context, string) ([]entities.Inventory, *errs.XError)
 	GetByProductId(*context.Context, uint) (*entities.Inventory, *errs.XError)
-	UpdateQuantity(*context.Context, uint, int) *errs.XError
-	GetLowStockItems(*context.Context) ([]entities.Inventory, *errs.XError)
-	UpdateThreshold(*context.Context, uint, int) *errs.XError
+	LockByProductId(*context.Context, uint) (*entities.Inventory, *errs.XError)
+	EnsureForProduct(*context.Context, uint) *errs.XError
+	AdjustQuantity(*context.Context, uint, float64) *errs.XError
+	GetLowStockItems(*context.Context, *uint) ([]entities.Inventory, *errs.XError)
+	GetByCategoryId(*context.Context, *uint) ([]entities.Inventory, *errs.XError)
+	UpdateThreshold(*context.Context, uint, float64) *errs.XError
+	GetByChannelIds(*context.Context, []uint, *uint) ([]entities.Inventory, *errs.XError)
+	MarkLowStockAlerted(*context.Context, []uint, time.Time) *errs.XError
+	ClearReplenishedAlerts(*context.Context) *errs.XError
 }
 
 type inventoryRepository struct {
@@ -43,7 +51,8 @@ func (ir *inventoryRepository) Update(ctx *context.Context, inventory *entities.
 
 func (ir *inventoryRepository) Get(ctx *context.Context, id uint) (*entities.Inventory, *errs.XError) {
 	inventory := entities.Inventory{}
-	res := ir.WithDB(ctx).
+	res := ir.WithDB(ctx).Model(&entities.Inventory{}).
+		Scopes(scopes.WithReservedQuantity()).
 		Preload("Product").
 		Preload("Product.Category").
 		Find(&inventory, id)
@@ -57,6 +66,7 @@ func (ir *inventoryRepository) GetAll(ctx *context.Context, search string) ([]en
 	var inventories []entities.Inventory
 	res := ir.WithDB(ctx).Model(entities.Inventory{}).
 		Scopes(scopes.Channel(), scopes.IsActive()).
+		Scopes(scopes.WithReservedQuantity()).
 		Scopes(db.Paginate(ctx)).
 		Preload("Product").
 		Preload("Product.Category").
@@ -69,7 +79,8 @@ func (ir *inventoryRepository) GetAll(ctx *context.Context, search string) ([]en
 
 func (ir *inventoryRepository) GetByProductId(ctx *context.Context, productId uint) (*entities.Inventory, *errs.XError) {
 	inventory := entities.Inventory{}
-	res := ir.WithDB(ctx).
+	res := ir.WithDB(ctx).Model(&entities.Inventory{}).
+		Scopes(scopes.Channel(), scopes.WithReservedQuantity()).
 		Where("product_id = ?", productId).
 		Preload("Product").
 		First(&inventory)
@@ -79,12 +90,43 @@ func (ir *inventoryRepository) GetByProductId(ctx *context.Context, productId ui
 	return &inventory, nil
 }
 
-func (ir *inventoryRepository) UpdateQuantity(ctx *context.Context, productId uint, newQuantity int) *errs.XError {
+// LockByProductId reads the channel's inventory row with a row lock held until the transaction ends
+func (ir *inventoryRepository) LockByProductId(ctx *context.Context, productId uint) (*entities.Inventory, *errs.XError) {
+	inventory := entities.Inventory{}
+	res := ir.WithDB(ctx).Model(&entities.Inventory{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Scopes(scopes.Channel()).
+		Where("product_id = ?", productId).
+		First(&inventory)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find inventory for product", res.Error)
+	}
+	return &inventory, nil
+}
+
+// EnsureForProduct creates an empty inventory row for the product in the current channel
+func (ir *inventoryRepository) EnsureForProduct(ctx *context.Context, productId uint) *errs.XError {
+	inventory := entities.Inventory{
+		Model:     &entities.Model{IsActive: true},
+		ProductId: productId,
+	}
+	res := ir.WithDB(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&inventory)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to create inventory", res.Error)
+	}
+	return nil
+}
+
+// AdjustQuantity adds the change to the stored quantity instead of overwriting it
+func (ir *inventoryRepository) AdjustQuantity(ctx *context.Context, productId uint, change float64) *errs.XError {
 	res := ir.WithDB(ctx).
 		Model(&entities.Inventory{}).
+		Scopes(scopes.Channel()).
 		Where("product_id = ?", productId).
 		Updates(map[string]interface{}{
-			"quantity":   newQuantity,
+			"quantity":   gorm.Expr("quantity + ?", change),
 			"updated_at": time.Now(),
 		})
 	if res.Error != nil {
@@ -93,13 +135,17 @@ func (ir *inventoryRepository) UpdateQuantity(ctx *context.Context, productId ui
 	return nil
 }
 
-func (ir *inventoryRepository) GetLowStockItems(ctx *context.Context) ([]entities.Inventory, *errs.XError) {
+// GetLowStockItems returns the inventory rows whose unreserved stock is at or below the threshold
+func (ir *inventoryRepository) GetLowStockItems(ctx *context.Context, categoryId *uint) ([]entities.Inventory, *errs.XError) {
 	var inventories []entities.Inventory
-	res := ir.WithDB(ctx).
+	res := ir.WithDB(ctx).Model(&entities.Inventory{}).
 		Scopes(scopes.Channel(), scopes.IsActive()).
-		Where("quantity <= low_stock_threshold").
+		Scopes(scopes.WithReservedQuantity(), scopes.LowAvailableStock()).
+		Scopes(scopes.ProductInCategoryTree(categoryId, `"stich"."Inventories".product_id`)).
+		Scopes(scopes.GroupedByParentProduct()).
 		Preload("Product").
 		Preload("Product.Category").
+		Preload("Product.Parent", scopes.SelectFields("name")).
 		Find(&inventories)
 	if res.Error != nil {
 		return nil, errs.NewXError(errs.DATABASE, "Unable to find low stock items", res.Error)
@@ -107,9 +153,26 @@ func (ir *inventoryRepository) GetLowStockItems(ctx *context.Context) ([]entitie
 	return inventories, nil
 }
 
-func (ir *inventoryRepository) UpdateThreshold(ctx *context.Context, productId uint, threshold int) *errs.XError {
+// GetByCategoryId returns the inventory of all active products in the category and its descendants, or of every product when categoryId is nil
+func (ir *inventoryRepository) GetByCategoryId(ctx *context.Context, categoryId *uint) ([]entities.Inventory, *errs.XError) {
+	var inventories []entities.Inventory
+	query := ir.WithDB(ctx).Model(&entities.Inventory{}).
+		Joins(`JOIN "stich"."Products" p ON p.id = "stich"."Inventories".product_id AND p.is_active = true`).
+		Scopes(scopes.Channel(), scopes.IsActive())
+	res := query.
+		Scopes(scopes.InCategoryTree(categoryId, "p.category_id")).
+		Order("p.name ASC").
+		Find(&inventories)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find inventories", res.Error)
+	}
+	return inventories, nil
+}
+
+func (ir *inventoryRepository) UpdateThreshold(ctx *context.Context, productId uint, threshold float64) *errs.XError {
 	res := ir.WithDB(ctx).
 		Model(&entities.Inventory{}).
+		Scopes(scopes.Channel()).
 		Where("product_id = ?", productId).
 		Update("low_stock_threshold", threshold)
 	if res.Error != nil {
@@ -117,3 +180,56 @@ func (ir *inventoryRepository) UpdateThreshold(ctx *context.Context, productId u
 	}
 	return nil
 }
+
+// GetByChannelIds returns the inventory rows of the given channels with the channel name, for one product when productId is given
+func (ir *inventoryRepository) GetByChannelIds(ctx *context.Context, channelIds []uint, productId *uint) ([]entities.Inventory, *errs.XError) {
+	var inventories []entities.Inventory
+	query := ir.WithDB(ctx).Model(&entities.Inventory{}).
+		Select(`"stich"."Inventories".*,
+			(SELECT name FROM "stich"."Channels" WHERE "stich"."Channels".id = "stich"."Inventories".channel_id) AS channel_name,
+			`+scopes.ReservedQuantitySQL+` AS reserved_quantity`).
+		Scopes(scopes.IsActive()).
+		Where(`"stich"."Inventories".channel_id IN ?`, channelIds)
+	if productId != nil {
+		query = query.Where(`"stich"."Inventories".product_id = ?`, *productId)
+	}
+
+	res := query.
+		Preload("Product", scopes.SelectFields("name", "sku", "unit")).
+		Order("product_id ASC, channel_id ASC").
+		Find(&inventories)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find inventories", res.Error)
+	}
+	return inventories, nil
+}
+
+// MarkLowStockAlerted records that the owner has been told about the low stock of the inventory rows
+func (ir *inventoryRepository) MarkLowStockAlerted(ctx *context.Context, ids []uint, alertedAt time.Time) *errs.XError {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	res := ir.WithDB(ctx).
+		Model(&entities.Inventory{}).
+		Where("id IN ?", ids).
+		Update("low_stock_alerted_at", alertedAt)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update low stock alert", res.Error)
+	}
+	return nil
+}
+
+// ClearReplenishedAlerts forgets the alert of rows that are no longer low on stock, so they are alerted again when they run low
+func (ir *inventoryRepository) ClearReplenishedAlerts(ctx *context.Context) *errs.XError {
+	res := ir.WithDB(ctx).
+		Model(&entities.Inventory{}).
+		Scopes(scopes.Channel()).
+		Where(`"stich"."Inventories".low_stock_alerted_at IS NOT NULL`).
+		Where(`"stich"."Inventories".quantity - `+scopes.ReservedQuantitySQL+` > "stich"."Inventories".low_stock_threshold`).
+		Update("low_stock_alerted_at", nil)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to clear low stock alerts", res.Error)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type MeasurementFieldRepository interface {
+	GetByDressTypeId(*context.Context, uint) ([]entities.MeasurementField, *errs.XError)
+	ReplaceForDressType(*context.Context, uint, []entities.MeasurementField) *errs.XError
+}
+
+type measurementFieldRepository struct {
+	GormDAL
+}
+
+func ProvideMeasurementFieldRepository(customDB GormDAL) MeasurementFieldRepository {
+	return &measurementFieldRepository{GormDAL: customDB}
+}
+
+func (mfr *measurementFieldRepository) GetByDressTypeId(ctx *context.Context, dressTypeId uint) ([]entities.MeasurementField, *errs.XError) {
+	var fields []entities.MeasurementField
+	res := mfr.WithDB(ctx).Model(&entities.MeasurementField{}).
+		Where("dress_type_id = ?", dressTypeId).
+		Scopes(scopes.OrderedMeasurementFields()).
+		Find(&fields)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find measurement schema", res.Error)
+	}
+	return fields, nil
+}
+
+// ReplaceForDressType replaces the schema of the dress type with the given fields
+func (mfr *measurementFieldRepository) ReplaceForDressType(ctx *context.Context, dressTypeId uint, fields []entities.MeasurementField) *errs.XError {
+	res := mfr.WithDB(ctx).Model(&entities.MeasurementField{}).
+		Where("dress_type_id = ? AND is_active = ?", dressTypeId, true).
+		Update("is_active", false)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update measurement schema", res.Error)
+	}
+
+	labels := make([]string, 0, len(fields))
+	for _, field := range fields {
+		labels = append(labels, field.Label)
+	}
+	res = mfr.WithDB(ctx).Model(&entities.DressType{}).
+		Where("id = ?", dressTypeId).
+		Update("measurements", strings.Join(labels, ", "))
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update measurement schema", res.Error)
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	res = mfr.WithDB(ctx).Create(&fields)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save measurement schema", res.Error)
+	}
+	return nil
+}
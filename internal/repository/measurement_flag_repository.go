@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/db"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type MeasurementFlagRepository interface {
+	BatchCreate(*context.Context, []entities.MeasurementFlag) *errs.XError
+	Get(*context.Context, uint) (*entities.MeasurementFlag, *errs.XError)
+	GetAll(*context.Context, entities.MeasurementFlagStatus, *uint) ([]entities.MeasurementFlag, *errs.XError)
+	SupersedeOpen(*context.Context, uint) *errs.XError
+	Review(*context.Context, uint, string, uint, time.Time) *errs.XError
+}
+
+type measurementFlagRepository struct {
+	GormDAL
+}
+
+func ProvideMeasurementFlagRepository(customDB GormDAL) MeasurementFlagRepository {
+	return &measurementFlagRepository{GormDAL: customDB}
+}
+
+func (mfr *measurementFlagRepository) BatchCreate(ctx *context.Context, flags []entities.MeasurementFlag) *errs.XError {
+	if len(flags) == 0 {
+		return nil
+	}
+
+	res := mfr.WithDB(ctx).CreateInBatches(flags, 100)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save measurement flags", res.Error)
+	}
+	return nil
+}
+
+func (mfr *measurementFlagRepository) Get(ctx *context.Context, id uint) (*entities.MeasurementFlag, *errs.XError) {
+	flag := entities.MeasurementFlag{}
+	res := mfr.WithDB(ctx).Model(flag).
+		Scopes(scopes.Channel()).
+		Find(&flag, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find measurement flag", res.Error)
+	}
+	return &flag, nil
+}
+
+// GetAll lists the flags in the status given, newest first, optionally for one person only
+func (mfr *measurementFlagRepository) GetAll(ctx *context.Context, status entities.MeasurementFlagStatus, personId *uint) ([]entities.MeasurementFlag, *errs.XError) {
+	var flags []entities.MeasurementFlag
+	query := mfr.WithDB(ctx).Model(&entities.MeasurementFlag{}).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("status = ?", status)
+	if personId != nil {
+		query = query.Where(`measurement_id IN (SELECT id FROM "stich"."Measurements" WHERE person_id = ?)`, *personId)
+	}
+
+	res := query.
+		Scopes(db.Paginate(ctx)).
+		Preload("Measurement", scopes.SelectFields("person_id", "dress_type_id", "value", "unit")).
+		Preload("Measurement.Person", scopes.SelectFields("first_name", "last_name")).
+		Preload("Measurement.DressType", scopes.SelectFields("name")).
+		Preload("ReviewedBy", scopes.SelectFields("first_name", "last_name")).
+		Order("created_at DESC, id DESC").
+		Find(&flags)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find measurement flags", res.Error)
+	}
+	return flags, nil
+}
+
+// SupersedeOpen closes the open flags of a measurement that is being saved again, its new values get flags of their own
+func (mfr *measurementFlagRepository) SupersedeOpen(ctx *context.Context, measurementId uint) *errs.XError {
+	res := mfr.WithDB(ctx).Model(&entities.MeasurementFlag{}).
+		Where("measurement_id = ? AND status = ? AND is_active = ?", measurementId, entities.MeasurementFlagOpen, true).
+		Updates(map[string]interface{}{
+			"status":     entities.MeasurementFlagSuperseded,
+			"updated_at": time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update measurement flags", res.Error)
+	}
+	return nil
+}
+
+func (mfr *measurementFlagRepository) Review(ctx *context.Context, id uint, note string, reviewedById uint, at time.Time) *errs.XError {
+	res := mfr.WithDB(ctx).Model(&entities.MeasurementFlag{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":         entities.MeasurementFlagReviewed,
+			"review_note":    note,
+			"reviewed_by_id": reviewedById,
+			"reviewed_at":    at,
+			"updated_at":     time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to review measurement flag", res.Error)
+	}
+	return nil
+}
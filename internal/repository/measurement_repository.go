@@ -20,9 +20,11 @@ type MeasurementRepository interface {
 	Update(*context.Context, *entities.Measurement) *errs.XError
 	BatchUpdate(*context.Context, []*entities.Measurement) *errs.XError
 	Get(*context.Context, uint) (*entities.Measurement, *errs.XError)
+	GetByIds(*context.Context, []uint) ([]entities.Measurement, *errs.XError)
 	GetByPersonIdAndDressTypeId(*context.Context, uint, uint) (*entities.Measurement, *errs.XError)
 	GetAll(*context.Context, string) ([]responseModel.MeasurementBrowse, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
+	GetFieldStats(*context.Context, uint, uint) ([]entities.MeasurementFieldStats, *errs.XError)
 }
 
 type measurementRepository struct {
@@ -89,6 +91,21 @@ func (mr *measurementRepository) Get(ctx *context.Context, id uint) (*entities.M
 	return &measurement, nil
 }
 
+func (mr *measurementRepository) GetByIds(ctx *context.Context, ids []uint) ([]entities.Measurement, *errs.XError) {
+	var measurements []entities.Measurement
+	if len(ids) == 0 {
+		return measurements, nil
+	}
+	res := mr.WithDB(ctx).Model(&entities.Measurement{}).
+		Preload("DressType").
+		Where("id IN ?", ids).
+		Find(&measurements)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find measurements", res.Error)
+	}
+	return measurements, nil
+}
+
 func (mr *measurementRepository) GetByPersonIdAndDressTypeId(ctx *context.Context, personId uint, dressTypeId uint) (*entities.Measurement, *errs.XError) {
 	measurement := entities.Measurement{}
 	res := mr.WithDB(ctx).
@@ -183,3 +200,23 @@ func (mr *measurementRepository) Delete(ctx *context.Context, id uint) *errs.XEr
 	}
 	return nil
 }
+
+// GetFieldStats returns the mean and spread of each field of a dress type across the channel
+func (mr *measurementRepository) GetFieldStats(ctx *context.Context, dressTypeId uint, excludeId uint) ([]entities.MeasurementFieldStats, *errs.XError) {
+	var stats []entities.MeasurementFieldStats
+	res := mr.WithDB(ctx).Model(&entities.Measurement{}).
+		Joins(`CROSS JOIN LATERAL jsonb_each_text(CASE WHEN jsonb_typeof("stich"."Measurements".value) = 'object' THEN "stich"."Measurements".value ELSE '{}'::jsonb END) v`).
+		Select(`v.key AS field,
+			AVG(v.value::numeric) AS mean,
+			COALESCE(STDDEV_POP(v.value::numeric), 0) AS std_dev,
+			COUNT(*) AS samples`).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where(`"stich"."Measurements".dress_type_id = ? AND "stich"."Measurements".id <> ?`, dressTypeId, excludeId).
+		Where("v.value ~ ?", `^\s*[0-9]+(\.[0-9]+)?\s*$`).
+		Group("v.key").
+		Scan(&stats)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find measurement ranges", res.Error)
+	}
+	return stats, nil
+}
@@ -9,7 +9,7 @@ import (
 )
 
 type NotificationRepository interface {
-	CreateNotification(ctx *context.Context, notif entities.Notification) *errs.XError
+	CreateNotification(ctx *context.Context, notif *entities.Notification) *errs.XError
 	GetPendingNotifications(ctx *context.Context) ([]entities.Notification, *errs.XError)
 	UpdateEmailNotificationStatus(ctx *context.Context, id uint, status entities.NotificationStatus) *errs.XError
 	UpdateNotificationStatus(ctx *context.Context, id uint, status entities.NotificationStatus) *errs.XError
@@ -24,8 +24,8 @@ func ProvideNotificationRepository(customDB GormDAL) NotificationRepository {
 
 }
 
-func (repo *notificationRepository) CreateNotification(ctx *context.Context, notif entities.Notification) *errs.XError {
-	res := repo.WithDB(ctx).Create(&notif)
+func (repo *notificationRepository) CreateNotification(ctx *context.Context, notif *entities.Notification) *errs.XError {
+	res := repo.WithDB(ctx).Create(notif)
 	if res.Error != nil {
 		return errs.NewXError(errs.DATABASE, "Unable to create notification", res.Error)
 	}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/imkarthi24/sf-backend/internal/entities"
+	entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
 	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
 	"github.com/loop-kar/pixie/db"
 	"github.com/loop-kar/pixie/errs"
@@ -15,6 +17,9 @@ type OrderItemRepository interface {
 	Get(*context.Context, uint) (*entities.OrderItem, *errs.XError)
 	GetAll(*context.Context, string) ([]entities.OrderItem, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
+	GetByOrderId(*context.Context, uint) ([]entities.OrderItem, *errs.XError)
+	UpdateStage(*context.Context, uint, entities.OrderItemStage, time.Time, *uint) *errs.XError
+	UpdateMeasurementSnapshot(*context.Context, uint, entitiy_types.JSON, time.Time) *errs.XError
 }
 
 type orderItemRepository struct {
@@ -41,6 +46,8 @@ func (oir *orderItemRepository) Get(ctx *context.Context, id uint) (*entities.Or
 	orderItem := entities.OrderItem{}
 	res := oir.WithDB(ctx).Model(orderItem).
 		Scopes(scopes.WithAuditInfo()).
+		Preload("AssignedTailor", scopes.SelectFields("first_name", "last_name")).
+		Preload("Measurement", scopes.SelectFields("dress_type_id", "value", "unit")).
 		Preload("Order").Find(&orderItem, id)
 	if res.Error != nil {
 		return nil, errs.NewXError(errs.DATABASE, "Unable to find order item", res.Error)
@@ -52,6 +59,7 @@ func (oir *orderItemRepository) GetAll(ctx *context.Context, search string) ([]e
 	var orderItems []entities.OrderItem
 	res := oir.WithDB(ctx).Model(&entities.OrderItem{}).
 		Scopes(db.Paginate(ctx)).
+		Preload("AssignedTailor", scopes.SelectFields("first_name", "last_name")).
 		Preload("Order").
 		Find(&orderItems)
 	if res.Error != nil {
@@ -68,3 +76,51 @@ func (oir *orderItemRepository) Delete(ctx *context.Context, id uint) *errs.XErr
 	}
 	return nil
 }
+
+func (oir *orderItemRepository) GetByOrderId(ctx *context.Context, orderId uint) ([]entities.OrderItem, *errs.XError) {
+	var orderItems []entities.OrderItem
+	res := oir.WithDB(ctx).Model(&entities.OrderItem{}).
+		Where("order_id = ?", orderId).
+		Scopes(scopes.IsActive()).
+		Find(&orderItems)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find order items", res.Error)
+	}
+	return orderItems, nil
+}
+
+// UpdateStage moves the item to the stage and stamps the time it entered it
+func (oir *orderItemRepository) UpdateStage(ctx *context.Context, id uint, stage entities.OrderItemStage, at time.Time, assignedTailorId *uint) *errs.XError {
+	updates := map[string]interface{}{
+		"stage":             stage,
+		"stage_updated_at":  at,
+		stage.StageColumn(): at,
+		"updated_at":        time.Now(),
+	}
+	if assignedTailorId != nil {
+		updates["assigned_tailor_id"] = *assignedTailorId
+	}
+
+	res := oir.WithDB(ctx).Model(&entities.OrderItem{}).
+		Where("id = ?", id).
+		Updates(updates)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update order item stage", res.Error)
+	}
+	return nil
+}
+
+// UpdateMeasurementSnapshot freezes the measurement the item is made to
+func (oir *orderItemRepository) UpdateMeasurementSnapshot(ctx *context.Context, id uint, snapshot entitiy_types.JSON, at time.Time) *errs.XError {
+	res := oir.WithDB(ctx).Model(&entities.OrderItem{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"measurement_snapshot":    snapshot,
+			"measurement_snapshot_at": at,
+			"updated_at":              time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save measurement snapshot", res.Error)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type OrderPaymentRepository interface {
+	Create(*context.Context, *entities.OrderPayment) *errs.XError
+	Update(*context.Context, *entities.OrderPayment) *errs.XError
+	Get(*context.Context, uint) (*entities.OrderPayment, *errs.XError)
+	GetByOrderId(*context.Context, uint) ([]entities.OrderPayment, *errs.XError)
+	Delete(*context.Context, uint) *errs.XError
+}
+
+type orderPaymentRepository struct {
+	GormDAL
+}
+
+func ProvideOrderPaymentRepository(dal GormDAL) OrderPaymentRepository {
+	return &orderPaymentRepository{GormDAL: dal}
+}
+
+func (opr *orderPaymentRepository) Create(ctx *context.Context, payment *entities.OrderPayment) *errs.XError {
+	res := opr.WithDB(ctx).Create(payment)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save order payment", res.Error)
+	}
+	return nil
+}
+
+func (opr *orderPaymentRepository) Update(ctx *context.Context, payment *entities.OrderPayment) *errs.XError {
+	return opr.GormDAL.Update(ctx, *payment)
+}
+
+func (opr *orderPaymentRepository) Get(ctx *context.Context, id uint) (*entities.OrderPayment, *errs.XError) {
+	payment := entities.OrderPayment{}
+	res := opr.WithDB(ctx).Model(entities.OrderPayment{}).
+		Scopes(scopes.WithAuditInfo()).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Find(&payment, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find order payment", res.Error)
+	}
+	return &payment, nil
+}
+
+func (opr *orderPaymentRepository) GetByOrderId(ctx *context.Context, orderId uint) ([]entities.OrderPayment, *errs.XError) {
+	var payments []entities.OrderPayment
+	res := opr.WithDB(ctx).Model(entities.OrderPayment{}).
+		Scopes(scopes.WithAuditInfo()).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("order_id = ?", orderId).
+		Order("paid_at ASC").
+		Find(&payments)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find order payments", res.Error)
+	}
+	return payments, nil
+}
+
+func (opr *orderPaymentRepository) Delete(ctx *context.Context, id uint) *errs.XError {
+	payment := &entities.OrderPayment{Model: &entities.Model{ID: id, IsActive: false}}
+	err := opr.GormDAL.Delete(ctx, payment)
+	if err != nil {
+		return err
+	}
+	return nil
+}
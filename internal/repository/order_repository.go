@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
@@ -9,6 +10,7 @@ import (
 	"github.com/loop-kar/pixie/db"
 	"github.com/loop-kar/pixie/errs"
 	"github.com/loop-kar/pixie/util"
+	"gorm.io/gorm/clause"
 )
 
 type OrderRepository interface {
@@ -17,6 +19,10 @@ type OrderRepository interface {
 	Get(*context.Context, uint) (*entities.Order, *errs.XError)
 	GetAll(*context.Context, string) ([]entities.Order, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
+	UpdateStatus(*context.Context, uint, entities.OrderStatus, *time.Time) *errs.XError
+	SetInvoice(*context.Context, uint, uint, time.Time) *errs.XError
+	GetLastInvoiceSequence(*context.Context, uint) (uint, *errs.XError)
+	Lock(*context.Context, uint) *errs.XError
 }
 
 type orderRepository struct {
@@ -46,11 +52,26 @@ func (or *orderRepository) Get(ctx *context.Context, id uint) (*entities.Order,
 			(SELECT COALESCE(SUM(quantity), 0) FROM "stich"."OrderItems"
 			 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) as order_quantity,
 			(SELECT COALESCE(SUM(total), 0) FROM "stich"."OrderItems"
-			 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) as order_value`).
+			 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) as order_value,
+			(SELECT COALESCE(SUM(cgst_amount), 0) FROM "stich"."OrderItems"
+			 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) as cgst_total,
+			(SELECT COALESCE(SUM(sgst_amount), 0) FROM "stich"."OrderItems"
+			 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) as sgst_total,
+			(SELECT COALESCE(SUM(igst_amount), 0) FROM "stich"."OrderItems"
+			 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) as igst_total,
+			(SELECT COALESCE(SUM(cgst_amount + sgst_amount + igst_amount), 0) FROM "stich"."OrderItems"
+			 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) as tax_total,
+			(SELECT COALESCE(SUM(amount), 0) FROM "stich"."OrderPayments"
+			 WHERE "stich"."OrderPayments".order_id = "stich"."Orders".id AND "stich"."OrderPayments".is_active = true) as paid_amount,
+			(SELECT COALESCE(SUM(total + cgst_amount + sgst_amount + igst_amount), 0) FROM "stich"."OrderItems"
+			 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) + COALESCE("stich"."Orders".additional_charges, 0) -
+			(SELECT COALESCE(SUM(amount), 0) FROM "stich"."OrderPayments"
+			 WHERE "stich"."OrderPayments".order_id = "stich"."Orders".id AND "stich"."OrderPayments".is_active = true) as balance_due`).
 		Scopes(scopes.WithAuditInfo()).
 		Preload("Customer").
 		Preload("OrderTakenBy", scopes.SelectFields("first_name", "last_name")).
-		Preload("OrderItems.Measurement", scopes.SelectFields("person_id", "dress_type_id")).
+		Preload("OrderItems.AssignedTailor", scopes.SelectFields("first_name", "last_name")).
+		Preload("OrderItems.Measurement", scopes.SelectFields("person_id", "dress_type_id", "value", "unit")).
 		Preload("OrderItems.Measurement.Person", scopes.SelectFields("first_name", "last_name")).
 		Preload("OrderItems.Measurement.DressType", scopes.SelectFields("name")).
 		Find(&order, id)
@@ -77,6 +98,13 @@ func (or *orderRepository) GetAll(ctx *context.Context, search string) ([]entiti
 			COALESCE(uu.first_name || ' ' || uu.last_name, '') AS updated_by,
 			(SELECT COALESCE(SUM(quantity), 0) FROM "stich"."OrderItems" WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) AS order_quantity,
 			(SELECT COALESCE(SUM(total), 0) FROM "stich"."OrderItems" WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) AS order_value,
+			(SELECT COALESCE(SUM(cgst_amount), 0) FROM "stich"."OrderItems" WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) AS cgst_total,
+			(SELECT COALESCE(SUM(sgst_amount), 0) FROM "stich"."OrderItems" WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) AS sgst_total,
+			(SELECT COALESCE(SUM(igst_amount), 0) FROM "stich"."OrderItems" WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) AS igst_total,
+			(SELECT COALESCE(SUM(cgst_amount + sgst_amount + igst_amount), 0) FROM "stich"."OrderItems" WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) AS tax_total,
+			(SELECT COALESCE(SUM(amount), 0) FROM "stich"."OrderPayments" WHERE "stich"."OrderPayments".order_id = "stich"."Orders".id AND "stich"."OrderPayments".is_active = true) AS paid_amount,
+			(SELECT COALESCE(SUM(total + cgst_amount + sgst_amount + igst_amount), 0) FROM "stich"."OrderItems" WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) + COALESCE("stich"."Orders".additional_charges, 0) -
+			(SELECT COALESCE(SUM(amount), 0) FROM "stich"."OrderPayments" WHERE "stich"."OrderPayments".order_id = "stich"."Orders".id AND "stich"."OrderPayments".is_active = true) AS balance_due,
 			(SELECT MIN(expected_delivery_date) FROM "stich"."OrderItems" WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) AS expected_delivery_date`).
 		Scopes(scopes.Channel(), scopes.IsActive()).
 		Scopes(scopes.GetOrders_Search(search)).
@@ -99,3 +127,61 @@ func (or *orderRepository) Delete(ctx *context.Context, id uint) *errs.XError {
 	}
 	return nil
 }
+
+func (or *orderRepository) UpdateStatus(ctx *context.Context, id uint, status entities.OrderStatus, deliveredDate *time.Time) *errs.XError {
+	res := or.WithDB(ctx).
+		Model(&entities.Order{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":         status,
+			"delivered_date": deliveredDate,
+			"updated_at":     time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update order status", res.Error)
+	}
+	return nil
+}
+
+// SetInvoice stores the invoice sequence, number and date of the order
+func (or *orderRepository) SetInvoice(ctx *context.Context, id uint, sequence uint, invoicedAt time.Time) *errs.XError {
+	res := or.WithDB(ctx).
+		Model(&entities.Order{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"invoice_sequence": sequence,
+			"invoice_number":   entities.InvoiceNumber(sequence),
+			"invoiced_at":      invoicedAt,
+			"updated_at":       time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update order invoice", res.Error)
+	}
+	return nil
+}
+
+// GetLastInvoiceSequence returns the highest invoice sequence issued in the channel, 0 when none was
+func (or *orderRepository) GetLastInvoiceSequence(ctx *context.Context, channelId uint) (uint, *errs.XError) {
+	var sequence uint
+	res := or.WithDB(ctx).Model(&entities.Order{}).
+		Where("channel_id = ?", channelId).
+		Select("COALESCE(MAX(invoice_sequence), 0)").
+		Scan(&sequence)
+	if res.Error != nil {
+		return 0, errs.NewXError(errs.DATABASE, "Unable to find last invoice sequence", res.Error)
+	}
+	return sequence, nil
+}
+
+// Lock takes a row lock on the order until the transaction ends, so it cannot be invoiced twice
+func (or *orderRepository) Lock(ctx *context.Context, id uint) *errs.XError {
+	var order entities.Order
+	res := or.WithDB(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&order, id)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to find order", res.Error)
+	}
+	return nil
+}
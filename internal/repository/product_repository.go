@@ -5,6 +5,7 @@ import (
 
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/imkarthi24/sf-backend/internal/utils"
 	"github.com/loop-kar/pixie/db"
 	"github.com/loop-kar/pixie/errs"
 )
@@ -13,11 +14,18 @@ type ProductRepository interface {
 	Create(*context.Context, *entities.Product) *errs.XError
 	Update(*context.Context, *entities.Product) *errs.XError
 	Get(*context.Context, uint) (*entities.Product, *errs.XError)
-	GetAll(*context.Context, string) ([]entities.Product, *errs.XError)
+	GetAll(*context.Context, string, *uint) ([]entities.Product, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
 	AutocompleteProduct(*context.Context, string) ([]entities.Product, *errs.XError)
 	GetBySKU(*context.Context, string) (*entities.Product, *errs.XError)
-	GetLowStockProducts(*context.Context) ([]entities.Product, *errs.XError)
+	GetByCode(*context.Context, string) (*entities.Product, *errs.XError)
+	GetByIds(*context.Context, []uint) ([]entities.Product, *errs.XError)
+	GetLowStockProducts(*context.Context, *uint) ([]entities.Product, *errs.XError)
+	ReplaceUnitConversions(*context.Context, uint, []entities.ProductUnitConversion) *errs.XError
+	CreateAttributes(*context.Context, []entities.ProductAttribute) *errs.XError
+	CreateVariantValues(*context.Context, []entities.ProductVariantValue) *errs.XError
+	UpdateVariants(*context.Context, uint, map[string]interface{}) *errs.XError
+	DeleteVariants(*context.Context, uint) *errs.XError
 }
 
 type productRepository struct {
@@ -44,7 +52,9 @@ func (pr *productRepository) Get(ctx *context.Context, id uint) (*entities.Produ
 	product := entities.Product{}
 	res := pr.WithDB(ctx).Model(product).
 		Preload("Category").
-		Preload("Inventory").
+		Preload("Inventory", scopes.Channel(), scopes.WithReservedQuantity()).
+		Preload("UnitConversions", scopes.IsActive()).
+		Scopes(scopes.WithVariants(), scopes.WithVariantValues()).
 		Find(&product, id)
 	if res.Error != nil {
 		return nil, errs.NewXError(errs.DATABASE, "Unable to find product", res.Error)
@@ -52,15 +62,19 @@ func (pr *productRepository) Get(ctx *context.Context, id uint) (*entities.Produ
 	return &product, nil
 }
 
-func (pr *productRepository) GetAll(ctx *context.Context, search string) ([]entities.Product, *errs.XError) {
+// GetAll returns the top level products, of the category and its descendants when categoryId is given
+func (pr *productRepository) GetAll(ctx *context.Context, search string, categoryId *uint) ([]entities.Product, *errs.XError) {
 	var products []entities.Product
 	res := pr.WithDB(ctx).Model(entities.Product{}).
-		Scopes(scopes.Channel(), scopes.IsActive()).
-		Scopes(scopes.ILike(search, "name", "sku", "description")).
+		Scopes(scopes.AccessibleChannels(utils.GetAccessibleLocationIds(ctx)), scopes.IsActive()).
+		Scopes(scopes.TopLevelProducts(), scopes.ProductOrVariantILike(search, "name", "sku", "description")).
+		Scopes(scopes.InCategoryTree(categoryId, `"stich"."Products".category_id`)).
 		Scopes(db.Paginate(ctx)).
 		Scopes(scopes.WithAuditInfo()).
 		Preload("Category").
-		Preload("Inventory").
+		Preload("Inventory", scopes.Channel(), scopes.WithReservedQuantity()).
+		Preload("UnitConversions", scopes.IsActive()).
+		Scopes(scopes.WithVariants()).
 		Find(&products)
 	if res.Error != nil {
 		return nil, errs.NewXError(errs.DATABASE, "Unable to find products", res.Error)
@@ -79,11 +93,12 @@ func (pr *productRepository) Delete(ctx *context.Context, id uint) *errs.XError
 
 func (pr *productRepository) AutocompleteProduct(ctx *context.Context, search string) ([]entities.Product, *errs.XError) {
 	var products []entities.Product
-	res := pr.WithDB(ctx).
-		Scopes(scopes.Channel(), scopes.IsActive()).
-		Scopes(scopes.ILike(search, "name", "sku")).
-		Select("id", "name", "sku").
-		Preload("Inventory").
+	res := pr.WithDB(ctx).Model(&entities.Product{}).
+		Scopes(scopes.AccessibleChannels(utils.GetAccessibleLocationIds(ctx)), scopes.IsActive()).
+		Scopes(scopes.TopLevelProducts(), scopes.ProductOrVariantILike(search, "name", "sku")).
+		Select("id", "name", "sku", "unit").
+		Preload("Inventory", scopes.Channel(), scopes.WithReservedQuantity()).
+		Scopes(scopes.WithVariants()).
 		Find(&products)
 	if res.Error != nil {
 		return nil, errs.NewXError(errs.DATABASE, "Unable to find products for autocomplete", res.Error)
@@ -94,10 +109,12 @@ func (pr *productRepository) AutocompleteProduct(ctx *context.Context, search st
 func (pr *productRepository) GetBySKU(ctx *context.Context, sku string) (*entities.Product, *errs.XError) {
 	product := entities.Product{}
 	res := pr.WithDB(ctx).
-		Scopes(scopes.Channel(), scopes.IsActive()).
+		Scopes(scopes.AccessibleChannels(utils.GetAccessibleLocationIds(ctx)), scopes.IsActive()).
 		Where("sku = ?", sku).
 		Preload("Category").
-		Preload("Inventory").
+		Preload("Inventory", scopes.Channel(), scopes.WithReservedQuantity()).
+		Preload("UnitConversions", scopes.IsActive()).
+		Scopes(scopes.WithVariants(), scopes.WithVariantValues()).
 		First(&product)
 	if res.Error != nil {
 		return nil, errs.NewXError(errs.DATABASE, "Unable to find product by SKU", res.Error)
@@ -105,17 +122,124 @@ func (pr *productRepository) GetBySKU(ctx *context.Context, sku string) (*entiti
 	return &product, nil
 }
 
-func (pr *productRepository) GetLowStockProducts(ctx *context.Context) ([]entities.Product, *errs.XError) {
+// GetByCode finds the product a scanned label belongs to, scanners may change the case of the SKU
+func (pr *productRepository) GetByCode(ctx *context.Context, code string) (*entities.Product, *errs.XError) {
+	product := entities.Product{}
+	res := pr.WithDB(ctx).Model(&entities.Product{}).
+		Scopes(scopes.AccessibleChannels(utils.GetAccessibleLocationIds(ctx)), scopes.IsActive()).
+		Where("LOWER(sku) = LOWER(?)", code).
+		Preload("Category").
+		Preload("Inventory", scopes.Channel(), scopes.WithReservedQuantity()).
+		Preload("UnitConversions", scopes.IsActive()).
+		Scopes(scopes.WithVariants(), scopes.WithVariantValues()).
+		Limit(1).
+		Find(&product)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find product by code", res.Error)
+	}
+	return &product, nil
+}
+
+func (pr *productRepository) GetByIds(ctx *context.Context, ids []uint) ([]entities.Product, *errs.XError) {
 	var products []entities.Product
-	res := pr.WithDB(ctx).
+	if len(ids) == 0 {
+		return products, nil
+	}
+	res := pr.WithDB(ctx).Model(&entities.Product{}).
+		Scopes(scopes.AccessibleChannels(utils.GetAccessibleLocationIds(ctx)), scopes.IsActive()).
+		Where("id IN ?", ids).
+		Scopes(scopes.WithVariants()).
+		Find(&products)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find products", res.Error)
+	}
+	return products, nil
+}
+
+// GetLowStockProducts returns the low stock products grouped by their parent
+func (pr *productRepository) GetLowStockProducts(ctx *context.Context, categoryId *uint) ([]entities.Product, *errs.XError) {
+	var products []entities.Product
+	lowStock := pr.WithDB(ctx).Model(&entities.Inventory{}).
+		Joins(`INNER JOIN "stich"."Products" P ON P.id = "stich"."Inventories".product_id AND P.is_active = true`).
 		Scopes(scopes.Channel(), scopes.IsActive()).
-		Joins("INNER JOIN \"stich\".\"Inventories\" ON \"stich\".\"Inventories\".product_id = \"stich\".\"Products\".id").
-		Where("\"stich\".\"Inventories\".quantity <= \"stich\".\"Inventories\".low_stock_threshold").
+		Scopes(scopes.LowAvailableStock()).
+		Select("COALESCE(P.parent_id, P.id)")
+	res := pr.WithDB(ctx).Model(&entities.Product{}).
+		Scopes(scopes.IsActive()).
+		Where(`"stich"."Products".id IN (?)`, lowStock).
+		Scopes(scopes.InCategoryTree(categoryId, `"stich"."Products".category_id`)).
 		Preload("Category").
-		Preload("Inventory").
+		Preload("Inventory", scopes.Channel(), scopes.WithReservedQuantity()).
+		Scopes(scopes.WithVariants()).
 		Find(&products)
 	if res.Error != nil {
 		return nil, errs.NewXError(errs.DATABASE, "Unable to find low stock products", res.Error)
 	}
 	return products, nil
 }
+
+// ReplaceUnitConversions deactivates the product's conversions and saves the given ones in their place
+func (pr *productRepository) ReplaceUnitConversions(ctx *context.Context, productId uint, conversions []entities.ProductUnitConversion) *errs.XError {
+	res := pr.WithDB(ctx).Model(&entities.ProductUnitConversion{}).
+		Where("product_id = ? AND is_active = ?", productId, true).
+		Update("is_active", false)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update product unit conversions", res.Error)
+	}
+
+	if len(conversions) == 0 {
+		return nil
+	}
+
+	for i := range conversions {
+		conversions[i].ProductId = productId
+	}
+	res = pr.WithDB(ctx).Create(&conversions)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save product unit conversions", res.Error)
+	}
+	return nil
+}
+
+func (pr *productRepository) CreateAttributes(ctx *context.Context, attributes []entities.ProductAttribute) *errs.XError {
+	if len(attributes) == 0 {
+		return nil
+	}
+	res := pr.WithDB(ctx).Create(&attributes)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save product attributes", res.Error)
+	}
+	return nil
+}
+
+func (pr *productRepository) CreateVariantValues(ctx *context.Context, values []entities.ProductVariantValue) *errs.XError {
+	if len(values) == 0 {
+		return nil
+	}
+	res := pr.WithDB(ctx).Create(&values)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save product variant values", res.Error)
+	}
+	return nil
+}
+
+// UpdateVariants applies the details variants share with their parent to all of them
+func (pr *productRepository) UpdateVariants(ctx *context.Context, parentId uint, fields map[string]interface{}) *errs.XError {
+	res := pr.WithDB(ctx).Model(&entities.Product{}).
+		Where("parent_id = ? AND is_active = ?", parentId, true).
+		Updates(fields)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update product variants", res.Error)
+	}
+	return nil
+}
+
+func (pr *productRepository) DeleteVariants(ctx *context.Context, parentId uint) *errs.XError {
+	res := pr.WithDB(ctx).Model(&entities.Product{}).
+		Where("parent_id = ?", parentId).
+		Update("is_active", false)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to delete product variants", res.Error)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/db"
+	"github.com/loop-kar/pixie/errs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PurchaseOrderRepository interface {
+	Create(*context.Context, *entities.PurchaseOrder) *errs.XError
+	UpdateDetails(*context.Context, *entities.PurchaseOrder) *errs.XError
+	ReplaceLines(*context.Context, uint, []entities.PurchaseOrderLine) *errs.XError
+	Get(*context.Context, uint) (*entities.PurchaseOrder, *errs.XError)
+	GetAll(*context.Context, *uint, string) ([]entities.PurchaseOrder, *errs.XError)
+	Lock(*context.Context, uint) *errs.XError
+	AddReceivedQuantity(*context.Context, uint, float64) *errs.XError
+	UpdateStatus(*context.Context, uint, entities.PurchaseOrderStatus) *errs.XError
+}
+
+type purchaseOrderRepository struct {
+	GormDAL
+}
+
+func ProvidePurchaseOrderRepository(customDB GormDAL) PurchaseOrderRepository {
+	return &purchaseOrderRepository{GormDAL: customDB}
+}
+
+func (pr *purchaseOrderRepository) Create(ctx *context.Context, purchaseOrder *entities.PurchaseOrder) *errs.XError {
+	res := pr.WithDB(ctx).Create(&purchaseOrder)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save purchase order", res.Error)
+	}
+	return nil
+}
+
+// UpdateDetails updates the header fields only, lines are replaced through ReplaceLines
+func (pr *purchaseOrderRepository) UpdateDetails(ctx *context.Context, purchaseOrder *entities.PurchaseOrder) *errs.XError {
+	res := pr.WithDB(ctx).Model(&entities.PurchaseOrder{}).
+		Where("id = ?", purchaseOrder.ID).
+		Updates(map[string]interface{}{
+			"supplier_id":   purchaseOrder.SupplierId,
+			"order_date":    purchaseOrder.OrderDate,
+			"expected_date": purchaseOrder.ExpectedDate,
+			"notes":         purchaseOrder.Notes,
+			"updated_at":    time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update purchase order", res.Error)
+	}
+	return nil
+}
+
+// ReplaceLines deactivates the current lines of the purchase order and saves the given lines in their place
+func (pr *purchaseOrderRepository) ReplaceLines(ctx *context.Context, purchaseOrderId uint, lines []entities.PurchaseOrderLine) *errs.XError {
+	res := pr.WithDB(ctx).Model(&entities.PurchaseOrderLine{}).
+		Where("purchase_order_id = ? AND is_active = ?", purchaseOrderId, true).
+		Update("is_active", false)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update purchase order lines", res.Error)
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	for i := range lines {
+		lines[i].PurchaseOrderId = purchaseOrderId
+	}
+	res = pr.WithDB(ctx).Create(&lines)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save purchase order lines", res.Error)
+	}
+	return nil
+}
+
+func (pr *purchaseOrderRepository) Get(ctx *context.Context, id uint) (*entities.PurchaseOrder, *errs.XError) {
+	purchaseOrder := entities.PurchaseOrder{}
+	res := pr.WithDB(ctx).Model(purchaseOrder).
+		Scopes(scopes.WithAuditInfo()).
+		Preload("Supplier", scopes.SelectFields("name")).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Where("is_active = ?", true).Order("id ASC")
+		}).
+		Preload("Lines.Product", scopes.SelectFields("name", "sku")).
+		Preload("Expenses", scopes.IsActive()).
+		Preload("Expenses.ExpenseDetails", scopes.IsActive()).
+		Find(&purchaseOrder, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find purchase order", res.Error)
+	}
+	return &purchaseOrder, nil
+}
+
+func (pr *purchaseOrderRepository) GetAll(ctx *context.Context, supplierId *uint, status string) ([]entities.PurchaseOrder, *errs.XError) {
+	var purchaseOrders []entities.PurchaseOrder
+	query := pr.WithDB(ctx).Model(&entities.PurchaseOrder{}).
+		Scopes(scopes.Channel(), scopes.IsActive())
+	if supplierId != nil {
+		query = query.Where("supplier_id = ?", *supplierId)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	res := query.
+		Scopes(db.Paginate(ctx)).
+		Preload("Supplier", scopes.SelectFields("name")).
+		Preload("Lines", scopes.IsActive()).
+		Order("id DESC").
+		Find(&purchaseOrders)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find purchase orders", res.Error)
+	}
+	return purchaseOrders, nil
+}
+
+// Lock takes a row lock on the purchase order until the transaction ends
+func (pr *purchaseOrderRepository) Lock(ctx *context.Context, id uint) *errs.XError {
+	var purchaseOrder entities.PurchaseOrder
+	res := pr.WithDB(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&purchaseOrder, id)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to find purchase order", res.Error)
+	}
+	return nil
+}
+
+func (pr *purchaseOrderRepository) AddReceivedQuantity(ctx *context.Context, lineId uint, quantity float64) *errs.XError {
+	res := pr.WithDB(ctx).Model(&entities.PurchaseOrderLine{}).
+		Where("id = ?", lineId).
+		Updates(map[string]interface{}{
+			"received_quantity": gorm.Expr("received_quantity + ?", quantity),
+			"updated_at":        time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update received quantity", res.Error)
+	}
+	return nil
+}
+
+func (pr *purchaseOrderRepository) UpdateStatus(ctx *context.Context, id uint, status entities.PurchaseOrderStatus) *errs.XError {
+	res := pr.WithDB(ctx).Model(&entities.PurchaseOrder{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update purchase order status", res.Error)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/db"
+	"github.com/loop-kar/pixie/errs"
+	"gorm.io/gorm"
+)
+
+type SaleRepository interface {
+	Create(*context.Context, *entities.Sale) *errs.XError
+	Get(*context.Context, uint) (*entities.Sale, *errs.XError)
+	GetAll(*context.Context, *uint, *time.Time, *time.Time) ([]entities.Sale, *errs.XError)
+}
+
+type saleRepository struct {
+	GormDAL
+}
+
+func ProvideSaleRepository(customDB GormDAL) SaleRepository {
+	return &saleRepository{GormDAL: customDB}
+}
+
+func (sr *saleRepository) Create(ctx *context.Context, sale *entities.Sale) *errs.XError {
+	res := sr.WithDB(ctx).Create(&sale)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save sale", res.Error)
+	}
+	return nil
+}
+
+func (sr *saleRepository) Get(ctx *context.Context, id uint) (*entities.Sale, *errs.XError) {
+	sale := entities.Sale{}
+	res := sr.WithDB(ctx).Model(sale).
+		Scopes(scopes.Channel()).
+		Scopes(scopes.WithAuditInfo()).
+		Preload("Customer").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Where("is_active = ?", true).Order("id ASC")
+		}).
+		Preload("Lines.Product", scopes.SelectFields("name", "sku", "unit")).
+		Find(&sale, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find sale", res.Error)
+	}
+	return &sale, nil
+}
+
+// GetAll returns the sales of the channel made between from and to, optionally to one customer
+func (sr *saleRepository) GetAll(ctx *context.Context, customerId *uint, from *time.Time, to *time.Time) ([]entities.Sale, *errs.XError) {
+	var sales []entities.Sale
+	query := sr.WithDB(ctx).Model(&entities.Sale{}).
+		Scopes(scopes.Channel(), scopes.IsActive())
+	if customerId != nil {
+		query = query.Where("customer_id = ?", *customerId)
+	}
+	if from != nil {
+		query = query.Where("sold_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("sold_at < ?", to.AddDate(0, 0, 1))
+	}
+
+	res := query.
+		Scopes(db.Paginate(ctx)).
+		Preload("Customer", scopes.SelectFields("first_name", "last_name")).
+		Preload("Lines", scopes.IsActive()).
+		Order("id DESC").
+		Find(&sales)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find sales", res.Error)
+	}
+	return sales, nil
+}
@@ -0,0 +1,31 @@
+package scopes
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// categorySubtreeSQL selects the ids of a category and of all its descendants, whose paths start with the category's path
+const categorySubtreeSQL = `SELECT CT.id FROM "stich"."Categories" CT
+	WHERE CT.is_active = true AND CT.path LIKE (SELECT CP.path FROM "stich"."Categories" CP WHERE CP.id = ?) || '%'`
+
+// InCategoryTree keeps the rows whose categoryColumn is the category or one of its descendants, all rows when categoryId is nil
+func InCategoryTree(categoryId *uint, categoryColumn string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if categoryId == nil {
+			return db
+		}
+		return db.Where(fmt.Sprintf("%s IN (%s)", categoryColumn, categorySubtreeSQL), *categoryId)
+	}
+}
+
+// ProductInCategoryTree keeps the rows whose productColumn is a product of the category or of one of its descendants
+func ProductInCategoryTree(categoryId *uint, productColumn string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if categoryId == nil {
+			return db
+		}
+		return db.Where(fmt.Sprintf(`%s IN (SELECT CTP.id FROM "stich"."Products" CTP WHERE CTP.category_id IN (%s))`, productColumn, categorySubtreeSQL), *categoryId)
+	}
+}
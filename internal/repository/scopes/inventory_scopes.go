@@ -0,0 +1,35 @@
+package scopes
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ReservedQuantitySQL sums the open reservations of a row of "stich"."Inventories", which the query selects from
+const ReservedQuantitySQL = `(SELECT COALESCE(SUM(r.quantity), 0) FROM "stich"."StockReservations" r
+	WHERE r.product_id = "stich"."Inventories".product_id AND r.channel_id = "stich"."Inventories".channel_id
+	AND r.status = 'RESERVED' AND r.is_active = true)`
+
+// WithReservedQuantity fills Inventory.ReservedQuantity
+func WithReservedQuantity() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Select(fmt.Sprintf(`"stich"."Inventories".*, %s AS reserved_quantity`, ReservedQuantitySQL))
+	}
+}
+
+// GroupedByParentProduct orders inventory rows so the variants of a product follow each other
+func GroupedByParentProduct() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Joins(`INNER JOIN "stich"."Products" GP ON GP.id = "stich"."Inventories".product_id`).
+			Order("COALESCE(GP.parent_id, GP.id) ASC, GP.name ASC")
+	}
+}
+
+// LowAvailableStock keeps the inventory rows whose unreserved stock is at or below the threshold
+func LowAvailableStock() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(fmt.Sprintf(`"stich"."Inventories".quantity - %s <= "stich"."Inventories".low_stock_threshold`, ReservedQuantitySQL))
+	}
+}
@@ -138,3 +138,10 @@ func GetMeasurements_SearchByCustomerName(search string) func(db *gorm.DB) *gorm
 // 		return db
 // 	}
 // }
+
+// OrderedMeasurementFields keeps the active fields of a schema in the order they are shown in
+func OrderedMeasurementFields() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(IsActive()).Order("display_order ASC, id ASC")
+	}
+}
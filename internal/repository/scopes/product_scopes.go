@@ -0,0 +1,66 @@
+package scopes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/loop-kar/pixie/util"
+	"gorm.io/gorm"
+)
+
+// TopLevelProducts leaves out variants, they are listed under their parent
+func TopLevelProducts() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(`"stich"."Products".parent_id IS NULL`)
+	}
+}
+
+// ProductOrVariantILike matches products whose own fields, or those of one of their active variants, contain the search
+func ProductOrVariantILike(search string, params ...string) func(db *gorm.DB) *gorm.DB {
+	defaultReturn := func(db *gorm.DB) *gorm.DB { return db }
+
+	if len(params) == 0 || util.IsNilOrEmptyString(&search) {
+		return defaultReturn
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		formattedSearch := util.EncloseWithPercentageOperator(search)
+
+		own := make([]string, 0, len(params))
+		variant := make([]string, 0, len(params))
+		for _, param := range params {
+			own = append(own, fmt.Sprintf(`"stich"."Products".%s ILIKE %s`, param, formattedSearch))
+			variant = append(variant, fmt.Sprintf(`V.%s ILIKE %s`, param, formattedSearch))
+		}
+
+		whereClause := fmt.Sprintf(
+			`(%s OR EXISTS (SELECT 1 FROM "stich"."Products" V WHERE V.parent_id = "stich"."Products".id AND V.is_active = true AND (%s)))`,
+			strings.Join(own, OR), strings.Join(variant, OR),
+		)
+		return db.Where(whereClause)
+	}
+}
+
+// WithVariants preloads a parent's attributes and its active variants with their stock and values
+func WithVariants() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Preload("Attributes", IsActive()).
+			Preload("Variants", func(db *gorm.DB) *gorm.DB {
+				return db.Scopes(IsActive()).Order("id ASC")
+			}).
+			Preload("Variants.Inventory", Channel(), WithReservedQuantity()).
+			Preload("Variants.VariantValues", IsActive()).
+			Preload("Variants.VariantValues.Attribute")
+	}
+}
+
+// WithVariantValues preloads what a variant needs to be shown on its own, its parent and its values
+func WithVariantValues() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Preload("Parent", SelectFields("name", "sku")).
+			Preload("VariantValues", IsActive()).
+			Preload("VariantValues.Attribute")
+	}
+}
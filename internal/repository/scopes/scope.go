@@ -136,7 +136,12 @@ func AccessibleChannels(accesibleChannelIds []uint) func(db *gorm.DB) *gorm.DB {
 			return db
 		}
 
-		return db.Where("channel_id", accesibleChannelIds)
+		tableName, err := getTableName(db)
+		if err != nil {
+			return db.Where("channel_id", accesibleChannelIds)
+		}
+
+		return db.Where(fmt.Sprintf("%s.channel_id", tableName), accesibleChannelIds)
 
 	}
 
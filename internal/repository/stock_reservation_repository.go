@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type StockReservationRepository interface {
+	CreateAll(*context.Context, []entities.StockReservation) *errs.XError
+	ReleaseByOrderId(*context.Context, uint) *errs.XError
+	ConsumeByOrderItemId(*context.Context, uint, uint) *errs.XError
+	GetReservedQuantity(*context.Context, uint, *uint) (float64, *errs.XError)
+	GetReservedByOrderId(*context.Context, uint) (map[uint]float64, *errs.XError)
+}
+
+type stockReservationRepository struct {
+	GormDAL
+}
+
+func ProvideStockReservationRepository(customDB GormDAL) StockReservationRepository {
+	return &stockReservationRepository{GormDAL: customDB}
+}
+
+func (rr *stockReservationRepository) CreateAll(ctx *context.Context, reservations []entities.StockReservation) *errs.XError {
+	if len(reservations) == 0 {
+		return nil
+	}
+
+	res := rr.WithDB(ctx).Create(&reservations)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to create stock reservations", res.Error)
+	}
+	return nil
+}
+
+// ReleaseByOrderId gives the stock held for the order back
+func (rr *stockReservationRepository) ReleaseByOrderId(ctx *context.Context, orderId uint) *errs.XError {
+	res := rr.WithDB(ctx).Model(&entities.StockReservation{}).
+		Where("order_id = ? AND status = ?", orderId, entities.RESERVATION_RESERVED).
+		Updates(map[string]interface{}{
+			"status":     entities.RESERVATION_RELEASED,
+			"updated_at": time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to release stock reservations", res.Error)
+	}
+	return nil
+}
+
+// ConsumeByOrderItemId marks the item's reservation of the product as used up once the material is booked out
+func (rr *stockReservationRepository) ConsumeByOrderItemId(ctx *context.Context, orderItemId uint, productId uint) *errs.XError {
+	res := rr.WithDB(ctx).Model(&entities.StockReservation{}).
+		Where("order_item_id = ? AND product_id = ? AND status = ?", orderItemId, productId, entities.RESERVATION_RESERVED).
+		Updates(map[string]interface{}{
+			"status":     entities.RESERVATION_CONSUMED,
+			"updated_at": time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to consume stock reservations", res.Error)
+	}
+	return nil
+}
+
+// GetReservedQuantity sums the open reservations of the product, except those of the given order item
+func (rr *stockReservationRepository) GetReservedQuantity(ctx *context.Context, productId uint, exceptOrderItemId *uint) (float64, *errs.XError) {
+	var reserved float64
+	query := rr.WithDB(ctx).Model(&entities.StockReservation{}).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("product_id = ? AND status = ?", productId, entities.RESERVATION_RESERVED)
+	if exceptOrderItemId != nil {
+		query = query.Where("order_item_id <> ?", *exceptOrderItemId)
+	}
+
+	res := query.Select("COALESCE(SUM(quantity), 0)").Scan(&reserved)
+	if res.Error != nil {
+		return 0, errs.NewXError(errs.DATABASE, "Unable to find reserved quantity", res.Error)
+	}
+	return reserved, nil
+}
+
+// GetReservedByOrderId sums the open reservations of the order per product
+func (rr *stockReservationRepository) GetReservedByOrderId(ctx *context.Context, orderId uint) (map[uint]float64, *errs.XError) {
+	var rows []struct {
+		ProductId uint
+		Reserved  float64
+	}
+	res := rr.WithDB(ctx).Model(&entities.StockReservation{}).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("order_id = ? AND status = ?", orderId, entities.RESERVATION_RESERVED).
+		Select("product_id, COALESCE(SUM(quantity), 0) AS reserved").
+		Group("product_id").
+		Scan(&rows)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find reserved quantities of order", res.Error)
+	}
+
+	reserved := make(map[uint]float64, len(rows))
+	for _, row := range rows {
+		reserved[row.ProductId] = row.Reserved
+	}
+	return reserved, nil
+}
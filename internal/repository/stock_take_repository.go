@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/db"
+	"github.com/loop-kar/pixie/errs"
+	"gorm.io/gorm/clause"
+)
+
+type StockTakeRepository interface {
+	Create(*context.Context, *entities.StockTake) *errs.XError
+	Get(*context.Context, uint) (*entities.StockTake, *errs.XError)
+	Lock(*context.Context, uint) *errs.XError
+	GetAll(*context.Context) ([]entities.StockTake, *errs.XError)
+	UpdateCount(*context.Context, uint, uint, float64) (bool, *errs.XError)
+	UpdatePostedVariance(*context.Context, uint, float64) *errs.XError
+	UpdateStatus(*context.Context, uint, entities.StockTakeStatus, string, *time.Time) *errs.XError
+}
+
+type stockTakeRepository struct {
+	GormDAL
+}
+
+func ProvideStockTakeRepository(customDB GormDAL) StockTakeRepository {
+	return &stockTakeRepository{GormDAL: customDB}
+}
+
+func (sr *stockTakeRepository) Create(ctx *context.Context, stockTake *entities.StockTake) *errs.XError {
+	res := sr.WithDB(ctx).Create(&stockTake)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save stock take", res.Error)
+	}
+	return nil
+}
+
+func (sr *stockTakeRepository) Get(ctx *context.Context, id uint) (*entities.StockTake, *errs.XError) {
+	stockTake := entities.StockTake{}
+	res := sr.WithDB(ctx).Model(stockTake).
+		Scopes(scopes.WithAuditInfo()).
+		Preload("Category", scopes.SelectFields("name")).
+		Preload("Lines", scopes.IsActive()).
+		Preload("Lines.Product", scopes.SelectFields("name", "sku")).
+		Preload("Lines.Product.Inventory").
+		Find(&stockTake, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find stock take", res.Error)
+	}
+	return &stockTake, nil
+}
+
+// Lock takes a row lock on the stock take until the transaction ends
+func (sr *stockTakeRepository) Lock(ctx *context.Context, id uint) *errs.XError {
+	var stockTake entities.StockTake
+	res := sr.WithDB(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&stockTake, id)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to find stock take", res.Error)
+	}
+	return nil
+}
+
+func (sr *stockTakeRepository) GetAll(ctx *context.Context) ([]entities.StockTake, *errs.XError) {
+	var stockTakes []entities.StockTake
+	res := sr.WithDB(ctx).Model(&entities.StockTake{}).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Scopes(db.Paginate(ctx)).
+		Preload("Category", scopes.SelectFields("name")).
+		Preload("Lines", scopes.IsActive()).
+		Order("opened_at DESC").
+		Find(&stockTakes)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find stock takes", res.Error)
+	}
+	return stockTakes, nil
+}
+
+// UpdateCount records the counted quantity of a product, it reports false when the product is not part of the session
+func (sr *stockTakeRepository) UpdateCount(ctx *context.Context, stockTakeId uint, productId uint, counted float64) (bool, *errs.XError) {
+	res := sr.WithDB(ctx).Model(&entities.StockTakeLine{}).
+		Where("stock_take_id = ? AND product_id = ? AND is_active = ?", stockTakeId, productId, true).
+		Updates(map[string]interface{}{
+			"counted_quantity": counted,
+			"updated_at":       time.Now(),
+		})
+	if res.Error != nil {
+		return false, errs.NewXError(errs.DATABASE, "Unable to update stock take count", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (sr *stockTakeRepository) UpdatePostedVariance(ctx *context.Context, lineId uint, variance float64) *errs.XError {
+	res := sr.WithDB(ctx).Model(&entities.StockTakeLine{}).
+		Where("id = ?", lineId).
+		Updates(map[string]interface{}{
+			"posted_variance": variance,
+			"updated_at":      time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update stock take line", res.Error)
+	}
+	return nil
+}
+
+func (sr *stockTakeRepository) UpdateStatus(ctx *context.Context, id uint, status entities.StockTakeStatus, reason string, postedAt *time.Time) *errs.XError {
+	res := sr.WithDB(ctx).Model(&entities.StockTake{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"reason":     reason,
+			"posted_at":  postedAt,
+			"updated_at": time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update stock take status", res.Error)
+	}
+	return nil
+}
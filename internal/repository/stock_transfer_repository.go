@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/db"
+	"github.com/loop-kar/pixie/errs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockTransferRepository interface {
+	Create(*context.Context, *entities.StockTransfer) *errs.XError
+	UpdateDetails(*context.Context, *entities.StockTransfer) *errs.XError
+	ReplaceLines(*context.Context, uint, []entities.StockTransferLine) *errs.XError
+	Get(*context.Context, uint) (*entities.StockTransfer, *errs.XError)
+	GetAll(*context.Context, string) ([]entities.StockTransfer, *errs.XError)
+	Lock(*context.Context, uint) *errs.XError
+	UpdateStatus(*context.Context, uint, entities.StockTransferStatus) *errs.XError
+	GetInTransitQuantities(*context.Context, []uint) (map[uint]float64, *errs.XError)
+}
+
+type stockTransferRepository struct {
+	GormDAL
+}
+
+func ProvideStockTransferRepository(customDB GormDAL) StockTransferRepository {
+	return &stockTransferRepository{GormDAL: customDB}
+}
+
+// sentOrReceived limits transfers to the ones sent from or to the current channel
+func sentOrReceived(ctx *context.Context) func(db *gorm.DB) *gorm.DB {
+	channelId := utils.GetChannelId(ctx)
+	return func(db *gorm.DB) *gorm.DB {
+		//System Admin needs access to all Data
+		if channelId == 0 {
+			return db
+		}
+		return db.Where("from_channel_id = ? OR to_channel_id = ?", channelId, channelId)
+	}
+}
+
+func (tr *stockTransferRepository) Create(ctx *context.Context, transfer *entities.StockTransfer) *errs.XError {
+	res := tr.WithDB(ctx).Create(&transfer)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save stock transfer", res.Error)
+	}
+	return nil
+}
+
+// UpdateDetails updates the header fields only, lines are replaced through ReplaceLines
+func (tr *stockTransferRepository) UpdateDetails(ctx *context.Context, transfer *entities.StockTransfer) *errs.XError {
+	res := tr.WithDB(ctx).Model(&entities.StockTransfer{}).
+		Where("id = ?", transfer.ID).
+		Updates(map[string]interface{}{
+			"to_channel_id": transfer.ToChannelId,
+			"notes":         transfer.Notes,
+			"updated_at":    time.Now(),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update stock transfer", res.Error)
+	}
+	return nil
+}
+
+// ReplaceLines deactivates the current lines of the transfer and saves the given lines in their place
+func (tr *stockTransferRepository) ReplaceLines(ctx *context.Context, transferId uint, lines []entities.StockTransferLine) *errs.XError {
+	res := tr.WithDB(ctx).Model(&entities.StockTransferLine{}).
+		Where("stock_transfer_id = ? AND is_active = ?", transferId, true).
+		Update("is_active", false)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update stock transfer lines", res.Error)
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	for i := range lines {
+		lines[i].StockTransferId = transferId
+	}
+	res = tr.WithDB(ctx).Create(&lines)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save stock transfer lines", res.Error)
+	}
+	return nil
+}
+
+func (tr *stockTransferRepository) Get(ctx *context.Context, id uint) (*entities.StockTransfer, *errs.XError) {
+	transfer := entities.StockTransfer{}
+	res := tr.WithDB(ctx).Model(transfer).
+		Scopes(sentOrReceived(ctx)).
+		Scopes(scopes.WithAuditInfo()).
+		Preload("FromChannel", scopes.SelectFields("name")).
+		Preload("ToChannel", scopes.SelectFields("name")).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Where("is_active = ?", true).Order("id ASC")
+		}).
+		Preload("Lines.Product", scopes.SelectFields("name", "sku", "unit")).
+		Find(&transfer, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find stock transfer", res.Error)
+	}
+	return &transfer, nil
+}
+
+func (tr *stockTransferRepository) GetAll(ctx *context.Context, status string) ([]entities.StockTransfer, *errs.XError) {
+	var transfers []entities.StockTransfer
+	query := tr.WithDB(ctx).Model(&entities.StockTransfer{}).
+		Scopes(sentOrReceived(ctx), scopes.IsActive())
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	res := query.
+		Scopes(db.Paginate(ctx)).
+		Preload("FromChannel", scopes.SelectFields("name")).
+		Preload("ToChannel", scopes.SelectFields("name")).
+		Preload("Lines", scopes.IsActive()).
+		Order("id DESC").
+		Find(&transfers)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find stock transfers", res.Error)
+	}
+	return transfers, nil
+}
+
+// Lock takes a row lock on the transfer until the transaction ends, so it cannot be dispatched or received twice
+func (tr *stockTransferRepository) Lock(ctx *context.Context, id uint) *errs.XError {
+	var transfer entities.StockTransfer
+	res := tr.WithDB(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&transfer, id)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to find stock transfer", res.Error)
+	}
+	return nil
+}
+
+// UpdateStatus also stamps the dispatch or receipt time when moving to DISPATCHED or RECEIVED
+func (tr *stockTransferRepository) UpdateStatus(ctx *context.Context, id uint, status entities.StockTransferStatus) *errs.XError {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":     status,
+		"updated_at": now,
+	}
+	switch status {
+	case entities.StockTransferStatusDISPATCHED:
+		updates["dispatched_at"] = now
+	case entities.StockTransferStatusRECEIVED:
+		updates["received_at"] = now
+	}
+
+	res := tr.WithDB(ctx).Model(&entities.StockTransfer{}).
+		Where("id = ?", id).
+		Updates(updates)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update stock transfer status", res.Error)
+	}
+	return nil
+}
+
+// GetInTransitQuantities sums, per product, the quantities dispatched to the given channels and not received yet
+func (tr *stockTransferRepository) GetInTransitQuantities(ctx *context.Context, channelIds []uint) (map[uint]float64, *errs.XError) {
+	var rows []struct {
+		ProductId uint
+		Quantity  float64
+	}
+	res := tr.WithDB(ctx).Table(`"stich"."StockTransferLines" l`).
+		Select("l.product_id, COALESCE(SUM(l.quantity), 0) AS quantity").
+		Joins(`JOIN "stich"."StockTransfers" t ON t.id = l.stock_transfer_id`).
+		Where("l.is_active = ? AND t.is_active = ? AND t.status = ? AND t.to_channel_id IN ?",
+			true, true, entities.StockTransferStatusDISPATCHED, channelIds).
+		Group("l.product_id").
+		Scan(&rows)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find stock in transit", res.Error)
+	}
+
+	quantities := make(map[uint]float64, len(rows))
+	for _, row := range rows {
+		quantities[row.ProductId] = row.Quantity
+	}
+	return quantities, nil
+}
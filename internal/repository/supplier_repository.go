@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/db"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type SupplierRepository interface {
+	Create(*context.Context, *entities.Supplier) *errs.XError
+	Update(*context.Context, *entities.Supplier) *errs.XError
+	Get(*context.Context, uint) (*entities.Supplier, *errs.XError)
+	GetAll(*context.Context, string) ([]entities.Supplier, *errs.XError)
+	Delete(*context.Context, uint) *errs.XError
+	AutocompleteSupplier(*context.Context, string) ([]entities.Supplier, *errs.XError)
+}
+
+type supplierRepository struct {
+	GormDAL
+}
+
+func ProvideSupplierRepository(customDB GormDAL) SupplierRepository {
+	return &supplierRepository{GormDAL: customDB}
+}
+
+const supplierOutstandingBalance = `(SELECT COALESCE(SUM(balance), 0) FROM "stich"."Expenses"
+			 WHERE "stich"."Expenses".supplier_id = "stich"."Suppliers".id AND "stich"."Expenses".is_active = true) AS outstanding_balance`
+
+func (sr *supplierRepository) Create(ctx *context.Context, supplier *entities.Supplier) *errs.XError {
+	res := sr.WithDB(ctx).Create(&supplier)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save supplier", res.Error)
+	}
+	return nil
+}
+
+func (sr *supplierRepository) Update(ctx *context.Context, supplier *entities.Supplier) *errs.XError {
+	return sr.GormDAL.Update(ctx, *supplier)
+}
+
+func (sr *supplierRepository) Get(ctx *context.Context, id uint) (*entities.Supplier, *errs.XError) {
+	supplier := entities.Supplier{}
+	res := sr.WithDB(ctx).Model(supplier).
+		Joins(`LEFT JOIN "stich"."Users" cu ON cu.id = "stich"."Suppliers".created_by_id`).
+		Joins(`LEFT JOIN "stich"."Users" uu ON uu.id = "stich"."Suppliers".updated_by_id`).
+		Select(`"stich"."Suppliers".*,
+			COALESCE(cu.first_name || ' ' || cu.last_name, '') AS created_by,
+			COALESCE(uu.first_name || ' ' || uu.last_name, '') AS updated_by,
+			`+supplierOutstandingBalance).
+		Find(&supplier, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find supplier", res.Error)
+	}
+	return &supplier, nil
+}
+
+func (sr *supplierRepository) GetAll(ctx *context.Context, search string) ([]entities.Supplier, *errs.XError) {
+	var suppliers []entities.Supplier
+	res := sr.WithDB(ctx).Model(entities.Supplier{}).
+		Select(`"stich"."Suppliers".*, `+supplierOutstandingBalance).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Scopes(scopes.ILike(search, "name", "contact_person", "phone_number")).
+		Scopes(db.Paginate(ctx)).
+		Order("name ASC").
+		Find(&suppliers)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find suppliers", res.Error)
+	}
+	return suppliers, nil
+}
+
+func (sr *supplierRepository) Delete(ctx *context.Context, id uint) *errs.XError {
+	supplier := &entities.Supplier{Model: &entities.Model{ID: id, IsActive: false}}
+	err := sr.GormDAL.Delete(ctx, supplier)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sr *supplierRepository) AutocompleteSupplier(ctx *context.Context, search string) ([]entities.Supplier, *errs.XError) {
+	var suppliers []entities.Supplier
+	res := sr.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Scopes(scopes.ILike(search, "name")).
+		Select("id", "name").
+		Find(&suppliers)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find suppliers for autocomplete", res.Error)
+	}
+	return suppliers, nil
+}
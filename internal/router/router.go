@@ -160,6 +160,15 @@ func InitRouter(handler baseHandler.BaseHandler, newRelic *newrelic.Application,
 			orderEndpoints.GET(":id", handler.OrderHandler.Get)
 			orderEndpoints.GET("", handler.OrderHandler.GetAllOrders)
 			orderEndpoints.DELETE(":id", handler.OrderHandler.Delete)
+			orderEndpoints.POST(":id/transition", handler.OrderHandler.TransitionOrder)
+			orderEndpoints.GET(":id/invoice", handler.InvoiceHandler.GetOrderInvoice)
+			orderEndpoints.POST(":id/invoice", handler.InvoiceHandler.IssueOrderInvoice)
+
+			orderEndpoints.GET(":id/payment", handler.OrderPaymentHandler.GetByOrderId)
+			orderEndpoints.POST(":id/payment", handler.OrderPaymentHandler.Save)
+			orderEndpoints.GET(":id/payment/:paymentId", handler.OrderPaymentHandler.Get)
+			orderEndpoints.PUT(":id/payment/:paymentId", handler.OrderPaymentHandler.Update)
+			orderEndpoints.DELETE(":id/payment/:paymentId", handler.OrderPaymentHandler.Delete)
 		}
 
 		orderItemEndpoints := appRouter.Group("order-item", router.VerifyJWT(srvConfig.JwtSecretKey))
@@ -169,6 +178,7 @@ func InitRouter(handler baseHandler.BaseHandler, newRelic *newrelic.Application,
 			orderItemEndpoints.GET(":id", handler.OrderItemHandler.Get)
 			orderItemEndpoints.GET("", handler.OrderItemHandler.GetAllOrderItems)
 			orderItemEndpoints.DELETE(":id", handler.OrderItemHandler.Delete)
+			orderItemEndpoints.POST(":id/stage", handler.OrderItemHandler.MoveStage)
 		}
 
 		measurementEndpoints := appRouter.Group("measurement", router.VerifyJWT(srvConfig.JwtSecretKey))
@@ -177,11 +187,19 @@ func InitRouter(handler baseHandler.BaseHandler, newRelic *newrelic.Application,
 			measurementEndpoints.POST("bulk", handler.MeasurementHandler.SaveBulkMeasurements)
 			measurementEndpoints.PUT("bulk", handler.MeasurementHandler.BulkUpdateMeasurements)
 			measurementEndpoints.PUT(":id", handler.MeasurementHandler.UpdateMeasurement)
+			measurementEndpoints.GET(":id/diff", handler.MeasurementHandler.Diff)
+			measurementEndpoints.POST(":id/restore/:historyId", handler.MeasurementHandler.Restore)
 			measurementEndpoints.GET(":id", handler.MeasurementHandler.Get)
 			measurementEndpoints.GET("", handler.MeasurementHandler.GetAllMeasurements)
 			measurementEndpoints.DELETE(":id", handler.MeasurementHandler.Delete)
 		}
 
+		measurementFlagEndpoints := appRouter.Group("measurement-flag", router.VerifyJWT(srvConfig.JwtSecretKey))
+		{
+			measurementFlagEndpoints.GET("", handler.MeasurementHandler.GetFlagged)
+			measurementFlagEndpoints.PUT(":id/review", handler.MeasurementHandler.ReviewFlag)
+		}
+
 		personEndpoints := appRouter.Group("person", router.VerifyJWT(srvConfig.JwtSecretKey))
 		{
 			personEndpoints.POST("", handler.PersonHandler.SavePerson)
@@ -199,6 +217,10 @@ func InitRouter(handler baseHandler.BaseHandler, newRelic *newrelic.Application,
 			dressTypeEndpoints.GET(":id", handler.DressTypeHandler.Get)
 			dressTypeEndpoints.GET("", handler.DressTypeHandler.GetAllDressTypes)
 			dressTypeEndpoints.DELETE(":id", handler.DressTypeHandler.Delete)
+			dressTypeEndpoints.GET(":id/bill-of-materials", handler.DressTypeHandler.GetBillOfMaterials)
+			dressTypeEndpoints.PUT(":id/bill-of-materials", handler.DressTypeHandler.UpdateBillOfMaterials)
+			dressTypeEndpoints.GET(":id/measurement-schema", handler.DressTypeHandler.GetMeasurementSchema)
+			dressTypeEndpoints.PUT(":id/measurement-schema", handler.DressTypeHandler.UpdateMeasurementSchema)
 		}
 
 		orderHistoryEndpoints := appRouter.Group("order-history", router.VerifyJWT(srvConfig.JwtSecretKey))
@@ -260,6 +282,8 @@ func InitRouter(handler baseHandler.BaseHandler, newRelic *newrelic.Application,
 			categoryEndpoints.POST("", handler.CategoryHandler.SaveCategory)
 			categoryEndpoints.PUT(":id", handler.CategoryHandler.UpdateCategory)
 			categoryEndpoints.GET("autocomplete", handler.CategoryHandler.AutocompleteCategory)
+			categoryEndpoints.GET("tree", handler.CategoryHandler.GetTree)
+			categoryEndpoints.PUT(":id/move", handler.CategoryHandler.Move)
 			categoryEndpoints.GET(":id", handler.CategoryHandler.Get)
 			categoryEndpoints.GET("", handler.CategoryHandler.GetAllCategories)
 			categoryEndpoints.DELETE(":id", handler.CategoryHandler.Delete)
@@ -272,6 +296,8 @@ func InitRouter(handler baseHandler.BaseHandler, newRelic *newrelic.Application,
 			productEndpoints.GET("autocomplete", handler.ProductHandler.AutocompleteProduct)
 			productEndpoints.GET("low-stock", handler.ProductHandler.GetLowStockProducts)
 			productEndpoints.GET("sku", handler.ProductHandler.GetBySKU)
+			productEndpoints.GET("scan/:code", handler.ProductHandler.Scan)
+			productEndpoints.POST("labels", handler.ProductHandler.GetLabels)
 			productEndpoints.GET(":id", handler.ProductHandler.Get)
 			productEndpoints.GET("", handler.ProductHandler.GetAllProducts)
 			productEndpoints.DELETE(":id", handler.ProductHandler.Delete)
@@ -282,16 +308,71 @@ func InitRouter(handler baseHandler.BaseHandler, newRelic *newrelic.Application,
 			inventoryEndpoints.POST("movement", handler.InventoryHandler.RecordStockMovement)
 			inventoryEndpoints.PUT(":id/threshold", handler.InventoryHandler.UpdateThreshold)
 			inventoryEndpoints.GET("low-stock", handler.InventoryHandler.GetLowStockItems)
+			inventoryEndpoints.GET("reorder-suggestions", handler.InventoryHandler.GetReorderSuggestions)
+			inventoryEndpoints.GET("valuation", handler.InventoryHandler.GetValuation)
+			inventoryEndpoints.GET("cogs", handler.InventoryHandler.GetCOGSReport)
+			inventoryEndpoints.GET("consolidated", handler.InventoryHandler.GetConsolidated)
 			inventoryEndpoints.GET("product/:productId", handler.InventoryHandler.GetByProductId)
+			inventoryEndpoints.GET("product/:productId/lots", handler.InventoryHandler.GetLots)
 			inventoryEndpoints.GET(":id", handler.InventoryHandler.Get)
 			inventoryEndpoints.GET("", handler.InventoryHandler.GetAllInventories)
 		}
 
+		supplierEndpoints := appRouter.Group("supplier", router.VerifyJWT(srvConfig.JwtSecretKey))
+		{
+			supplierEndpoints.POST("", handler.SupplierHandler.SaveSupplier)
+			supplierEndpoints.PUT(":id", handler.SupplierHandler.UpdateSupplier)
+			supplierEndpoints.GET("autocomplete", handler.SupplierHandler.AutocompleteSupplier)
+			supplierEndpoints.GET(":id", handler.SupplierHandler.Get)
+			supplierEndpoints.GET("", handler.SupplierHandler.GetAllSuppliers)
+			supplierEndpoints.DELETE(":id", handler.SupplierHandler.Delete)
+		}
+
+		purchaseOrderEndpoints := appRouter.Group("purchase-order", router.VerifyJWT(srvConfig.JwtSecretKey))
+		{
+			purchaseOrderEndpoints.POST("", handler.PurchaseOrderHandler.SavePurchaseOrder)
+			purchaseOrderEndpoints.PUT(":id", handler.PurchaseOrderHandler.UpdatePurchaseOrder)
+			purchaseOrderEndpoints.POST(":id/receive", handler.PurchaseOrderHandler.Receive)
+			purchaseOrderEndpoints.POST(":id/cancel", handler.PurchaseOrderHandler.Cancel)
+			purchaseOrderEndpoints.GET(":id", handler.PurchaseOrderHandler.Get)
+			purchaseOrderEndpoints.GET("", handler.PurchaseOrderHandler.GetAllPurchaseOrders)
+		}
+
+		stockTransferEndpoints := appRouter.Group("stock-transfer", router.VerifyJWT(srvConfig.JwtSecretKey))
+		{
+			stockTransferEndpoints.POST("", handler.StockTransferHandler.SaveStockTransfer)
+			stockTransferEndpoints.PUT(":id", handler.StockTransferHandler.UpdateStockTransfer)
+			stockTransferEndpoints.POST(":id/dispatch", handler.StockTransferHandler.Dispatch)
+			stockTransferEndpoints.POST(":id/receive", handler.StockTransferHandler.Receive)
+			stockTransferEndpoints.POST(":id/cancel", handler.StockTransferHandler.Cancel)
+			stockTransferEndpoints.GET(":id", handler.StockTransferHandler.Get)
+			stockTransferEndpoints.GET("", handler.StockTransferHandler.GetAllStockTransfers)
+		}
+
+		saleEndpoints := appRouter.Group("sale", router.VerifyJWT(srvConfig.JwtSecretKey))
+		{
+			saleEndpoints.POST("", handler.SaleHandler.SaveSale)
+			saleEndpoints.GET(":id/receipt", handler.SaleHandler.GetReceipt)
+			saleEndpoints.GET(":id", handler.SaleHandler.Get)
+			saleEndpoints.GET("", handler.SaleHandler.GetAllSales)
+		}
+
+		stockTakeEndpoints := appRouter.Group("stock-take", router.VerifyJWT(srvConfig.JwtSecretKey))
+		{
+			stockTakeEndpoints.POST("", handler.StockTakeHandler.Open)
+			stockTakeEndpoints.PUT(":id/count", handler.StockTakeHandler.SubmitCounts)
+			stockTakeEndpoints.POST(":id/post", handler.StockTakeHandler.Post)
+			stockTakeEndpoints.GET(":id", handler.StockTakeHandler.Get)
+			stockTakeEndpoints.GET("", handler.StockTakeHandler.GetAll)
+			stockTakeEndpoints.DELETE(":id", handler.StockTakeHandler.Cancel)
+		}
+
 		inventoryLogEndpoints := appRouter.Group("inventory-log", router.VerifyJWT(srvConfig.JwtSecretKey))
 		{
 			inventoryLogEndpoints.GET("change-type", handler.InventoryLogHandler.GetByChangeType)
 			inventoryLogEndpoints.GET("date-range", handler.InventoryLogHandler.GetByDateRange)
 			inventoryLogEndpoints.GET("product/:productId", handler.InventoryLogHandler.GetByProductId)
+			inventoryLogEndpoints.POST(":id/reverse", handler.InventoryLogHandler.Reverse)
 			inventoryLogEndpoints.GET(":id", handler.InventoryLogHandler.Get)
 			inventoryLogEndpoints.GET("", handler.InventoryLogHandler.GetAllInventoryLogs)
 		}
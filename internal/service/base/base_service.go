@@ -18,6 +18,7 @@ type BaseService struct {
 	MeasurementHistoryService service.MeasurementHistoryService
 	ExpenseTrackerService     service.ExpenseTrackerService
 	TaskService               service.TaskService
+	InventoryService          service.InventoryService
 }
 
 func ProvideBaseService(
@@ -36,6 +37,7 @@ func ProvideBaseService(
 	measurementHistoryService service.MeasurementHistoryService,
 	expenseTrackerService service.ExpenseTrackerService,
 	taskService service.TaskService,
+	inventoryService service.InventoryService,
 ) BaseService {
 	return BaseService{
 		UserService:               user,
@@ -53,5 +55,6 @@ func ProvideBaseService(
 		MeasurementHistoryService: measurementHistoryService,
 		ExpenseTrackerService:     expenseTrackerService,
 		TaskService:               taskService,
+		InventoryService:          inventoryService,
 	}
 }
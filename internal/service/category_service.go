@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
 	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
 	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
@@ -17,6 +18,8 @@ type CategoryService interface {
 	GetAll(*context.Context, string) ([]responseModel.Category, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
 	AutocompleteCategory(*context.Context, string) ([]responseModel.CategoryAutoComplete, *errs.XError)
+	GetTree(*context.Context) ([]responseModel.Category, *errs.XError)
+	Move(*context.Context, uint, requestModel.CategoryMove) *errs.XError
 }
 
 type categoryService struct {
@@ -43,11 +46,33 @@ func (svc categoryService) SaveCategory(ctx *context.Context, category requestMo
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save category", err)
 	}
 
+	// The parent is locked so it cannot be moved while its new child takes its path
+	parentPath := ""
+	depth := 0
+	if dbCategory.ParentId != nil {
+		errr := svc.categoryRepo.Lock(ctx, *dbCategory.ParentId)
+		if errr != nil {
+			return errr
+		}
+		parent, errr := svc.getActive(ctx, *dbCategory.ParentId, "Parent category not found")
+		if errr != nil {
+			return errr
+		}
+		parentPath = parent.Path
+		depth = parent.Depth + 1
+	}
+
 	errr := svc.categoryRepo.Create(ctx, dbCategory)
 	if errr != nil {
 		return errr
 	}
 
+	// The path ends with the category's own id, known once it is saved
+	errr = svc.categoryRepo.SetPosition(ctx, dbCategory.ID, dbCategory.ParentId, entities.CategoryPath(parentPath, dbCategory.ID), depth)
+	if errr != nil {
+		return errr
+	}
+
 	return nil
 }
 
@@ -57,8 +82,18 @@ func (svc categoryService) UpdateCategory(ctx *context.Context, category request
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update category", err)
 	}
 
+	current, errr := svc.getActive(ctx, id, "Category not found")
+	if errr != nil {
+		return errr
+	}
+
+	// The position in the tree only changes through Move
 	dbCategory.ID = id
-	errr := svc.categoryRepo.Update(ctx, dbCategory)
+	dbCategory.ParentId = current.ParentId
+	dbCategory.Path = current.Path
+	dbCategory.Depth = current.Depth
+
+	errr = svc.categoryRepo.Update(ctx, dbCategory)
 	if errr != nil {
 		return errr
 	}
@@ -76,6 +111,14 @@ func (svc categoryService) Get(ctx *context.Context, id uint) (*responseModel.Ca
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Category data", mapErr)
 	}
 
+	if category.Model != nil {
+		names, errr := svc.ancestorNames(ctx, []entities.Category{*category})
+		if errr != nil {
+			return nil, errr
+		}
+		mappedCategory.Path = category.DisplayPath(names)
+	}
+
 	return mappedCategory, nil
 }
 
@@ -90,10 +133,26 @@ func (svc categoryService) GetAll(ctx *context.Context, search string) ([]respon
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Category data", mapErr)
 	}
 
+	names, errr := svc.ancestorNames(ctx, categories)
+	if errr != nil {
+		return nil, errr
+	}
+	for i := range categories {
+		mappedCategories[i].Path = categories[i].DisplayPath(names)
+	}
+
 	return mappedCategories, nil
 }
 
 func (svc categoryService) Delete(ctx *context.Context, id uint) *errs.XError {
+	children, errr := svc.categoryRepo.CountChildren(ctx, id)
+	if errr != nil {
+		return errr
+	}
+	if children > 0 {
+		return errs.NewXError(errs.VALIDATION, "Category has subcategories, move or delete them first", nil)
+	}
+
 	err := svc.categoryRepo.Delete(ctx, id)
 	if err != nil {
 		return err
@@ -107,13 +166,143 @@ func (svc categoryService) AutocompleteCategory(ctx *context.Context, search str
 		return nil, err
 	}
 
+	names, err := svc.ancestorNames(ctx, categories)
+	if err != nil {
+		return nil, err
+	}
+
 	res := make([]responseModel.CategoryAutoComplete, 0)
 	for _, category := range categories {
 		res = append(res, responseModel.CategoryAutoComplete{
 			ID:   category.ID,
 			Name: category.Name,
+			Path: category.DisplayPath(names),
 		})
 	}
 
 	return res, nil
 }
+
+// GetTree returns the root categories with their subcategories nested under them
+func (svc categoryService) GetTree(ctx *context.Context) ([]responseModel.Category, *errs.XError) {
+	categories, err := svc.categoryRepo.GetTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uint]string, len(categories))
+	children := make(map[uint][]entities.Category)
+	roots := make([]entities.Category, 0)
+	for _, category := range categories {
+		names[category.ID] = category.Name
+		if category.ParentId == nil {
+			roots = append(roots, category)
+			continue
+		}
+		children[*category.ParentId] = append(children[*category.ParentId], category)
+	}
+
+	var build func([]entities.Category) ([]responseModel.Category, error)
+	build = func(nodes []entities.Category) ([]responseModel.Category, error) {
+		result := make([]responseModel.Category, 0, len(nodes))
+		for _, node := range nodes {
+			mapped, err := svc.respMapper.Category(&node)
+			if err != nil {
+				return nil, err
+			}
+			mapped.Path = node.DisplayPath(names)
+			mapped.Children, err = build(children[node.ID])
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, *mapped)
+		}
+		return result, nil
+	}
+
+	tree, mapErr := build(roots)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Category data", mapErr)
+	}
+	return tree, nil
+}
+
+// Move re-parents the category along with its subcategories
+func (svc categoryService) Move(ctx *context.Context, id uint, request requestModel.CategoryMove) *errs.XError {
+	if request.ParentId != nil && *request.ParentId == id {
+		return errs.NewXError(errs.VALIDATION, "A category cannot be its own parent", nil)
+	}
+
+	// Moves are checked against the tree one at a time
+	err := svc.categoryRepo.LockTree(ctx)
+	if err != nil {
+		return err
+	}
+
+	category, err := svc.getActive(ctx, id, "Category not found")
+	if err != nil {
+		return err
+	}
+
+	parentPath := ""
+	depth := 0
+	if request.ParentId != nil {
+		parent, err := svc.getActive(ctx, *request.ParentId, "Parent category not found")
+		if err != nil {
+			return err
+		}
+		if category.Contains(parent) {
+			return errs.NewXError(errs.VALIDATION, "A category cannot be moved under itself or one of its subcategories", nil)
+		}
+		parentPath = parent.Path
+		depth = parent.Depth + 1
+	}
+
+	path := entities.CategoryPath(parentPath, id)
+	if path == category.Path {
+		return nil
+	}
+
+	err = svc.categoryRepo.MoveSubtree(ctx, category.Path, path, depth-category.Depth)
+	if err != nil {
+		return err
+	}
+
+	return svc.categoryRepo.SetPosition(ctx, id, request.ParentId, path, depth)
+}
+
+func (svc categoryService) getActive(ctx *context.Context, id uint, notFound string) (*entities.Category, *errs.XError) {
+	category, err := svc.categoryRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if category.Model == nil || !category.IsActive {
+		return nil, errs.NewXError(errs.NOT_EXIST, notFound, nil)
+	}
+	return category, nil
+}
+
+// ancestorNames loads the names of the categories above the given ones, to show their paths
+func (svc categoryService) ancestorNames(ctx *context.Context, categories []entities.Category) (map[uint]string, *errs.XError) {
+	ids := make([]uint, 0)
+	seen := make(map[uint]bool)
+	for _, category := range categories {
+		for _, id := range category.AncestorIds() {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	ancestors, err := svc.categoryRepo.GetByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uint]string, len(ancestors))
+	for _, ancestor := range ancestors {
+		names[ancestor.ID] = ancestor.Name
+	}
+	return names, nil
+}
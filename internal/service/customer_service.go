@@ -21,18 +21,20 @@ type CustomerService interface {
 }
 
 type customerService struct {
-	customerRepo repository.CustomerRepository
-	personRepo   repository.PersonRepository
-	mapper       mapper.Mapper
-	respMapper   mapper.ResponseMapper
+	customerRepo   repository.CustomerRepository
+	personRepo     repository.PersonRepository
+	measurementSvc MeasurementService
+	mapper         mapper.Mapper
+	respMapper     mapper.ResponseMapper
 }
 
-func ProvideCustomerService(repo repository.CustomerRepository, personRepo repository.PersonRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) CustomerService {
+func ProvideCustomerService(repo repository.CustomerRepository, personRepo repository.PersonRepository, measurementSvc MeasurementService, mapper mapper.Mapper, respMapper mapper.ResponseMapper) CustomerService {
 	return customerService{
-		customerRepo: repo,
-		personRepo:   personRepo,
-		mapper:       mapper,
-		respMapper:   respMapper,
+		customerRepo:   repo,
+		personRepo:     personRepo,
+		measurementSvc: measurementSvc,
+		mapper:         mapper,
+		respMapper:     respMapper,
 	}
 }
 
@@ -90,6 +92,14 @@ func (svc customerService) Get(ctx *context.Context, id uint) (*responseModel.Cu
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Customer data", mapErr)
 	}
 
+	persons := make([]*responseModel.Person, 0, len(mappedCustomer.Persons))
+	for i := range mappedCustomer.Persons {
+		persons = append(persons, &mappedCustomer.Persons[i])
+	}
+	if err := svc.measurementSvc.ShowInDisplayUnit(ctx, "", personMeasurements(persons...)); err != nil {
+		return nil, err
+	}
+
 	return mappedCustomer, nil
 }
 
@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
 	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
 	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
@@ -16,17 +19,27 @@ type DressTypeService interface {
 	Get(*context.Context, uint) (*responseModel.DressType, *errs.XError)
 	GetAll(*context.Context, string) ([]responseModel.DressType, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
+	GetBillOfMaterials(*context.Context, uint) ([]responseModel.DressTypeComponent, *errs.XError)
+	UpdateBillOfMaterials(*context.Context, uint, []requestModel.DressTypeComponent) *errs.XError
+	GetMeasurementSchema(*context.Context, uint) ([]responseModel.MeasurementField, *errs.XError)
+	UpdateMeasurementSchema(*context.Context, uint, []requestModel.MeasurementField) *errs.XError
 }
 
 type dressTypeService struct {
 	dressTypeRepo repository.DressTypeRepository
+	componentRepo repository.DressTypeComponentRepository
+	fieldRepo     repository.MeasurementFieldRepository
+	productRepo   repository.ProductRepository
 	mapper        mapper.Mapper
 	respMapper    mapper.ResponseMapper
 }
 
-func ProvideDressTypeService(repo repository.DressTypeRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) DressTypeService {
+func ProvideDressTypeService(repo repository.DressTypeRepository, componentRepo repository.DressTypeComponentRepository, fieldRepo repository.MeasurementFieldRepository, productRepo repository.ProductRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) DressTypeService {
 	return dressTypeService{
 		dressTypeRepo: repo,
+		componentRepo: componentRepo,
+		fieldRepo:     fieldRepo,
+		productRepo:   productRepo,
 		mapper:        mapper,
 		respMapper:    respMapper,
 	}
@@ -43,7 +56,11 @@ func (svc dressTypeService) SaveDressType(ctx *context.Context, dressType reques
 		return errr
 	}
 
-	return nil
+	fields := dressType.MeasurementFields
+	if len(fields) == 0 {
+		fields = measurementFieldsFromList(dressType.Measurements, nil)
+	}
+	return svc.replaceMeasurementSchema(ctx, dbDressType.ID, fields)
 }
 
 func (svc dressTypeService) UpdateDressType(ctx *context.Context, dressType requestModel.DressType, id uint) *errs.XError {
@@ -57,7 +74,20 @@ func (svc dressTypeService) UpdateDressType(ctx *context.Context, dressType requ
 	if errr != nil {
 		return errr
 	}
-	return nil
+
+	if len(dressType.MeasurementFields) > 0 {
+		return svc.replaceMeasurementSchema(ctx, id, dressType.MeasurementFields)
+	}
+	if dressType.Measurements == "" {
+		return nil
+	}
+
+	// Older clients only send the list, the bounds of the fields that stay on it are kept
+	existing, errr := svc.fieldRepo.GetByDressTypeId(ctx, id)
+	if errr != nil {
+		return errr
+	}
+	return svc.replaceMeasurementSchema(ctx, id, measurementFieldsFromList(dressType.Measurements, existing))
 }
 
 func (svc dressTypeService) Get(ctx *context.Context, id uint) (*responseModel.DressType, *errs.XError) {
@@ -95,3 +125,145 @@ func (svc dressTypeService) Delete(ctx *context.Context, id uint) *errs.XError {
 	}
 	return nil
 }
+
+func (svc dressTypeService) GetBillOfMaterials(ctx *context.Context, dressTypeId uint) ([]responseModel.DressTypeComponent, *errs.XError) {
+	components, err := svc.componentRepo.GetByDressTypeId(ctx, dressTypeId)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedComponents, mapErr := svc.respMapper.DressTypeComponents(components)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map bill of materials", mapErr)
+	}
+
+	return mappedComponents, nil
+}
+
+// UpdateBillOfMaterials replaces the products consumed to stitch one piece of the dress type
+func (svc dressTypeService) UpdateBillOfMaterials(ctx *context.Context, dressTypeId uint, components []requestModel.DressTypeComponent) *errs.XError {
+	dressType, err := svc.dressTypeRepo.Get(ctx, dressTypeId)
+	if err != nil {
+		return err
+	}
+	if dressType.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Dress type not found", nil)
+	}
+
+	seen := make(map[uint]bool)
+	for _, component := range components {
+		if component.Quantity <= 0 {
+			return errs.NewXError(errs.VALIDATION, "Component quantity must be greater than 0", nil)
+		}
+		if seen[component.ProductId] {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Product %d is listed more than once", component.ProductId), nil)
+		}
+		seen[component.ProductId] = true
+
+		product, err := svc.productRepo.Get(ctx, component.ProductId)
+		if err != nil {
+			return err
+		}
+		if product.Model == nil {
+			return errs.NewXError(errs.NOT_EXIST, fmt.Sprintf("Product %d not found", component.ProductId), nil)
+		}
+	}
+
+	dbComponents, mapErr := svc.mapper.DressTypeComponents(dressTypeId, components)
+	if mapErr != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update bill of materials", mapErr)
+	}
+
+	return svc.componentRepo.ReplaceForDressType(ctx, dressTypeId, dbComponents)
+}
+
+func (svc dressTypeService) GetMeasurementSchema(ctx *context.Context, dressTypeId uint) ([]responseModel.MeasurementField, *errs.XError) {
+	fields, err := svc.fieldRepo.GetByDressTypeId(ctx, dressTypeId)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedFields, mapErr := svc.respMapper.MeasurementFields(fields)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map measurement schema", mapErr)
+	}
+
+	return mappedFields, nil
+}
+
+// UpdateMeasurementSchema replaces the measurements taken for the dress type
+func (svc dressTypeService) UpdateMeasurementSchema(ctx *context.Context, dressTypeId uint, fields []requestModel.MeasurementField) *errs.XError {
+	dressType, err := svc.dressTypeRepo.Get(ctx, dressTypeId)
+	if err != nil {
+		return err
+	}
+	if dressType.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Dress type not found", nil)
+	}
+
+	return svc.replaceMeasurementSchema(ctx, dressTypeId, fields)
+}
+
+func (svc dressTypeService) replaceMeasurementSchema(ctx *context.Context, dressTypeId uint, fields []requestModel.MeasurementField) *errs.XError {
+	dbFields, mapErr := svc.mapper.MeasurementFields(dressTypeId, fields)
+	if mapErr != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update measurement schema", mapErr)
+	}
+
+	seen := make(map[string]bool)
+	for _, field := range dbFields {
+		if field.Label == "" {
+			return errs.NewXError(errs.VALIDATION, "Measurement label is required", nil)
+		}
+		if !entities.IsValidMeasurementFieldKey(field.Key) {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Key of %s must start with a letter and have only lower case letters, digits and underscores", field.Label), nil)
+		}
+		if seen[field.Key] {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Measurement %s is listed more than once", field.Key), nil)
+		}
+		seen[field.Key] = true
+
+		if !field.Unit.IsValid() {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Unit of %s must be one of INCH, CM", field.Label), nil)
+		}
+		if (field.MinValue != nil && *field.MinValue <= 0) || (field.MaxValue != nil && *field.MaxValue <= 0) {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Bounds of %s must be greater than 0", field.Label), nil)
+		}
+		if field.MinValue != nil && field.MaxValue != nil && *field.MinValue > *field.MaxValue {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Minimum of %s cannot be more than its maximum", field.Label), nil)
+		}
+	}
+
+	return svc.fieldRepo.ReplaceForDressType(ctx, dressTypeId, dbFields)
+}
+
+// measurementFieldsFromList makes a schema from a list of labels like "Hip, Waist, Chest"
+func measurementFieldsFromList(list string, existing []entities.MeasurementField) []requestModel.MeasurementField {
+	byKey := make(map[string]entities.MeasurementField, len(existing))
+	for _, field := range existing {
+		byKey[field.Key] = field
+	}
+
+	fields := make([]requestModel.MeasurementField, 0)
+	for _, label := range strings.Split(list, ",") {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+
+		field := requestModel.MeasurementField{Label: label}
+		if current, ok := byKey[entities.MeasurementFieldKey(label)]; ok {
+			field = requestModel.MeasurementField{
+				Key:       current.Key,
+				Label:     label,
+				Unit:      string(current.Unit),
+				MinValue:  current.MinValue,
+				MaxValue:  current.MaxValue,
+				Required:  current.Required,
+				GroupName: current.GroupName,
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
@@ -46,13 +46,23 @@ func (svc expenseTrackerService) SaveExpenseTracker(ctx *context.Context, expens
 }
 
 func (svc expenseTrackerService) UpdateExpenseTracker(ctx *context.Context, expenseTracker requestModel.ExpenseTracker, id uint) *errs.XError {
+	existing, errr := svc.expenseTrackerRepo.Get(ctx, id)
+	if errr != nil {
+		return errr
+	}
+	if existing.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Expense not found", nil)
+	}
+
 	dbExpenseTracker, err := svc.mapper.ExpenseTracker(expenseTracker)
 	if err != nil {
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update expense tracker", err)
 	}
 
 	dbExpenseTracker.ID = id
-	errr := svc.expenseTrackerRepo.Update(ctx, dbExpenseTracker)
+	// The purchase order link is set by receiving stock and cannot be changed here
+	dbExpenseTracker.PurchaseOrderId = existing.PurchaseOrderId
+	errr = svc.expenseTrackerRepo.Update(ctx, dbExpenseTracker)
 	if errr != nil {
 		return errr
 	}
@@ -0,0 +1,703 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/errs"
+)
+
+// stockStore keeps the stock tables of one channel in memory, the fake repositories below share it
+type stockStore struct {
+	products     map[uint]*entities.Product
+	inventories  map[uint]*entities.Inventory // By product id
+	lots         []*entities.InventoryLot
+	logs         []*entities.InventoryLog
+	reservations []*entities.StockReservation
+	components   map[uint][]entities.DressTypeComponent // By dress type id
+	orders       map[uint]*entities.Order
+	orderHistory []*entities.OrderHistory
+	locks        []uint // Product ids, in the order their inventory rows were locked
+	logLocks     []uint // Inventory log ids, in the order they were locked
+	lastId       uint
+
+	purchaseOrders     map[uint]*entities.PurchaseOrder
+	purchaseOrderLocks []uint
+	stockTransfers     map[uint]*entities.StockTransfer
+	stockTransferLocks []uint
+	sales              map[uint]*entities.Sale
+	stockTakes         map[uint]*entities.StockTake
+	stockTakeLocks     []uint
+	expenses           []*entities.Expense
+	expenseDetails     []*entities.ExpenseDetail
+}
+
+func newStockStore() *stockStore {
+	return &stockStore{
+		products:    map[uint]*entities.Product{},
+		inventories: map[uint]*entities.Inventory{},
+		components:  map[uint][]entities.DressTypeComponent{},
+		orders:      map[uint]*entities.Order{},
+
+		purchaseOrders: map[uint]*entities.PurchaseOrder{},
+		stockTransfers: map[uint]*entities.StockTransfer{},
+		sales:          map[uint]*entities.Sale{},
+		stockTakes:     map[uint]*entities.StockTake{},
+	}
+}
+
+func (s *stockStore) nextId() uint {
+	s.lastId++
+	return s.lastId
+}
+
+func (s *stockStore) addProduct(name string, unit entities.UnitOfMeasure) *entities.Product {
+	product := &entities.Product{Model: &entities.Model{ID: s.nextId(), IsActive: true}, Name: name, Unit: unit}
+	s.products[product.ID] = product
+	return product
+}
+
+// addLot receives quantity into a new lot and onto the inventory of the product
+func (s *stockStore) addLot(productId uint, lotCode string, quantity float64) *entities.InventoryLot {
+	lot := &entities.InventoryLot{
+		Model:             &entities.Model{ID: s.nextId(), IsActive: true},
+		ProductId:         productId,
+		LotCode:           lotCode,
+		Quantity:          quantity,
+		RemainingQuantity: quantity,
+	}
+	s.lots = append(s.lots, lot)
+	s.inventory(productId).Quantity += quantity
+	return lot
+}
+
+func (s *stockStore) inventory(productId uint) *entities.Inventory {
+	inventory, ok := s.inventories[productId]
+	if !ok {
+		inventory = &entities.Inventory{Model: &entities.Model{ID: s.nextId(), IsActive: true}, ProductId: productId}
+		s.inventories[productId] = inventory
+	}
+	return inventory
+}
+
+func (s *stockStore) onHand(productId uint) float64 {
+	return s.inventory(productId).Quantity
+}
+
+func (s *stockStore) logsFor(productId uint) []*entities.InventoryLog {
+	var logs []*entities.InventoryLog
+	for _, log := range s.logs {
+		if log.ProductId == productId {
+			logs = append(logs, log)
+		}
+	}
+	return logs
+}
+
+// addOrder saves an order of one item per dress type, each made pieces times
+func (s *stockStore) addOrder(status entities.OrderStatus, pieces int, dressTypeIds ...uint) *entities.Order {
+	order := &entities.Order{Model: &entities.Model{ID: s.nextId(), IsActive: true}, Status: status}
+	for _, dressTypeId := range dressTypeIds {
+		order.OrderItems = append(order.OrderItems, entities.OrderItem{
+			Model:       &entities.Model{ID: s.nextId(), IsActive: true},
+			OrderId:     order.ID,
+			Quantity:    pieces,
+			Measurement: &entities.Measurement{DressTypeId: dressTypeId},
+		})
+	}
+	s.orders[order.ID] = order
+	return order
+}
+
+func (s *stockStore) reservedFor(orderId uint) float64 {
+	var reserved float64
+	for _, reservation := range s.reservations {
+		if reservation.OrderId == orderId && reservation.Status == entities.RESERVATION_RESERVED {
+			reserved += reservation.Quantity
+		}
+	}
+	return entities.RoundQuantity(reserved)
+}
+
+func testContext() *context.Context {
+	return channelContext(1)
+}
+
+func channelContext(channelId uint) *context.Context {
+	ctx := context.Background()
+	ctx = utils.NewChannelSession(&ctx, entities.Channel{Model: &entities.Model{ID: channelId}, OwnerUserID: 1})
+	return &ctx
+}
+
+func newTestReservationService(store *stockStore) stockReservationService {
+	return stockReservationService{
+		reservationRepo:  fakeStockReservationRepo{store: store},
+		orderRepo:        fakeOrderRepo{store: store},
+		componentRepo:    fakeDressTypeComponentRepo{store: store},
+		inventoryLogRepo: fakeInventoryLogRepo{store: store},
+		inventoryRepo:    fakeInventoryRepo{store: store},
+	}
+}
+
+func newTestInventoryService(store *stockStore) inventoryService {
+	return inventoryService{
+		inventoryRepo:    fakeInventoryRepo{store: store},
+		inventoryLogRepo: fakeInventoryLogRepo{store: store},
+		inventoryLotRepo: fakeInventoryLotRepo{store: store},
+		productRepo:      fakeProductRepo{store: store},
+		reservationRepo:  fakeStockReservationRepo{store: store},
+		reservationSvc:   newTestReservationService(store),
+	}
+}
+
+type fakeProductRepo struct {
+	repository.ProductRepository
+	store *stockStore
+}
+
+func (r fakeProductRepo) Get(ctx *context.Context, id uint) (*entities.Product, *errs.XError) {
+	if product, ok := r.store.products[id]; ok {
+		return product, nil
+	}
+	return &entities.Product{}, nil
+}
+
+type fakeInventoryRepo struct {
+	repository.InventoryRepository
+	store *stockStore
+}
+
+func (r fakeInventoryRepo) EnsureForProduct(ctx *context.Context, productId uint) *errs.XError {
+	r.store.inventory(productId)
+	return nil
+}
+
+func (r fakeInventoryRepo) LockByProductId(ctx *context.Context, productId uint) (*entities.Inventory, *errs.XError) {
+	r.store.locks = append(r.store.locks, productId)
+	inventory := *r.store.inventory(productId)
+	return &inventory, nil
+}
+
+func (r fakeInventoryRepo) AdjustQuantity(ctx *context.Context, productId uint, change float64) *errs.XError {
+	inventory := r.store.inventory(productId)
+	inventory.Quantity = entities.RoundQuantity(inventory.Quantity + change)
+	return nil
+}
+
+type fakeInventoryLogRepo struct {
+	repository.InventoryLogRepository
+	store *stockStore
+}
+
+func (r fakeInventoryLogRepo) Create(ctx *context.Context, log *entities.InventoryLog) *errs.XError {
+	log.ID = r.store.nextId()
+	log.ChannelId = utils.GetChannelId(ctx)
+	r.store.logs = append(r.store.logs, log)
+	return nil
+}
+
+// Get returns a copy of the log with its product and order item, as the repository preloads them
+func (r fakeInventoryLogRepo) Get(ctx *context.Context, id uint) (*entities.InventoryLog, *errs.XError) {
+	for _, log := range r.store.logs {
+		if log.ID != id {
+			continue
+		}
+		found := *log
+		found.Product = r.store.products[log.ProductId]
+		if log.OrderItemId != nil {
+			for _, order := range r.store.orders {
+				for i := range order.OrderItems {
+					if order.OrderItems[i].ID == *log.OrderItemId {
+						found.OrderItem = &order.OrderItems[i]
+					}
+				}
+			}
+		}
+		return &found, nil
+	}
+	return &entities.InventoryLog{}, nil
+}
+
+func (r fakeInventoryLogRepo) Lock(ctx *context.Context, id uint) *errs.XError {
+	r.store.logLocks = append(r.store.logLocks, id)
+	return nil
+}
+
+func (r fakeInventoryLogRepo) MarkReversed(ctx *context.Context, id uint, reversalId uint) *errs.XError {
+	for _, log := range r.store.logs {
+		if log.ID == id {
+			if log.ReversedByLogId != nil {
+				return errs.NewXError(errs.VALIDATION, "Stock movement has already been reversed", nil)
+			}
+			log.ReversedByLogId = &reversalId
+		}
+	}
+	return nil
+}
+
+// GetByStockTransferId returns the logs of the transfer with their lots, in any channel
+func (r fakeInventoryLogRepo) GetByStockTransferId(ctx *context.Context, transferId uint, changeType entities.InventoryLogChangeType) ([]entities.InventoryLog, *errs.XError) {
+	var logs []entities.InventoryLog
+	for _, log := range r.store.logs {
+		if log.StockTransferId == nil || *log.StockTransferId != transferId || log.ChangeType != changeType {
+			continue
+		}
+		found := *log
+		for _, lot := range r.store.lots {
+			if log.LotId != nil && lot.ID == *log.LotId {
+				found.Lot = lot
+			}
+		}
+		logs = append(logs, found)
+	}
+	return logs, nil
+}
+
+// GetLoggedBefore returns the logs with their products by product, in the order they were logged
+func (r fakeInventoryLogRepo) GetLoggedBefore(ctx *context.Context, before time.Time, categoryId *uint) ([]entities.InventoryLog, *errs.XError) {
+	var logs []entities.InventoryLog
+	for _, log := range r.store.logs {
+		if log.LoggedAt.Before(before) {
+			found := *log
+			found.Product = r.store.products[log.ProductId]
+			logs = append(logs, found)
+		}
+	}
+	sort.SliceStable(logs, func(i, j int) bool { return logs[i].ProductId < logs[j].ProductId })
+	return logs, nil
+}
+
+func (r fakeInventoryLogRepo) GetByIdempotencyKey(ctx *context.Context, key string) ([]entities.InventoryLog, *errs.XError) {
+	var logs []entities.InventoryLog
+	for _, log := range r.store.logs {
+		if log.IdempotencyKey != nil && *log.IdempotencyKey == key {
+			logs = append(logs, *log)
+		}
+	}
+	return logs, nil
+}
+
+// GetBookedOutByOrderItems sums the OUT logs per order item and product, net of reversals
+func (r fakeInventoryLogRepo) GetBookedOutByOrderItems(ctx *context.Context, orderItemIds []uint) (map[uint]map[uint]float64, *errs.XError) {
+	bookedOut := make(map[uint]map[uint]float64)
+	for _, log := range r.store.logs {
+		if log.OrderItemId == nil {
+			continue
+		}
+		quantity := 0.0
+		switch {
+		case log.ChangeType == entities.InventoryLogChangeTypeOUT:
+			quantity = log.Quantity
+		case log.ChangeType == entities.InventoryLogChangeTypeIN && log.ReversalOfLogId != nil:
+			quantity = -log.Quantity
+		}
+		for _, id := range orderItemIds {
+			if id == *log.OrderItemId {
+				if bookedOut[id] == nil {
+					bookedOut[id] = make(map[uint]float64)
+				}
+				bookedOut[id][log.ProductId] += quantity
+			}
+		}
+	}
+	return bookedOut, nil
+}
+
+type fakeInventoryLotRepo struct {
+	repository.InventoryLotRepository
+	store *stockStore
+}
+
+func (r fakeInventoryLotRepo) Create(ctx *context.Context, lot *entities.InventoryLot) *errs.XError {
+	lot.ID = r.store.nextId()
+	r.store.lots = append(r.store.lots, lot)
+	return nil
+}
+
+func (r fakeInventoryLotRepo) Get(ctx *context.Context, id uint) (*entities.InventoryLot, *errs.XError) {
+	for _, lot := range r.store.lots {
+		if lot.ID == id {
+			return lot, nil
+		}
+	}
+	return &entities.InventoryLot{}, nil
+}
+
+// GetAvailable returns the lots with stock left, oldest first
+func (r fakeInventoryLotRepo) GetAvailable(ctx *context.Context, productId uint) ([]entities.InventoryLot, *errs.XError) {
+	var lots []entities.InventoryLot
+	for _, lot := range r.store.lots {
+		if lot.ProductId == productId && lot.RemainingQuantity > 0 {
+			lots = append(lots, *lot)
+		}
+	}
+	sort.SliceStable(lots, func(i, j int) bool { return lots[i].ReceivedAt.Before(lots[j].ReceivedAt) })
+	return lots, nil
+}
+
+func (r fakeInventoryLotRepo) AdjustRemaining(ctx *context.Context, id uint, change float64) *errs.XError {
+	for _, lot := range r.store.lots {
+		if lot.ID == id {
+			lot.RemainingQuantity = entities.RoundQuantity(lot.RemainingQuantity + change)
+		}
+	}
+	return nil
+}
+
+type fakeStockReservationRepo struct {
+	repository.StockReservationRepository
+	store *stockStore
+}
+
+func (r fakeStockReservationRepo) CreateAll(ctx *context.Context, reservations []entities.StockReservation) *errs.XError {
+	for i := range reservations {
+		reservation := reservations[i]
+		if reservation.Model == nil {
+			reservation.Model = &entities.Model{IsActive: true}
+		}
+		reservation.ID = r.store.nextId()
+		r.store.reservations = append(r.store.reservations, &reservation)
+	}
+	return nil
+}
+
+func (r fakeStockReservationRepo) ReleaseByOrderId(ctx *context.Context, orderId uint) *errs.XError {
+	for _, reservation := range r.store.reservations {
+		if reservation.OrderId == orderId && reservation.Status == entities.RESERVATION_RESERVED {
+			reservation.Status = entities.RESERVATION_RELEASED
+		}
+	}
+	return nil
+}
+
+func (r fakeStockReservationRepo) ConsumeByOrderItemId(ctx *context.Context, orderItemId uint, productId uint) *errs.XError {
+	for _, reservation := range r.store.reservations {
+		if reservation.OrderItemId == orderItemId && reservation.ProductId == productId && reservation.Status == entities.RESERVATION_RESERVED {
+			reservation.Status = entities.RESERVATION_CONSUMED
+		}
+	}
+	return nil
+}
+
+func (r fakeStockReservationRepo) GetReservedQuantity(ctx *context.Context, productId uint, exceptOrderItemId *uint) (float64, *errs.XError) {
+	var reserved float64
+	for _, reservation := range r.store.reservations {
+		if reservation.ProductId != productId || reservation.Status != entities.RESERVATION_RESERVED {
+			continue
+		}
+		if exceptOrderItemId != nil && reservation.OrderItemId == *exceptOrderItemId {
+			continue
+		}
+		reserved += reservation.Quantity
+	}
+	return entities.RoundQuantity(reserved), nil
+}
+
+func (r fakeStockReservationRepo) GetReservedByOrderId(ctx *context.Context, orderId uint) (map[uint]float64, *errs.XError) {
+	reserved := make(map[uint]float64)
+	for _, reservation := range r.store.reservations {
+		if reservation.OrderId == orderId && reservation.Status == entities.RESERVATION_RESERVED {
+			reserved[reservation.ProductId] += reservation.Quantity
+		}
+	}
+	return reserved, nil
+}
+
+type fakeOrderRepo struct {
+	repository.OrderRepository
+	store *stockStore
+}
+
+func (r fakeOrderRepo) Get(ctx *context.Context, id uint) (*entities.Order, *errs.XError) {
+	if order, ok := r.store.orders[id]; ok {
+		return order, nil
+	}
+	return &entities.Order{}, nil
+}
+
+func (r fakeOrderRepo) UpdateStatus(ctx *context.Context, id uint, status entities.OrderStatus, deliveredDate *time.Time) *errs.XError {
+	order := r.store.orders[id]
+	order.Status, order.DeliveredDate = status, deliveredDate
+	return nil
+}
+
+func (r fakeOrderRepo) Lock(ctx *context.Context, id uint) *errs.XError {
+	return nil
+}
+
+func (r fakeOrderRepo) GetLastInvoiceSequence(ctx *context.Context, channelId uint) (uint, *errs.XError) {
+	var last uint
+	for _, order := range r.store.orders {
+		if order.ChannelId == channelId && order.InvoiceSequence != nil && *order.InvoiceSequence > last {
+			last = *order.InvoiceSequence
+		}
+	}
+	return last, nil
+}
+
+func (r fakeOrderRepo) SetInvoice(ctx *context.Context, id uint, sequence uint, invoicedAt time.Time) *errs.XError {
+	order := r.store.orders[id]
+	order.InvoiceSequence = &sequence
+	order.InvoiceNumber = entities.InvoiceNumber(sequence)
+	order.InvoicedAt = &invoicedAt
+	return nil
+}
+
+type fakeOrderItemRepo struct {
+	repository.OrderItemRepository
+	store *stockStore
+}
+
+func (r fakeOrderItemRepo) item(id uint) (*entities.Order, *entities.OrderItem) {
+	for _, order := range r.store.orders {
+		for i := range order.OrderItems {
+			if order.OrderItems[i].ID == id {
+				return order, &order.OrderItems[i]
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Get returns a copy of the order item with its order, as the repository preloads it
+func (r fakeOrderItemRepo) Get(ctx *context.Context, id uint) (*entities.OrderItem, *errs.XError) {
+	order, stored := r.item(id)
+	if stored == nil {
+		return &entities.OrderItem{}, nil
+	}
+	orderItem := *stored
+	orderItem.Order = order
+	return &orderItem, nil
+}
+
+func (r fakeOrderItemRepo) GetByOrderId(ctx *context.Context, orderId uint) ([]entities.OrderItem, *errs.XError) {
+	return append([]entities.OrderItem(nil), r.store.orders[orderId].OrderItems...), nil
+}
+
+func (r fakeOrderItemRepo) UpdateStage(ctx *context.Context, id uint, stage entities.OrderItemStage, at time.Time, assignedTailorId *uint) *errs.XError {
+	_, orderItem := r.item(id)
+	orderItem.Stage = stage
+	if stage == entities.STAGE_CUTTING {
+		orderItem.CuttingAt = &at
+	}
+	if assignedTailorId != nil {
+		orderItem.AssignedTailorId = assignedTailorId
+	}
+	return nil
+}
+
+type fakeOrderHistoryRepo struct {
+	repository.OrderHistoryRepository
+	store *stockStore
+}
+
+func (r fakeOrderHistoryRepo) Create(ctx *context.Context, history *entities.OrderHistory) *errs.XError {
+	history.ID = r.store.nextId()
+	r.store.orderHistory = append(r.store.orderHistory, history)
+	return nil
+}
+
+// fakeMasterConfigService holds the configs set by name, the defaults apply to the rest
+type fakeMasterConfigService struct {
+	MasterConfigService
+	values map[string]string
+}
+
+func (svc fakeMasterConfigService) GetByName(ctx *context.Context, name string) (string, *errs.XError) {
+	return svc.values[name], nil
+}
+
+type fakeChannelRepo struct {
+	repository.ChannelRepository
+}
+
+func (r fakeChannelRepo) Lock(ctx *context.Context, id uint) *errs.XError {
+	return nil
+}
+
+type fakeDressTypeComponentRepo struct {
+	repository.DressTypeComponentRepository
+	store *stockStore
+}
+
+func (r fakeDressTypeComponentRepo) GetByDressTypeId(ctx *context.Context, dressTypeId uint) ([]entities.DressTypeComponent, *errs.XError) {
+	return r.store.components[dressTypeId], nil
+}
+
+type fakePurchaseOrderRepo struct {
+	repository.PurchaseOrderRepository
+	store *stockStore
+}
+
+// Get returns a copy of the purchase order with the products of its lines
+func (r fakePurchaseOrderRepo) Get(ctx *context.Context, id uint) (*entities.PurchaseOrder, *errs.XError) {
+	stored, ok := r.store.purchaseOrders[id]
+	if !ok {
+		return &entities.PurchaseOrder{}, nil
+	}
+	purchaseOrder := *stored
+	purchaseOrder.Lines = append([]entities.PurchaseOrderLine(nil), stored.Lines...)
+	for i := range purchaseOrder.Lines {
+		purchaseOrder.Lines[i].Product = r.store.products[purchaseOrder.Lines[i].ProductId]
+	}
+	return &purchaseOrder, nil
+}
+
+func (r fakePurchaseOrderRepo) Lock(ctx *context.Context, id uint) *errs.XError {
+	r.store.purchaseOrderLocks = append(r.store.purchaseOrderLocks, id)
+	return nil
+}
+
+func (r fakePurchaseOrderRepo) AddReceivedQuantity(ctx *context.Context, lineId uint, quantity float64) *errs.XError {
+	for _, purchaseOrder := range r.store.purchaseOrders {
+		for i := range purchaseOrder.Lines {
+			if line := &purchaseOrder.Lines[i]; line.ID == lineId {
+				line.ReceivedQuantity = entities.RoundQuantity(line.ReceivedQuantity + quantity)
+			}
+		}
+	}
+	return nil
+}
+
+func (r fakePurchaseOrderRepo) UpdateStatus(ctx *context.Context, id uint, status entities.PurchaseOrderStatus) *errs.XError {
+	r.store.purchaseOrders[id].Status = status
+	return nil
+}
+
+type fakeExpenseRepo struct {
+	repository.ExpenseTrackerRepository
+	store *stockStore
+}
+
+func (r fakeExpenseRepo) Create(ctx *context.Context, expense *entities.Expense) *errs.XError {
+	expense.ID = r.store.nextId()
+	r.store.expenses = append(r.store.expenses, expense)
+	return nil
+}
+
+func (r fakeExpenseRepo) RecalculateAndUpdateBalance(ctx *context.Context, expenseId uint) *errs.XError {
+	for _, expense := range r.store.expenses {
+		if expense.ID != expenseId {
+			continue
+		}
+		expense.Balance = expense.Price
+		for _, detail := range r.store.expenseDetails {
+			if detail.ExpenseId == expenseId {
+				expense.Balance -= detail.Price
+			}
+		}
+	}
+	return nil
+}
+
+type fakeExpenseDetailRepo struct {
+	repository.ExpenseDetailRepository
+	store *stockStore
+}
+
+func (r fakeExpenseDetailRepo) Create(ctx *context.Context, detail *entities.ExpenseDetail) *errs.XError {
+	detail.ID = r.store.nextId()
+	r.store.expenseDetails = append(r.store.expenseDetails, detail)
+	return nil
+}
+
+type fakeStockTransferRepo struct {
+	repository.StockTransferRepository
+	store *stockStore
+}
+
+func (r fakeStockTransferRepo) Get(ctx *context.Context, id uint) (*entities.StockTransfer, *errs.XError) {
+	if transfer, ok := r.store.stockTransfers[id]; ok {
+		found := *transfer
+		return &found, nil
+	}
+	return &entities.StockTransfer{}, nil
+}
+
+func (r fakeStockTransferRepo) Lock(ctx *context.Context, id uint) *errs.XError {
+	r.store.stockTransferLocks = append(r.store.stockTransferLocks, id)
+	return nil
+}
+
+func (r fakeStockTransferRepo) UpdateStatus(ctx *context.Context, id uint, status entities.StockTransferStatus) *errs.XError {
+	r.store.stockTransfers[id].Status = status
+	return nil
+}
+
+type fakeSaleRepo struct {
+	repository.SaleRepository
+	store *stockStore
+}
+
+func (r fakeSaleRepo) Create(ctx *context.Context, sale *entities.Sale) *errs.XError {
+	sale.ID = r.store.nextId()
+	for i := range sale.Lines {
+		sale.Lines[i].ID = r.store.nextId()
+		sale.Lines[i].SaleId = sale.ID
+	}
+	r.store.sales[sale.ID] = sale
+	return nil
+}
+
+func (r fakeSaleRepo) Get(ctx *context.Context, id uint) (*entities.Sale, *errs.XError) {
+	if sale, ok := r.store.sales[id]; ok {
+		return sale, nil
+	}
+	return &entities.Sale{}, nil
+}
+
+type fakeStockTakeRepo struct {
+	repository.StockTakeRepository
+	store *stockStore
+}
+
+// Get returns a copy of the stock take with the products of its lines
+func (r fakeStockTakeRepo) Get(ctx *context.Context, id uint) (*entities.StockTake, *errs.XError) {
+	stored, ok := r.store.stockTakes[id]
+	if !ok {
+		return &entities.StockTake{}, nil
+	}
+	stockTake := *stored
+	stockTake.Lines = append([]entities.StockTakeLine(nil), stored.Lines...)
+	for i := range stockTake.Lines {
+		stockTake.Lines[i].Product = r.store.products[stockTake.Lines[i].ProductId]
+	}
+	return &stockTake, nil
+}
+
+func (r fakeStockTakeRepo) Lock(ctx *context.Context, id uint) *errs.XError {
+	r.store.stockTakeLocks = append(r.store.stockTakeLocks, id)
+	return nil
+}
+
+func (r fakeStockTakeRepo) UpdateCount(ctx *context.Context, stockTakeId uint, productId uint, counted float64) (bool, *errs.XError) {
+	found := false
+	for i := range r.store.stockTakes[stockTakeId].Lines {
+		if line := &r.store.stockTakes[stockTakeId].Lines[i]; line.ProductId == productId {
+			line.CountedQuantity = &counted
+			found = true
+		}
+	}
+	return found, nil
+}
+
+func (r fakeStockTakeRepo) UpdatePostedVariance(ctx *context.Context, lineId uint, variance float64) *errs.XError {
+	for _, stockTake := range r.store.stockTakes {
+		for i := range stockTake.Lines {
+			if line := &stockTake.Lines[i]; line.ID == lineId {
+				line.PostedVariance = &variance
+			}
+		}
+	}
+	return nil
+}
+
+func (r fakeStockTakeRepo) UpdateStatus(ctx *context.Context, id uint, status entities.StockTakeStatus, reason string, postedAt *time.Time) *errs.XError {
+	stockTake := r.store.stockTakes[id]
+	stockTake.Status, stockTake.Reason, stockTake.PostedAt = status, reason, postedAt
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
 	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
 	"github.com/imkarthi24/sf-backend/internal/repository"
 	"github.com/loop-kar/pixie/errs"
@@ -16,22 +17,26 @@ type InventoryLogService interface {
 	GetByProductId(*context.Context, uint) ([]responseModel.InventoryLog, *errs.XError)
 	GetByChangeType(*context.Context, string) ([]responseModel.InventoryLog, *errs.XError)
 	GetByDateRange(*context.Context, string, string) ([]responseModel.InventoryLog, *errs.XError)
+	Reverse(*context.Context, uint, requestModel.InventoryLogReversal) (*responseModel.InventoryLog, *errs.XError)
 }
 
 type inventoryLogService struct {
 	inventoryLogRepo repository.InventoryLogRepository
 	inventoryRepo    repository.InventoryRepository
+	inventorySvc     InventoryService
 	respMapper       mapper.ResponseMapper
 }
 
 func ProvideInventoryLogService(
 	repo repository.InventoryLogRepository,
 	inventoryRepo repository.InventoryRepository,
+	inventorySvc InventoryService,
 	respMapper mapper.ResponseMapper,
 ) InventoryLogService {
 	return inventoryLogService{
 		inventoryLogRepo: repo,
 		inventoryRepo:    inventoryRepo,
+		inventorySvc:     inventorySvc,
 		respMapper:       respMapper,
 	}
 }
@@ -105,3 +110,12 @@ func (svc inventoryLogService) GetByDateRange(ctx *context.Context, startDate st
 
 	return mappedLogs, nil
 }
+
+// Reverse books a reversing entry for a mistaken movement and returns it
+func (svc inventoryLogService) Reverse(ctx *context.Context, id uint, reversal requestModel.InventoryLogReversal) (*responseModel.InventoryLog, *errs.XError) {
+	reversalId, err := svc.inventorySvc.ReverseStockMovement(ctx, id, reversal.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return svc.Get(ctx, reversalId)
+}
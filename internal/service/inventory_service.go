@@ -3,12 +3,18 @@ package service
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/imkarthi24/sf-backend/internal/constants"
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
 	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
 	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
 	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
 	"github.com/loop-kar/pixie/errs"
 	"github.com/loop-kar/pixie/util"
 )
@@ -18,33 +24,64 @@ type InventoryService interface {
 	GetAll(*context.Context, string) ([]responseModel.Inventory, *errs.XError)
 	GetByProductId(*context.Context, uint) (*responseModel.Inventory, *errs.XError)
 	UpdateThreshold(*context.Context, requestModel.Inventory, uint) *errs.XError
-	GetLowStockItems(*context.Context) ([]responseModel.LowStockItem, *errs.XError)
+	GetLowStockItems(*context.Context, *uint) ([]responseModel.LowStockItem, *errs.XError)
+	GetConsolidated(*context.Context, *uint) ([]responseModel.ConsolidatedStock, *errs.XError)
+	GetReorderSuggestions(*context.Context, *uint) ([]responseModel.ReorderSuggestion, *errs.XError)
+	GetLowStockAlerts(*context.Context) ([]responseModel.LowStockAlert, *errs.XError)
+	SendLowStockAlert(*context.Context, responseModel.LowStockAlert) *errs.XError
+	GetLots(*context.Context, uint, bool) ([]responseModel.InventoryLot, *errs.XError)
 
 	// Stock movement operations
 	RecordStockMovement(*context.Context, requestModel.StockMovementRequest) (*responseModel.StockMovementResponse, *errs.XError)
+	ReverseStockMovement(*context.Context, uint, string) (uint, *errs.XError)
+
+	// Valuation
+	GetValuation(*context.Context, *time.Time, *uint) (*responseModel.InventoryValuation, *errs.XError)
+	GetCOGSReport(*context.Context, *time.Time, *time.Time, *uint) (*responseModel.COGSReport, *errs.XError)
 }
 
 type inventoryService struct {
-	inventoryRepo    repository.InventoryRepository
-	inventoryLogRepo repository.InventoryLogRepository
-	productRepo      repository.ProductRepository
-	mapper           mapper.Mapper
-	respMapper       mapper.ResponseMapper
+	inventoryRepo     repository.InventoryRepository
+	inventoryLogRepo  repository.InventoryLogRepository
+	inventoryLotRepo  repository.InventoryLotRepository
+	productRepo       repository.ProductRepository
+	stockTransferRepo repository.StockTransferRepository
+	reservationRepo   repository.StockReservationRepository
+	reservationSvc    StockReservationService
+	channelRepo       repository.ChannelRepository
+	masterConfigSvc   MasterConfigService
+	notificationSvc   NotificationService
+	mapper            mapper.Mapper
+	respMapper        mapper.ResponseMapper
 }
 
 func ProvideInventoryService(
 	repo repository.InventoryRepository,
 	logRepo repository.InventoryLogRepository,
+	lotRepo repository.InventoryLotRepository,
 	productRepo repository.ProductRepository,
+	stockTransferRepo repository.StockTransferRepository,
+	reservationRepo repository.StockReservationRepository,
+	reservationSvc StockReservationService,
+	channelRepo repository.ChannelRepository,
+	masterConfigSvc MasterConfigService,
+	notificationSvc NotificationService,
 	mapper mapper.Mapper,
 	respMapper mapper.ResponseMapper,
 ) InventoryService {
 	return inventoryService{
-		inventoryRepo:    repo,
-		inventoryLogRepo: logRepo,
-		productRepo:      productRepo,
-		mapper:           mapper,
-		respMapper:       respMapper,
+		inventoryRepo:     repo,
+		inventoryLogRepo:  logRepo,
+		inventoryLotRepo:  lotRepo,
+		productRepo:       productRepo,
+		stockTransferRepo: stockTransferRepo,
+		reservationRepo:   reservationRepo,
+		reservationSvc:    reservationSvc,
+		channelRepo:       channelRepo,
+		masterConfigSvc:   masterConfigSvc,
+		notificationSvc:   notificationSvc,
+		mapper:            mapper,
+		respMapper:        respMapper,
 	}
 }
 
@@ -106,8 +143,8 @@ func (svc inventoryService) UpdateThreshold(ctx *context.Context, inventory requ
 	return nil
 }
 
-func (svc inventoryService) GetLowStockItems(ctx *context.Context) ([]responseModel.LowStockItem, *errs.XError) {
-	inventories, err := svc.inventoryRepo.GetLowStockItems(ctx)
+func (svc inventoryService) GetLowStockItems(ctx *context.Context, categoryId *uint) ([]responseModel.LowStockItem, *errs.XError) {
+	inventories, err := svc.inventoryRepo.GetLowStockItems(ctx, categoryId)
 	if err != nil {
 		return nil, err
 	}
@@ -121,9 +158,17 @@ func (svc inventoryService) GetLowStockItems(ctx *context.Context) ([]responseMo
 
 		productName := ""
 		productSKU := ""
+		parentName := ""
+		var parentId *uint
+		unit := entities.UnitOfMeasurePIECE
 		if inv.Product != nil {
 			productName = inv.Product.Name
 			productSKU = inv.Product.SKU
+			unit = inv.Product.StockUnit()
+			parentId = inv.Product.ParentId
+			if inv.Product.Parent != nil {
+				parentName = inv.Product.Parent.Name
+			}
 		}
 
 		res = append(res, responseModel.LowStockItem{
@@ -131,8 +176,215 @@ func (svc inventoryService) GetLowStockItems(ctx *context.Context) ([]responseMo
 			ProductName:       productName,
 			ProductSKU:        productSKU,
 			CurrentStock:      inv.Quantity,
+			ReservedQuantity:  inv.ReservedQuantity,
+			AvailableQuantity: inv.Available(),
 			LowStockThreshold: inv.LowStockThreshold,
+			Unit:              string(unit),
 			CategoryName:      categoryName,
+			ParentProductId:   parentId,
+			ParentProductName: parentName,
+		})
+	}
+
+	return res, nil
+}
+
+// GetReorderSuggestions lists the channel's low stock items with the quantity to order
+func (svc inventoryService) GetReorderSuggestions(ctx *context.Context, categoryId *uint) ([]responseModel.ReorderSuggestion, *errs.XError) {
+	inventories, err := svc.inventoryRepo.GetLowStockItems(ctx, categoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	windowDays := svc.reorderWindowDays(ctx)
+	consumed, err := svc.inventoryLogRepo.GetConsumedQuantities(ctx, util.GetLocalTime().AddDate(0, 0, -windowDays))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]responseModel.ReorderSuggestion, 0, len(inventories))
+	for _, inv := range inventories {
+		suggestion := responseModel.ReorderSuggestion{
+			InventoryId:             inv.ID,
+			ProductId:               inv.ProductId,
+			Unit:                    string(entities.UnitOfMeasurePIECE),
+			CurrentStock:            inv.Quantity,
+			AvailableQuantity:       inv.Available(),
+			LowStockThreshold:       inv.LowStockThreshold,
+			WindowDays:              windowDays,
+			ConsumedQuantity:        consumed[inv.ProductId],
+			AverageDailyConsumption: entities.RoundQuantity(consumed[inv.ProductId] / float64(windowDays)),
+			SuggestedQuantity:       inv.SuggestedReorder(consumed[inv.ProductId]),
+			LowStockAlertedAt:       inv.LowStockAlertedAt,
+		}
+		if inv.Product != nil {
+			suggestion.ProductName = inv.Product.Name
+			suggestion.ProductSKU = inv.Product.SKU
+			suggestion.Unit = string(inv.Product.StockUnit())
+			if inv.Product.Category != nil {
+				suggestion.CategoryName = inv.Product.Category.Name
+			}
+			suggestion.ParentProductId = inv.Product.ParentId
+			if inv.Product.Parent != nil {
+				suggestion.ParentProductName = inv.Product.Parent.Name
+			}
+		}
+		res = append(res, suggestion)
+	}
+
+	return res, nil
+}
+
+// GetLowStockAlerts collects the low stock items each channel owner has not been alerted about yet
+func (svc inventoryService) GetLowStockAlerts(ctx *context.Context) ([]responseModel.LowStockAlert, *errs.XError) {
+	channels, err := svc.channelRepo.GetActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]responseModel.LowStockAlert, 0)
+	for _, channel := range channels {
+		if channel.OwnerUser == nil || channel.OwnerUser.Email == "" {
+			continue
+		}
+
+		channelCtx := utils.NewChannelSession(ctx, channel)
+		err = svc.inventoryRepo.ClearReplenishedAlerts(&channelCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		suggestions, err := svc.GetReorderSuggestions(&channelCtx, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		pending := make([]responseModel.ReorderSuggestion, 0)
+		for _, suggestion := range suggestions {
+			if suggestion.LowStockAlertedAt == nil {
+				pending = append(pending, suggestion)
+			}
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		alerts = append(alerts, responseModel.LowStockAlert{
+			ChannelId:   channel.ID,
+			ChannelName: channel.Name,
+			OwnerUserId: channel.OwnerUserID,
+			OwnerEmail:  channel.OwnerUser.Email,
+			Suggestions: pending,
+		})
+	}
+
+	return alerts, nil
+}
+
+// SendLowStockAlert emails the channel owner and marks the items as alerted once the email is sent
+func (svc inventoryService) SendLowStockAlert(ctx *context.Context, alert responseModel.LowStockAlert) *errs.XError {
+	channelCtx := utils.NewChannelSession(ctx, entities.Channel{
+		Model:       &entities.Model{ID: alert.ChannelId},
+		Name:        alert.ChannelName,
+		OwnerUserID: alert.OwnerUserId,
+	})
+
+	var body strings.Builder
+	body.WriteString(fmt.Sprintf("The following items are low on stock in %s.\n\n", alert.ChannelName))
+	inventoryIds := make([]uint, 0, len(alert.Suggestions))
+	for _, suggestion := range alert.Suggestions {
+		body.WriteString(fmt.Sprintf("%s (%s): %s %s available, threshold %s %s, suggested reorder %s %s\n",
+			suggestion.ProductName, suggestion.ProductSKU,
+			entities.FormatQuantity(suggestion.AvailableQuantity), suggestion.Unit,
+			entities.FormatQuantity(suggestion.LowStockThreshold), suggestion.Unit,
+			entities.FormatQuantity(suggestion.SuggestedQuantity), suggestion.Unit))
+		inventoryIds = append(inventoryIds, suggestion.InventoryId)
+	}
+
+	err := svc.notificationSvc.SendEmailNotification(&channelCtx, requestModel.EmaiNotification{
+		Notification:  &requestModel.Notification{SourceEntity: "Inventory", EntityId: alert.ChannelId},
+		ToMailAddress: alert.OwnerEmail,
+		Subject:       fmt.Sprintf("Low stock alert: %d item(s) in %s", len(alert.Suggestions), alert.ChannelName),
+		Body:          body.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return svc.inventoryRepo.MarkLowStockAlerted(&channelCtx, inventoryIds, util.GetLocalTime())
+}
+
+// reorderWindowDays reads the consumption window from master config, 30 days when missing or invalid
+func (svc inventoryService) reorderWindowDays(ctx *context.Context) int {
+	value, err := svc.masterConfigSvc.GetByName(ctx, constants.INVENTORY_REORDER_WINDOW_CONFIG)
+	days, convErr := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || convErr != nil || days <= 0 {
+		return 30
+	}
+	return days
+}
+
+// GetLots lists the channel's lots of a product in the order outgoing stock is taken from them
+func (svc inventoryService) GetLots(ctx *context.Context, productId uint, includeEmpty bool) ([]responseModel.InventoryLot, *errs.XError) {
+	lots, err := svc.inventoryLotRepo.GetByProductId(ctx, productId, includeEmpty)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedLots, mapErr := svc.respMapper.InventoryLots(lots)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map InventoryLot data", mapErr)
+	}
+
+	return mappedLots, nil
+}
+
+// GetConsolidated sums the stock of each product over the channels the user can access
+func (svc inventoryService) GetConsolidated(ctx *context.Context, productId *uint) ([]responseModel.ConsolidatedStock, *errs.XError) {
+	channelIds := utils.GetAccessibleLocationIds(ctx)
+	if len(channelIds) == 0 {
+		return []responseModel.ConsolidatedStock{}, nil
+	}
+
+	inventories, err := svc.inventoryRepo.GetByChannelIds(ctx, channelIds, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	inTransit, err := svc.stockTransferRepo.GetInTransitQuantities(ctx, channelIds)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]responseModel.ConsolidatedStock, 0)
+	positions := make(map[uint]int)
+	for _, inv := range inventories {
+		pos, ok := positions[inv.ProductId]
+		if !ok {
+			stock := responseModel.ConsolidatedStock{
+				ProductId:         inv.ProductId,
+				Unit:              string(entities.UnitOfMeasurePIECE),
+				InTransitQuantity: inTransit[inv.ProductId],
+				Channels:          make([]responseModel.ConsolidatedStockChannel, 0),
+			}
+			if inv.Product != nil {
+				stock.ProductName = inv.Product.Name
+				stock.ProductSKU = inv.Product.SKU
+				stock.Unit = string(inv.Product.StockUnit())
+			}
+			res = append(res, stock)
+			pos = len(res) - 1
+			positions[inv.ProductId] = pos
+		}
+
+		res[pos].TotalQuantity = entities.RoundQuantity(res[pos].TotalQuantity + inv.Quantity)
+		res[pos].Channels = append(res[pos].Channels, responseModel.ConsolidatedStockChannel{
+			ChannelId:         inv.ChannelId,
+			ChannelName:       inv.ChannelName,
+			Quantity:          inv.Quantity,
+			AvailableQuantity: inv.Available(),
+			LowStockThreshold: inv.LowStockThreshold,
+			IsLowStock:        inv.IsLowStock(),
 		})
 	}
 
@@ -142,10 +394,6 @@ func (svc inventoryService) GetLowStockItems(ctx *context.Context) ([]responseMo
 // RecordStockMovement handles all stock movements (IN, OUT, ADJUST) with business rules
 func (svc inventoryService) RecordStockMovement(ctx *context.Context, request requestModel.StockMovementRequest) (*responseModel.StockMovementResponse, *errs.XError) {
 	// Validation
-	if request.Quantity <= 0 {
-		return nil, errs.NewXError(errs.INVALID_REQUEST, "Quantity must be greater than 0", nil)
-	}
-
 	changeType := entities.InventoryLogChangeType(request.ChangeType)
 	if changeType != entities.InventoryLogChangeTypeIN &&
 		changeType != entities.InventoryLogChangeTypeOUT &&
@@ -153,69 +401,219 @@ func (svc inventoryService) RecordStockMovement(ctx *context.Context, request re
 		return nil, errs.NewXError(errs.INVALID_REQUEST, "Invalid change type. Must be IN, OUT, or ADJUST", nil)
 	}
 
-	// Get current inventory
-	inventory, err := svc.inventoryRepo.GetByProductId(ctx, request.ProductId)
+	if changeType != entities.InventoryLogChangeTypeIN && request.UnitCost != nil {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Unit cost can only be given for IN movements", nil)
+	}
+
+	product, err := svc.productRepo.Get(ctx, request.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	if product.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Product not found", nil)
+	}
+	if product.HasVariants() {
+		return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("Stock of %s is kept on its variants, pick a variant", product.Name), nil)
+	}
+
+	// Quantities are booked in the product's stock unit, 1 ROLL is received as 40 METER
+	stockUnit := product.StockUnit()
+	enteredUnit := entities.ParseUnitOfMeasure(request.Unit)
+	quantity, ok := product.ToStockUnit(request.Quantity, enteredUnit)
+	if !ok {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, fmt.Sprintf("%s cannot be converted to %s for this product", request.Unit, stockUnit), nil)
+	}
+
+	// ADJUST is signed, IN and OUT carry the direction in the change type
+	if changeType == entities.InventoryLogChangeTypeADJUST && quantity == 0 {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Adjustment quantity cannot be 0", nil)
+	}
+	if changeType != entities.InventoryLogChangeTypeADJUST && quantity <= 0 {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Quantity must be greater than 0", nil)
+	}
+
+	incoming := changeType == entities.InventoryLogChangeTypeIN || (changeType == entities.InventoryLogChangeTypeADJUST && quantity > 0)
+	if !incoming && (request.LotCode != "" || request.SupplierId != nil || request.ExpiresAt != nil) {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Lot code, supplier and expiry can only be given for incoming stock", nil)
+	}
+	if incoming && request.SingleLot {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Single lot can only be asked for outgoing stock", nil)
+	}
+
+	var unitCost *float64
+	if changeType == entities.InventoryLogChangeTypeIN {
+		cost, err := incomingUnitCost(request, product, quantity)
+		if err != nil {
+			return nil, err
+		}
+		unitCost = cost
+	}
+
+	var enteredQuantity *float64
+	if enteredUnit == "" || enteredUnit == stockUnit {
+		enteredUnit = ""
+	} else {
+		enteredQuantity = &request.Quantity
+	}
+
+	// A channel stocking the product for the first time gets its own inventory row
+	if err := svc.inventoryRepo.EnsureForProduct(ctx, request.ProductId); err != nil {
+		return nil, err
+	}
+
+	// Lock the inventory row, movements for the same product wait here until this one commits
+	inventory, err := svc.inventoryRepo.LockByProductId(ctx, request.ProductId)
 	if err != nil {
 		return nil, errs.NewXError(errs.INVALID_REQUEST, "Product inventory not found", err)
 	}
 
+	// A retry with the same idempotency key returns the movement that was already booked
+	var idempotencyKey *string
+	if request.IdempotencyKey != "" {
+		idempotencyKey = &request.IdempotencyKey
+		existing, err := svc.inventoryLogRepo.GetByIdempotencyKey(ctx, request.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) > 0 {
+			return replayedStockMovement(existing, request, quantity, inventory.Quantity, stockUnit)
+		}
+	}
+
 	previousStock := inventory.Quantity
 
 	// Calculate new stock based on change type
-	var newStock int
-	var netChange int
+	var newStock float64
+	var netChange float64
 
 	switch changeType {
 	case entities.InventoryLogChangeTypeIN:
-		newStock = previousStock + request.Quantity
-		netChange = request.Quantity
+		netChange = quantity
+		newStock = entities.RoundQuantity(previousStock + netChange)
 
 	case entities.InventoryLogChangeTypeOUT:
-		netChange = -request.Quantity
-		newStock = previousStock + netChange
+		netChange = -quantity
+		newStock = entities.RoundQuantity(previousStock + netChange)
 
 		// Prevent negative stock unless admin override
 		if newStock < 0 && !request.AdminOverride {
 			return nil, errs.NewXError(
 				errs.INVALID_REQUEST,
-				fmt.Sprintf("Insufficient stock. Available: %d, Requested: %d", previousStock, request.Quantity),
+				fmt.Sprintf("Insufficient stock. Available: %s %s, Requested: %s %s",
+					entities.FormatQuantity(previousStock), stockUnit, entities.FormatQuantity(quantity), stockUnit),
 				nil,
 			)
 		}
 
+		// Stock held for confirmed orders is not available, except to the order item it is held for
+		if !request.AdminOverride {
+			reserved, err := svc.reservationRepo.GetReservedQuantity(ctx, request.ProductId, request.OrderItemId)
+			if err != nil {
+				return nil, err
+			}
+			if quantity > entities.RoundQuantity(previousStock-reserved) {
+				return nil, errs.NewXError(
+					errs.INVALID_REQUEST,
+					fmt.Sprintf("Insufficient unreserved stock. On hand: %s %s, Reserved: %s %s, Requested: %s %s",
+						entities.FormatQuantity(previousStock), stockUnit, entities.FormatQuantity(reserved), stockUnit,
+						entities.FormatQuantity(quantity), stockUnit),
+					nil,
+				)
+			}
+		}
+
 	case entities.InventoryLogChangeTypeADJUST:
 		// For ADJUST, the quantity can be positive (add) or negative (remove)
-		// We treat the request.Quantity as the adjustment amount
-		if request.Quantity > 0 {
-			netChange = request.Quantity
-		} else {
-			netChange = -request.Quantity // Make it negative for removal
+		netChange = quantity
+		newStock = entities.RoundQuantity(previousStock + netChange)
+
+		if newStock < 0 && !request.AdminOverride {
+			return nil, errs.NewXError(
+				errs.INVALID_REQUEST,
+				fmt.Sprintf("Adjustment would make stock negative. Available: %s %s, Adjustment: %s %s",
+					entities.FormatQuantity(previousStock), stockUnit, entities.FormatQuantity(quantity), stockUnit),
+				nil,
+			)
 		}
-		newStock = previousStock + netChange
 	}
 
-	// Create inventory log entry
-	logEntry := &entities.InventoryLog{
-		Model:      &entities.Model{IsActive: true},
-		ProductId:  request.ProductId,
-		ChangeType: changeType,
-		Quantity:   request.Quantity,
-		Reason:     request.Reason,
-		Notes:      request.Notes,
-		LoggedAt:   util.GetLocalTime(),
+	// Incoming stock opens a lot, outgoing stock is taken from lots
+	var lots []lotChange
+	if netChange > 0 {
+		lot, err := svc.receiveIntoLot(ctx, request, netChange, newStock)
+		if err != nil {
+			return nil, err
+		}
+		lots = []lotChange{lot}
+	} else {
+		lots, err = svc.drawFromLots(ctx, request, product, -netChange)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	errr := svc.inventoryLogRepo.Create(ctx, logEntry)
-	if errr != nil {
-		return nil, errs.NewXError(errs.DATABASE, "Failed to create inventory log", errr)
+	// One log per lot, a movement drawn from several lots is split over them
+	loggedAt := util.GetLocalTime()
+	movementLots := make([]responseModel.StockMovementLot, 0, len(lots))
+	for _, lot := range lots {
+		logQuantity := lot.quantity
+		if changeType == entities.InventoryLogChangeTypeADJUST && netChange < 0 {
+			logQuantity = -logQuantity
+		}
+
+		var loggedEnteredQuantity *float64
+		if enteredQuantity != nil {
+			part := entities.RoundQuantity(*enteredQuantity * lot.quantity / math.Abs(quantity))
+			loggedEnteredQuantity = &part
+		}
+
+		logEntry := &entities.InventoryLog{
+			Model:           &entities.Model{IsActive: true},
+			ProductId:       request.ProductId,
+			ChangeType:      changeType,
+			Quantity:        logQuantity,
+			EnteredQuantity: loggedEnteredQuantity,
+			EnteredUnit:     enteredUnit,
+			Reason:          request.Reason,
+			Notes:           request.Notes,
+			LoggedAt:        loggedAt,
+			UnitCost:        unitCost,
+			OrderItemId:     request.OrderItemId,
+			PurchaseOrderId: request.PurchaseOrderId,
+			StockTransferId: request.StockTransferId,
+			SaleId:          request.SaleId,
+			LotId:           lot.lotId,
+			IdempotencyKey:  idempotencyKey,
+		}
+
+		errr := svc.inventoryLogRepo.Create(ctx, logEntry)
+		if errr != nil {
+			return nil, errs.NewXError(errs.DATABASE, "Failed to create inventory log", errr)
+		}
+
+		if lot.lotId != nil {
+			movementLots = append(movementLots, responseModel.StockMovementLot{
+				LotId:    *lot.lotId,
+				LotCode:  lot.lotCode,
+				Quantity: lot.quantity,
+			})
+		}
 	}
 
 	// Update inventory quantity
-	errr = svc.inventoryRepo.UpdateQuantity(ctx, request.ProductId, newStock)
+	errr := svc.inventoryRepo.AdjustQuantity(ctx, request.ProductId, netChange)
 	if errr != nil {
 		return nil, errs.NewXError(errs.DATABASE, "Failed to update inventory quantity", errr)
 	}
 
+	// Material booked out for an order item is no longer held for it
+	if changeType == entities.InventoryLogChangeTypeOUT && request.OrderItemId != nil {
+		errr = svc.reservationRepo.ConsumeByOrderItemId(ctx, *request.OrderItemId, request.ProductId)
+		if errr != nil {
+			return nil, errr
+		}
+	}
+
 	// Return response
 	response := &responseModel.StockMovementResponse{
 		Success:       true,
@@ -224,7 +622,461 @@ func (svc inventoryService) RecordStockMovement(ctx *context.Context, request re
 		PreviousStock: previousStock,
 		NewStock:      newStock,
 		ChangeAmount:  netChange,
+		Unit:          string(stockUnit),
+		Lots:          movementLots,
 	}
 
 	return response, nil
 }
+
+// ReverseStockMovement undoes a mistaken movement with a linked reversing entry and returns its id
+func (svc inventoryService) ReverseStockMovement(ctx *context.Context, logId uint, reason string) (uint, *errs.XError) {
+	if strings.TrimSpace(reason) == "" {
+		return 0, errs.NewXError(errs.INVALID_REQUEST, "Reason is required to reverse a stock movement", nil)
+	}
+
+	// The log is locked first, a second reversal of it waits here and then finds it reversed
+	if err := svc.inventoryLogRepo.Lock(ctx, logId); err != nil {
+		return 0, err
+	}
+	log, err := svc.inventoryLogRepo.Get(ctx, logId)
+	if err != nil {
+		return 0, err
+	}
+	if log.Model == nil || !log.IsActive {
+		return 0, errs.NewXError(errs.NOT_EXIST, "Stock movement not found", nil)
+	}
+	if log.ChannelId != utils.GetChannelId(ctx) {
+		return 0, errs.NewXError(errs.VALIDATION, "A stock movement can only be reversed in the channel it was booked in", nil)
+	}
+	if log.ReversalOfLogId != nil {
+		return 0, errs.NewXError(errs.VALIDATION, "A reversing entry cannot be reversed, record the movement again instead", nil)
+	}
+	if log.IsReversed() {
+		return 0, errs.NewXError(errs.VALIDATION, "Stock movement has already been reversed", nil)
+	}
+	if log.PurchaseOrderId != nil || log.StockTransferId != nil || log.SaleId != nil {
+		return 0, errs.NewXError(errs.VALIDATION, "Stock movement was booked by a purchase order, stock transfer or sale and cannot be reversed on its own", nil)
+	}
+
+	inventory, err := svc.inventoryRepo.LockByProductId(ctx, log.ProductId)
+	if err != nil {
+		return 0, errs.NewXError(errs.INVALID_REQUEST, "Product inventory not found", err)
+	}
+
+	changeType, quantity := log.Reversal()
+	netChange := -log.CalculateNetChange()
+	stockUnit := entities.UnitOfMeasurePIECE
+	if log.Product != nil {
+		stockUnit = log.Product.StockUnit()
+	}
+
+	// Taking stock back out needs it to be there, unreserved and in the lot it went into
+	if netChange < 0 {
+		reserved, err := svc.reservationRepo.GetReservedQuantity(ctx, log.ProductId, nil)
+		if err != nil {
+			return 0, err
+		}
+		if -netChange > entities.RoundQuantity(inventory.Quantity-reserved) {
+			return 0, errs.NewXError(errs.INVALID_REQUEST,
+				fmt.Sprintf("Insufficient unreserved stock to reverse. On hand: %s %s, Reserved: %s %s, Reversal: %s %s",
+					entities.FormatQuantity(inventory.Quantity), stockUnit, entities.FormatQuantity(reserved), stockUnit,
+					entities.FormatQuantity(-netChange), stockUnit),
+				nil,
+			)
+		}
+		if log.LotId != nil {
+			lot, err := svc.getLot(ctx, *log.LotId, log.ProductId)
+			if err != nil {
+				return 0, err
+			}
+			if lot.RemainingQuantity < -netChange {
+				return 0, errs.NewXError(errs.INVALID_REQUEST,
+					fmt.Sprintf("Lot %s has only %s %s left", lotName(lot), entities.FormatQuantity(lot.RemainingQuantity), stockUnit), nil)
+			}
+		}
+	}
+
+	if log.LotId != nil {
+		if err := svc.inventoryLotRepo.AdjustRemaining(ctx, *log.LotId, netChange); err != nil {
+			return 0, err
+		}
+	}
+
+	reversal := &entities.InventoryLog{
+		Model:           &entities.Model{IsActive: true},
+		ProductId:       log.ProductId,
+		ChangeType:      changeType,
+		Quantity:        quantity,
+		Reason:          strings.TrimSpace(reason),
+		Notes:           fmt.Sprintf("Reversal of stock movement #%d", log.ID),
+		LoggedAt:        util.GetLocalTime(),
+		OrderItemId:     log.OrderItemId,
+		LotId:           log.LotId,
+		ReversalOfLogId: &log.ID,
+	}
+	if err := svc.inventoryLogRepo.Create(ctx, reversal); err != nil {
+		return 0, err
+	}
+	if err := svc.inventoryLogRepo.MarkReversed(ctx, log.ID, reversal.ID); err != nil {
+		return 0, err
+	}
+
+	if err := svc.inventoryRepo.AdjustQuantity(ctx, log.ProductId, netChange); err != nil {
+		return 0, errs.NewXError(errs.DATABASE, "Failed to update inventory quantity", err)
+	}
+
+	// Material put back from an order item is held for its order again
+	if log.OrderItem != nil {
+		if err := svc.reservationSvc.SyncForOrder(ctx, log.OrderItem.OrderId); err != nil {
+			return 0, err
+		}
+	}
+
+	return reversal.ID, nil
+}
+
+// lotChange is the quantity a movement put into or took from one lot
+type lotChange struct {
+	lotId    *uint
+	lotCode  string
+	quantity float64
+}
+
+// receiveIntoLot opens a lot for incoming stock, or puts it back into the given lot
+func (svc inventoryService) receiveIntoLot(ctx *context.Context, request requestModel.StockMovementRequest, quantity float64, newStock float64) (lotChange, *errs.XError) {
+	if request.LotId != nil {
+		lot, err := svc.getLot(ctx, *request.LotId, request.ProductId)
+		if err != nil {
+			return lotChange{}, err
+		}
+		if err := svc.inventoryLotRepo.AdjustRemaining(ctx, lot.ID, quantity); err != nil {
+			return lotChange{}, err
+		}
+		return lotChange{lotId: &lot.ID, lotCode: lot.LotCode, quantity: quantity}, nil
+	}
+
+	expiresAt := request.LotExpiresAt
+	if request.ExpiresAt != nil {
+		date, err := util.GenerateDateTimeFromString(request.ExpiresAt)
+		if err != nil {
+			return lotChange{}, errs.NewXError(errs.INVALID_REQUEST, "Invalid expiry date", err)
+		}
+		expiresAt = date
+	}
+
+	receivedAt := util.GetLocalTime()
+	if request.ReceivedAt != nil {
+		receivedAt = *request.ReceivedAt
+	}
+
+	remaining := quantity
+	if newStock < remaining {
+		remaining = math.Max(newStock, 0)
+	}
+
+	lot := &entities.InventoryLot{
+		Model:             &entities.Model{IsActive: true},
+		ProductId:         request.ProductId,
+		SupplierId:        request.SupplierId,
+		LotCode:           strings.TrimSpace(request.LotCode),
+		ReceivedAt:        receivedAt,
+		Quantity:          quantity,
+		RemainingQuantity: remaining,
+		ExpiresAt:         expiresAt,
+	}
+	if err := svc.inventoryLotRepo.Create(ctx, lot); err != nil {
+		return lotChange{}, err
+	}
+	return lotChange{lotId: &lot.ID, lotCode: lot.LotCode, quantity: quantity}, nil
+}
+
+// drawFromLots takes outgoing stock from the given lot, or from the oldest lots first
+func (svc inventoryService) drawFromLots(ctx *context.Context, request requestModel.StockMovementRequest, product *entities.Product, quantity float64) ([]lotChange, *errs.XError) {
+	var picks []entities.LotPick
+	var remainder float64
+
+	if request.LotId != nil {
+		lot, err := svc.getLot(ctx, *request.LotId, request.ProductId)
+		if err != nil {
+			return nil, err
+		}
+		if lot.RemainingQuantity < quantity && !request.AdminOverride {
+			return nil, errs.NewXError(errs.INVALID_REQUEST,
+				fmt.Sprintf("Lot %s has only %s %s left", lotName(lot), entities.FormatQuantity(lot.RemainingQuantity), product.StockUnit()), nil)
+		}
+		take := math.Min(quantity, math.Max(lot.RemainingQuantity, 0))
+		if take > 0 {
+			picks = []entities.LotPick{{Lot: lot, Quantity: take}}
+		}
+		remainder = entities.RoundQuantity(quantity - take)
+	} else {
+		lots, err := svc.inventoryLotRepo.GetAvailable(ctx, request.ProductId)
+		if err != nil {
+			return nil, err
+		}
+
+		var ok bool
+		picks, remainder, ok = entities.PickLots(lots, quantity, request.SingleLot)
+		if !ok {
+			return nil, errs.NewXError(errs.INVALID_REQUEST,
+				fmt.Sprintf("No single lot of %s has %s %s left", product.Name, entities.FormatQuantity(quantity), product.StockUnit()), nil)
+		}
+	}
+
+	changes := make([]lotChange, 0, len(picks)+1)
+	for _, pick := range picks {
+		if err := svc.inventoryLotRepo.AdjustRemaining(ctx, pick.Lot.ID, -pick.Quantity); err != nil {
+			return nil, err
+		}
+		lotId := pick.Lot.ID
+		changes = append(changes, lotChange{lotId: &lotId, lotCode: pick.Lot.LotCode, quantity: pick.Quantity})
+	}
+	if remainder > 0 {
+		changes = append(changes, lotChange{quantity: remainder})
+	}
+	return changes, nil
+}
+
+func (svc inventoryService) getLot(ctx *context.Context, id uint, productId uint) (*entities.InventoryLot, *errs.XError) {
+	lot, err := svc.inventoryLotRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if lot.Model == nil || !lot.IsActive || lot.ProductId != productId {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Lot not found for this product", nil)
+	}
+	return lot, nil
+}
+
+func lotName(lot *entities.InventoryLot) string {
+	if lot.LotCode != "" {
+		return lot.LotCode
+	}
+	return fmt.Sprintf("#%d", lot.ID)
+}
+
+// replayedStockMovement answers a retried request without booking the movement again
+func replayedStockMovement(existing []entities.InventoryLog, request requestModel.StockMovementRequest, quantity float64, currentStock float64, unit entities.UnitOfMeasure) (*responseModel.StockMovementResponse, *errs.XError) {
+	first := existing[0]
+
+	var bookedQuantity, netChange float64
+	lots := make([]responseModel.StockMovementLot, 0, len(existing))
+	for _, log := range existing {
+		bookedQuantity = entities.RoundQuantity(bookedQuantity + log.Quantity)
+		netChange = entities.RoundQuantity(netChange + log.CalculateNetChange())
+		if log.LotId != nil {
+			lot := responseModel.StockMovementLot{LotId: *log.LotId, Quantity: math.Abs(log.Quantity)}
+			if log.Lot != nil {
+				lot.LotCode = log.Lot.LotCode
+			}
+			lots = append(lots, lot)
+		}
+	}
+
+	if first.ProductId != request.ProductId ||
+		string(first.ChangeType) != request.ChangeType ||
+		bookedQuantity != quantity {
+		return nil, errs.NewXError(errs.VALIDATION, "Idempotency key was already used for a different stock movement", nil)
+	}
+
+	return &responseModel.StockMovementResponse{
+		Success:       true,
+		Message:       fmt.Sprintf("Stock %s already recorded", first.ChangeType),
+		ProductId:     first.ProductId,
+		PreviousStock: entities.RoundQuantity(currentStock - netChange),
+		NewStock:      currentStock,
+		ChangeAmount:  netChange,
+		Unit:          string(unit),
+		Replayed:      true,
+		Lots:          lots,
+	}, nil
+}
+
+// incomingUnitCost is the cost of one stock unit of incoming stock
+func incomingUnitCost(request requestModel.StockMovementRequest, product *entities.Product, quantity float64) (*float64, *errs.XError) {
+	if request.UnitCost != nil {
+		if *request.UnitCost < 0 {
+			return nil, errs.NewXError(errs.INVALID_REQUEST, "Unit cost cannot be negative", nil)
+		}
+		cost := *request.UnitCost * request.Quantity / quantity
+		return &cost, nil
+	}
+
+	cost := product.CostPrice
+	return &cost, nil
+}
+
+// costingMethod reads the channel's costing method from master config, weighted average unless FIFO is configured
+func (svc inventoryService) costingMethod(ctx *context.Context) entities.CostingMethod {
+	value, err := svc.masterConfigSvc.GetByName(ctx, constants.INVENTORY_COSTING_METHOD_CONFIG)
+	method := entities.CostingMethod(strings.ToUpper(strings.TrimSpace(value)))
+	if err != nil || !method.IsValid() {
+		return entities.CostingMethodWEIGHTED_AVERAGE
+	}
+	return method
+}
+
+// GetValuation values the stock on hand at the end of the asOf day, today when not given
+func (svc inventoryService) GetValuation(ctx *context.Context, asOf *time.Time, categoryId *uint) (*responseModel.InventoryValuation, *errs.XError) {
+	day := util.GetLocalTime()
+	if asOf != nil {
+		day = *asOf
+	}
+	day = startOfDay(day)
+
+	logs, err := svc.inventoryLogRepo.GetLoggedBefore(ctx, day.AddDate(0, 0, 1), categoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	method := svc.costingMethod(ctx)
+	valuation := &responseModel.InventoryValuation{
+		AsOf:          day,
+		CostingMethod: string(method),
+		Items:         make([]responseModel.InventoryValuationItem, 0),
+	}
+
+	for _, pl := range replayLedgers(logs, method, nil) {
+		if pl.ledger.Quantity() == 0 {
+			continue
+		}
+		name, sku := pl.productDetails()
+		valuation.Items = append(valuation.Items, responseModel.InventoryValuationItem{
+			ProductId:   pl.productId,
+			ProductName: name,
+			ProductSKU:  sku,
+			Unit:        pl.unit(),
+			Quantity:    pl.ledger.Quantity(),
+			UnitCost:    roundCost(pl.ledger.UnitCost()),
+			Value:       pl.ledger.Value(),
+		})
+		valuation.TotalValue += pl.ledger.Value()
+	}
+	valuation.TotalValue = roundCost(valuation.TotalValue)
+
+	return valuation, nil
+}
+
+// GetCOGSReport costs the stock taken out between the from and to days, both inclusive
+func (svc inventoryService) GetCOGSReport(ctx *context.Context, from *time.Time, to *time.Time, categoryId *uint) (*responseModel.COGSReport, *errs.XError) {
+	end := util.GetLocalTime()
+	if to != nil {
+		end = *to
+	}
+	end = startOfDay(end)
+	start := time.Date(end.Year(), end.Month(), 1, 0, 0, 0, 0, end.Location())
+	if from != nil {
+		start = startOfDay(*from)
+	}
+	if start.After(end) {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "From date must not be after to date", nil)
+	}
+
+	// Movements before the period are replayed too, they decide the cost of what is taken out in it
+	logs, err := svc.inventoryLogRepo.GetLoggedBefore(ctx, end.AddDate(0, 0, 1), categoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	method := svc.costingMethod(ctx)
+	items := make(map[uint]*responseModel.COGSReportItem)
+	ledgers := replayLedgers(logs, method, func(log *entities.InventoryLog, cost float64) {
+		if log.LoggedAt.Before(start) || log.CalculateNetChange() >= 0 {
+			return
+		}
+		// Stock taken out by mistake and put back, or a mistaken receipt taken back out, was not consumed
+		if log.IsReversed() || log.ReversalOfLogId != nil {
+			return
+		}
+		item, ok := items[log.ProductId]
+		if !ok {
+			item = &responseModel.COGSReportItem{ProductId: log.ProductId}
+			items[log.ProductId] = item
+		}
+		if log.ChangeType == entities.InventoryLogChangeTypeOUT {
+			item.QuantityConsumed = entities.RoundQuantity(item.QuantityConsumed + log.Quantity)
+			item.CostOfGoods += cost
+		} else {
+			item.QuantityWrittenOff = entities.RoundQuantity(item.QuantityWrittenOff - log.CalculateNetChange())
+			item.WriteOffCost += cost
+		}
+	})
+
+	report := &responseModel.COGSReport{
+		From:          start,
+		To:            end,
+		CostingMethod: string(method),
+		Items:         make([]responseModel.COGSReportItem, 0, len(items)),
+	}
+	for _, pl := range ledgers {
+		item, ok := items[pl.productId]
+		if !ok {
+			continue
+		}
+		item.ProductName, item.ProductSKU = pl.productDetails()
+		item.Unit = pl.unit()
+		item.CostOfGoods = roundCost(item.CostOfGoods)
+		item.WriteOffCost = roundCost(item.WriteOffCost)
+		report.TotalCost += item.CostOfGoods
+		report.TotalWriteOff += item.WriteOffCost
+		report.Items = append(report.Items, *item)
+	}
+	report.TotalCost = roundCost(report.TotalCost)
+	report.TotalWriteOff = roundCost(report.TotalWriteOff)
+
+	return report, nil
+}
+
+type productLedger struct {
+	productId uint
+	product   *entities.Product
+	ledger    *entities.CostLedger
+}
+
+func (pl productLedger) productDetails() (string, string) {
+	if pl.product == nil {
+		return "", ""
+	}
+	return pl.product.Name, pl.product.SKU
+}
+
+func (pl productLedger) unit() string {
+	if pl.product == nil {
+		return string(entities.UnitOfMeasurePIECE)
+	}
+	return string(pl.product.StockUnit())
+}
+
+// replayLedgers runs the logs through a cost ledger per product
+func replayLedgers(logs []entities.InventoryLog, method entities.CostingMethod, visit func(*entities.InventoryLog, float64)) []productLedger {
+	ledgers := make([]productLedger, 0)
+	for i := range logs {
+		log := &logs[i]
+		if len(ledgers) == 0 || ledgers[len(ledgers)-1].productId != log.ProductId {
+			fallbackCost := 0.0
+			if log.Product != nil {
+				fallbackCost = log.Product.CostPrice
+			}
+			ledgers = append(ledgers, productLedger{
+				productId: log.ProductId,
+				product:   log.Product,
+				ledger:    entities.NewCostLedger(method, fallbackCost),
+			})
+		}
+
+		cost := ledgers[len(ledgers)-1].ledger.Apply(log)
+		if visit != nil {
+			visit(log, cost)
+		}
+	}
+	return ledgers
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func roundCost(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
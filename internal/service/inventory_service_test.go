@@ -0,0 +1,222 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/imkarthi24/sf-backend/internal/constants"
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RecordStockMovement_Idempotency(t *testing.T) {
+
+	store := newStockStore()
+	silk := store.addProduct("Silk", entities.UnitOfMeasureMETER)
+	store.addLot(silk.ID, "DL-01", 2)
+	store.addLot(silk.ID, "DL-02", 3)
+
+	svc := newTestInventoryService(store)
+	move := func(changeType entities.InventoryLogChangeType, quantity float64, key string) (bool, *errs.XError) {
+		response, err := svc.RecordStockMovement(testContext(), requestModel.StockMovementRequest{
+			ProductId:      silk.ID,
+			ChangeType:     string(changeType),
+			Quantity:       quantity,
+			Reason:         "Cutting",
+			IdempotencyKey: key,
+		})
+		if err != nil {
+			return false, err
+		}
+		return response.Replayed, nil
+	}
+
+	// Every movement is booked under a lock on the inventory row
+	replayed, err := move(entities.InventoryLogChangeTypeOUT, 4, "cut-1")
+	require.Nil(t, err)
+	require.False(t, replayed)
+	require.Equal(t, []uint{silk.ID}, store.locks)
+	require.Equal(t, 1.0, store.onHand(silk.ID))
+
+	// A movement drawn from two lots is logged once per lot, both logs carry the key
+	logs := store.logsFor(silk.ID)
+	require.Len(t, logs, 2)
+	for _, log := range logs {
+		require.Equal(t, "cut-1", *log.IdempotencyKey)
+	}
+
+	// A retry is answered from the logs without booking the movement again
+	replayed, err = move(entities.InventoryLogChangeTypeOUT, 4, "cut-1")
+	require.Nil(t, err)
+	require.True(t, replayed)
+	require.Equal(t, []uint{silk.ID, silk.ID}, store.locks)
+	require.Equal(t, 1.0, store.onHand(silk.ID))
+	require.Len(t, store.logsFor(silk.ID), 2)
+
+	// The key cannot be reused for a different movement
+	_, err = move(entities.InventoryLogChangeTypeOUT, 1, "cut-1")
+	require.NotNil(t, err)
+	require.Equal(t, errs.VALIDATION, err.Code)
+	_, err = move(entities.InventoryLogChangeTypeIN, 4, "cut-1")
+	require.NotNil(t, err)
+	require.Equal(t, 1.0, store.onHand(silk.ID))
+
+	// Movements without a key are booked every time
+	for i := 0; i < 2; i++ {
+		replayed, err = move(entities.InventoryLogChangeTypeIN, 2, "")
+		require.Nil(t, err)
+		require.False(t, replayed)
+	}
+	require.Equal(t, 5.0, store.onHand(silk.ID))
+	require.Len(t, store.logsFor(silk.ID), 4)
+}
+
+func Test_ReverseStockMovement(t *testing.T) {
+
+	store := newStockStore()
+	silk := store.addProduct("Silk", entities.UnitOfMeasureMETER)
+	lot := store.addLot(silk.ID, "DL-01", 5)
+	store.components[7] = []entities.DressTypeComponent{{ProductId: silk.ID, Product: silk, Quantity: 2}}
+
+	order := store.addOrder(entities.CONFIRMED, 2, 7)
+	require.Nil(t, newTestReservationService(store).SyncForOrder(testContext(), order.ID))
+
+	svc := newTestInventoryService(store)
+	move := func(changeType entities.InventoryLogChangeType, quantity float64, orderItemId *uint) uint {
+		_, err := svc.RecordStockMovement(testContext(), requestModel.StockMovementRequest{
+			ProductId:   silk.ID,
+			ChangeType:  string(changeType),
+			Quantity:    quantity,
+			Reason:      "Cutting",
+			OrderItemId: orderItemId,
+		})
+		require.Nil(t, err)
+		return store.logs[len(store.logs)-1].ID
+	}
+
+	// Material booked out for an order item goes back into its lot and is held for the order again
+	orderItemId := order.OrderItems[0].ID
+	cut := move(entities.InventoryLogChangeTypeOUT, 4, &orderItemId)
+	require.Equal(t, 0.0, store.reservedFor(order.ID))
+
+	_, err := svc.ReverseStockMovement(testContext(), cut, " ")
+	require.NotNil(t, err)
+	require.Equal(t, errs.INVALID_REQUEST, err.Code)
+
+	reversalId, err := svc.ReverseStockMovement(testContext(), cut, "Cut by mistake")
+	require.Nil(t, err)
+	require.Equal(t, []uint{cut}, store.logLocks)
+	require.Equal(t, 5.0, store.onHand(silk.ID))
+	require.Equal(t, 5.0, lot.RemainingQuantity)
+	require.Equal(t, 4.0, store.reservedFor(order.ID))
+
+	reversal := store.logs[len(store.logs)-1]
+	require.Equal(t, reversalId, reversal.ID)
+	require.Equal(t, entities.InventoryLogChangeTypeIN, reversal.ChangeType)
+	require.Equal(t, cut, *reversal.ReversalOfLogId)
+	require.Equal(t, lot.ID, *reversal.LotId)
+
+	// A movement is reversed once, and a reversing entry is not reversed at all
+	_, err = svc.ReverseStockMovement(testContext(), cut, "Cut by mistake")
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "already been reversed")
+	_, err = svc.ReverseStockMovement(testContext(), reversalId, "Undo")
+	require.NotNil(t, err)
+	require.Equal(t, errs.VALIDATION, err.Code)
+
+	// Stock cannot be taken back out once it is held for an order
+	received := move(entities.InventoryLogChangeTypeIN, 2, nil)
+	move(entities.InventoryLogChangeTypeOUT, 3, nil)
+	_, err = svc.ReverseStockMovement(testContext(), received, "Wrong delivery")
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "Insufficient unreserved stock to reverse")
+	require.Equal(t, 4.0, store.onHand(silk.ID))
+}
+
+func Test_GetValuation(t *testing.T) {
+
+	store := newStockStore()
+	silk := store.addProduct("Silk", entities.UnitOfMeasureMETER)
+
+	svc := newTestInventoryService(store)
+	config := fakeMasterConfigService{values: map[string]string{}}
+	svc.masterConfigSvc = config
+	cost := func(unitCost float64) *float64 { return &unitCost }
+	for _, movement := range []requestModel.StockMovementRequest{
+		{ChangeType: string(entities.InventoryLogChangeTypeIN), Quantity: 10, UnitCost: cost(100)},
+		{ChangeType: string(entities.InventoryLogChangeTypeIN), Quantity: 10, UnitCost: cost(130)},
+		{ChangeType: string(entities.InventoryLogChangeTypeOUT), Quantity: 15},
+	} {
+		movement.ProductId, movement.Reason = silk.ID, "Stock"
+		_, err := svc.RecordStockMovement(testContext(), movement)
+		require.Nil(t, err)
+	}
+
+	// Stock left is valued at the average cost of everything received
+	valuation, err := svc.GetValuation(testContext(), nil, nil)
+	require.Nil(t, err)
+	require.Equal(t, string(entities.CostingMethodWEIGHTED_AVERAGE), valuation.CostingMethod)
+	require.Len(t, valuation.Items, 1)
+	require.Equal(t, 5.0, valuation.Items[0].Quantity)
+	require.Equal(t, 575.0, valuation.TotalValue)
+
+	// With FIFO the oldest stock went out first, what is left cost the latest price
+	config.values[constants.INVENTORY_COSTING_METHOD_CONFIG] = "fifo"
+	valuation, err = svc.GetValuation(testContext(), nil, nil)
+	require.Nil(t, err)
+	require.Equal(t, string(entities.CostingMethodFIFO), valuation.CostingMethod)
+	require.Equal(t, 650.0, valuation.TotalValue)
+
+	// A cost is only given for stock coming in
+	_, err = svc.RecordStockMovement(testContext(), requestModel.StockMovementRequest{
+		ProductId:  silk.ID,
+		ChangeType: string(entities.InventoryLogChangeTypeOUT),
+		Quantity:   1,
+		UnitCost:   cost(100),
+	})
+	require.NotNil(t, err)
+	require.Equal(t, errs.INVALID_REQUEST, err.Code)
+}
+
+func Test_RecordStockMovement_Units(t *testing.T) {
+
+	store := newStockStore()
+	silk := store.addProduct("Silk", entities.UnitOfMeasureMETER)
+	silk.UnitConversions = []entities.ProductUnitConversion{{Unit: entities.UnitOfMeasureROLL, Factor: 40}}
+
+	svc := newTestInventoryService(store)
+	move := func(changeType entities.InventoryLogChangeType, quantity float64, unit entities.UnitOfMeasure) (*responseModel.StockMovementResponse, *errs.XError) {
+		return svc.RecordStockMovement(testContext(), requestModel.StockMovementRequest{
+			ProductId:  silk.ID,
+			ChangeType: string(changeType),
+			Quantity:   quantity,
+			Unit:       string(unit),
+			Reason:     "Stock",
+		})
+	}
+
+	// Rolls are booked in metres, the log keeps what was entered
+	response, err := move(entities.InventoryLogChangeTypeIN, 2, entities.UnitOfMeasureROLL)
+	require.Nil(t, err)
+	require.Equal(t, 80.0, response.NewStock)
+	require.Equal(t, "METER", response.Unit)
+
+	log := store.logsFor(silk.ID)[0]
+	require.Equal(t, 80.0, log.Quantity)
+	require.Equal(t, 2.0, *log.EnteredQuantity)
+	require.Equal(t, entities.UnitOfMeasureROLL, log.EnteredUnit)
+
+	// Fractional lengths are taken out
+	response, err = move(entities.InventoryLogChangeTypeOUT, 1.25, "")
+	require.Nil(t, err)
+	require.Equal(t, 78.75, response.NewStock)
+	require.Nil(t, store.logsFor(silk.ID)[1].EnteredQuantity)
+
+	// A unit the product has no conversion for is refused
+	_, err = move(entities.InventoryLogChangeTypeIN, 3, entities.UnitOfMeasurePIECE)
+	require.NotNil(t, err)
+	require.Equal(t, errs.INVALID_REQUEST, err.Code)
+	require.Equal(t, 78.75, store.onHand(silk.ID))
+}
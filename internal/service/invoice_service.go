@@ -0,0 +1,579 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/constants"
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/imkarthi24/sf-backend/internal/utils/pdf"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
+)
+
+const (
+	INVOICE_FORMAT_HTML = "html"
+	INVOICE_FORMAT_PDF  = "pdf"
+)
+
+type InvoiceService interface {
+	GetOrderInvoice(*context.Context, uint, string) (*responseModel.FileContent, *errs.XError)
+	IssueOrderInvoice(*context.Context, uint) *errs.XError
+	GetSaleReceipt(*context.Context, uint) (*responseModel.FileContent, *errs.XError)
+}
+
+type invoiceService struct {
+	orderRepo        repository.OrderRepository
+	orderPaymentRepo repository.OrderPaymentRepository
+	channelRepo      repository.ChannelRepository
+	saleRepo         repository.SaleRepository
+}
+
+func ProvideInvoiceService(orderRepo repository.OrderRepository, orderPaymentRepo repository.OrderPaymentRepository, channelRepo repository.ChannelRepository, saleRepo repository.SaleRepository) InvoiceService {
+	return &invoiceService{
+		orderRepo:        orderRepo,
+		orderPaymentRepo: orderPaymentRepo,
+		channelRepo:      channelRepo,
+		saleRepo:         saleRepo,
+	}
+}
+
+// orderInvoice holds everything printed on an invoice, shared by the HTML and PDF renderers
+type orderInvoice struct {
+	Number            string
+	Date              time.Time
+	ChannelName       string
+	ChannelGSTIN      string
+	Order             *entities.Order
+	Items             []entities.OrderItem // Active items of the order
+	Payments          []entities.OrderPayment
+	ItemsTotal        float64
+	CGSTTotal         float64
+	SGSTTotal         float64
+	IGSTTotal         float64
+	AdditionalCharges float64
+	GrandTotal        float64
+	PaidAmount        float64
+	BalanceDue        float64
+}
+
+func (invoice *orderInvoice) TaxTotal() float64 {
+	return invoice.CGSTTotal + invoice.SGSTTotal + invoice.IGSTTotal
+}
+
+// Title is TAX INVOICE for GST registered channels
+func (invoice *orderInvoice) Title() string {
+	if invoice.ChannelGSTIN != "" {
+		return "TAX INVOICE"
+	}
+	return "INVOICE"
+}
+
+type invoiceTaxLine struct {
+	Label  string
+	Amount float64
+}
+
+// taxLines lists the GST heads that apply to the invoice
+func (invoice *orderInvoice) taxLines() []invoiceTaxLine {
+	var lines []invoiceTaxLine
+	if invoice.IGSTTotal > 0 {
+		lines = append(lines, invoiceTaxLine{"IGST", invoice.IGSTTotal})
+	}
+	if invoice.CGSTTotal > 0 || invoice.SGSTTotal > 0 {
+		lines = append(lines, invoiceTaxLine{"CGST", invoice.CGSTTotal}, invoiceTaxLine{"SGST", invoice.SGSTTotal})
+	}
+	return lines
+}
+
+func (svc *invoiceService) GetOrderInvoice(ctx *context.Context, orderId uint, format string) (*responseModel.FileContent, *errs.XError) {
+	if format == "" {
+		format = INVOICE_FORMAT_HTML
+	}
+	if format != INVOICE_FORMAT_HTML && format != INVOICE_FORMAT_PDF {
+		return nil, errs.NewXError(errs.VALIDATION, "Invoice format must be html or pdf", nil)
+	}
+
+	invoice, err := svc.buildOrderInvoice(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := fmt.Sprintf("%s.%s", invoice.Number, format)
+	if format == INVOICE_FORMAT_PDF {
+		return &responseModel.FileContent{
+			FileName:    fileName,
+			ContentType: "application/pdf",
+			Content:     renderInvoicePDF(invoice),
+		}, nil
+	}
+
+	content, err := renderInvoiceHTML(invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &responseModel.FileContent{
+		FileName:    fileName,
+		ContentType: "text/html; charset=utf-8",
+		Content:     content,
+	}, nil
+}
+
+// IssueOrderInvoice gives the order the next number of its channel's invoice sequence, an order is invoiced once
+func (svc *invoiceService) IssueOrderInvoice(ctx *context.Context, orderId uint) *errs.XError {
+	err := svc.orderRepo.Lock(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	order, err := svc.orderRepo.Get(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	if order.Model == nil || !order.IsActive {
+		return errs.NewXError(errs.NOT_EXIST, "Order not found", nil)
+	}
+	if order.InvoicedAt != nil {
+		return nil
+	}
+	if !order.Status.CanBeInvoiced() {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("A %s order cannot be invoiced", order.Status), nil)
+	}
+
+	// Invoices of a channel are numbered one at a time, without gaps
+	err = svc.channelRepo.Lock(ctx, order.ChannelId)
+	if err != nil {
+		return err
+	}
+	lastSequence, err := svc.orderRepo.GetLastInvoiceSequence(ctx, order.ChannelId)
+	if err != nil {
+		return err
+	}
+
+	return svc.orderRepo.SetInvoice(ctx, orderId, lastSequence+1, util.GetLocalTime())
+}
+
+func (svc *invoiceService) buildOrderInvoice(ctx *context.Context, orderId uint) (*orderInvoice, *errs.XError) {
+	order, err := svc.orderRepo.Get(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	if order.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Order not found", nil)
+	}
+
+	if order.InvoicedAt == nil {
+		return nil, errs.NewXError(errs.VALIDATION, "No invoice has been issued for the order yet", nil)
+	}
+
+	payments, err := svc.orderPaymentRepo.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := svc.channelRepo.Get(ctx, utils.GetChannelId(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	invoice := &orderInvoice{
+		Number:            order.InvoiceNumber,
+		Date:              *order.InvoicedAt,
+		ChannelName:       channel.Name,
+		ChannelGSTIN:      channel.GSTIN,
+		Order:             order,
+		Payments:          payments,
+		AdditionalCharges: order.AdditionalCharges,
+	}
+
+	// Removed items stay on the order inactive, they are not billed
+	for _, item := range order.OrderItems {
+		if item.Model == nil || !item.IsActive {
+			continue
+		}
+		invoice.Items = append(invoice.Items, item)
+		invoice.ItemsTotal += item.Total
+		invoice.CGSTTotal += item.CGSTAmount
+		invoice.SGSTTotal += item.SGSTAmount
+		invoice.IGSTTotal += item.IGSTAmount
+	}
+	for _, payment := range payments {
+		invoice.PaidAmount += payment.Amount
+	}
+	invoice.GrandTotal = invoice.ItemsTotal + invoice.TaxTotal() + invoice.AdditionalCharges
+	invoice.BalanceDue = invoice.GrandTotal - invoice.PaidAmount
+
+	return invoice, nil
+}
+
+func renderInvoiceHTML(invoice *orderInvoice) ([]byte, *errs.XError) {
+	templatePath := filepath.Join(constants.HTML_TEMPLATE_PATH, constants.ORDER_INVOICE_HTML_TEMPLATE)
+	template, err := os.ReadFile(templatePath)
+	if err != nil {
+		return nil, errs.NewXError(errs.IO, "Unable to read invoice template", err)
+	}
+
+	var itemRows strings.Builder
+	for i, item := range invoice.Items {
+		fmt.Fprintf(&itemRows, `<tr><td>%d</td><td>%s</td><td>%s</td><td class="amount">%d</td><td class="amount">%s</td><td class="amount">%s</td><td class="amount">%s</td><td class="amount">%s</td><td class="amount">%s</td></tr>`,
+			i+1, html.EscapeString(orderItemLabel(item)), html.EscapeString(item.HSNCode), item.Quantity, formatAmount(item.Price), formatAmount(item.AdditionalCharges),
+			formatAmount(item.Total), formatTaxRate(item.TaxRate), formatAmount(item.TaxAmount()))
+	}
+
+	var taxRows strings.Builder
+	for _, line := range invoice.taxLines() {
+		fmt.Fprintf(&taxRows, `<tr><td>%s</td><td class="amount">%s</td></tr>`, line.Label, formatAmount(line.Amount))
+	}
+
+	var companyGSTIN string
+	if invoice.ChannelGSTIN != "" {
+		companyGSTIN = fmt.Sprintf(`<p style="color: #666; font-size: 14px; margin: 0;">GSTIN: %s</p>`, html.EscapeString(invoice.ChannelGSTIN))
+	}
+
+	var paymentRows strings.Builder
+	for _, payment := range invoice.Payments {
+		fmt.Fprintf(&paymentRows, `<tr><td>%s</td><td>%s</td><td>%s</td><td class="amount">%s</td></tr>`,
+			payment.PaidAt.Format(time.DateOnly), html.EscapeString(string(payment.Mode)), html.EscapeString(payment.Reference), formatAmount(payment.Amount))
+	}
+	if len(invoice.Payments) == 0 {
+		paymentRows.WriteString(`<tr><td colspan="4" style="color: #666;">No payments recorded</td></tr>`)
+	}
+
+	customerName, customerPhone, customerAddress := invoiceCustomer(invoice.Order)
+
+	replacer := strings.NewReplacer(
+		"**COMPANY_NAME**", html.EscapeString(invoice.ChannelName),
+		"**COMPANY_GSTIN**", companyGSTIN,
+		"**INVOICE_TITLE**", invoice.Title(),
+		"**PLACE_OF_SUPPLY**", html.EscapeString(placeOfSupply(invoice.Order)),
+		"**INVOICE_NUMBER**", invoice.Number,
+		"**INVOICE_DATE**", invoice.Date.Format(time.DateOnly),
+		"**CUSTOMER_NAME**", html.EscapeString(customerName),
+		"**CUSTOMER_PHONE**", html.EscapeString(customerPhone),
+		"**CUSTOMER_ADDRESS**", html.EscapeString(customerAddress),
+		"**ORDER_STATUS**", string(invoice.Order.Status),
+		"**EXPECTED_DELIVERY_DATE**", formatDate(invoice.Order.ExpectedDeliveryDate),
+		"**ORDER_ITEM_ROWS**", itemRows.String(),
+		"**ITEMS_TOTAL**", formatAmount(invoice.ItemsTotal),
+		"**TAX_ROWS**", taxRows.String(),
+		"**ADDITIONAL_CHARGES**", formatAmount(invoice.AdditionalCharges),
+		"**GRAND_TOTAL**", formatAmount(invoice.GrandTotal),
+		"**PAYMENT_ROWS**", paymentRows.String(),
+		"**PAID_AMOUNT**", formatAmount(invoice.PaidAmount),
+		"**BALANCE_DUE**", formatAmount(invoice.BalanceDue),
+	)
+
+	return []byte(replacer.Replace(string(template))), nil
+}
+
+func renderInvoicePDF(invoice *orderInvoice) []byte {
+	const (
+		left   = 40.0
+		right  = pdf.PageWidth - 40
+		bottom = pdf.PageHeight - 60
+	)
+
+	doc := pdf.NewDocument()
+	y := 60.0
+
+	// newLine moves to the next line, starting a new page when the current one is full
+	newLine := func(height float64) {
+		y += height
+		if y > bottom {
+			doc.AddPage()
+			y = 60
+		}
+	}
+
+	doc.Text(left, y, 18, true, invoice.ChannelName)
+	doc.TextRight(right, y, 16, true, invoice.Title())
+	newLine(18)
+	if invoice.ChannelGSTIN != "" {
+		doc.Text(left, y, 10, false, "GSTIN: "+invoice.ChannelGSTIN)
+	}
+	doc.TextRight(right, y, 10, false, "No: "+invoice.Number)
+	newLine(14)
+	doc.TextRight(right, y, 10, false, "Date: "+invoice.Date.Format(time.DateOnly))
+	newLine(12)
+	doc.Line(left, y, right, y, 0.5)
+	newLine(22)
+
+	customerName, customerPhone, customerAddress := invoiceCustomer(invoice.Order)
+	doc.Text(left, y, 10, false, "Billed To")
+	doc.TextRight(right, y, 10, false, "Order Status: "+string(invoice.Order.Status))
+	newLine(14)
+	doc.Text(left, y, 11, true, customerName)
+	doc.TextRight(right, y, 10, false, "Expected Delivery: "+formatDate(invoice.Order.ExpectedDeliveryDate))
+	newLine(14)
+	doc.TextRight(right, y, 10, false, "Place of Supply: "+placeOfSupply(invoice.Order))
+	for _, line := range []string{customerPhone, customerAddress} {
+		if line != "" {
+			doc.Text(left, y, 10, false, line)
+			newLine(14)
+		}
+	}
+	newLine(16)
+
+	// Order items
+	cols := []float64{left, left + 20, right - 340, right - 255, right - 200, right - 145, right - 90, right - 55, right}
+	doc.Text(cols[0], y, 9, true, "#")
+	doc.Text(cols[1], y, 9, true, "Item")
+	doc.Text(cols[2], y, 9, true, "HSN")
+	doc.TextRight(cols[3], y, 9, true, "Qty")
+	doc.TextRight(cols[4], y, 9, true, "Price")
+	doc.TextRight(cols[5], y, 9, true, "Addl.")
+	doc.TextRight(cols[6], y, 9, true, "Taxable")
+	doc.TextRight(cols[7], y, 9, true, "GST %")
+	doc.TextRight(cols[8], y, 9, true, "Tax")
+	newLine(6)
+	doc.Line(left, y, right, y, 0.5)
+	newLine(14)
+	for i, item := range invoice.Items {
+		doc.Text(cols[0], y, 9, false, fmt.Sprintf("%d", i+1))
+		doc.Text(cols[1], y, 9, false, orderItemLabel(item))
+		doc.Text(cols[2], y, 9, false, item.HSNCode)
+		doc.TextRight(cols[3], y, 9, false, fmt.Sprintf("%d", item.Quantity))
+		doc.TextRight(cols[4], y, 9, false, formatAmount(item.Price))
+		doc.TextRight(cols[5], y, 9, false, formatAmount(item.AdditionalCharges))
+		doc.TextRight(cols[6], y, 9, false, formatAmount(item.Total))
+		doc.TextRight(cols[7], y, 9, false, formatTaxRate(item.TaxRate))
+		doc.TextRight(cols[8], y, 9, false, formatAmount(item.TaxAmount()))
+		newLine(16)
+	}
+	doc.Line(left, y-10, right, y-10, 0.5)
+	newLine(4)
+
+	summaryLine := func(label string, amount float64, bold bool) {
+		doc.Text(right-200, y, 10, bold, label)
+		doc.TextRight(right, y, 10, bold, formatAmount(amount))
+		newLine(16)
+	}
+	summaryLine("Taxable Value", invoice.ItemsTotal, false)
+	for _, line := range invoice.taxLines() {
+		summaryLine(line.Label, line.Amount, false)
+	}
+	summaryLine("Additional Charges", invoice.AdditionalCharges, false)
+	summaryLine("Grand Total", invoice.GrandTotal, true)
+	newLine(14)
+
+	// Payments
+	doc.Text(left, y, 12, true, "Payments")
+	newLine(18)
+	doc.Text(left, y, 10, true, "Date")
+	doc.Text(left+90, y, 10, true, "Mode")
+	doc.Text(left+160, y, 10, true, "Reference")
+	doc.TextRight(right, y, 10, true, "Amount")
+	newLine(6)
+	doc.Line(left, y, right, y, 0.5)
+	newLine(14)
+	if len(invoice.Payments) == 0 {
+		doc.Text(left, y, 10, false, "No payments recorded")
+		newLine(16)
+	}
+	for _, payment := range invoice.Payments {
+		doc.Text(left, y, 10, false, payment.PaidAt.Format(time.DateOnly))
+		doc.Text(left+90, y, 10, false, string(payment.Mode))
+		doc.Text(left+160, y, 10, false, payment.Reference)
+		doc.TextRight(right, y, 10, false, formatAmount(payment.Amount))
+		newLine(16)
+	}
+	newLine(4)
+	summaryLine("Paid", invoice.PaidAmount, false)
+	summaryLine("Balance Due", invoice.BalanceDue, true)
+
+	newLine(20)
+	doc.Text(left, y, 10, false, "Thank you for your business!")
+
+	return doc.Bytes()
+}
+
+// GetSaleReceipt renders the PDF receipt of a counter sale
+func (svc *invoiceService) GetSaleReceipt(ctx *context.Context, saleId uint) (*responseModel.FileContent, *errs.XError) {
+	sale, err := svc.saleRepo.Get(ctx, saleId)
+	if err != nil {
+		return nil, err
+	}
+	if sale.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Sale not found", nil)
+	}
+
+	channel, err := svc.channelRepo.Get(ctx, utils.GetChannelId(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &responseModel.FileContent{
+		FileName:    sale.ReceiptNumber() + ".pdf",
+		ContentType: "application/pdf",
+		Content:     renderSaleReceiptPDF(sale, channel),
+	}, nil
+}
+
+func renderSaleReceiptPDF(sale *entities.Sale, channel *entities.Channel) []byte {
+	const (
+		left   = 40.0
+		right  = pdf.PageWidth - 40
+		bottom = pdf.PageHeight - 60
+	)
+
+	doc := pdf.NewDocument()
+	y := 60.0
+
+	newLine := func(height float64) {
+		y += height
+		if y > bottom {
+			doc.AddPage()
+			y = 60
+		}
+	}
+
+	title := "RECEIPT"
+	if channel.GSTIN != "" {
+		title = "TAX INVOICE"
+	}
+	doc.Text(left, y, 18, true, channel.Name)
+	doc.TextRight(right, y, 16, true, title)
+	newLine(18)
+	if channel.GSTIN != "" {
+		doc.Text(left, y, 10, false, "GSTIN: "+channel.GSTIN)
+	}
+	doc.TextRight(right, y, 10, false, "No: "+sale.ReceiptNumber())
+	newLine(14)
+	doc.TextRight(right, y, 10, false, "Date: "+sale.SoldAt.Format("2006-01-02 15:04"))
+	newLine(12)
+	doc.Line(left, y, right, y, 0.5)
+	newLine(22)
+
+	if sale.Customer != nil {
+		doc.Text(left, y, 10, false, "Billed To")
+		newLine(14)
+		doc.Text(left, y, 11, true, strings.TrimSpace(sale.Customer.FirstName+" "+sale.Customer.LastName))
+		newLine(14)
+		if sale.Customer.PhoneNumber != "" {
+			doc.Text(left, y, 10, false, sale.Customer.PhoneNumber)
+			newLine(14)
+		}
+		newLine(16)
+	}
+
+	cols := []float64{left, left + 20, right - 320, right - 250, right - 190, right - 140, right - 90, right - 55, right}
+	doc.Text(cols[0], y, 9, true, "#")
+	doc.Text(cols[1], y, 9, true, "Item")
+	doc.Text(cols[2], y, 9, true, "HSN")
+	doc.TextRight(cols[3], y, 9, true, "Qty")
+	doc.TextRight(cols[4], y, 9, true, "Price")
+	doc.TextRight(cols[5], y, 9, true, "Disc.")
+	doc.TextRight(cols[6], y, 9, true, "Taxable")
+	doc.TextRight(cols[7], y, 9, true, "GST %")
+	doc.TextRight(cols[8], y, 9, true, "Amount")
+	newLine(6)
+	doc.Line(left, y, right, y, 0.5)
+	newLine(14)
+	for i, line := range sale.Lines {
+		var name, unit string
+		if line.Product != nil {
+			name = line.Product.Name
+			unit = string(line.Product.StockUnit())
+		}
+		doc.Text(cols[0], y, 9, false, fmt.Sprintf("%d", i+1))
+		doc.Text(cols[1], y, 9, false, name)
+		doc.Text(cols[2], y, 9, false, line.HSNCode)
+		doc.TextRight(cols[3], y, 9, false, strconv.FormatFloat(line.Quantity, 'f', -1, 64)+" "+strings.ToLower(unit))
+		doc.TextRight(cols[4], y, 9, false, formatAmount(line.SellingPrice))
+		doc.TextRight(cols[5], y, 9, false, formatAmount(line.Discount))
+		doc.TextRight(cols[6], y, 9, false, formatAmount(line.TaxableValue))
+		doc.TextRight(cols[7], y, 9, false, formatTaxRate(line.TaxRate))
+		doc.TextRight(cols[8], y, 9, false, formatAmount(line.Total()))
+		newLine(16)
+	}
+	doc.Line(left, y-10, right, y-10, 0.5)
+	newLine(4)
+
+	summaryLine := func(label string, amount float64, bold bool) {
+		doc.Text(right-200, y, 10, bold, label)
+		doc.TextRight(right, y, 10, bold, formatAmount(amount))
+		newLine(16)
+	}
+	summaryLine("Sub Total", sale.SubTotal, false)
+	if sale.Discount > 0 {
+		summaryLine("Discount", -sale.Discount, false)
+	}
+	summaryLine("Taxable Value", sale.TaxableValue, false)
+	if sale.IGSTAmount > 0 {
+		summaryLine("IGST", sale.IGSTAmount, false)
+	}
+	if sale.CGSTAmount > 0 || sale.SGSTAmount > 0 {
+		summaryLine("CGST", sale.CGSTAmount, false)
+		summaryLine("SGST", sale.SGSTAmount, false)
+	}
+	summaryLine("Grand Total", sale.GrandTotal, true)
+	newLine(10)
+
+	payment := "Paid by " + string(sale.PaymentMode)
+	if sale.PaymentReference != "" {
+		payment += " (" + sale.PaymentReference + ")"
+	}
+	doc.Text(left, y, 10, false, payment)
+	newLine(30)
+	doc.Text(left, y, 10, false, "Thank you for shopping with us!")
+
+	return doc.Bytes()
+}
+
+func invoiceCustomer(order *entities.Order) (name string, phone string, address string) {
+	if order.Customer == nil {
+		return "", "", ""
+	}
+	return strings.TrimSpace(order.Customer.FirstName + " " + order.Customer.LastName), order.Customer.PhoneNumber, order.Customer.Address
+}
+
+// orderItemLabel prefers the item description and falls back to the dress type and person
+func orderItemLabel(item entities.OrderItem) string {
+	if item.Description != "" {
+		return item.Description
+	}
+	if item.Measurement != nil {
+		var parts []string
+		if item.Measurement.DressType != nil {
+			parts = append(parts, item.Measurement.DressType.Name)
+		}
+		if item.Measurement.Person != nil {
+			parts = append(parts, strings.TrimSpace(item.Measurement.Person.FirstName+" "+item.Measurement.Person.LastName))
+		}
+		return strings.Join(parts, " - ")
+	}
+	return ""
+}
+
+// placeOfSupply is the customer's state, which decides between CGST/SGST and IGST
+func placeOfSupply(order *entities.Order) string {
+	if order.Customer == nil || order.Customer.State == "" {
+		return "-"
+	}
+	return order.Customer.State
+}
+
+func formatTaxRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', -1, 64)
+}
+
+func formatAmount(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
+
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return "-"
+	}
+	return date.Format(time.DateOnly)
+}
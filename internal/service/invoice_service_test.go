@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IssueOrderInvoice(t *testing.T) {
+
+	store := newStockStore()
+	svc := &invoiceService{orderRepo: fakeOrderRepo{store: store}, channelRepo: fakeChannelRepo{}}
+	order := func(channelId uint, status entities.OrderStatus) *entities.Order {
+		order := store.addOrder(status, 1)
+		order.ChannelId = channelId
+		return order
+	}
+
+	// Draft and cancelled orders are not invoiced
+	draft := order(1, entities.DRAFT)
+	err := svc.IssueOrderInvoice(testContext(), draft.ID)
+	require.NotNil(t, err)
+	require.Equal(t, errs.VALIDATION, err.Code)
+	require.Nil(t, draft.InvoicedAt)
+	require.NotNil(t, svc.IssueOrderInvoice(testContext(), order(1, entities.CANCELLED).ID))
+
+	// Every channel numbers its invoices from 1
+	first, second, other := order(1, entities.DELIVERED), order(1, entities.CONFIRMED), order(2, entities.DELIVERED)
+	require.Nil(t, svc.IssueOrderInvoice(testContext(), first.ID))
+	require.Nil(t, svc.IssueOrderInvoice(testContext(), second.ID))
+	require.Nil(t, svc.IssueOrderInvoice(testContext(), other.ID))
+	require.Equal(t, "INV-000001", first.InvoiceNumber)
+	require.Equal(t, "INV-000002", second.InvoiceNumber)
+	require.Equal(t, "INV-000001", other.InvoiceNumber)
+
+	// An invoiced order keeps its number
+	invoicedAt := first.InvoicedAt
+	require.Nil(t, svc.IssueOrderInvoice(testContext(), first.ID))
+	require.Equal(t, "INV-000001", first.InvoiceNumber)
+	require.Equal(t, invoicedAt, first.InvoicedAt)
+}
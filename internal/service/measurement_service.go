@@ -2,7 +2,13 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/imkarthi24/sf-backend/internal/constants"
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
@@ -15,35 +21,55 @@ import (
 )
 
 type MeasurementService interface {
-	SaveMeasurement(*context.Context, requestModel.Measurement) *errs.XError
-	SaveBulkMeasurements(*context.Context, []requestModel.BulkMeasurementRequest) *errs.XError
-	UpdateMeasurement(*context.Context, requestModel.Measurement, uint) *errs.XError
-	BulkUpdateMeasurements(*context.Context, []requestModel.Measurement) *errs.XError
-	Get(*context.Context, uint) (*responseModel.Measurement, *errs.XError)
+	SaveMeasurement(*context.Context, requestModel.Measurement) ([]responseModel.MeasurementAnomaly, *errs.XError)
+	SaveBulkMeasurements(*context.Context, []requestModel.BulkMeasurementRequest) ([]responseModel.MeasurementAnomaly, *errs.XError)
+	UpdateMeasurement(*context.Context, requestModel.Measurement, uint) ([]responseModel.MeasurementAnomaly, *errs.XError)
+	BulkUpdateMeasurements(*context.Context, []requestModel.Measurement) ([]responseModel.MeasurementAnomaly, *errs.XError)
+	Get(*context.Context, uint, string) (*responseModel.Measurement, *errs.XError)
 	GetAll(*context.Context, string) ([]responseModel.MeasurementBrowse, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
+	ShowInDisplayUnit(*context.Context, string, []*responseModel.Measurement) *errs.XError
+	Diff(*context.Context, uint, *uint, *uint, string) (*responseModel.MeasurementDiff, *errs.XError)
+	Restore(*context.Context, uint, uint) ([]responseModel.MeasurementAnomaly, *errs.XError)
+	SnapshotForOrder(*context.Context, uint) *errs.XError
+	GetFlagged(*context.Context, string, *uint) ([]responseModel.MeasurementFlag, *errs.XError)
+	ReviewFlag(*context.Context, uint, requestModel.MeasurementFlagReview) *errs.XError
 }
 
 type measurementService struct {
 	measurementRepo        repository.MeasurementRepository
 	measurementHistoryRepo repository.MeasurementHistoryRepository
+	fieldRepo              repository.MeasurementFieldRepository
+	flagRepo               repository.MeasurementFlagRepository
+	orderItemRepo          repository.OrderItemRepository
+	userRepo               repository.UserRepository
+	masterConfigSvc        MasterConfigService
 	mapper                 mapper.Mapper
 	respMapper             mapper.ResponseMapper
 }
 
-func ProvideMeasurementService(repo repository.MeasurementRepository, measurementHistoryRepo repository.MeasurementHistoryRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) MeasurementService {
+func ProvideMeasurementService(repo repository.MeasurementRepository, measurementHistoryRepo repository.MeasurementHistoryRepository, fieldRepo repository.MeasurementFieldRepository, flagRepo repository.MeasurementFlagRepository, orderItemRepo repository.OrderItemRepository, userRepo repository.UserRepository, masterConfigSvc MasterConfigService, mapper mapper.Mapper, respMapper mapper.ResponseMapper) MeasurementService {
 	return measurementService{
 		measurementRepo:        repo,
 		measurementHistoryRepo: measurementHistoryRepo,
+		fieldRepo:              fieldRepo,
+		flagRepo:               flagRepo,
+		orderItemRepo:          orderItemRepo,
+		userRepo:               userRepo,
+		masterConfigSvc:        masterConfigSvc,
 		mapper:                 mapper,
 		respMapper:             respMapper,
 	}
 }
 
-func (svc measurementService) SaveMeasurement(ctx *context.Context, measurement requestModel.Measurement) *errs.XError {
+func (svc measurementService) SaveMeasurement(ctx *context.Context, measurement requestModel.Measurement) ([]responseModel.MeasurementAnomaly, *errs.XError) {
 	dbMeasurement, err := svc.mapper.Measurement(measurement)
 	if err != nil {
-		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save measurement", err)
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Unable to save measurement", err)
+	}
+
+	if errr := svc.storeValues(ctx, []*entities.Measurement{dbMeasurement}); errr != nil {
+		return nil, errr
 	}
 
 	// Set TakenById to the current user if it's not provided in the request
@@ -54,19 +80,19 @@ func (svc measurementService) SaveMeasurement(ctx *context.Context, measurement
 
 	errr := svc.measurementRepo.Create(ctx, dbMeasurement)
 	if errr != nil {
-		return errr
+		return nil, errr
 	}
 
 	// Record measurement history for CREATED action
 	errr = svc.recordMeasurementHistory(ctx, dbMeasurement.ID, entities.MeasurementHistoryActionCreated, nil)
 	if errr != nil {
-		return errr
+		return nil, errr
 	}
 
-	return nil
+	return svc.flagAnomalies(ctx, []*entities.Measurement{dbMeasurement}, nil)
 }
 
-func (svc measurementService) SaveBulkMeasurements(ctx *context.Context, bulkRequests []requestModel.BulkMeasurementRequest) *errs.XError {
+func (svc measurementService) SaveBulkMeasurements(ctx *context.Context, bulkRequests []requestModel.BulkMeasurementRequest) ([]responseModel.MeasurementAnomaly, *errs.XError) {
 	var measurementsToCreate []*entities.Measurement
 	userID := utils.GetUserId(ctx)
 
@@ -82,6 +108,7 @@ func (svc measurementService) SaveBulkMeasurements(ctx *context.Context, bulkReq
 					IsActive: true,
 				},
 				Value:       valuesJSON,
+				Unit:        entities.ParseMeasurementUnit(measurementItem.Unit),
 				PersonId:    bulkRequest.PersonId,
 				DressTypeId: measurementItem.DressTypeId,
 				TakenById:   &userID,
@@ -91,38 +118,49 @@ func (svc measurementService) SaveBulkMeasurements(ctx *context.Context, bulkReq
 		}
 	}
 
+	if errr := svc.storeValues(ctx, measurementsToCreate); errr != nil {
+		return nil, errr
+	}
+
 	// Batch create all measurements
 	errr := svc.measurementRepo.BatchCreate(ctx, measurementsToCreate)
 	if errr != nil {
-		return errr
+		return nil, errr
 	}
 
 	// Record measurement history for each created measurement
 	for _, measurement := range measurementsToCreate {
 		errr = svc.recordMeasurementHistory(ctx, measurement.ID, entities.MeasurementHistoryActionCreated, nil)
 		if errr != nil {
-			return errr
+			return nil, errr
 		}
 	}
 
-	return nil
+	return svc.flagAnomalies(ctx, measurementsToCreate, nil)
 }
 
-func (svc measurementService) UpdateMeasurement(ctx *context.Context, measurement requestModel.Measurement, id uint) *errs.XError {
+func (svc measurementService) UpdateMeasurement(ctx *context.Context, measurement requestModel.Measurement, id uint) ([]responseModel.MeasurementAnomaly, *errs.XError) {
 	oldMeasurement, err := svc.measurementRepo.Get(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if oldMeasurement == nil {
-		return errs.NewXError(errs.NOT_EXIST, "Measurement not found", nil)
+		return nil, errs.NewXError(errs.NOT_EXIST, "Measurement not found", nil)
 	}
 
 	dbMeasurement, mapErr := svc.mapper.Measurement(measurement)
 	if mapErr != nil {
-		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update measurement", mapErr)
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Unable to update measurement", mapErr)
 	}
 
 	dbMeasurement.ID = id
+	if dbMeasurement.DressTypeId == 0 {
+		dbMeasurement.DressTypeId = oldMeasurement.DressTypeId
+	}
+	if errr := svc.storeValues(ctx, []*entities.Measurement{dbMeasurement}); errr != nil {
+		return nil, errr
+	}
+
 	// Set TakenById to the current user if it's not provided in the request
 	if measurement.TakenById == nil {
 		userID := utils.GetUserId(ctx)
@@ -131,24 +169,25 @@ func (svc measurementService) UpdateMeasurement(ctx *context.Context, measuremen
 
 	errr := svc.measurementRepo.Update(ctx, dbMeasurement)
 	if errr != nil {
-		return errr
+		return nil, errr
 	}
 
 	errr = svc.recordMeasurementHistory(ctx, id, entities.MeasurementHistoryActionUpdated, &oldMeasurement.Value)
 	if errr != nil {
-		return errr
+		return nil, errr
 	}
 
-	return nil
+	return svc.flagAnomalies(ctx, []*entities.Measurement{dbMeasurement}, map[uint]entitiy_types.JSON{id: oldMeasurement.Value})
 }
 
-func (svc measurementService) BulkUpdateMeasurements(ctx *context.Context, measurements []requestModel.Measurement) *errs.XError {
+func (svc measurementService) BulkUpdateMeasurements(ctx *context.Context, measurements []requestModel.Measurement) ([]responseModel.MeasurementAnomaly, *errs.XError) {
 	if len(measurements) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	var measurementsToUpdate []*entities.Measurement
 	var oldMeasurementsMap = make(map[uint]*entities.Measurement)
+	previousValues := make(map[uint]entitiy_types.JSON)
 
 	var ids []uint
 	for _, measurement := range measurements {
@@ -161,7 +200,7 @@ func (svc measurementService) BulkUpdateMeasurements(ctx *context.Context, measu
 	for _, id := range ids {
 		oldMeasurement, err := svc.measurementRepo.Get(ctx, id)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if oldMeasurement != nil {
 			oldMeasurementsMap[id] = oldMeasurement
@@ -175,17 +214,27 @@ func (svc measurementService) BulkUpdateMeasurements(ctx *context.Context, measu
 
 		dbMeasurement, mapErr := svc.mapper.Measurement(measurement)
 		if mapErr != nil {
-			return errs.NewXError(errs.INVALID_REQUEST, "Unable to update measurement", mapErr)
+			return nil, errs.NewXError(errs.INVALID_REQUEST, "Unable to update measurement", mapErr)
 		}
 
 		dbMeasurement.ID = measurement.ID
+		if oldMeasurement, exists := oldMeasurementsMap[measurement.ID]; exists {
+			if dbMeasurement.DressTypeId == 0 {
+				dbMeasurement.DressTypeId = oldMeasurement.DressTypeId
+			}
+			previousValues[measurement.ID] = oldMeasurement.Value
+		}
 		measurementsToUpdate = append(measurementsToUpdate, dbMeasurement)
 	}
 
+	if errr := svc.storeValues(ctx, measurementsToUpdate); errr != nil {
+		return nil, errr
+	}
+
 	if len(measurementsToUpdate) > 0 {
 		errr := svc.measurementRepo.BatchUpdate(ctx, measurementsToUpdate)
 		if errr != nil {
-			return errr
+			return nil, errr
 		}
 
 		// Record measurement history for each updated measurement
@@ -194,16 +243,17 @@ func (svc measurementService) BulkUpdateMeasurements(ctx *context.Context, measu
 			if exists {
 				errr = svc.recordMeasurementHistory(ctx, measurement.ID, entities.MeasurementHistoryActionUpdated, &oldMeasurement.Value)
 				if errr != nil {
-					return errr
+					return nil, errr
 				}
 			}
 		}
 	}
 
-	return nil
+	return svc.flagAnomalies(ctx, measurementsToUpdate, previousValues)
 }
 
-func (svc measurementService) Get(ctx *context.Context, id uint) (*responseModel.Measurement, *errs.XError) {
+// Get returns the measurement with its values in the unit asked for, the display unit of the user when none is
+func (svc measurementService) Get(ctx *context.Context, id uint, unit string) (*responseModel.Measurement, *errs.XError) {
 	measurement, err := svc.measurementRepo.Get(ctx, id)
 	if err != nil {
 		return nil, err
@@ -214,6 +264,10 @@ func (svc measurementService) Get(ctx *context.Context, id uint) (*responseModel
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Measurement data", mapErr)
 	}
 
+	if err := svc.ShowInDisplayUnit(ctx, unit, []*responseModel.Measurement{mappedMeasurement}); err != nil {
+		return nil, err
+	}
+
 	return mappedMeasurement, nil
 }
 
@@ -247,6 +301,446 @@ func (svc measurementService) Delete(ctx *context.Context, id uint) *errs.XError
 	return nil
 }
 
+// Diff compares two versions of a measurement field by field
+func (svc measurementService) Diff(ctx *context.Context, id uint, fromHistoryId *uint, toHistoryId *uint, unit string) (*responseModel.MeasurementDiff, *errs.XError) {
+	measurement, err := svc.measurementRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if measurement.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Measurement not found", nil)
+	}
+
+	target := entities.ParseMeasurementUnit(unit)
+	if target == "" {
+		target = svc.displayUnit(ctx)
+	}
+	if !target.IsValid() {
+		return nil, errs.NewXError(errs.VALIDATION, "Unit must be one of INCH, CM", nil)
+	}
+
+	fromValues, fromReplacedAt, err := svc.version(ctx, measurement, fromHistoryId)
+	if err != nil {
+		return nil, err
+	}
+	toValues, toReplacedAt, err := svc.version(ctx, measurement, toHistoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	fromValues, convErr := entities.ConvertMeasurementValues(fromValues, entities.MeasurementStorageUnit, target, entities.MeasurementDisplayDecimals)
+	if convErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to convert measurement values", convErr)
+	}
+	toValues, convErr = entities.ConvertMeasurementValues(toValues, entities.MeasurementStorageUnit, target, entities.MeasurementDisplayDecimals)
+	if convErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to convert measurement values", convErr)
+	}
+	changes, diffErr := entities.DiffMeasurementValues(fromValues, toValues)
+	if diffErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to compare measurement values", diffErr)
+	}
+
+	// Fields of the schema come first, in the order they are shown in
+	fields, err := svc.fieldRepo.GetByDressTypeId(ctx, measurement.DressTypeId)
+	if err != nil {
+		return nil, err
+	}
+	rank := make(map[string]int, len(fields))
+	labels := make(map[string]string, len(fields))
+	for i, field := range fields {
+		rank[field.Key] = i
+		labels[field.Key] = field.Label
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		rankI, knownI := rank[changes[i].Field]
+		rankJ, knownJ := rank[changes[j].Field]
+		if knownI != knownJ {
+			return knownI
+		}
+		return knownI && rankI < rankJ
+	})
+
+	diff := &responseModel.MeasurementDiff{
+		MeasurementId:  id,
+		FromHistoryId:  fromHistoryId,
+		FromReplacedAt: fromReplacedAt,
+		ToHistoryId:    toHistoryId,
+		ToReplacedAt:   toReplacedAt,
+		Unit:           string(target),
+		Changes:        make([]responseModel.MeasurementChange, 0, len(changes)),
+	}
+	for _, change := range changes {
+		diff.Changes = append(diff.Changes, responseModel.MeasurementChange{
+			Field:  change.Field,
+			Label:  labels[change.Field],
+			From:   change.From,
+			To:     change.To,
+			Delta:  change.Delta,
+			Status: string(change.Status),
+		})
+	}
+	return diff, nil
+}
+
+// Restore puts back the values a measurement had before a change in its history, they are checked
+// against the current schema and for anomalies as an update would be
+func (svc measurementService) Restore(ctx *context.Context, id uint, historyId uint) ([]responseModel.MeasurementAnomaly, *errs.XError) {
+	measurement, err := svc.measurementRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if measurement.Model == nil || !measurement.IsActive {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Measurement not found", nil)
+	}
+
+	values, _, err := svc.version(ctx, measurement, &historyId)
+	if err != nil {
+		return nil, err
+	}
+
+	// History keeps values in the storage unit, they are given back in the unit the measurement was taken in
+	values, convertErr := entities.ConvertMeasurementValues(values, entities.MeasurementStorageUnit, measurement.Unit, entities.MeasurementStorageDecimals)
+	if convertErr != nil {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Unable to restore measurement", convertErr)
+	}
+
+	userID := utils.GetUserId(ctx)
+	restored := &entities.Measurement{
+		Model:       &entities.Model{ID: id, IsActive: true},
+		Value:       values,
+		Unit:        measurement.Unit,
+		PersonId:    measurement.PersonId,
+		DressTypeId: measurement.DressTypeId,
+		TakenById:   &userID,
+	}
+	if err := svc.storeValues(ctx, []*entities.Measurement{restored}); err != nil {
+		return nil, err
+	}
+
+	if err := svc.measurementRepo.Update(ctx, restored); err != nil {
+		return nil, err
+	}
+
+	if err := svc.recordMeasurementHistory(ctx, id, entities.MeasurementHistoryActionUpdated, &measurement.Value); err != nil {
+		return nil, err
+	}
+
+	return svc.flagAnomalies(ctx, []*entities.Measurement{restored}, map[uint]entitiy_types.JSON{id: measurement.Value})
+}
+
+// SnapshotForOrder freezes the measurements of the items of a confirmed order
+func (svc measurementService) SnapshotForOrder(ctx *context.Context, orderId uint) *errs.XError {
+	items, err := svc.orderItemRepo.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	pending := make([]entities.OrderItem, 0)
+	measurementIds := make([]uint, 0)
+	for _, item := range items {
+		if item.NeedsMeasurementSnapshot() {
+			pending = append(pending, item)
+			measurementIds = append(measurementIds, *item.MeasurementId)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	measurements, err := svc.measurementRepo.GetByIds(ctx, measurementIds)
+	if err != nil {
+		return err
+	}
+	measurementsById := make(map[uint]entities.Measurement, len(measurements))
+	for _, measurement := range measurements {
+		measurementsById[measurement.ID] = measurement
+	}
+
+	takenAt := util.GetLocalTime()
+	schemas := make(map[uint][]entities.MeasurementField)
+	for _, item := range pending {
+		measurement, ok := measurementsById[*item.MeasurementId]
+		if !ok {
+			return errs.NewXError(errs.NOT_EXIST, fmt.Sprintf("Measurement %d of order item %d not found", *item.MeasurementId, item.ID), nil)
+		}
+
+		fields, ok := schemas[measurement.DressTypeId]
+		if !ok {
+			fields, err = svc.fieldRepo.GetByDressTypeId(ctx, measurement.DressTypeId)
+			if err != nil {
+				return err
+			}
+			schemas[measurement.DressTypeId] = fields
+		}
+
+		snapshot, jsonErr := json.Marshal(entities.NewMeasurementSnapshot(measurement, fields))
+		if jsonErr != nil {
+			return errs.NewXError(errs.MAPPING_ERROR, "Unable to snapshot measurement", jsonErr)
+		}
+		if err := svc.orderItemRepo.UpdateMeasurementSnapshot(ctx, item.ID, entitiy_types.JSON(snapshot), takenAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetFlagged lists the anomalies kept for review, the open ones when no status is given
+func (svc measurementService) GetFlagged(ctx *context.Context, status string, personId *uint) ([]responseModel.MeasurementFlag, *errs.XError) {
+	flagStatus := entities.MeasurementFlagOpen
+	if status != "" {
+		flagStatus = entities.MeasurementFlagStatus(strings.ToUpper(status))
+	}
+	if !flagStatus.IsValid() {
+		return nil, errs.NewXError(errs.VALIDATION, "Status must be one of OPEN, REVIEWED, SUPERSEDED", nil)
+	}
+
+	flags, err := svc.flagRepo.GetAll(ctx, flagStatus, personId)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedFlags, mapErr := svc.respMapper.MeasurementFlags(flags)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map MeasurementFlag data", mapErr)
+	}
+
+	measurements := make([]*responseModel.Measurement, 0, len(mappedFlags))
+	for i := range mappedFlags {
+		measurements = append(measurements, mappedFlags[i].Measurement)
+	}
+	if err := svc.ShowInDisplayUnit(ctx, "", measurements); err != nil {
+		return nil, err
+	}
+
+	return mappedFlags, nil
+}
+
+// ReviewFlag closes an open flag once the measurement was checked, with a note of what was found
+func (svc measurementService) ReviewFlag(ctx *context.Context, id uint, review requestModel.MeasurementFlagReview) *errs.XError {
+	flag, err := svc.flagRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if flag.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Measurement flag not found", nil)
+	}
+	if flag.Status != entities.MeasurementFlagOpen {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Measurement flag is already %s", flag.Status), nil)
+	}
+
+	note := strings.TrimSpace(review.Note)
+	if note == "" {
+		return errs.NewXError(errs.VALIDATION, "Note is required", nil)
+	}
+
+	return svc.flagRepo.Review(ctx, id, note, utils.GetUserId(ctx), util.GetLocalTime())
+}
+
+// version gives the values of a measurement at a history entry, or its current values
+func (svc measurementService) version(ctx *context.Context, measurement *entities.Measurement, historyId *uint) (entitiy_types.JSON, *time.Time, *errs.XError) {
+	if historyId == nil {
+		return measurement.Value, nil, nil
+	}
+
+	history, err := svc.measurementHistoryRepo.Get(ctx, *historyId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if history.Model == nil || !history.IsActive || history.MeasurementId != measurement.ID {
+		return nil, nil, errs.NewXError(errs.NOT_EXIST, fmt.Sprintf("History entry %d not found for this measurement", *historyId), nil)
+	}
+	if len(history.OldValues) == 0 {
+		return nil, nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("History entry %d records the creation of the measurement and has no earlier values", *historyId), nil)
+	}
+	return history.OldValues, &history.PerformedAt, nil
+}
+
+// storeValues validates the values of the measurements and converts them to the storage unit
+func (svc measurementService) storeValues(ctx *context.Context, measurements []*entities.Measurement) *errs.XError {
+	var displayUnit entities.MeasurementUnit
+	schemas := make(map[uint][]entities.MeasurementField)
+
+	var fieldErrors entities.MeasurementFieldErrors
+	for _, measurement := range measurements {
+		if measurement.Unit == "" {
+			if displayUnit == "" {
+				displayUnit = svc.displayUnit(ctx)
+			}
+			measurement.Unit = displayUnit
+		}
+		if !measurement.Unit.IsValid() {
+			return errs.NewXError(errs.VALIDATION, "Unit must be one of INCH, CM", nil)
+		}
+
+		fields, loaded := schemas[measurement.DressTypeId]
+		if !loaded {
+			var err *errs.XError
+			fields, err = svc.fieldRepo.GetByDressTypeId(ctx, measurement.DressTypeId)
+			if err != nil {
+				return err
+			}
+			schemas[measurement.DressTypeId] = fields
+		}
+
+		for _, fieldError := range entities.ValidateMeasurementValues(fields, measurement.Value, measurement.Unit) {
+			fieldError.DressTypeId = measurement.DressTypeId
+			fieldErrors = append(fieldErrors, fieldError)
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return errs.NewXError(errs.VALIDATION, "Invalid measurements: "+fieldErrors.Error(), fieldErrors)
+	}
+
+	for _, measurement := range measurements {
+		values, err := entities.ConvertMeasurementValues(measurement.Value, measurement.Unit, entities.MeasurementStorageUnit, entities.MeasurementStorageDecimals)
+		if err != nil {
+			return errs.NewXError(errs.INVALID_REQUEST, "Measurement values must be an object of measurement keys and numbers", err)
+		}
+		measurement.Value = values
+	}
+	return nil
+}
+
+// ShowInDisplayUnit converts the values of mapped measurements to the unit asked for or the display unit
+func (svc measurementService) ShowInDisplayUnit(ctx *context.Context, unit string, measurements []*responseModel.Measurement) *errs.XError {
+	target := entities.ParseMeasurementUnit(unit)
+	if target == "" {
+		target = svc.displayUnit(ctx)
+	}
+	if !target.IsValid() {
+		return errs.NewXError(errs.VALIDATION, "Unit must be one of INCH, CM", nil)
+	}
+
+	for _, measurement := range measurements {
+		if measurement == nil {
+			continue
+		}
+		values, err := entities.ConvertMeasurementValues(measurement.Values, entities.ParseMeasurementUnit(measurement.Unit), target, entities.MeasurementDisplayDecimals)
+		if err != nil {
+			return errs.NewXError(errs.MAPPING_ERROR, "Failed to convert measurement values", err)
+		}
+		measurement.Values = values
+		measurement.Unit = string(target)
+	}
+	return nil
+}
+
+// personMeasurements collects the measurements of mapped persons to be converted in place
+func personMeasurements(persons ...*responseModel.Person) []*responseModel.Measurement {
+	measurements := make([]*responseModel.Measurement, 0)
+	for _, person := range persons {
+		if person == nil {
+			continue
+		}
+		for i := range person.Measurements {
+			measurements = append(measurements, &person.Measurements[i])
+		}
+	}
+	return measurements
+}
+
+// flagAnomalies flags values of saved measurements that look like mistakes and returns them as warnings
+func (svc measurementService) flagAnomalies(ctx *context.Context, measurements []*entities.Measurement, previousValues map[uint]entitiy_types.JSON) ([]responseModel.MeasurementAnomaly, *errs.XError) {
+	settings := svc.anomalySettings(ctx)
+
+	warnings := make([]responseModel.MeasurementAnomaly, 0)
+	flags := make([]entities.MeasurementFlag, 0)
+	for _, measurement := range measurements {
+		if err := svc.flagRepo.SupersedeOpen(ctx, measurement.ID); err != nil {
+			return nil, err
+		}
+
+		stats, err := svc.measurementRepo.GetFieldStats(ctx, measurement.DressTypeId, measurement.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Values that are not an object of numbers are saved for dress types without a schema, there is nothing to check in them
+		anomalies, detectErr := entities.DetectMeasurementAnomalies(measurement.Value, previousValues[measurement.ID], stats, settings, measurement.Unit)
+		if detectErr != nil {
+			continue
+		}
+
+		for _, anomaly := range anomalies {
+			flags = append(flags, entities.MeasurementFlag{
+				Model:         &entities.Model{IsActive: true},
+				MeasurementId: measurement.ID,
+				Field:         anomaly.Field,
+				Kind:          anomaly.Kind,
+				Message:       anomaly.Message,
+				Status:        entities.MeasurementFlagOpen,
+			})
+			warnings = append(warnings, responseModel.MeasurementAnomaly{
+				MeasurementId: measurement.ID,
+				PersonId:      measurement.PersonId,
+				DressTypeId:   measurement.DressTypeId,
+				Field:         anomaly.Field,
+				Kind:          string(anomaly.Kind),
+				Message:       anomaly.Message,
+			})
+		}
+	}
+
+	if err := svc.flagRepo.BatchCreate(ctx, flags); err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
+
+// anomalySettings reads the channel's anomaly check settings over the defaults
+func (svc measurementService) anomalySettings(ctx *context.Context) entities.MeasurementAnomalySettings {
+	settings := entities.DefaultMeasurementAnomalySettings
+	value, err := svc.masterConfigSvc.GetByName(ctx, constants.MEASUREMENT_ANOMALY_CHECK_CONFIG)
+	if err != nil || value == "" {
+		return settings
+	}
+
+	// Rules given replace the default rules, decoding into the default slice would overwrite it
+	settings.Rules = nil
+	if jsonErr := json.Unmarshal([]byte(value), &settings); jsonErr != nil {
+		return entities.DefaultMeasurementAnomalySettings
+	}
+	if settings.Rules == nil {
+		settings.Rules = entities.DefaultMeasurementAnomalySettings.Rules
+	}
+	return settings
+}
+
+// orderItemMeasurements collects the measurements of mapped order items and their snapshots
+func orderItemMeasurements(items []responseModel.OrderItem) []*responseModel.Measurement {
+	measurements := make([]*responseModel.Measurement, 0)
+	for i := range items {
+		measurements = append(measurements, items[i].Measurement)
+		if items[i].MeasurementSnapshot != nil {
+			measurements = append(measurements, &items[i].MeasurementSnapshot.Measurement)
+		}
+	}
+	return measurements
+}
+
+// displayUnit is the unit the user works in, from their config, the channel's or inches
+func (svc measurementService) displayUnit(ctx *context.Context) entities.MeasurementUnit {
+	config, err := svc.userRepo.GetUserConfig(ctx, utils.GetUserId(ctx))
+	if err == nil && config.Model != nil && config.Config != "" {
+		var settings struct {
+			MeasurementUnit string `json:"measurementUnit"`
+		}
+		if json.Unmarshal([]byte(config.Config), &settings) == nil {
+			if unit := entities.ParseMeasurementUnit(settings.MeasurementUnit); unit.IsValid() {
+				return unit
+			}
+		}
+	}
+
+	value, _ := svc.masterConfigSvc.GetByName(ctx, constants.MEASUREMENT_DISPLAY_UNIT_CONFIG)
+	if unit := entities.ParseMeasurementUnit(value); unit.IsValid() {
+		return unit
+	}
+	return entities.MeasurementUnitINCH
+}
+
 // recordMeasurementHistory creates a measurement history record
 func (svc measurementService) recordMeasurementHistory(ctx *context.Context, measurementId uint, action entities.MeasurementHistoryAction, oldValues *entitiy_types.JSON) *errs.XError {
 	userID := utils.GetUserId(ctx)
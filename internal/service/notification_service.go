@@ -20,6 +20,7 @@ type NotificationService interface {
 	GetPendingNotifications(ctx *context.Context) ([]entities.Notification, *errs.XError)
 
 	SendNotification(ctx *context.Context, notif entities.Notification) *errs.XError
+	SendEmailNotification(ctx *context.Context, notif requestModel.EmaiNotification) *errs.XError
 }
 
 type notificationService struct {
@@ -49,7 +50,7 @@ func (svc *notificationService) CreateEmailNotification(ctx *context.Context, em
 
 	notification.AddEmailNotification(*emailNotif)
 
-	return svc.notifRepo.CreateNotification(ctx, *notification)
+	return svc.notifRepo.CreateNotification(ctx, notification)
 
 }
 
@@ -71,7 +72,7 @@ func (svc *notificationService) CreateEmailNotifications(ctx *context.Context, n
 	notification := createNotification(notifs[0].Notification)
 	notification.AddEmailNotification(emailNotifs...)
 
-	err := svc.notifRepo.CreateNotification(ctx, *notification)
+	err := svc.notifRepo.CreateNotification(ctx, notification)
 
 	return err
 }
@@ -93,6 +94,35 @@ func (svc *notificationService) SendNotification(ctx *context.Context, notif ent
 
 }
 
+// SendEmailNotification records the email and sends it right away instead of waiting for the notification task
+func (svc *notificationService) SendEmailNotification(ctx *context.Context, email requestModel.EmaiNotification) *errs.XError {
+
+	emailNotif, err := createEmailNotification(email)
+	if err != nil {
+		return errs.NewXError(errs.EMAILERROR, "Error creating Email Notification", err)
+	}
+
+	notification := createNotification(email.Notification)
+	notification.AddEmailNotification(*emailNotif)
+
+	createErr := svc.notifRepo.CreateNotification(ctx, notification)
+	if createErr != nil {
+		return createErr
+	}
+
+	notifStatus := entities.NOTIF_COMPLETED
+	sendErr := svc.sendEmailNotification(ctx, notification.EmailNotifications)
+	if sendErr != nil {
+		notifStatus = entities.NOTIF_FAULTED
+	}
+
+	updateErr := svc.notifRepo.UpdateNotificationStatus(ctx, notification.ID, notifStatus)
+	if sendErr != nil {
+		return sendErr
+	}
+	return updateErr
+}
+
 func createNotification(notif *requestModel.Notification) *entities.Notification {
 	return &entities.Notification{
 		Status:       entities.NOTIF_PENDING,
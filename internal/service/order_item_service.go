@@ -2,12 +2,20 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
 	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
 	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
 	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
 	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
 )
 
 type OrderItemService interface {
@@ -16,19 +24,36 @@ type OrderItemService interface {
 	Get(*context.Context, uint) (*responseModel.OrderItem, *errs.XError)
 	GetAll(*context.Context, string) ([]responseModel.OrderItem, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
+	MoveStage(*context.Context, uint, requestModel.OrderItemStage) *errs.XError
 }
 
 type orderItemService struct {
-	orderItemRepo repository.OrderItemRepository
-	mapper        mapper.Mapper
-	respMapper    mapper.ResponseMapper
+	orderItemRepo    repository.OrderItemRepository
+	orderRepo        repository.OrderRepository
+	orderHistoryRepo repository.OrderHistoryRepository
+	taxSvc           TaxService
+	inventorySvc     InventoryService
+	componentRepo    repository.DressTypeComponentRepository
+	reservationSvc   StockReservationService
+	measurementSvc   MeasurementService
+	masterConfigSvc  MasterConfigService
+	mapper           mapper.Mapper
+	respMapper       mapper.ResponseMapper
 }
 
-func ProvideOrderItemService(repo repository.OrderItemRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) OrderItemService {
+func ProvideOrderItemService(repo repository.OrderItemRepository, orderRepo repository.OrderRepository, orderHistoryRepo repository.OrderHistoryRepository, taxSvc TaxService, inventorySvc InventoryService, componentRepo repository.DressTypeComponentRepository, reservationSvc StockReservationService, measurementSvc MeasurementService, masterConfigSvc MasterConfigService, mapper mapper.Mapper, respMapper mapper.ResponseMapper) OrderItemService {
 	return orderItemService{
-		orderItemRepo: repo,
-		mapper:        mapper,
-		respMapper:    respMapper,
+		orderItemRepo:    repo,
+		orderRepo:        orderRepo,
+		orderHistoryRepo: orderHistoryRepo,
+		taxSvc:           taxSvc,
+		inventorySvc:     inventorySvc,
+		componentRepo:    componentRepo,
+		reservationSvc:   reservationSvc,
+		measurementSvc:   measurementSvc,
+		masterConfigSvc:  masterConfigSvc,
+		mapper:           mapper,
+		respMapper:       respMapper,
 	}
 }
 
@@ -38,12 +63,22 @@ func (svc orderItemService) SaveOrderItem(ctx *context.Context, orderItem reques
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save order item", err)
 	}
 
-	errr := svc.orderItemRepo.Create(ctx, dbOrderItem)
+	errr := svc.applyTax(ctx, dbOrderItem)
 	if errr != nil {
 		return errr
 	}
 
-	return nil
+	errr = svc.orderItemRepo.Create(ctx, dbOrderItem)
+	if errr != nil {
+		return errr
+	}
+
+	errr = svc.snapshotMeasurements(ctx, dbOrderItem.OrderId)
+	if errr != nil {
+		return errr
+	}
+
+	return svc.reservationSvc.SyncForOrder(ctx, dbOrderItem.OrderId)
 }
 
 func (svc orderItemService) UpdateOrderItem(ctx *context.Context, orderItem requestModel.OrderItem, id uint) *errs.XError {
@@ -52,12 +87,33 @@ func (svc orderItemService) UpdateOrderItem(ctx *context.Context, orderItem requ
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update order item", err)
 	}
 
+	storedItem, errr := svc.orderItemRepo.Get(ctx, id)
+	if errr != nil {
+		return errr
+	}
+	if storedItem.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Order item not found", nil)
+	}
+	dbOrderItem.KeepProduction(*storedItem)
+	dbOrderItem.KeepMeasurementSnapshot(*storedItem)
+
+	errr = svc.applyTax(ctx, dbOrderItem)
+	if errr != nil {
+		return errr
+	}
+
 	dbOrderItem.ID = id
-	errr := svc.orderItemRepo.Update(ctx, dbOrderItem)
+	errr = svc.orderItemRepo.Update(ctx, dbOrderItem)
 	if errr != nil {
 		return errr
 	}
-	return nil
+
+	errr = svc.snapshotMeasurements(ctx, storedItem.OrderId)
+	if errr != nil {
+		return errr
+	}
+
+	return svc.reservationSvc.SyncForOrder(ctx, storedItem.OrderId)
 }
 
 func (svc orderItemService) Get(ctx *context.Context, id uint) (*responseModel.OrderItem, *errs.XError) {
@@ -71,6 +127,10 @@ func (svc orderItemService) Get(ctx *context.Context, id uint) (*responseModel.O
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map OrderItem data", mapErr)
 	}
 
+	if err := svc.measurementSvc.ShowInDisplayUnit(ctx, "", orderItemMeasurements([]responseModel.OrderItem{*mappedOrderItem})); err != nil {
+		return nil, err
+	}
+
 	return mappedOrderItem, nil
 }
 
@@ -85,13 +145,240 @@ func (svc orderItemService) GetAll(ctx *context.Context, search string) ([]respo
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map OrderItem data", mapErr)
 	}
 
+	if err := svc.measurementSvc.ShowInDisplayUnit(ctx, "", orderItemMeasurements(mappedOrderItems)); err != nil {
+		return nil, err
+	}
+
 	return mappedOrderItems, nil
 }
 
 func (svc orderItemService) Delete(ctx *context.Context, id uint) *errs.XError {
-	err := svc.orderItemRepo.Delete(ctx, id)
+	orderItem, err := svc.orderItemRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = svc.orderItemRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if orderItem.Model == nil {
+		return nil
+	}
+
+	err = svc.reservationSvc.SyncForOrder(ctx, orderItem.OrderId)
+	if err != nil {
+		return err
+	}
+
+	// The removed item may have been the one holding the order back
+	return svc.syncOrderStatus(ctx, orderItem.OrderId)
+}
+
+func (svc orderItemService) MoveStage(ctx *context.Context, id uint, stageChange requestModel.OrderItemStage) *errs.XError {
+	orderItem, err := svc.orderItemRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if orderItem.Model == nil || !orderItem.IsActive {
+		return errs.NewXError(errs.NOT_EXIST, "Order item not found", nil)
+	}
+
+	order := orderItem.Order
+	if order == nil || !isInProduction(order.Status) {
+		return errs.NewXError(errs.VALIDATION, "Order items can only change stage while the order is in production", nil)
+	}
+
+	targetStage := entities.OrderItemStage(stageChange.Stage)
+	if !targetStage.IsValid() {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid order item stage %s", targetStage), nil)
+	}
+	if !orderItem.Stage.CanMoveTo(targetStage) {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Order item cannot move from %s to %s", stageLabel(orderItem.Stage), targetStage), nil)
+	}
+
+	movedAt := util.GetLocalTime()
+	err = svc.orderItemRepo.UpdateStage(ctx, id, targetStage, movedAt, stageChange.AssignedTailorId)
+	if err != nil {
+		return err
+	}
+
+	// Materials are consumed the first time the item is cut, not again on rework
+	if targetStage == entities.STAGE_CUTTING && orderItem.CuttingAt == nil {
+		err = svc.consumeMaterials(ctx, orderItem)
+		if err != nil {
+			return err
+		}
+	}
+
+	changedFields := []string{entities.OrderChangeFieldItemStage}
+	assignedTailorId := orderItem.AssignedTailorId
+	if stageChange.AssignedTailorId != nil {
+		changedFields = append(changedFields, entities.OrderChangeFieldAssignedTailor)
+		assignedTailorId = stageChange.AssignedTailorId
+	}
+
+	itemData, jsonErr := json.Marshal(orderItemStageChange{
+		OrderItemId:      id,
+		FromStage:        orderItem.Stage,
+		ToStage:          targetStage,
+		AssignedTailorId: assignedTailorId,
+		MovedAt:          movedAt,
+	})
+	if jsonErr != nil {
+		return errs.NewXError(errs.INTERNAL, "Unable to record order item stage change", jsonErr)
+	}
+
+	orderItemData := entitiy_types.JSON(itemData)
+	err = svc.orderHistoryRepo.Create(ctx, &entities.OrderHistory{
+		Model:         &entities.Model{IsActive: true},
+		Action:        entities.OrderHistoryActionUpdated,
+		ChangedFields: strings.Join(changedFields, ","),
+		Reason:        stageChange.Reason,
+		Status:        &order.Status,
+		OrderItemId:   &id,
+		OrderItemData: &orderItemData,
+		OrderId:       order.ID,
+		PerformedAt:   movedAt,
+		PerformedById: utils.GetUserId(ctx),
+	})
+	if err != nil {
+		return err
+	}
+
+	return svc.syncOrderStatus(ctx, order.ID)
+}
+
+// consumeMaterials records an OUT movement for every product in the bill of materials of the item's dress type
+func (svc orderItemService) consumeMaterials(ctx *context.Context, orderItem *entities.OrderItem) *errs.XError {
+	if orderItem.Measurement == nil {
+		return nil
+	}
+
+	components, err := svc.componentRepo.GetByDressTypeId(ctx, orderItem.Measurement.DressTypeId)
+	if err != nil {
+		return err
+	}
+
+	pieces := orderItem.Quantity
+	if pieces <= 0 {
+		pieces = 1
+	}
+
+	for _, component := range components {
+		_, err = svc.inventorySvc.RecordStockMovement(ctx, requestModel.StockMovementRequest{
+			ProductId:   component.ProductId,
+			ChangeType:  string(entities.InventoryLogChangeTypeOUT),
+			Quantity:    component.Quantity * float64(pieces),
+			Reason:      fmt.Sprintf("Consumed for order #%d item #%d", orderItem.OrderId, orderItem.ID),
+			OrderItemId: &orderItem.ID,
+			SingleLot:   component.SingleLot,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// orderItemStageChange is stored in OrderHistory.OrderItemData for every stage move
+type orderItemStageChange struct {
+	OrderItemId      uint                    `json:"orderItemId"`
+	FromStage        entities.OrderItemStage `json:"fromStage,omitempty"`
+	ToStage          entities.OrderItemStage `json:"toStage"`
+	AssignedTailorId *uint                   `json:"assignedTailorId,omitempty"`
+	MovedAt          time.Time               `json:"movedAt"`
+}
+
+// syncOrderStatus sets the order status from its least-advanced item once every item is in production
+func (svc orderItemService) syncOrderStatus(ctx *context.Context, orderId uint) *errs.XError {
+	order, err := svc.orderRepo.Get(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	if order.Model == nil || !isInProduction(order.Status) {
+		return nil
+	}
+
+	orderItems, err := svc.orderItemRepo.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	derivedStatus, ok := entities.DeriveOrderStatus(orderItems)
+	if !ok || derivedStatus == order.Status {
+		return nil
+	}
+
+	// Moves outside the channel's graph, e.g. back to cutting on rework, leave the order status to staff
+	if !getStatusTransitions(ctx, svc.masterConfigSvc).Allows(order.Status, derivedStatus) {
+		return nil
+	}
+
+	err = svc.orderRepo.UpdateStatus(ctx, orderId, derivedStatus, order.DeliveredDate)
+	if err != nil {
+		return err
+	}
+
+	return svc.orderHistoryRepo.Create(ctx, &entities.OrderHistory{
+		Model:                &entities.Model{IsActive: true},
+		Action:               entities.OrderHistoryActionUpdated,
+		ChangedFields:        entities.OrderChangeFieldStatus,
+		Reason:               "Derived from order item stages",
+		Status:               &order.Status,
+		ExpectedDeliveryDate: order.ExpectedDeliveryDate,
+		DeliveredDate:        order.DeliveredDate,
+		OrderId:              orderId,
+		PerformedAt:          util.GetLocalTime(),
+		PerformedById:        utils.GetUserId(ctx),
+	})
+}
+
+// snapshotMeasurements freezes the measurements of the items when the order is already confirmed
+func (svc orderItemService) snapshotMeasurements(ctx *context.Context, orderId uint) *errs.XError {
+	order, err := svc.orderRepo.Get(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	if order.Model == nil || !order.Status.ReservesStock() {
+		return nil
+	}
+	return svc.measurementSvc.SnapshotForOrder(ctx, orderId)
+}
+
+// isInProduction reports whether the order has been confirmed and is not yet delivered or cancelled
+func isInProduction(status entities.OrderStatus) bool {
+	switch status {
+	case entities.DRAFT, entities.DELIVERED, entities.CANCELLED:
+		return false
+	}
+	return true
+}
+
+func stageLabel(stage entities.OrderItemStage) string {
+	if stage == "" {
+		return "NOT_STARTED"
+	}
+	return string(stage)
+}
+
+// applyTax computes GST for the item against the customer of the order it belongs to
+func (svc orderItemService) applyTax(ctx *context.Context, orderItem *entities.OrderItem) *errs.XError {
+	order, err := svc.orderRepo.Get(ctx, orderItem.OrderId)
+	if err != nil {
+		return err
+	}
+	if order.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Order not found", nil)
+	}
+
+	items := []entities.OrderItem{*orderItem}
+	err = svc.taxSvc.ApplyOrderItemTaxes(ctx, order.CustomerId, items)
 	if err != nil {
 		return err
 	}
+	*orderItem = items[0]
 	return nil
 }
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConsumeMaterials_SplitLots(t *testing.T) {
+
+	store := newStockStore()
+	lining := store.addProduct("Lining", entities.UnitOfMeasureMETER)
+	silk := store.addProduct("Silk", entities.UnitOfMeasureMETER)
+	store.addLot(lining.ID, "LN-01", 1.5)
+	store.addLot(lining.ID, "LN-02", 4)
+	store.addLot(silk.ID, "DL-01", 2)
+	dyeLot := store.addLot(silk.ID, "DL-02", 3)
+
+	svc := orderItemService{
+		inventorySvc:  newTestInventoryService(store),
+		componentRepo: fakeDressTypeComponentRepo{store: store},
+	}
+	orderItem := func(id uint) *entities.OrderItem {
+		return &entities.OrderItem{
+			Model:       &entities.Model{ID: id, IsActive: true},
+			OrderId:     10,
+			Quantity:    1,
+			Measurement: &entities.Measurement{DressTypeId: 7},
+		}
+	}
+
+	// A line that is not single lot is drawn oldest lot first, across lots
+	store.components[7] = []entities.DressTypeComponent{{ProductId: lining.ID, Quantity: 2.5}}
+	require.Nil(t, svc.consumeMaterials(testContext(), orderItem(100)))
+	require.Equal(t, 3.0, store.onHand(lining.ID))
+
+	logs := store.logsFor(lining.ID)
+	require.Len(t, logs, 2)
+	require.Equal(t, 1.5, logs[0].Quantity)
+	require.Equal(t, 1.0, logs[1].Quantity)
+	require.Equal(t, uint(100), *logs[0].OrderItemId)
+
+	// A single lot line needs one lot holding the whole length
+	store.components[7] = []entities.DressTypeComponent{{ProductId: silk.ID, Quantity: 4, SingleLot: true}}
+	err := svc.consumeMaterials(testContext(), orderItem(101))
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "No single lot of Silk")
+	require.Equal(t, 5.0, store.onHand(silk.ID))
+	require.Empty(t, store.logsFor(silk.ID))
+
+	store.components[7] = []entities.DressTypeComponent{{ProductId: silk.ID, Quantity: 2.5, SingleLot: true}}
+	require.Nil(t, svc.consumeMaterials(testContext(), orderItem(101)))
+	logs = store.logsFor(silk.ID)
+	require.Len(t, logs, 1)
+	require.Equal(t, dyeLot.ID, *logs[0].LotId)
+}
+
+func Test_MoveStage(t *testing.T) {
+
+	store := newStockStore()
+	silk := store.addProduct("Silk", entities.UnitOfMeasureMETER)
+	store.addLot(silk.ID, "DL-01", 5)
+	store.components[7] = []entities.DressTypeComponent{{ProductId: silk.ID, Product: silk, Quantity: 2}}
+
+	order := store.addOrder(entities.CONFIRMED, 1, 7, 7)
+	require.Nil(t, newTestReservationService(store).SyncForOrder(testContext(), order.ID))
+	first, second := order.OrderItems[0].ID, order.OrderItems[1].ID
+
+	svc := orderItemService{
+		orderItemRepo:    fakeOrderItemRepo{store: store},
+		orderRepo:        fakeOrderRepo{store: store},
+		orderHistoryRepo: fakeOrderHistoryRepo{store: store},
+		inventorySvc:     newTestInventoryService(store),
+		componentRepo:    fakeDressTypeComponentRepo{store: store},
+		masterConfigSvc:  fakeMasterConfigService{},
+	}
+	move := func(id uint, stage entities.OrderItemStage) *errs.XError {
+		return svc.MoveStage(testContext(), id, requestModel.OrderItemStage{Stage: string(stage)})
+	}
+
+	// Cutting an item draws its material from what is held for the order
+	require.Nil(t, move(first, entities.STAGE_CUTTING))
+	require.Equal(t, 3.0, store.onHand(silk.ID))
+	require.Equal(t, 2.0, store.reservedFor(order.ID))
+	require.Len(t, store.orderHistory, 1)
+	require.Equal(t, entities.CONFIRMED, order.Status)
+
+	// The order follows its least-advanced item once every item is in production
+	require.Nil(t, move(second, entities.STAGE_CUTTING))
+	require.Equal(t, 1.0, store.onHand(silk.ID))
+	require.Equal(t, entities.CUTTING, order.Status)
+
+	// Items move one stage forward at a time, going back for rework does not cut the material again
+	err := move(first, entities.STAGE_FINISHING)
+	require.NotNil(t, err)
+	require.Equal(t, errs.VALIDATION, err.Code)
+	require.Nil(t, move(first, entities.STAGE_STITCHING))
+	require.Nil(t, move(first, entities.STAGE_CUTTING))
+	require.Equal(t, 1.0, store.onHand(silk.ID))
+
+	// An item removed from the order no longer moves
+	order.OrderItems[1].IsActive = false
+	err = move(second, entities.STAGE_STITCHING)
+	require.NotNil(t, err)
+	require.Equal(t, errs.NOT_EXIST, err.Code)
+
+	// Nor does any item once the order has left production
+	order.Status = entities.DELIVERED
+	err = move(first, entities.STAGE_STITCHING)
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "while the order is in production")
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type OrderPaymentService interface {
+	Save(*context.Context, requestModel.OrderPayment, uint) *errs.XError
+	Update(*context.Context, requestModel.OrderPayment, uint, uint) *errs.XError
+	Get(*context.Context, uint, uint) (*responseModel.OrderPayment, *errs.XError)
+	GetByOrderId(*context.Context, uint) ([]responseModel.OrderPayment, *errs.XError)
+	Delete(*context.Context, uint, uint) *errs.XError
+}
+
+type orderPaymentService struct {
+	orderPaymentRepo repository.OrderPaymentRepository
+	orderRepo        repository.OrderRepository
+	mapper           mapper.Mapper
+	respMapper       mapper.ResponseMapper
+}
+
+func ProvideOrderPaymentService(repo repository.OrderPaymentRepository, orderRepo repository.OrderRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) OrderPaymentService {
+	return &orderPaymentService{
+		orderPaymentRepo: repo,
+		orderRepo:        orderRepo,
+		mapper:           mapper,
+		respMapper:       respMapper,
+	}
+}
+
+func (svc *orderPaymentService) Save(ctx *context.Context, req requestModel.OrderPayment, orderId uint) *errs.XError {
+	order, err := svc.orderRepo.Get(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	if order.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Order not found", nil)
+	}
+
+	req.OrderId = orderId
+	ent, mapErr := svc.mapper.OrderPayment(req)
+	if mapErr != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save order payment", mapErr)
+	}
+	if err := validateOrderPayment(ent); err != nil {
+		return err
+	}
+
+	return svc.orderPaymentRepo.Create(ctx, ent)
+}
+
+func (svc *orderPaymentService) Update(ctx *context.Context, req requestModel.OrderPayment, orderId uint, id uint) *errs.XError {
+	if _, err := svc.getForOrder(ctx, orderId, id); err != nil {
+		return err
+	}
+
+	req.OrderId = orderId
+	ent, mapErr := svc.mapper.OrderPayment(req)
+	if mapErr != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update order payment", mapErr)
+	}
+	if err := validateOrderPayment(ent); err != nil {
+		return err
+	}
+
+	ent.ID = id
+	return svc.orderPaymentRepo.Update(ctx, ent)
+}
+
+func (svc *orderPaymentService) Get(ctx *context.Context, orderId uint, id uint) (*responseModel.OrderPayment, *errs.XError) {
+	payment, err := svc.getForOrder(ctx, orderId, id)
+	if err != nil {
+		return nil, err
+	}
+	mapped, mapErr := svc.respMapper.OrderPayment(payment)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map order payment", mapErr)
+	}
+	return mapped, nil
+}
+
+func (svc *orderPaymentService) GetByOrderId(ctx *context.Context, orderId uint) ([]responseModel.OrderPayment, *errs.XError) {
+	payments, err := svc.orderPaymentRepo.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	mapped, mapErr := svc.respMapper.OrderPayments(payments)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map order payments", mapErr)
+	}
+	return mapped, nil
+}
+
+func (svc *orderPaymentService) Delete(ctx *context.Context, orderId uint, id uint) *errs.XError {
+	if _, err := svc.getForOrder(ctx, orderId, id); err != nil {
+		return err
+	}
+	return svc.orderPaymentRepo.Delete(ctx, id)
+}
+
+// getForOrder fetches a payment and makes sure it belongs to the given order
+func (svc *orderPaymentService) getForOrder(ctx *context.Context, orderId uint, id uint) (*entities.OrderPayment, *errs.XError) {
+	payment, err := svc.orderPaymentRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Model == nil || payment.OrderId != orderId {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Order payment not found", nil)
+	}
+	return payment, nil
+}
+
+func validateOrderPayment(payment *entities.OrderPayment) *errs.XError {
+	if payment.Amount <= 0 {
+		return errs.NewXError(errs.VALIDATION, "Payment amount must be greater than 0", nil)
+	}
+	if !payment.Mode.IsValid() {
+		return errs.NewXError(errs.VALIDATION, "Payment mode must be one of CASH, UPI, CARD, BANK", nil)
+	}
+	return nil
+}
@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
+	"github.com/imkarthi24/sf-backend/internal/constants"
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
 	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
@@ -21,19 +24,30 @@ type OrderService interface {
 	Get(*context.Context, uint) (*responseModel.Order, *errs.XError)
 	GetAll(*context.Context, string) ([]responseModel.Order, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
+	TransitionOrder(*context.Context, uint, requestModel.Status) *errs.XError
 }
 
 type orderService struct {
 	orderRepo        repository.OrderRepository
 	orderHistoryRepo repository.OrderHistoryRepository
+	masterConfigSvc  MasterConfigService
+	taxSvc           TaxService
+	reservationSvc   StockReservationService
+	measurementSvc   MeasurementService
+	invoiceSvc       InvoiceService
 	mapper           mapper.Mapper
 	respMapper       mapper.ResponseMapper
 }
 
-func ProvideOrderService(repo repository.OrderRepository, orderHistoryRepo repository.OrderHistoryRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) OrderService {
+func ProvideOrderService(repo repository.OrderRepository, orderHistoryRepo repository.OrderHistoryRepository, masterConfigSvc MasterConfigService, taxSvc TaxService, reservationSvc StockReservationService, measurementSvc MeasurementService, invoiceSvc InvoiceService, mapper mapper.Mapper, respMapper mapper.ResponseMapper) OrderService {
 	return orderService{
 		orderRepo:        repo,
 		orderHistoryRepo: orderHistoryRepo,
+		masterConfigSvc:  masterConfigSvc,
+		taxSvc:           taxSvc,
+		reservationSvc:   reservationSvc,
+		measurementSvc:   measurementSvc,
+		invoiceSvc:       invoiceSvc,
 		mapper:           mapper,
 		respMapper:       respMapper,
 	}
@@ -51,17 +65,43 @@ func (svc orderService) SaveOrder(ctx *context.Context, order requestModel.Order
 		dbOrder.OrderTakenById = &userID
 	}
 
-	errr := svc.orderRepo.Create(ctx, dbOrder)
+	if dbOrder.Status == "" {
+		dbOrder.Status = entities.DRAFT
+	}
+	if !dbOrder.Status.IsValid() {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid order status %s", dbOrder.Status), nil)
+	}
+
+	errr := svc.taxSvc.ApplyOrderItemTaxes(ctx, dbOrder.CustomerId, dbOrder.OrderItems)
+	if errr != nil {
+		return errr
+	}
+
+	errr = svc.orderRepo.Create(ctx, dbOrder)
 	if errr != nil {
 		return errr
 	}
 
 	// Record order history for CREATED action
-	errr = svc.recordOrderHistory(ctx, dbOrder.ID, entities.OrderHistoryActionCreated, nil, nil, nil, nil)
+	errr = svc.recordOrderHistory(ctx, dbOrder.ID, entities.OrderHistoryActionCreated, nil, nil, nil, nil, nil)
 	if errr != nil {
 		return errr
 	}
 
+	if dbOrder.Status == entities.DELIVERED {
+		errr = svc.invoiceSvc.IssueOrderInvoice(ctx, dbOrder.ID)
+		if errr != nil {
+			return errr
+		}
+	}
+
+	if dbOrder.Status.ReservesStock() {
+		errr = svc.measurementSvc.SnapshotForOrder(ctx, dbOrder.ID)
+		if errr != nil {
+			return errr
+		}
+		return svc.reservationSvc.SyncForOrder(ctx, dbOrder.ID)
+	}
 	return nil
 }
 
@@ -83,8 +123,43 @@ func (svc orderService) UpdateOrder(ctx *context.Context, order requestModel.Ord
 		dbOrder.OrderTakenById = &userID
 	}
 
+	// Status changes on update go through the same transition graph as the transition endpoint
+	if dbOrder.Status == "" {
+		dbOrder.Status = oldOrder.Status
+	}
+	if dbOrder.Status != oldOrder.Status {
+		errr := svc.validateTransition(ctx, oldOrder.Status, dbOrder.Status)
+		if errr != nil {
+			return errr
+		}
+		if dbOrder.Status == entities.DELIVERED && dbOrder.DeliveredDate == nil {
+			deliveredDate := util.GetLocalTime()
+			dbOrder.DeliveredDate = &deliveredDate
+		}
+	}
+
+	// Item stages are only moved through the order item stage endpoint, and measurement
+	// snapshots are only taken by the order
+	storedItems := make(map[uint]entities.OrderItem)
+	for _, item := range oldOrder.OrderItems {
+		storedItems[item.ID] = item
+	}
+	for i := range dbOrder.OrderItems {
+		if stored, ok := storedItems[dbOrder.OrderItems[i].ID]; ok {
+			dbOrder.OrderItems[i].KeepProduction(stored)
+			dbOrder.OrderItems[i].KeepMeasurementSnapshot(stored)
+		}
+	}
+
+	dbOrder.KeepInvoice(*oldOrder)
+
+	errr := svc.taxSvc.ApplyOrderItemTaxes(ctx, dbOrder.CustomerId, dbOrder.OrderItems)
+	if errr != nil {
+		return errr
+	}
+
 	dbOrder.ID = id
-	errr := svc.orderRepo.Update(ctx, dbOrder)
+	errr = svc.orderRepo.Update(ctx, dbOrder)
 	if errr != nil {
 		return errr
 	}
@@ -104,11 +179,31 @@ func (svc orderService) UpdateOrder(ctx *context.Context, order requestModel.Ord
 	changedFieldsStr := strings.Join(changedFields, ",")
 
 	// Record order history for UPDATED action with old values
-	errr = svc.recordOrderHistory(ctx, id, entities.OrderHistoryActionUpdated, &oldOrder.Status, oldOrder.ExpectedDeliveryDate, oldOrder.DeliveredDate, &changedFieldsStr)
+	errr = svc.recordOrderHistory(ctx, id, entities.OrderHistoryActionUpdated, &oldOrder.Status, oldOrder.ExpectedDeliveryDate, oldOrder.DeliveredDate, &changedFieldsStr, nil)
 	if errr != nil {
 		return errr
 	}
 
+	// Delivering issues the invoice
+	if dbOrder.Status == entities.DELIVERED && oldOrder.Status != entities.DELIVERED {
+		errr = svc.invoiceSvc.IssueOrderInvoice(ctx, id)
+		if errr != nil {
+			return errr
+		}
+	}
+
+	// Items of a confirmed order that were added or moved to another measurement get their snapshot
+	if dbOrder.Status.ReservesStock() {
+		errr = svc.measurementSvc.SnapshotForOrder(ctx, id)
+		if errr != nil {
+			return errr
+		}
+	}
+
+	// Items and status may both have changed, so the reservations are rebuilt
+	if oldOrder.Status.ReservesStock() || dbOrder.Status.ReservesStock() {
+		return svc.reservationSvc.SyncForOrder(ctx, id)
+	}
 	return nil
 }
 
@@ -123,6 +218,10 @@ func (svc orderService) Get(ctx *context.Context, id uint) (*responseModel.Order
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Order data", mapErr)
 	}
 
+	if err := svc.measurementSvc.ShowInDisplayUnit(ctx, "", orderItemMeasurements(mappedOrder.OrderItems)); err != nil {
+		return nil, err
+	}
+
 	return mappedOrder, nil
 }
 
@@ -153,16 +252,102 @@ func (svc orderService) Delete(ctx *context.Context, id uint) *errs.XError {
 	}
 
 	// Record order history for DELETED action with old values
-	err = svc.recordOrderHistory(ctx, id, entities.OrderHistoryActionDeleted, &oldOrder.Status, oldOrder.ExpectedDeliveryDate, oldOrder.DeliveredDate, nil)
+	err = svc.recordOrderHistory(ctx, id, entities.OrderHistoryActionDeleted, &oldOrder.Status, oldOrder.ExpectedDeliveryDate, oldOrder.DeliveredDate, nil, nil)
 	if err != nil {
 		return err
 	}
 
+	return svc.reservationSvc.ReleaseForOrder(ctx, id)
+}
+
+func (svc orderService) TransitionOrder(ctx *context.Context, id uint, transition requestModel.Status) *errs.XError {
+	order, err := svc.orderRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if order.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Order not found", nil)
+	}
+
+	targetStatus := entities.OrderStatus(transition.Status)
+	err = svc.validateTransition(ctx, order.Status, targetStatus)
+	if err != nil {
+		return err
+	}
+
+	changedFields := []string{entities.OrderChangeFieldStatus}
+	deliveredDate := order.DeliveredDate
+	if targetStatus == entities.DELIVERED && deliveredDate == nil {
+		now := util.GetLocalTime()
+		deliveredDate = &now
+		changedFields = append(changedFields, entities.OrderChangeFieldDeliveredDate)
+	}
+
+	err = svc.orderRepo.UpdateStatus(ctx, id, targetStatus, deliveredDate)
+	if err != nil {
+		return err
+	}
+
+	changedFieldsStr := strings.Join(changedFields, ",")
+	err = svc.recordOrderHistory(ctx, id, entities.OrderHistoryActionUpdated, &order.Status, order.ExpectedDeliveryDate, order.DeliveredDate, &changedFieldsStr, &transition.StatusReason)
+	if err != nil {
+		return err
+	}
+
+	// Delivering issues the invoice
+	if targetStatus == entities.DELIVERED {
+		err = svc.invoiceSvc.IssueOrderInvoice(ctx, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Confirming freezes the measurements the items are made to
+	if targetStatus.ReservesStock() {
+		err = svc.measurementSvc.SnapshotForOrder(ctx, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Confirming reserves the materials, cancelling or delivering gives them back
+	if order.Status.ReservesStock() != targetStatus.ReservesStock() {
+		return svc.reservationSvc.SyncForOrder(ctx, id)
+	}
+	return nil
+}
+
+// validateTransition checks the move against the channel's transition graph
+func (svc orderService) validateTransition(ctx *context.Context, from, to entities.OrderStatus) *errs.XError {
+	if !to.IsValid() {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid order status %s", to), nil)
+	}
+
+	transitions := getStatusTransitions(ctx, svc.masterConfigSvc)
+	if !transitions.Allows(from, to) {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Order cannot move from %s to %s", from, to), nil)
+	}
+
 	return nil
 }
 
+// getStatusTransitions reads the channel's transition graph, the default graph when it is not configured
+func getStatusTransitions(ctx *context.Context, masterConfigSvc MasterConfigService) entities.OrderStatusTransitions {
+	value, err := masterConfigSvc.GetByName(ctx, constants.ORDER_STATUS_TRANSITIONS_CONFIG)
+	if err != nil || value == "" {
+		return entities.DefaultOrderStatusTransitions
+	}
+
+	var transitions entities.OrderStatusTransitions
+	if jsonErr := json.Unmarshal([]byte(value), &transitions); jsonErr != nil || len(transitions) == 0 {
+		return entities.DefaultOrderStatusTransitions
+	}
+
+	return transitions
+}
+
 // recordOrderHistory creates an order history record
-func (svc orderService) recordOrderHistory(ctx *context.Context, orderId uint, action entities.OrderHistoryAction, oldStatus *entities.OrderStatus, oldExpectedDeliveryDate *time.Time, oldDeliveredDate *time.Time, changedFields *string) *errs.XError {
+func (svc orderService) recordOrderHistory(ctx *context.Context, orderId uint, action entities.OrderHistoryAction, oldStatus *entities.OrderStatus, oldExpectedDeliveryDate *time.Time, oldDeliveredDate *time.Time, changedFields *string, reason *string) *errs.XError {
 	userID := utils.GetUserId(ctx)
 	performedAt := util.GetLocalTime()
 
@@ -179,6 +364,10 @@ func (svc orderService) recordOrderHistory(ctx *context.Context, orderId uint, a
 		history.ChangedFields = *changedFields
 	}
 
+	if reason != nil {
+		history.Reason = *reason
+	}
+
 	// Set old values for tracking
 	if oldStatus != nil {
 		history.Status = oldStatus
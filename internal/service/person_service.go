@@ -20,16 +20,18 @@ type PersonService interface {
 }
 
 type personService struct {
-	personRepo repository.PersonRepository
-	mapper     mapper.Mapper
-	respMapper mapper.ResponseMapper
+	personRepo     repository.PersonRepository
+	measurementSvc MeasurementService
+	mapper         mapper.Mapper
+	respMapper     mapper.ResponseMapper
 }
 
-func ProvidePersonService(repo repository.PersonRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) PersonService {
+func ProvidePersonService(repo repository.PersonRepository, measurementSvc MeasurementService, mapper mapper.Mapper, respMapper mapper.ResponseMapper) PersonService {
 	return personService{
-		personRepo: repo,
-		mapper:     mapper,
-		respMapper: respMapper,
+		personRepo:     repo,
+		measurementSvc: measurementSvc,
+		mapper:         mapper,
+		respMapper:     respMapper,
 	}
 }
 
@@ -72,6 +74,10 @@ func (svc personService) Get(ctx *context.Context, id uint) (*responseModel.Pers
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Person data", mapErr)
 	}
 
+	if err := svc.measurementSvc.ShowInDisplayUnit(ctx, "", personMeasurements(mappedPerson)); err != nil {
+		return nil, err
+	}
+
 	return mappedPerson, nil
 }
 
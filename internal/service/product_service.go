@@ -2,12 +2,16 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
 	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
 	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
 	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils/pdf"
 	"github.com/loop-kar/pixie/errs"
 )
 
@@ -15,11 +19,13 @@ type ProductService interface {
 	SaveProduct(*context.Context, requestModel.Product) *errs.XError
 	UpdateProduct(*context.Context, requestModel.Product, uint) *errs.XError
 	Get(*context.Context, uint) (*responseModel.Product, *errs.XError)
-	GetAll(*context.Context, string) ([]responseModel.Product, *errs.XError)
+	GetAll(*context.Context, string, *uint) ([]responseModel.Product, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
 	AutocompleteProduct(*context.Context, string) ([]responseModel.ProductAutoComplete, *errs.XError)
 	GetBySKU(*context.Context, string) (*responseModel.Product, *errs.XError)
-	GetLowStockProducts(*context.Context) ([]responseModel.Product, *errs.XError)
+	GetLowStockProducts(*context.Context, *uint) ([]responseModel.Product, *errs.XError)
+	Scan(*context.Context, string) (*responseModel.Product, *errs.XError)
+	GetLabels(*context.Context, requestModel.ProductLabels) (*responseModel.FileContent, *errs.XError)
 }
 
 type productService struct {
@@ -49,11 +55,52 @@ func (svc productService) SaveProduct(ctx *context.Context, product requestModel
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save product", err)
 	}
 
-	errr := svc.productRepo.Create(ctx, dbProduct)
+	conversions, errr := unitConversions(dbProduct, product.UnitConversions)
 	if errr != nil {
 		return errr
 	}
 
+	attributes, errr := variantAttributes(product.VariantAttributes)
+	if errr != nil {
+		return errr
+	}
+	if len(attributes) > 0 && strings.TrimSpace(dbProduct.SKU) == "" {
+		return errs.NewXError(errs.VALIDATION, "SKU is required to generate the SKUs of the variants", nil)
+	}
+
+	errr = svc.productRepo.Create(ctx, dbProduct)
+	if errr != nil {
+		return errr
+	}
+
+	errr = svc.productRepo.ReplaceUnitConversions(ctx, dbProduct.ID, conversions)
+	if errr != nil {
+		return errr
+	}
+
+	// A parent keeps no stock, every variant gets its own inventory
+	if len(attributes) > 0 {
+		dbAttributes := make([]entities.ProductAttribute, 0, len(attributes))
+		for i, attribute := range attributes {
+			dbAttributes = append(dbAttributes, entities.ProductAttribute{
+				Model:     &entities.Model{IsActive: true},
+				ProductId: dbProduct.ID,
+				Name:      attribute.Name,
+				Position:  i,
+			})
+		}
+		errr = svc.productRepo.CreateAttributes(ctx, dbAttributes)
+		if errr != nil {
+			return errr
+		}
+
+		values := make([][]string, 0, len(attributes))
+		for _, attribute := range attributes {
+			values = append(values, attribute.Values)
+		}
+		return svc.createVariants(ctx, dbProduct, dbAttributes, entities.VariantCombinations(values), conversions, product.LowStockThreshold)
+	}
+
 	// Auto-create inventory entry for the product
 	inventory := &entities.Inventory{
 		Model:             &entities.Model{IsActive: true},
@@ -77,11 +124,59 @@ func (svc productService) UpdateProduct(ctx *context.Context, product requestMod
 	}
 
 	dbProduct.ID = id
-	errr := svc.productRepo.Update(ctx, dbProduct)
+	conversions, errr := unitConversions(dbProduct, product.UnitConversions)
 	if errr != nil {
 		return errr
 	}
 
+	// Stock on hand is counted in the current unit, it cannot be reinterpreted in another one
+	current, errr := svc.productRepo.Get(ctx, id)
+	if errr != nil {
+		return errr
+	}
+	if current.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Product not found", nil)
+	}
+	if current.StockUnit() != dbProduct.StockUnit() && current.Inventory != nil && current.Inventory.Quantity != 0 {
+		return errs.NewXError(errs.VALIDATION, "Unit cannot be changed while the product has stock", nil)
+	}
+
+	if len(product.VariantAttributes) > 0 && current.IsVariant() {
+		return errs.NewXError(errs.INVALID_REQUEST, "A variant cannot have variants of its own", nil)
+	}
+	if len(product.VariantAttributes) > 0 && !current.HasVariants() {
+		return errs.NewXError(errs.VALIDATION, "Variants can only be added to a product created with variant attributes", nil)
+	}
+
+	// The matrix and the parent a variant belongs to are not changed through the product's fields
+	dbProduct.ParentId = current.ParentId
+
+	errr = svc.productRepo.Update(ctx, dbProduct)
+	if errr != nil {
+		return errr
+	}
+
+	errr = svc.productRepo.ReplaceUnitConversions(ctx, id, conversions)
+	if errr != nil {
+		return errr
+	}
+
+	if current.HasVariants() {
+		errr = svc.productRepo.UpdateVariants(ctx, id, map[string]interface{}{
+			"category_id": dbProduct.CategoryId,
+			"hsn_code":    dbProduct.HSNCode,
+			"tax_rate":    dbProduct.TaxRate,
+		})
+		if errr != nil {
+			return errr
+		}
+
+		errr = svc.addVariants(ctx, current, dbProduct, product.VariantAttributes, conversions, product.LowStockThreshold)
+		if errr != nil {
+			return errr
+		}
+	}
+
 	errr = svc.inventoryRepo.UpdateThreshold(ctx, id, product.LowStockThreshold)
 	if errr != nil {
 		return errr
@@ -104,8 +199,8 @@ func (svc productService) Get(ctx *context.Context, id uint) (*responseModel.Pro
 	return mappedProduct, nil
 }
 
-func (svc productService) GetAll(ctx *context.Context, search string) ([]responseModel.Product, *errs.XError) {
-	products, err := svc.productRepo.GetAll(ctx, search)
+func (svc productService) GetAll(ctx *context.Context, search string, categoryId *uint) ([]responseModel.Product, *errs.XError) {
+	products, err := svc.productRepo.GetAll(ctx, search, categoryId)
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +218,12 @@ func (svc productService) Delete(ctx *context.Context, id uint) *errs.XError {
 	if err != nil {
 		return err
 	}
+
+	// Variants go with their parent
+	err = svc.productRepo.DeleteVariants(ctx, id)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -134,25 +235,38 @@ func (svc productService) AutocompleteProduct(ctx *context.Context, search strin
 
 	res := make([]responseModel.ProductAutoComplete, 0)
 	for _, product := range products {
-		currentStock := 0
-		isLowStock := false
-		if product.Inventory != nil {
-			currentStock = product.Inventory.Quantity
-			isLowStock = product.Inventory.IsLowStock()
-		}
-
-		res = append(res, responseModel.ProductAutoComplete{
-			ID:           product.ID,
-			Name:         product.Name,
-			SKU:          product.SKU,
-			CurrentStock: currentStock,
-			IsLowStock:   isLowStock,
-		})
+		res = append(res, productAutoComplete(product))
 	}
 
 	return res, nil
 }
 
+// productAutoComplete lists a parent with its variants, the parent's stock is the total of theirs
+func productAutoComplete(product entities.Product) responseModel.ProductAutoComplete {
+	item := responseModel.ProductAutoComplete{
+		ID:   product.ID,
+		Name: product.Name,
+		SKU:  product.SKU,
+		Unit: string(product.StockUnit()),
+	}
+	if product.Inventory != nil {
+		item.CurrentStock = product.Inventory.Quantity
+		item.IsLowStock = product.Inventory.IsLowStock()
+	}
+
+	if product.HasVariants() {
+		item.CurrentStock = 0
+		item.Variants = make([]responseModel.ProductAutoComplete, 0, len(product.Variants))
+		for _, variant := range product.Variants {
+			mapped := productAutoComplete(variant)
+			item.CurrentStock = entities.RoundQuantity(item.CurrentStock + mapped.CurrentStock)
+			item.IsLowStock = item.IsLowStock || mapped.IsLowStock
+			item.Variants = append(item.Variants, mapped)
+		}
+	}
+	return item
+}
+
 func (svc productService) GetBySKU(ctx *context.Context, sku string) (*responseModel.Product, *errs.XError) {
 	product, err := svc.productRepo.GetBySKU(ctx, sku)
 	if err != nil {
@@ -167,12 +281,26 @@ func (svc productService) GetBySKU(ctx *context.Context, sku string) (*responseM
 	return mappedProduct, nil
 }
 
-func (svc productService) GetLowStockProducts(ctx *context.Context) ([]responseModel.Product, *errs.XError) {
-	products, err := svc.productRepo.GetLowStockProducts(ctx)
+func (svc productService) GetLowStockProducts(ctx *context.Context, categoryId *uint) ([]responseModel.Product, *errs.XError) {
+	products, err := svc.productRepo.GetLowStockProducts(ctx, categoryId)
 	if err != nil {
 		return nil, err
 	}
 
+	// Only the variants that are low are listed under their parent
+	for i := range products {
+		if !products[i].HasVariants() {
+			continue
+		}
+		low := make([]entities.Product, 0, len(products[i].Variants))
+		for _, variant := range products[i].Variants {
+			if variant.Inventory != nil && variant.Inventory.IsLowStock() {
+				low = append(low, variant)
+			}
+		}
+		products[i].Variants = low
+	}
+
 	mappedProducts, mapErr := svc.respMapper.Products(products)
 	if mapErr != nil {
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Product data", mapErr)
@@ -180,3 +308,348 @@ func (svc productService) GetLowStockProducts(ctx *context.Context) ([]responseM
 
 	return mappedProducts, nil
 }
+
+// Scan resolves a code read off a product label to the product and its stock in the channel
+func (svc productService) Scan(ctx *context.Context, code string) (*responseModel.Product, *errs.XError) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Code is required", nil)
+	}
+
+	product, err := svc.productRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if product.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, fmt.Sprintf("No product found for code %s", code), nil)
+	}
+
+	mappedProduct, mapErr := svc.respMapper.Product(product)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Product data", mapErr)
+	}
+
+	return mappedProduct, nil
+}
+
+// maxLabelCopies keeps a single request to a few sheets per product
+const maxLabelCopies = 200
+
+// GetLabels renders a PDF sheet of SKU labels for the products in the order they were asked for
+func (svc productService) GetLabels(ctx *context.Context, request requestModel.ProductLabels) (*responseModel.FileContent, *errs.XError) {
+	if len(request.ProductIds) == 0 {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "At least one product is required", nil)
+	}
+
+	copies := request.Copies
+	if copies == 0 {
+		copies = 1
+	}
+	if copies < 0 || copies > maxLabelCopies {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, fmt.Sprintf("Copies must be between 1 and %d", maxLabelCopies), nil)
+	}
+
+	symbology := pdf.LabelSymbology(strings.ToUpper(request.Symbology))
+	if symbology == "" {
+		symbology = pdf.LabelCode128
+	}
+	if symbology != pdf.LabelCode128 && symbology != pdf.LabelQR {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Invalid symbology. Must be CODE128 or QR", nil)
+	}
+
+	layout, errr := labelLayout(request)
+	if errr != nil {
+		return nil, errr
+	}
+
+	products, errr := svc.productRepo.GetByIds(ctx, request.ProductIds)
+	if errr != nil {
+		return nil, errr
+	}
+	productsById := make(map[uint]entities.Product, len(products))
+	for _, product := range products {
+		productsById[product.ID] = product
+	}
+
+	labels := make([]pdf.Label, 0, len(request.ProductIds)*copies)
+	for _, id := range request.ProductIds {
+		product, ok := productsById[id]
+		if !ok {
+			return nil, errs.NewXError(errs.NOT_EXIST, fmt.Sprintf("Product %d not found", id), nil)
+		}
+
+		// Stock is scanned by variant, a parent prints the labels of its variants
+		printed := []entities.Product{product}
+		if product.HasVariants() {
+			printed = product.Variants
+		}
+		for _, item := range printed {
+			if strings.TrimSpace(item.SKU) == "" {
+				return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("Product %s has no SKU to print", item.Name), nil)
+			}
+
+			caption := string(item.StockUnit())
+			if item.SellingPrice > 0 {
+				caption = formatAmount(item.SellingPrice) + " / " + caption
+			}
+			for i := 0; i < copies; i++ {
+				labels = append(labels, pdf.Label{Code: item.SKU, Title: item.Name, Caption: caption})
+			}
+		}
+	}
+
+	content, err := pdf.LabelSheet(layout, symbology, labels)
+	if err != nil {
+		return nil, errs.NewXError(errs.VALIDATION, "Unable to generate labels. "+err.Error(), err)
+	}
+
+	return &responseModel.FileContent{
+		FileName:    "product-labels.pdf",
+		ContentType: "application/pdf",
+		Content:     content,
+	}, nil
+}
+
+// labelLayout picks the custom layout when given, otherwise the named one
+func labelLayout(request requestModel.ProductLabels) (pdf.LabelLayout, *errs.XError) {
+	if custom := request.Custom; custom != nil {
+		layout := pdf.NewLabelLayout(custom.Columns, custom.Rows, custom.LabelWidth, custom.LabelHeight,
+			custom.MarginLeft, custom.MarginTop, custom.GapX, custom.GapY)
+		err := layout.Validate()
+		if err != nil {
+			return layout, errs.NewXError(errs.INVALID_REQUEST, "Invalid label layout. "+err.Error(), err)
+		}
+		return layout, nil
+	}
+
+	name := strings.ToUpper(request.Layout)
+	if name == "" {
+		name = pdf.DefaultLabelLayout
+	}
+	layout, ok := pdf.LabelLayouts[name]
+	if !ok {
+		return layout, errs.NewXError(errs.INVALID_REQUEST, fmt.Sprintf("Invalid label layout. Must be one of %s", strings.Join(pdf.LabelLayoutNames(), ", ")), nil)
+	}
+	return layout, nil
+}
+
+// maxVariants keeps a matrix to what a counter can still pick from
+const maxVariants = 200
+
+// variantAttributes trims the attributes of a variant matrix and checks names and values are given once
+func variantAttributes(attributes []requestModel.ProductAttribute) ([]requestModel.ProductAttribute, *errs.XError) {
+	result := make([]requestModel.ProductAttribute, 0, len(attributes))
+	names := make(map[string]bool)
+	combinations := 1
+	for _, attribute := range attributes {
+		name := strings.TrimSpace(attribute.Name)
+		if name == "" {
+			return nil, errs.NewXError(errs.INVALID_REQUEST, "Attribute name is required", nil)
+		}
+		if len(name) > 50 {
+			return nil, errs.NewXError(errs.INVALID_REQUEST, fmt.Sprintf("Attribute name %s is longer than 50 characters", name), nil)
+		}
+		if names[strings.ToLower(name)] {
+			return nil, errs.NewXError(errs.INVALID_REQUEST, fmt.Sprintf("Attribute %s is given more than once", name), nil)
+		}
+		names[strings.ToLower(name)] = true
+
+		values := make([]string, 0, len(attribute.Values))
+		seen := make(map[string]bool)
+		for _, value := range attribute.Values {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				return nil, errs.NewXError(errs.INVALID_REQUEST, fmt.Sprintf("Values of %s cannot be empty", name), nil)
+			}
+			if len(value) > 50 {
+				return nil, errs.NewXError(errs.INVALID_REQUEST, fmt.Sprintf("Value %s is longer than 50 characters", value), nil)
+			}
+			if seen[strings.ToLower(value)] {
+				return nil, errs.NewXError(errs.INVALID_REQUEST, fmt.Sprintf("Value %s of %s is given more than once", value, name), nil)
+			}
+			seen[strings.ToLower(value)] = true
+			values = append(values, value)
+		}
+		if len(values) == 0 {
+			return nil, errs.NewXError(errs.INVALID_REQUEST, fmt.Sprintf("Attribute %s needs at least one value", name), nil)
+		}
+
+		combinations *= len(values)
+		if combinations > maxVariants {
+			return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("A product can have at most %d variants", maxVariants), nil)
+		}
+		result = append(result, requestModel.ProductAttribute{Name: name, Values: values})
+	}
+	return result, nil
+}
+
+// addVariants adds new attribute values to the parent and creates the variants they make
+func (svc productService) addVariants(ctx *context.Context, current *entities.Product, parent *entities.Product, requested []requestModel.ProductAttribute, conversions []entities.ProductUnitConversion, threshold float64) *errs.XError {
+	if len(requested) == 0 {
+		return nil
+	}
+
+	attributes, errr := variantAttributes(requested)
+	if errr != nil {
+		return errr
+	}
+
+	dbAttributes := make([]entities.ProductAttribute, len(current.Attributes))
+	copy(dbAttributes, current.Attributes)
+	sort.SliceStable(dbAttributes, func(i, j int) bool { return dbAttributes[i].Position < dbAttributes[j].Position })
+
+	byName := make(map[string]requestModel.ProductAttribute, len(attributes))
+	for _, attribute := range attributes {
+		byName[strings.ToLower(attribute.Name)] = attribute
+	}
+	if len(byName) != len(dbAttributes) {
+		return errs.NewXError(errs.VALIDATION, "Attributes of a product cannot be changed once it has variants, only values can be added", nil)
+	}
+
+	// Values already in the matrix per attribute, the requested ones are added after them
+	existing := make(map[uint]map[string]bool)
+	existingValues := make(map[uint][]string)
+	for _, variant := range current.Variants {
+		for _, value := range variant.OptionValues() {
+			if existing[value.AttributeId] == nil {
+				existing[value.AttributeId] = make(map[string]bool)
+			}
+			if existing[value.AttributeId][strings.ToLower(value.Value)] {
+				continue
+			}
+			existing[value.AttributeId][strings.ToLower(value.Value)] = true
+			existingValues[value.AttributeId] = append(existingValues[value.AttributeId], value.Value)
+		}
+	}
+
+	values := make([][]string, 0, len(dbAttributes))
+	count := 1
+	for _, dbAttribute := range dbAttributes {
+		attribute, ok := byName[strings.ToLower(dbAttribute.Name)]
+		if !ok {
+			return errs.NewXError(errs.VALIDATION, "Attributes of a product cannot be changed once it has variants, only values can be added", nil)
+		}
+		merged := append([]string{}, existingValues[dbAttribute.ID]...)
+		for _, value := range attribute.Values {
+			if !existing[dbAttribute.ID][strings.ToLower(value)] {
+				merged = append(merged, value)
+			}
+		}
+		values = append(values, merged)
+		count *= len(merged)
+	}
+	if count > maxVariants {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("A product can have at most %d variants", maxVariants), nil)
+	}
+
+	combinations := make([][]string, 0)
+	for _, combination := range entities.VariantCombinations(values) {
+		isNew := false
+		for i, value := range combination {
+			if !existing[dbAttributes[i].ID][strings.ToLower(value)] {
+				isNew = true
+				break
+			}
+		}
+		if isNew {
+			combinations = append(combinations, combination)
+		}
+	}
+
+	return svc.createVariants(ctx, parent, dbAttributes, combinations, conversions, threshold)
+}
+
+// createVariants saves a variant of the parent for every combination
+func (svc productService) createVariants(ctx *context.Context, parent *entities.Product, attributes []entities.ProductAttribute, combinations [][]string, conversions []entities.ProductUnitConversion, threshold float64) *errs.XError {
+	for _, combination := range combinations {
+		variant := &entities.Product{
+			Model:        &entities.Model{IsActive: true},
+			Name:         entities.VariantName(parent.Name, combination),
+			SKU:          entities.VariantSKU(parent.SKU, combination),
+			CategoryId:   parent.CategoryId,
+			Description:  parent.Description,
+			CostPrice:    parent.CostPrice,
+			SellingPrice: parent.SellingPrice,
+			HSNCode:      parent.HSNCode,
+			TaxRate:      parent.TaxRate,
+			Unit:         parent.StockUnit(),
+			ParentId:     &parent.ID,
+		}
+		errr := svc.productRepo.Create(ctx, variant)
+		if errr != nil {
+			return errr
+		}
+
+		variantConversions := make([]entities.ProductUnitConversion, 0, len(conversions))
+		for _, conversion := range conversions {
+			variantConversions = append(variantConversions, entities.ProductUnitConversion{
+				Model:  &entities.Model{IsActive: true},
+				Unit:   conversion.Unit,
+				Factor: conversion.Factor,
+			})
+		}
+		errr = svc.productRepo.ReplaceUnitConversions(ctx, variant.ID, variantConversions)
+		if errr != nil {
+			return errr
+		}
+
+		values := make([]entities.ProductVariantValue, 0, len(combination))
+		for i, value := range combination {
+			values = append(values, entities.ProductVariantValue{
+				Model:       &entities.Model{IsActive: true},
+				ProductId:   variant.ID,
+				AttributeId: attributes[i].ID,
+				Value:       value,
+			})
+		}
+		errr = svc.productRepo.CreateVariantValues(ctx, values)
+		if errr != nil {
+			return errr
+		}
+
+		errr = svc.inventoryRepo.Create(ctx, &entities.Inventory{
+			Model:             &entities.Model{IsActive: true},
+			ProductId:         variant.ID,
+			LowStockThreshold: threshold,
+		})
+		if errr != nil {
+			return errr
+		}
+	}
+	return nil
+}
+
+// unitConversions validates the product's unit and the conversions to it, defaulting the unit to pieces
+func unitConversions(product *entities.Product, conversions []requestModel.ProductUnitConversion) ([]entities.ProductUnitConversion, *errs.XError) {
+	product.Unit = product.StockUnit()
+	if !product.Unit.IsValid() {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Invalid unit. Must be PIECE, METER, KG or ROLL", nil)
+	}
+
+	result := make([]entities.ProductUnitConversion, 0, len(conversions))
+	seen := make(map[entities.UnitOfMeasure]bool)
+	for _, conversion := range conversions {
+		unit := entities.ParseUnitOfMeasure(conversion.Unit)
+		if !unit.IsValid() {
+			return nil, errs.NewXError(errs.INVALID_REQUEST, fmt.Sprintf("Invalid conversion unit %s", conversion.Unit), nil)
+		}
+		if unit == product.Unit {
+			return nil, errs.NewXError(errs.INVALID_REQUEST, "A conversion cannot be given for the product's own unit", nil)
+		}
+		if seen[unit] {
+			return nil, errs.NewXError(errs.INVALID_REQUEST, fmt.Sprintf("Conversion for %s is given more than once", unit), nil)
+		}
+		factor := entities.RoundQuantity(conversion.Factor)
+		if factor <= 0 {
+			return nil, errs.NewXError(errs.INVALID_REQUEST, "Conversion factor must be greater than 0", nil)
+		}
+		seen[unit] = true
+
+		result = append(result, entities.ProductUnitConversion{
+			Model:  &entities.Model{IsActive: true},
+			Unit:   unit,
+			Factor: factor,
+		})
+	}
+	return result, nil
+}
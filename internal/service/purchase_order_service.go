@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
+)
+
+type PurchaseOrderService interface {
+	SavePurchaseOrder(*context.Context, requestModel.PurchaseOrder) *errs.XError
+	UpdatePurchaseOrder(*context.Context, requestModel.PurchaseOrder, uint) *errs.XError
+	Get(*context.Context, uint) (*responseModel.PurchaseOrder, *errs.XError)
+	GetAll(*context.Context, *uint, string) ([]responseModel.PurchaseOrder, *errs.XError)
+	Receive(*context.Context, uint, requestModel.PurchaseOrderReceipt) (*responseModel.PurchaseOrder, *errs.XError)
+	Cancel(*context.Context, uint) *errs.XError
+}
+
+type purchaseOrderService struct {
+	purchaseOrderRepo repository.PurchaseOrderRepository
+	supplierRepo      repository.SupplierRepository
+	productRepo       repository.ProductRepository
+	expenseRepo       repository.ExpenseTrackerRepository
+	expenseDetailRepo repository.ExpenseDetailRepository
+	inventorySvc      InventoryService
+	mapper            mapper.Mapper
+	respMapper        mapper.ResponseMapper
+}
+
+func ProvidePurchaseOrderService(
+	repo repository.PurchaseOrderRepository,
+	supplierRepo repository.SupplierRepository,
+	productRepo repository.ProductRepository,
+	expenseRepo repository.ExpenseTrackerRepository,
+	expenseDetailRepo repository.ExpenseDetailRepository,
+	inventorySvc InventoryService,
+	mapper mapper.Mapper,
+	respMapper mapper.ResponseMapper,
+) PurchaseOrderService {
+	return purchaseOrderService{
+		purchaseOrderRepo: repo,
+		supplierRepo:      supplierRepo,
+		productRepo:       productRepo,
+		expenseRepo:       expenseRepo,
+		expenseDetailRepo: expenseDetailRepo,
+		inventorySvc:      inventorySvc,
+		mapper:            mapper,
+		respMapper:        respMapper,
+	}
+}
+
+func (svc purchaseOrderService) SavePurchaseOrder(ctx *context.Context, purchaseOrder requestModel.PurchaseOrder) *errs.XError {
+	if err := svc.validatePurchaseOrder(ctx, purchaseOrder); err != nil {
+		return err
+	}
+
+	dbPurchaseOrder, err := svc.mapper.PurchaseOrder(purchaseOrder)
+	if err != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save purchase order", err)
+	}
+
+	return svc.purchaseOrderRepo.Create(ctx, dbPurchaseOrder)
+}
+
+// UpdatePurchaseOrder replaces the details and lines of a purchase order, only allowed until stock is received against it
+func (svc purchaseOrderService) UpdatePurchaseOrder(ctx *context.Context, purchaseOrder requestModel.PurchaseOrder, id uint) *errs.XError {
+	existing, err := svc.getPurchaseOrder(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing.Status != entities.PurchaseOrderStatusORDERED {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Purchase order is %s and can no longer be changed", existing.Status), nil)
+	}
+
+	if err := svc.validatePurchaseOrder(ctx, purchaseOrder); err != nil {
+		return err
+	}
+
+	dbPurchaseOrder, mapErr := svc.mapper.PurchaseOrder(purchaseOrder)
+	if mapErr != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update purchase order", mapErr)
+	}
+
+	dbPurchaseOrder.ID = id
+	if err := svc.purchaseOrderRepo.UpdateDetails(ctx, dbPurchaseOrder); err != nil {
+		return err
+	}
+	return svc.purchaseOrderRepo.ReplaceLines(ctx, id, dbPurchaseOrder.Lines)
+}
+
+func (svc purchaseOrderService) Get(ctx *context.Context, id uint) (*responseModel.PurchaseOrder, *errs.XError) {
+	purchaseOrder, err := svc.getPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped, mapErr := svc.respMapper.PurchaseOrder(purchaseOrder)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map PurchaseOrder data", mapErr)
+	}
+	return mapped, nil
+}
+
+func (svc purchaseOrderService) GetAll(ctx *context.Context, supplierId *uint, status string) ([]responseModel.PurchaseOrder, *errs.XError) {
+	purchaseOrders, err := svc.purchaseOrderRepo.GetAll(ctx, supplierId, status)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped, mapErr := svc.respMapper.PurchaseOrders(purchaseOrders)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map PurchaseOrder data", mapErr)
+	}
+	return mapped, nil
+}
+
+// Receive books the arrived quantities as IN movements and an expense against the supplier
+func (svc purchaseOrderService) Receive(ctx *context.Context, id uint, receipt requestModel.PurchaseOrderReceipt) (*responseModel.PurchaseOrder, *errs.XError) {
+	if len(receipt.Lines) == 0 {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "At least one line must be received", nil)
+	}
+	if receipt.AmountPaid < 0 {
+		return nil, errs.NewXError(errs.VALIDATION, "Amount paid cannot be negative", nil)
+	}
+
+	receivedDate := util.GetLocalTime()
+	if receipt.ReceivedDate != nil {
+		date, err := util.GenerateDateTimeFromString(receipt.ReceivedDate)
+		if err != nil {
+			return nil, errs.NewXError(errs.INVALID_REQUEST, "Invalid received date", err)
+		}
+		receivedDate = *date
+	}
+
+	// Receipts against the same purchase order are applied one after another
+	if err := svc.purchaseOrderRepo.Lock(ctx, id); err != nil {
+		return nil, err
+	}
+
+	purchaseOrder, err := svc.getPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !purchaseOrder.IsOpen() {
+		return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("Purchase order is %s", purchaseOrder.Status), nil)
+	}
+
+	lines := make(map[uint]*entities.PurchaseOrderLine, len(purchaseOrder.Lines))
+	for i := range purchaseOrder.Lines {
+		lines[purchaseOrder.Lines[i].ID] = &purchaseOrder.Lines[i]
+	}
+
+	reference := fmt.Sprintf("Purchase order #%d", purchaseOrder.ID)
+	if receipt.BillNumber != "" {
+		reference = fmt.Sprintf("%s, bill %s", reference, receipt.BillNumber)
+	}
+
+	var receiptValue float64
+	materials := make([]string, 0, len(receipt.Lines))
+	seen := make(map[uint]bool)
+	for _, received := range receipt.Lines {
+		line, ok := lines[received.LineId]
+		if !ok {
+			return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("Line %d is not part of this purchase order", received.LineId), nil)
+		}
+		if seen[received.LineId] {
+			return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("Line %d is listed more than once", received.LineId), nil)
+		}
+		seen[received.LineId] = true
+
+		quantity := entities.RoundQuantity(received.Quantity)
+		if quantity <= 0 {
+			return nil, errs.NewXError(errs.VALIDATION, "Received quantity must be greater than 0", nil)
+		}
+		if quantity > line.OutstandingQuantity() {
+			return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("Line %d has only %s outstanding", line.ID, entities.FormatQuantity(line.OutstandingQuantity())), nil)
+		}
+
+		purchaseOrderId := purchaseOrder.ID
+		unitCost := line.UnitCost
+		_, err := svc.inventorySvc.RecordStockMovement(ctx, requestModel.StockMovementRequest{
+			ProductId:       line.ProductId,
+			ChangeType:      string(entities.InventoryLogChangeTypeIN),
+			Quantity:        quantity,
+			Reason:          "Purchase order received",
+			Notes:           reference,
+			UnitCost:        &unitCost,
+			PurchaseOrderId: &purchaseOrderId,
+			LotCode:         received.LotCode,
+			ExpiresAt:       received.ExpiresAt,
+			SupplierId:      &purchaseOrder.SupplierId,
+			ReceivedAt:      &receivedDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := svc.purchaseOrderRepo.AddReceivedQuantity(ctx, line.ID, quantity); err != nil {
+			return nil, err
+		}
+		line.ReceivedQuantity = entities.RoundQuantity(line.ReceivedQuantity + quantity)
+
+		receiptValue += quantity * line.UnitCost
+		if line.Product != nil {
+			materials = append(materials, fmt.Sprintf("%s x %s", line.Product.Name, entities.FormatQuantity(quantity)))
+		}
+	}
+
+	if err := svc.createReceiptExpense(ctx, purchaseOrder, receipt, receivedDate, receiptValue, strings.Join(materials, ", ")); err != nil {
+		return nil, err
+	}
+
+	if err := svc.purchaseOrderRepo.UpdateStatus(ctx, id, purchaseOrder.ReceiptStatus()); err != nil {
+		return nil, err
+	}
+
+	return svc.Get(ctx, id)
+}
+
+// Cancel closes a purchase order, quantities already received stay in stock
+func (svc purchaseOrderService) Cancel(ctx *context.Context, id uint) *errs.XError {
+	purchaseOrder, err := svc.getPurchaseOrder(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !purchaseOrder.IsOpen() {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Purchase order is %s", purchaseOrder.Status), nil)
+	}
+	return svc.purchaseOrderRepo.UpdateStatus(ctx, id, entities.PurchaseOrderStatusCANCELLED)
+}
+
+func (svc purchaseOrderService) createReceiptExpense(ctx *context.Context, purchaseOrder *entities.PurchaseOrder, receipt requestModel.PurchaseOrderReceipt, receivedDate time.Time, value float64, material string) *errs.XError {
+	value = roundCost(value)
+	if receipt.AmountPaid > value {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Amount paid %.2f is more than the received value %.2f", receipt.AmountPaid, value), nil)
+	}
+
+	var supplierName string
+	if purchaseOrder.Supplier != nil {
+		supplierName = purchaseOrder.Supplier.Name
+	}
+
+	purchaseOrderId := purchaseOrder.ID
+	supplierId := purchaseOrder.SupplierId
+	expense := &entities.Expense{
+		Model:           &entities.Model{IsActive: true},
+		PurchaseDate:    &receivedDate,
+		BillNumber:      receipt.BillNumber,
+		CompanyName:     supplierName,
+		Material:        material,
+		Price:           value,
+		SupplierId:      &supplierId,
+		PurchaseOrderId: &purchaseOrderId,
+	}
+	if receipt.Notes != "" {
+		notes := receipt.Notes
+		expense.Notes = &notes
+	}
+
+	if err := svc.expenseRepo.Create(ctx, expense); err != nil {
+		return err
+	}
+
+	if receipt.AmountPaid > 0 {
+		source := receipt.PaymentSource
+		if source == "" {
+			source = "Paid on receipt"
+		}
+		detail := &entities.ExpenseDetail{
+			Model:     &entities.Model{IsActive: true},
+			Source:    source,
+			Price:     receipt.AmountPaid,
+			ExpenseId: expense.ID,
+		}
+		if err := svc.expenseDetailRepo.Create(ctx, detail); err != nil {
+			return err
+		}
+	}
+
+	return svc.expenseRepo.RecalculateAndUpdateBalance(ctx, expense.ID)
+}
+
+func (svc purchaseOrderService) validatePurchaseOrder(ctx *context.Context, purchaseOrder requestModel.PurchaseOrder) *errs.XError {
+	supplier, err := svc.supplierRepo.Get(ctx, purchaseOrder.SupplierId)
+	if err != nil {
+		return err
+	}
+	if supplier.Model == nil || !supplier.IsActive {
+		return errs.NewXError(errs.NOT_EXIST, "Supplier not found", nil)
+	}
+
+	if len(purchaseOrder.Lines) == 0 {
+		return errs.NewXError(errs.VALIDATION, "Purchase order must have at least one line", nil)
+	}
+
+	seen := make(map[uint]bool)
+	for _, line := range purchaseOrder.Lines {
+		if line.Quantity <= 0 {
+			return errs.NewXError(errs.VALIDATION, "Line quantity must be greater than 0", nil)
+		}
+		if line.UnitCost < 0 {
+			return errs.NewXError(errs.VALIDATION, "Unit cost cannot be negative", nil)
+		}
+		if seen[line.ProductId] {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Product %d is listed more than once", line.ProductId), nil)
+		}
+		seen[line.ProductId] = true
+
+		product, err := svc.productRepo.Get(ctx, line.ProductId)
+		if err != nil {
+			return err
+		}
+		if product.Model == nil {
+			return errs.NewXError(errs.NOT_EXIST, fmt.Sprintf("Product %d not found", line.ProductId), nil)
+		}
+		if product.HasVariants() {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Stock of %s is kept on its variants, pick a variant", product.Name), nil)
+		}
+	}
+
+	return nil
+}
+
+func (svc purchaseOrderService) getPurchaseOrder(ctx *context.Context, id uint) (*entities.PurchaseOrder, *errs.XError) {
+	purchaseOrder, err := svc.purchaseOrderRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if purchaseOrder.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Purchase order not found", nil)
+	}
+	return purchaseOrder, nil
+}
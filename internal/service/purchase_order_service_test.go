@@ -0,0 +1,91 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReceivePurchaseOrder(t *testing.T) {
+
+	store := newStockStore()
+	silk := store.addProduct("Silk", entities.UnitOfMeasureMETER)
+	buttons := store.addProduct("Buttons", entities.UnitOfMeasurePIECE)
+
+	purchaseOrder := &entities.PurchaseOrder{
+		Model:      &entities.Model{ID: store.nextId(), IsActive: true},
+		SupplierId: 3,
+		Status:     entities.PurchaseOrderStatusORDERED,
+		Lines: []entities.PurchaseOrderLine{
+			{Model: &entities.Model{ID: store.nextId(), IsActive: true}, ProductId: silk.ID, Quantity: 10, UnitCost: 200},
+			{Model: &entities.Model{ID: store.nextId(), IsActive: true}, ProductId: buttons.ID, Quantity: 50, UnitCost: 2},
+		},
+	}
+	store.purchaseOrders[purchaseOrder.ID] = purchaseOrder
+	silkLine, buttonLine := purchaseOrder.Lines[0].ID, purchaseOrder.Lines[1].ID
+
+	svc := purchaseOrderService{
+		purchaseOrderRepo: fakePurchaseOrderRepo{store: store},
+		expenseRepo:       fakeExpenseRepo{store: store},
+		expenseDetailRepo: fakeExpenseDetailRepo{store: store},
+		inventorySvc:      newTestInventoryService(store),
+		respMapper:        mapper.ProvideResponseMapper(),
+	}
+
+	// A part receipt books the stock into a lot of the supplier at the line's cost
+	response, err := svc.Receive(testContext(), purchaseOrder.ID, requestModel.PurchaseOrderReceipt{
+		BillNumber: "B-17",
+		Lines:      []requestModel.PurchaseOrderReceiptLine{{LineId: silkLine, Quantity: 4, LotCode: "DL-09"}},
+		AmountPaid: 500,
+	})
+	require.Nil(t, err)
+	require.Equal(t, []uint{purchaseOrder.ID}, store.purchaseOrderLocks)
+	require.Equal(t, string(entities.PurchaseOrderStatusPARTIALLY_RECEIVED), response.Status)
+	require.Equal(t, 4.0, response.Lines[0].ReceivedQuantity)
+	require.Equal(t, 4.0, store.onHand(silk.ID))
+
+	logs := store.logsFor(silk.ID)
+	require.Len(t, logs, 1)
+	require.Equal(t, purchaseOrder.ID, *logs[0].PurchaseOrderId)
+	require.Equal(t, 200.0, *logs[0].UnitCost)
+
+	lot := store.lots[len(store.lots)-1]
+	require.Equal(t, "DL-09", lot.LotCode)
+	require.Equal(t, uint(3), *lot.SupplierId)
+
+	// The receipt is an expense of the received value, less what was paid on receipt
+	require.Len(t, store.expenses, 1)
+	require.Equal(t, 800.0, store.expenses[0].Price)
+	require.Equal(t, 300.0, store.expenses[0].Balance)
+	require.Equal(t, "B-17", store.expenses[0].BillNumber)
+
+	// More than is outstanding cannot be received
+	_, err = svc.Receive(testContext(), purchaseOrder.ID, requestModel.PurchaseOrderReceipt{
+		Lines: []requestModel.PurchaseOrderReceiptLine{{LineId: silkLine, Quantity: 7}},
+	})
+	require.NotNil(t, err)
+	require.Equal(t, errs.VALIDATION, err.Code)
+	require.Contains(t, err.Message, "has only 6 outstanding")
+	require.Equal(t, 4.0, store.onHand(silk.ID))
+
+	// Receiving the rest completes the purchase order, after which nothing more is received
+	response, err = svc.Receive(testContext(), purchaseOrder.ID, requestModel.PurchaseOrderReceipt{
+		Lines: []requestModel.PurchaseOrderReceiptLine{{LineId: silkLine, Quantity: 6}, {LineId: buttonLine, Quantity: 50}},
+	})
+	require.Nil(t, err)
+	require.Equal(t, string(entities.PurchaseOrderStatusRECEIVED), response.Status)
+	require.Equal(t, 10.0, store.onHand(silk.ID))
+	require.Equal(t, 50.0, store.onHand(buttons.ID))
+	require.Len(t, store.expenses, 2)
+	require.Equal(t, 1300.0, store.expenses[1].Price)
+
+	_, err = svc.Receive(testContext(), purchaseOrder.ID, requestModel.PurchaseOrderReceipt{
+		Lines: []requestModel.PurchaseOrderReceiptLine{{LineId: buttonLine, Quantity: 1}},
+	})
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "Purchase order is RECEIVED")
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type SaleService interface {
+	SaveSale(*context.Context, requestModel.Sale) (*responseModel.Sale, *errs.XError)
+	Get(*context.Context, uint) (*responseModel.Sale, *errs.XError)
+	GetAll(*context.Context, *uint, *time.Time, *time.Time) ([]responseModel.Sale, *errs.XError)
+}
+
+type saleService struct {
+	saleRepo     repository.SaleRepository
+	productRepo  repository.ProductRepository
+	customerRepo repository.CustomerRepository
+	inventorySvc InventoryService
+	taxSvc       TaxService
+	mapper       mapper.Mapper
+	respMapper   mapper.ResponseMapper
+}
+
+func ProvideSaleService(
+	repo repository.SaleRepository,
+	productRepo repository.ProductRepository,
+	customerRepo repository.CustomerRepository,
+	inventorySvc InventoryService,
+	taxSvc TaxService,
+	mapper mapper.Mapper,
+	respMapper mapper.ResponseMapper,
+) SaleService {
+	return saleService{
+		saleRepo:     repo,
+		productRepo:  productRepo,
+		customerRepo: customerRepo,
+		inventorySvc: inventorySvc,
+		taxSvc:       taxSvc,
+		mapper:       mapper,
+		respMapper:   respMapper,
+	}
+}
+
+// SaveSale posts a counter sale and books its stock out
+func (svc saleService) SaveSale(ctx *context.Context, sale requestModel.Sale) (*responseModel.Sale, *errs.XError) {
+	dbSale, mapErr := svc.mapper.Sale(sale)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Unable to save sale", mapErr)
+	}
+
+	if err := svc.validateSale(ctx, dbSale, sale.Lines); err != nil {
+		return nil, err
+	}
+
+	if err := svc.taxSvc.ApplySaleTaxes(ctx, dbSale); err != nil {
+		return nil, err
+	}
+
+	if err := svc.saleRepo.Create(ctx, dbSale); err != nil {
+		return nil, err
+	}
+
+	for _, line := range dbSale.Lines {
+		_, err := svc.inventorySvc.RecordStockMovement(ctx, requestModel.StockMovementRequest{
+			ProductId:  line.ProductId,
+			ChangeType: string(entities.InventoryLogChangeTypeOUT),
+			Quantity:   line.Quantity,
+			Reason:     "Counter sale",
+			Notes:      fmt.Sprintf("Sale %s", dbSale.ReceiptNumber()),
+			SaleId:     &dbSale.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return svc.Get(ctx, dbSale.ID)
+}
+
+func (svc saleService) Get(ctx *context.Context, id uint) (*responseModel.Sale, *errs.XError) {
+	sale, err := svc.saleRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sale.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Sale not found", nil)
+	}
+
+	mapped, mapErr := svc.respMapper.Sale(sale)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Sale data", mapErr)
+	}
+	return mapped, nil
+}
+
+func (svc saleService) GetAll(ctx *context.Context, customerId *uint, from *time.Time, to *time.Time) ([]responseModel.Sale, *errs.XError) {
+	sales, err := svc.saleRepo.GetAll(ctx, customerId, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped, mapErr := svc.respMapper.Sales(sales)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Sale data", mapErr)
+	}
+	return mapped, nil
+}
+
+// validateSale checks the lines and fills in the price and tax details from the product
+func (svc saleService) validateSale(ctx *context.Context, sale *entities.Sale, requestLines []requestModel.SaleLine) *errs.XError {
+	if !sale.PaymentMode.IsValid() {
+		return errs.NewXError(errs.VALIDATION, "Payment mode must be one of CASH, UPI, CARD, BANK", nil)
+	}
+
+	if sale.CustomerId != nil {
+		customer, err := svc.customerRepo.Get(ctx, *sale.CustomerId)
+		if err != nil {
+			return err
+		}
+		if customer.Model == nil {
+			return errs.NewXError(errs.NOT_EXIST, "Customer not found", nil)
+		}
+	}
+
+	if len(sale.Lines) == 0 {
+		return errs.NewXError(errs.VALIDATION, "Sale must have at least one line", nil)
+	}
+
+	seen := make(map[uint]bool)
+	subTotal := 0.0
+	for i := range sale.Lines {
+		line := &sale.Lines[i]
+		if line.Quantity <= 0 {
+			return errs.NewXError(errs.VALIDATION, "Line quantity must be greater than 0", nil)
+		}
+		if seen[line.ProductId] {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Product %d is listed more than once", line.ProductId), nil)
+		}
+		seen[line.ProductId] = true
+
+		product, err := svc.productRepo.Get(ctx, line.ProductId)
+		if err != nil {
+			return err
+		}
+		if product.Model == nil || !product.IsActive {
+			return errs.NewXError(errs.NOT_EXIST, fmt.Sprintf("Product %d not found", line.ProductId), nil)
+		}
+		if product.HasVariants() {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Stock of %s is kept on its variants, pick a variant", product.Name), nil)
+		}
+
+		if requestLines[i].SellingPrice == nil {
+			line.SellingPrice = product.SellingPrice
+		}
+		if line.SellingPrice < 0 {
+			return errs.NewXError(errs.VALIDATION, "Selling price cannot be negative", nil)
+		}
+		if line.Discount < 0 || line.Discount > line.GrossValue() {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Discount on %s must be between 0 and the line value", product.Name), nil)
+		}
+		line.HSNCode = product.HSNCode
+		line.TaxRate = product.TaxRate
+		subTotal += line.NetValue()
+	}
+
+	if sale.Discount < 0 || sale.Discount > subTotal {
+		return errs.NewXError(errs.VALIDATION, "Discount must be between 0 and the value of the lines", nil)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SaveSale(t *testing.T) {
+
+	store := newStockStore()
+	kurta := store.addProduct("Kurta", entities.UnitOfMeasurePIECE)
+	kurta.SellingPrice, kurta.TaxRate, kurta.HSNCode = 500, 5, "6211"
+	store.addLot(kurta.ID, "", 3)
+
+	svc := saleService{
+		saleRepo:     fakeSaleRepo{store: store},
+		productRepo:  fakeProductRepo{store: store},
+		inventorySvc: newTestInventoryService(store),
+		taxSvc:       taxService{},
+		mapper:       mapper.ProvideMapper(),
+		respMapper:   mapper.ProvideResponseMapper(),
+	}
+	sale := func(quantity float64, paymentMode string) requestModel.Sale {
+		return requestModel.Sale{
+			PaymentMode: paymentMode,
+			Lines:       []requestModel.SaleLine{{ProductId: kurta.ID, Quantity: quantity}},
+		}
+	}
+
+	// A sale is priced and taxed from the product and its stock is booked out against it
+	response, err := svc.SaveSale(testContext(), sale(2, "CASH"))
+	require.Nil(t, err)
+	require.Equal(t, 1000.0, response.SubTotal)
+	require.Equal(t, 1050.0, response.GrandTotal)
+	require.Equal(t, 25.0, response.CGSTAmount)
+	require.Equal(t, 1.0, store.onHand(kurta.ID))
+
+	logs := store.logsFor(kurta.ID)
+	require.Len(t, logs, 1)
+	require.Equal(t, entities.InventoryLogChangeTypeOUT, logs[0].ChangeType)
+	require.Equal(t, response.ID, *logs[0].SaleId)
+
+	// Nothing is sold past the stock on hand
+	_, err = svc.SaveSale(testContext(), sale(2, "CASH"))
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "Insufficient stock")
+	require.Equal(t, 1.0, store.onHand(kurta.ID))
+
+	// Sales are checked before any stock moves
+	_, err = svc.SaveSale(testContext(), sale(1, "CHEQUE"))
+	require.NotNil(t, err)
+	require.Equal(t, errs.VALIDATION, err.Code)
+
+	twice := sale(1, "UPI")
+	twice.Lines = append(twice.Lines, twice.Lines[0])
+	_, err = svc.SaveSale(testContext(), twice)
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "listed more than once")
+	require.Len(t, store.logsFor(kurta.ID), 1)
+}
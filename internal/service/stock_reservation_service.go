@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type StockReservationService interface {
+	SyncForOrder(*context.Context, uint) *errs.XError
+	ReleaseForOrder(*context.Context, uint) *errs.XError
+}
+
+type stockReservationService struct {
+	reservationRepo  repository.StockReservationRepository
+	orderRepo        repository.OrderRepository
+	componentRepo    repository.DressTypeComponentRepository
+	inventoryLogRepo repository.InventoryLogRepository
+	inventoryRepo    repository.InventoryRepository
+}
+
+func ProvideStockReservationService(repo repository.StockReservationRepository, orderRepo repository.OrderRepository, componentRepo repository.DressTypeComponentRepository, inventoryLogRepo repository.InventoryLogRepository, inventoryRepo repository.InventoryRepository) StockReservationService {
+	return stockReservationService{
+		reservationRepo:  repo,
+		orderRepo:        orderRepo,
+		componentRepo:    componentRepo,
+		inventoryLogRepo: inventoryLogRepo,
+		inventoryRepo:    inventoryRepo,
+	}
+}
+
+// SyncForOrder brings the order's reservations in line with its status and items, it fails when
+// the order needs more than is on hand and not held for other orders
+func (svc stockReservationService) SyncForOrder(ctx *context.Context, orderId uint) *errs.XError {
+	order, err := svc.orderRepo.Get(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	held, err := svc.reservationRepo.GetReservedByOrderId(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	err = svc.reservationRepo.ReleaseByOrderId(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	if order.Model == nil || !order.IsActive || !order.Status.ReservesStock() {
+		return nil
+	}
+
+	itemIds := make([]uint, 0, len(order.OrderItems))
+	for _, item := range order.OrderItems {
+		if item.Model != nil {
+			itemIds = append(itemIds, item.ID)
+		}
+	}
+	bookedOut, err := svc.inventoryLogRepo.GetBookedOutByOrderItems(ctx, itemIds)
+	if err != nil {
+		return err
+	}
+
+	components := make(map[uint][]entities.DressTypeComponent)
+	products := make(map[uint]*entities.Product)
+	reservations := make([]entities.StockReservation, 0)
+	for _, item := range order.OrderItems {
+		if item.Model == nil || !item.IsActive || item.Measurement == nil {
+			continue
+		}
+
+		dressTypeId := item.Measurement.DressTypeId
+		if _, ok := components[dressTypeId]; !ok {
+			components[dressTypeId], err = svc.componentRepo.GetByDressTypeId(ctx, dressTypeId)
+			if err != nil {
+				return err
+			}
+		}
+
+		pieces := item.Quantity
+		if pieces <= 0 {
+			pieces = 1
+		}
+
+		for _, component := range components[dressTypeId] {
+			booked, ok := bookedOut[item.ID][component.ProductId]
+			if item.CuttingAt != nil && !ok {
+				continue
+			}
+			remaining := entities.RoundQuantity(component.Quantity*float64(pieces) - booked)
+			if remaining <= 0 {
+				continue
+			}
+			products[component.ProductId] = component.Product
+			reservations = append(reservations, entities.StockReservation{
+				Model:       &entities.Model{IsActive: true},
+				ProductId:   component.ProductId,
+				OrderId:     orderId,
+				OrderItemId: item.ID,
+				Quantity:    remaining,
+				Status:      entities.RESERVATION_RESERVED,
+			})
+		}
+	}
+
+	err = svc.checkAvailable(ctx, held, products, reservations)
+	if err != nil {
+		return err
+	}
+
+	return svc.reservationRepo.CreateAll(ctx, reservations)
+}
+
+// checkAvailable locks the inventory of every product the order now needs more of than it held,
+// and rejects the reservations when that is more than the stock not held for other orders
+func (svc stockReservationService) checkAvailable(ctx *context.Context, held map[uint]float64, products map[uint]*entities.Product, reservations []entities.StockReservation) *errs.XError {
+	needed := make(map[uint]float64)
+	for _, reservation := range reservations {
+		needed[reservation.ProductId] += reservation.Quantity
+	}
+
+	// Locks are taken in product order so two orders confirmed together cannot deadlock
+	productIds := make([]uint, 0, len(needed))
+	for productId := range needed {
+		productIds = append(productIds, productId)
+	}
+	sort.Slice(productIds, func(i, j int) bool { return productIds[i] < productIds[j] })
+
+	shortfalls := make([]string, 0)
+	for _, productId := range productIds {
+		quantity := entities.RoundQuantity(needed[productId])
+		if quantity <= held[productId] {
+			continue
+		}
+
+		err := svc.inventoryRepo.EnsureForProduct(ctx, productId)
+		if err != nil {
+			return err
+		}
+		inventory, err := svc.inventoryRepo.LockByProductId(ctx, productId)
+		if err != nil {
+			return err
+		}
+
+		// The order's own reservations were released above, so these are the other orders'
+		reserved, err := svc.reservationRepo.GetReservedQuantity(ctx, productId, nil)
+		if err != nil {
+			return err
+		}
+
+		available := entities.RoundQuantity(inventory.Quantity - reserved)
+		if quantity > available {
+			name, unit := fmt.Sprintf("Product #%d", productId), entities.UnitOfMeasurePIECE
+			if product := products[productId]; product != nil {
+				name, unit = product.Name, product.StockUnit()
+			}
+			shortfalls = append(shortfalls, fmt.Sprintf("%s short by %s %s (needed %s, available %s)", name,
+				entities.FormatQuantity(entities.RoundQuantity(quantity-available)), unit,
+				entities.FormatQuantity(quantity), entities.FormatQuantity(math.Max(available, 0))))
+		}
+	}
+
+	if len(shortfalls) > 0 {
+		return errs.NewXError(errs.VALIDATION, "Not enough stock for the order: "+strings.Join(shortfalls, ", "), nil)
+	}
+	return nil
+}
+
+// ReleaseForOrder gives back everything held for the order
+func (svc stockReservationService) ReleaseForOrder(ctx *context.Context, orderId uint) *errs.XError {
+	return svc.reservationRepo.ReleaseByOrderId(ctx, orderId)
+}
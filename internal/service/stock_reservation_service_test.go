@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SyncForOrder(t *testing.T) {
+
+	store := newStockStore()
+	silk := store.addProduct("Silk", entities.UnitOfMeasureMETER)
+	store.addLot(silk.ID, "DL-01", 5)
+	store.components[7] = []entities.DressTypeComponent{{ProductId: silk.ID, Product: silk, Quantity: 2}}
+
+	svc := newTestReservationService(store)
+	first := store.addOrder(entities.CONFIRMED, 2, 7)
+	second := store.addOrder(entities.CONFIRMED, 1, 7)
+
+	// Confirming reserves the material under a lock on the inventory row
+	require.Nil(t, svc.SyncForOrder(testContext(), first.ID))
+	require.Equal(t, 4.0, store.reservedFor(first.ID))
+	require.Equal(t, []uint{silk.ID}, store.locks)
+
+	// A second order cannot be confirmed against the stock held for the first
+	err := svc.SyncForOrder(testContext(), second.ID)
+	require.NotNil(t, err)
+	require.Equal(t, errs.VALIDATION, err.Code)
+	require.Contains(t, err.Message, "Silk short by 1 METER")
+	require.Equal(t, 0.0, store.reservedFor(second.ID))
+
+	// What the order already held is kept even when the stock has dropped since
+	store.inventory(silk.ID).Quantity = 3
+	require.Nil(t, svc.SyncForOrder(testContext(), first.ID))
+	require.Equal(t, 4.0, store.reservedFor(first.ID))
+
+	// Cancelling gives the stock back to the next order
+	first.Status = entities.CANCELLED
+	require.Nil(t, svc.SyncForOrder(testContext(), first.ID))
+	require.Equal(t, 0.0, store.reservedFor(first.ID))
+	require.Nil(t, svc.SyncForOrder(testContext(), second.ID))
+	require.Equal(t, 2.0, store.reservedFor(second.ID))
+}
+
+func Test_RecordStockMovement_Reservations(t *testing.T) {
+
+	store := newStockStore()
+	silk := store.addProduct("Silk", entities.UnitOfMeasureMETER)
+	store.addLot(silk.ID, "DL-01", 5)
+	store.components[7] = []entities.DressTypeComponent{{ProductId: silk.ID, Product: silk, Quantity: 4}}
+
+	order := store.addOrder(entities.CONFIRMED, 1, 7)
+	require.Nil(t, newTestReservationService(store).SyncForOrder(testContext(), order.ID))
+
+	svc := newTestInventoryService(store)
+	out := func(quantity float64, orderItemId *uint) *errs.XError {
+		_, err := svc.RecordStockMovement(testContext(), requestModel.StockMovementRequest{
+			ProductId:   silk.ID,
+			ChangeType:  string(entities.InventoryLogChangeTypeOUT),
+			Quantity:    quantity,
+			Reason:      "Sold",
+			OrderItemId: orderItemId,
+		})
+		return err
+	}
+
+	// Stock held for the order is not available to anything else
+	err := out(2, nil)
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "Insufficient unreserved stock")
+	require.Nil(t, out(1, nil))
+
+	// The order item it is held for draws on it and uses the reservation up
+	orderItemId := order.OrderItems[0].ID
+	require.Nil(t, out(4, &orderItemId))
+	require.Equal(t, 0.0, store.onHand(silk.ID))
+	require.Equal(t, 0.0, store.reservedFor(order.ID))
+}
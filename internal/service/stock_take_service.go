@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
+)
+
+type StockTakeService interface {
+	Open(*context.Context, requestModel.StockTake) (*responseModel.StockTake, *errs.XError)
+	Get(*context.Context, uint) (*responseModel.StockTake, *errs.XError)
+	GetAll(*context.Context) ([]responseModel.StockTake, *errs.XError)
+	SubmitCounts(*context.Context, uint, []requestModel.StockTakeCount) *errs.XError
+	Post(*context.Context, uint, requestModel.StockTakePost) (*responseModel.StockTake, *errs.XError)
+	Cancel(*context.Context, uint) *errs.XError
+}
+
+type stockTakeService struct {
+	stockTakeRepo repository.StockTakeRepository
+	inventoryRepo repository.InventoryRepository
+	inventorySvc  InventoryService
+	mapper        mapper.Mapper
+	respMapper    mapper.ResponseMapper
+}
+
+func ProvideStockTakeService(repo repository.StockTakeRepository, inventoryRepo repository.InventoryRepository, inventorySvc InventoryService, mapper mapper.Mapper, respMapper mapper.ResponseMapper) StockTakeService {
+	return stockTakeService{
+		stockTakeRepo: repo,
+		inventoryRepo: inventoryRepo,
+		inventorySvc:  inventorySvc,
+		mapper:        mapper,
+		respMapper:    respMapper,
+	}
+}
+
+// Open starts a count session with a line for every product in scope, holding the stock at this moment
+func (svc stockTakeService) Open(ctx *context.Context, req requestModel.StockTake) (*responseModel.StockTake, *errs.XError) {
+	stockTake, mapErr := svc.mapper.StockTake(req)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Unable to open stock take", mapErr)
+	}
+
+	inventories, err := svc.inventoryRepo.GetByCategoryId(ctx, req.CategoryId)
+	if err != nil {
+		return nil, err
+	}
+	if len(inventories) == 0 {
+		return nil, errs.NewXError(errs.VALIDATION, "No products to count", nil)
+	}
+
+	stockTake.Lines = make([]entities.StockTakeLine, 0, len(inventories))
+	for _, inv := range inventories {
+		stockTake.Lines = append(stockTake.Lines, entities.StockTakeLine{
+			Model:            &entities.Model{IsActive: true},
+			ProductId:        inv.ProductId,
+			ExpectedQuantity: inv.Quantity,
+		})
+	}
+
+	if err := svc.stockTakeRepo.Create(ctx, stockTake); err != nil {
+		return nil, err
+	}
+
+	return svc.Get(ctx, stockTake.ID)
+}
+
+func (svc stockTakeService) Get(ctx *context.Context, id uint) (*responseModel.StockTake, *errs.XError) {
+	stockTake, err := svc.getStockTake(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped, mapErr := svc.respMapper.StockTake(stockTake)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map StockTake data", mapErr)
+	}
+	return mapped, nil
+}
+
+func (svc stockTakeService) GetAll(ctx *context.Context) ([]responseModel.StockTake, *errs.XError) {
+	stockTakes, err := svc.stockTakeRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped, mapErr := svc.respMapper.StockTakes(stockTakes)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map StockTake data", mapErr)
+	}
+	return mapped, nil
+}
+
+// SubmitCounts records counted quantities, counting a product again replaces its earlier count
+func (svc stockTakeService) SubmitCounts(ctx *context.Context, id uint, counts []requestModel.StockTakeCount) *errs.XError {
+	if len(counts) == 0 {
+		return errs.NewXError(errs.INVALID_REQUEST, "At least one count is required", nil)
+	}
+
+	if _, err := svc.getOpenStockTake(ctx, id); err != nil {
+		return err
+	}
+
+	for _, count := range counts {
+		if count.CountedQuantity < 0 {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Counted quantity for product %d cannot be negative", count.ProductId), nil)
+		}
+
+		found, err := svc.stockTakeRepo.UpdateCount(ctx, id, count.ProductId, entities.RoundQuantity(count.CountedQuantity))
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Product %d is not part of this stock take", count.ProductId), nil)
+		}
+	}
+
+	return nil
+}
+
+// Post books the variance of every counted product as a signed ADJUST movement
+func (svc stockTakeService) Post(ctx *context.Context, id uint, req requestModel.StockTakePost) (*responseModel.StockTake, *errs.XError) {
+	stockTake, err := svc.getOpenStockTake(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range stockTake.Lines {
+		line := &stockTake.Lines[i]
+		if line.CountedQuantity == nil {
+			continue
+		}
+
+		inventory, err := svc.inventoryRepo.LockByProductId(ctx, line.ProductId)
+		if err != nil {
+			return nil, err
+		}
+
+		variance := line.Variance(inventory.Quantity)
+		if *variance != 0 {
+			_, err = svc.inventorySvc.RecordStockMovement(ctx, requestModel.StockMovementRequest{
+				ProductId:     line.ProductId,
+				ChangeType:    string(entities.InventoryLogChangeTypeADJUST),
+				Quantity:      *variance,
+				Reason:        req.Reason,
+				Notes:         fmt.Sprintf("Stock take #%d", stockTake.ID),
+				AdminOverride: true, // the counted quantity is never negative
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err := svc.stockTakeRepo.UpdatePostedVariance(ctx, line.ID, *variance); err != nil {
+			return nil, err
+		}
+	}
+
+	postedAt := util.GetLocalTime()
+	if err := svc.stockTakeRepo.UpdateStatus(ctx, id, entities.StockTakeStatusPOSTED, req.Reason, &postedAt); err != nil {
+		return nil, err
+	}
+
+	return svc.Get(ctx, id)
+}
+
+func (svc stockTakeService) Cancel(ctx *context.Context, id uint) *errs.XError {
+	if _, err := svc.getOpenStockTake(ctx, id); err != nil {
+		return err
+	}
+	return svc.stockTakeRepo.UpdateStatus(ctx, id, entities.StockTakeStatusCANCELLED, "", nil)
+}
+
+func (svc stockTakeService) getStockTake(ctx *context.Context, id uint) (*entities.StockTake, *errs.XError) {
+	stockTake, err := svc.stockTakeRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stockTake.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Stock take not found", nil)
+	}
+	return stockTake, nil
+}
+
+// getOpenStockTake locks the stock take so counts, posting and cancelling are applied one after another
+func (svc stockTakeService) getOpenStockTake(ctx *context.Context, id uint) (*entities.StockTake, *errs.XError) {
+	if err := svc.stockTakeRepo.Lock(ctx, id); err != nil {
+		return nil, err
+	}
+
+	stockTake, err := svc.getStockTake(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stockTake.Status != entities.StockTakeStatusOPEN {
+		return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("Stock take is %s", stockTake.Status), nil)
+	}
+	return stockTake, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PostStockTake(t *testing.T) {
+
+	store := newStockStore()
+	silk := store.addProduct("Silk", entities.UnitOfMeasureMETER)
+	buttons := store.addProduct("Buttons", entities.UnitOfMeasurePIECE)
+	lining := store.addProduct("Lining", entities.UnitOfMeasureMETER)
+	store.addLot(silk.ID, "DL-01", 5)
+	store.addLot(buttons.ID, "", 10)
+
+	stockTake := &entities.StockTake{Model: &entities.Model{ID: store.nextId(), IsActive: true}, Status: entities.StockTakeStatusOPEN}
+	for _, product := range []*entities.Product{silk, buttons, lining} {
+		stockTake.Lines = append(stockTake.Lines, entities.StockTakeLine{
+			Model:            &entities.Model{ID: store.nextId(), IsActive: true},
+			StockTakeId:      stockTake.ID,
+			ProductId:        product.ID,
+			ExpectedQuantity: store.onHand(product.ID),
+		})
+	}
+	store.stockTakes[stockTake.ID] = stockTake
+
+	inventorySvc := newTestInventoryService(store)
+	svc := stockTakeService{
+		stockTakeRepo: fakeStockTakeRepo{store: store},
+		inventoryRepo: fakeInventoryRepo{store: store},
+		inventorySvc:  inventorySvc,
+		respMapper:    mapper.ProvideResponseMapper(),
+	}
+
+	require.Nil(t, svc.SubmitCounts(testContext(), stockTake.ID, []requestModel.StockTakeCount{
+		{ProductId: silk.ID, CountedQuantity: 4.5},
+		{ProductId: buttons.ID, CountedQuantity: 8},
+	}))
+
+	// Stock moving while the count is open is taken into account, the variance is against the stock at posting
+	_, err := inventorySvc.RecordStockMovement(testContext(), requestModel.StockMovementRequest{
+		ProductId:  silk.ID,
+		ChangeType: string(entities.InventoryLogChangeTypeOUT),
+		Quantity:   1,
+		Reason:     "Cutting",
+	})
+	require.Nil(t, err)
+
+	response, err := svc.Post(testContext(), stockTake.ID, requestModel.StockTakePost{Reason: "Cycle count"})
+	require.Nil(t, err)
+	require.Equal(t, string(entities.StockTakeStatusPOSTED), response.Status)
+	require.Equal(t, []uint{stockTake.ID, stockTake.ID}, store.stockTakeLocks)
+
+	// Each counted product is adjusted to its count, an uncounted product is left as it is
+	require.Equal(t, 4.5, store.onHand(silk.ID))
+	require.Equal(t, 8.0, store.onHand(buttons.ID))
+	require.Equal(t, 0.5, *stockTake.Lines[0].PostedVariance)
+	require.Equal(t, -2.0, *stockTake.Lines[1].PostedVariance)
+	require.Nil(t, stockTake.Lines[2].PostedVariance)
+	require.Empty(t, store.logsFor(lining.ID))
+
+	adjustments := store.logsFor(buttons.ID)
+	require.Len(t, adjustments, 1)
+	require.Equal(t, entities.InventoryLogChangeTypeADJUST, adjustments[0].ChangeType)
+	require.Equal(t, -2.0, adjustments[0].Quantity)
+	require.Equal(t, "Cycle count", adjustments[0].Reason)
+
+	// A posted stock take takes no more counts and is not posted or cancelled again
+	_, err = svc.Post(testContext(), stockTake.ID, requestModel.StockTakePost{Reason: "Cycle count"})
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "Stock take is POSTED")
+	require.NotNil(t, svc.Cancel(testContext(), stockTake.ID))
+	require.NotNil(t, svc.SubmitCounts(testContext(), stockTake.ID, []requestModel.StockTakeCount{{ProductId: silk.ID, CountedQuantity: 5}}))
+	require.Equal(t, 4.5, store.onHand(silk.ID))
+	require.Len(t, store.stockTakeLocks, 5)
+}
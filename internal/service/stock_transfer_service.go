@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/thoas/go-funk"
+)
+
+type StockTransferService interface {
+	SaveStockTransfer(*context.Context, requestModel.StockTransfer) *errs.XError
+	UpdateStockTransfer(*context.Context, requestModel.StockTransfer, uint) *errs.XError
+	Get(*context.Context, uint) (*responseModel.StockTransfer, *errs.XError)
+	GetAll(*context.Context, string) ([]responseModel.StockTransfer, *errs.XError)
+	Dispatch(*context.Context, uint) (*responseModel.StockTransfer, *errs.XError)
+	Receive(*context.Context, uint) (*responseModel.StockTransfer, *errs.XError)
+	Cancel(*context.Context, uint) *errs.XError
+}
+
+type stockTransferService struct {
+	stockTransferRepo repository.StockTransferRepository
+	inventoryLogRepo  repository.InventoryLogRepository
+	channelRepo       repository.ChannelRepository
+	productRepo       repository.ProductRepository
+	inventorySvc      InventoryService
+	mapper            mapper.Mapper
+	respMapper        mapper.ResponseMapper
+}
+
+func ProvideStockTransferService(
+	repo repository.StockTransferRepository,
+	logRepo repository.InventoryLogRepository,
+	channelRepo repository.ChannelRepository,
+	productRepo repository.ProductRepository,
+	inventorySvc InventoryService,
+	mapper mapper.Mapper,
+	respMapper mapper.ResponseMapper,
+) StockTransferService {
+	return stockTransferService{
+		stockTransferRepo: repo,
+		inventoryLogRepo:  logRepo,
+		channelRepo:       channelRepo,
+		productRepo:       productRepo,
+		inventorySvc:      inventorySvc,
+		mapper:            mapper,
+		respMapper:        respMapper,
+	}
+}
+
+// SaveStockTransfer drafts a transfer from the current channel, stock moves only when it is dispatched
+func (svc stockTransferService) SaveStockTransfer(ctx *context.Context, transfer requestModel.StockTransfer) *errs.XError {
+	if err := svc.validateStockTransfer(ctx, transfer); err != nil {
+		return err
+	}
+
+	dbTransfer, err := svc.mapper.StockTransfer(transfer)
+	if err != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save stock transfer", err)
+	}
+
+	dbTransfer.FromChannelId = utils.GetChannelId(ctx)
+	return svc.stockTransferRepo.Create(ctx, dbTransfer)
+}
+
+// UpdateStockTransfer replaces the destination and lines of a transfer that is still a draft
+func (svc stockTransferService) UpdateStockTransfer(ctx *context.Context, transfer requestModel.StockTransfer, id uint) *errs.XError {
+	existing, err := svc.getStockTransfer(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing.Status != entities.StockTransferStatusDRAFT {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Stock transfer is %s and can no longer be changed", existing.Status), nil)
+	}
+	if existing.FromChannelId != utils.GetChannelId(ctx) {
+		return errs.NewXError(errs.VALIDATION, "Only the sending channel can change the stock transfer", nil)
+	}
+
+	if err := svc.validateStockTransfer(ctx, transfer); err != nil {
+		return err
+	}
+
+	dbTransfer, mapErr := svc.mapper.StockTransfer(transfer)
+	if mapErr != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update stock transfer", mapErr)
+	}
+
+	dbTransfer.ID = id
+	if err := svc.stockTransferRepo.UpdateDetails(ctx, dbTransfer); err != nil {
+		return err
+	}
+	return svc.stockTransferRepo.ReplaceLines(ctx, id, dbTransfer.Lines)
+}
+
+func (svc stockTransferService) Get(ctx *context.Context, id uint) (*responseModel.StockTransfer, *errs.XError) {
+	transfer, err := svc.getStockTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped, mapErr := svc.respMapper.StockTransfer(transfer)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map StockTransfer data", mapErr)
+	}
+	return mapped, nil
+}
+
+func (svc stockTransferService) GetAll(ctx *context.Context, status string) ([]responseModel.StockTransfer, *errs.XError) {
+	transfers, err := svc.stockTransferRepo.GetAll(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped, mapErr := svc.respMapper.StockTransfers(transfers)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map StockTransfer data", mapErr)
+	}
+	return mapped, nil
+}
+
+// Dispatch takes the stock out of the sending channel, it is in transit until the receiving channel receives it
+func (svc stockTransferService) Dispatch(ctx *context.Context, id uint) (*responseModel.StockTransfer, *errs.XError) {
+	transfer, err := svc.lockStockTransfer(ctx, id, entities.StockTransferStatusDRAFT)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.FromChannelId != utils.GetChannelId(ctx) {
+		return nil, errs.NewXError(errs.VALIDATION, "Only the sending channel can dispatch the stock transfer", nil)
+	}
+
+	toChannelName := ""
+	if transfer.ToChannel != nil {
+		toChannelName = transfer.ToChannel.Name
+	}
+
+	for _, line := range transfer.Lines {
+		_, err := svc.inventorySvc.RecordStockMovement(ctx, requestModel.StockMovementRequest{
+			ProductId:       line.ProductId,
+			ChangeType:      string(entities.InventoryLogChangeTypeOUT),
+			Quantity:        line.Quantity,
+			Reason:          "Stock transfer dispatched",
+			Notes:           fmt.Sprintf("Stock transfer #%d to %s", transfer.ID, toChannelName),
+			StockTransferId: &transfer.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := svc.stockTransferRepo.UpdateStatus(ctx, id, entities.StockTransferStatusDISPATCHED); err != nil {
+		return nil, err
+	}
+	return svc.Get(ctx, id)
+}
+
+// Receive books the dispatched stock into the receiving channel, lot by lot
+func (svc stockTransferService) Receive(ctx *context.Context, id uint) (*responseModel.StockTransfer, *errs.XError) {
+	transfer, err := svc.lockStockTransfer(ctx, id, entities.StockTransferStatusDISPATCHED)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToChannelId != utils.GetChannelId(ctx) {
+		return nil, errs.NewXError(errs.VALIDATION, "Only the receiving channel can receive the stock transfer", nil)
+	}
+
+	dispatched, err := svc.inventoryLogRepo.GetByStockTransferId(ctx, transfer.ID, entities.InventoryLogChangeTypeOUT)
+	if err != nil {
+		return nil, err
+	}
+
+	fromChannelName := ""
+	if transfer.FromChannel != nil {
+		fromChannelName = transfer.FromChannel.Name
+	}
+	notes := fmt.Sprintf("Stock transfer #%d from %s", transfer.ID, fromChannelName)
+
+	for _, line := range transfer.Lines {
+		for _, movement := range receivedMovements(line, dispatched) {
+			movement.Reason = "Stock transfer received"
+			movement.Notes = notes
+			movement.StockTransferId = &transfer.ID
+			if _, err := svc.inventorySvc.RecordStockMovement(ctx, movement); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := svc.stockTransferRepo.UpdateStatus(ctx, id, entities.StockTransferStatusRECEIVED); err != nil {
+		return nil, err
+	}
+	return svc.Get(ctx, id)
+}
+
+// receivedMovements splits a line into one IN per lot it was dispatched from
+func receivedMovements(line entities.StockTransferLine, dispatched []entities.InventoryLog) []requestModel.StockMovementRequest {
+	movements := make([]requestModel.StockMovementRequest, 0)
+	remaining := line.Quantity
+	for _, log := range dispatched {
+		if log.ProductId != line.ProductId || log.Lot == nil || remaining <= 0 {
+			continue
+		}
+
+		quantity := math.Min(log.Quantity, remaining)
+		receivedAt := log.Lot.ReceivedAt
+		movements = append(movements, requestModel.StockMovementRequest{
+			ProductId:    line.ProductId,
+			ChangeType:   string(entities.InventoryLogChangeTypeIN),
+			Quantity:     quantity,
+			LotCode:      log.Lot.LotCode,
+			SupplierId:   log.Lot.SupplierId,
+			ReceivedAt:   &receivedAt,
+			LotExpiresAt: log.Lot.ExpiresAt,
+		})
+		remaining = entities.RoundQuantity(remaining - quantity)
+	}
+
+	if remaining > 0 {
+		movements = append(movements, requestModel.StockMovementRequest{
+			ProductId:  line.ProductId,
+			ChangeType: string(entities.InventoryLogChangeTypeIN),
+			Quantity:   remaining,
+		})
+	}
+	return movements
+}
+
+// Cancel drops a draft transfer, a dispatched transfer has to be received
+func (svc stockTransferService) Cancel(ctx *context.Context, id uint) *errs.XError {
+	transfer, err := svc.lockStockTransfer(ctx, id, entities.StockTransferStatusDRAFT)
+	if err != nil {
+		return err
+	}
+	if transfer.FromChannelId != utils.GetChannelId(ctx) {
+		return errs.NewXError(errs.VALIDATION, "Only the sending channel can cancel the stock transfer", nil)
+	}
+	return svc.stockTransferRepo.UpdateStatus(ctx, id, entities.StockTransferStatusCANCELLED)
+}
+
+func (svc stockTransferService) validateStockTransfer(ctx *context.Context, transfer requestModel.StockTransfer) *errs.XError {
+	if transfer.ToChannelId == utils.GetChannelId(ctx) {
+		return errs.NewXError(errs.VALIDATION, "Stock cannot be transferred to the same channel", nil)
+	}
+	if !funk.ContainsUInt(utils.GetAccessibleLocationIds(ctx), transfer.ToChannelId) {
+		return errs.NewXError(errs.VALIDATION, "Stock can only be transferred to a channel you have access to", nil)
+	}
+
+	channel, err := svc.channelRepo.Get(ctx, transfer.ToChannelId)
+	if err != nil {
+		return err
+	}
+	if channel.Model == nil || !channel.IsActive {
+		return errs.NewXError(errs.NOT_EXIST, "Channel not found", nil)
+	}
+
+	if len(transfer.Lines) == 0 {
+		return errs.NewXError(errs.VALIDATION, "Stock transfer must have at least one line", nil)
+	}
+
+	seen := make(map[uint]bool)
+	for _, line := range transfer.Lines {
+		if entities.RoundQuantity(line.Quantity) <= 0 {
+			return errs.NewXError(errs.VALIDATION, "Line quantity must be greater than 0", nil)
+		}
+		if seen[line.ProductId] {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Product %d is listed more than once", line.ProductId), nil)
+		}
+		seen[line.ProductId] = true
+
+		product, err := svc.productRepo.Get(ctx, line.ProductId)
+		if err != nil {
+			return err
+		}
+		if product.Model == nil {
+			return errs.NewXError(errs.NOT_EXIST, fmt.Sprintf("Product %d not found", line.ProductId), nil)
+		}
+		if product.HasVariants() {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Stock of %s is kept on its variants, pick a variant", product.Name), nil)
+		}
+	}
+
+	return nil
+}
+
+// lockStockTransfer locks the transfer and checks it is in the status the action starts from
+func (svc stockTransferService) lockStockTransfer(ctx *context.Context, id uint, status entities.StockTransferStatus) (*entities.StockTransfer, *errs.XError) {
+	if err := svc.stockTransferRepo.Lock(ctx, id); err != nil {
+		return nil, err
+	}
+
+	transfer, err := svc.getStockTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != status {
+		return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("Stock transfer is %s", transfer.Status), nil)
+	}
+	return transfer, nil
+}
+
+func (svc stockTransferService) getStockTransfer(ctx *context.Context, id uint) (*entities.StockTransfer, *errs.XError) {
+	transfer, err := svc.stockTransferRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Stock transfer not found", nil)
+	}
+	return transfer, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DispatchAndReceiveStockTransfer(t *testing.T) {
+
+	// Each channel keeps its stock in its own store, the transfers and their logs are read from the sender's
+	store, branch := newStockStore(), newStockStore()
+	silk := store.addProduct("Silk", entities.UnitOfMeasureMETER)
+	branch.products[silk.ID] = silk
+	supplierId := uint(3)
+	store.addLot(silk.ID, "DL-01", 2).SupplierId = &supplierId
+	store.addLot(silk.ID, "DL-02", 3)
+
+	addTransfer := func(quantity float64) *entities.StockTransfer {
+		transfer := &entities.StockTransfer{
+			Model:         &entities.Model{ID: store.nextId(), IsActive: true},
+			FromChannelId: 1,
+			ToChannelId:   2,
+			Status:        entities.StockTransferStatusDRAFT,
+			Lines:         []entities.StockTransferLine{{Model: &entities.Model{ID: store.nextId(), IsActive: true}, ProductId: silk.ID, Quantity: quantity}},
+		}
+		store.stockTransfers[transfer.ID] = transfer
+		return transfer
+	}
+	transfer := addTransfer(4)
+
+	sender := stockTransferService{
+		stockTransferRepo: fakeStockTransferRepo{store: store},
+		inventoryLogRepo:  fakeInventoryLogRepo{store: store},
+		inventorySvc:      newTestInventoryService(store),
+		respMapper:        mapper.ProvideResponseMapper(),
+	}
+	receiver := sender
+	receiver.inventorySvc = newTestInventoryService(branch)
+
+	// Only the sending channel dispatches, the stock leaves it lot by lot under a lock on the transfer
+	_, err := sender.Dispatch(channelContext(2), transfer.ID)
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "Only the sending channel")
+
+	response, err := sender.Dispatch(testContext(), transfer.ID)
+	require.Nil(t, err)
+	require.Equal(t, string(entities.StockTransferStatusDISPATCHED), response.Status)
+	require.Equal(t, []uint{transfer.ID, transfer.ID}, store.stockTransferLocks)
+	require.Equal(t, 1.0, store.onHand(silk.ID))
+
+	logs := store.logsFor(silk.ID)
+	require.Len(t, logs, 2)
+	for _, log := range logs {
+		require.Equal(t, transfer.ID, *log.StockTransferId)
+	}
+
+	_, err = sender.Dispatch(testContext(), transfer.ID)
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "Stock transfer is DISPATCHED")
+
+	// Only the receiving channel receives, into lots carrying the codes and suppliers of the lots sent
+	_, err = receiver.Receive(testContext(), transfer.ID)
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "Only the receiving channel")
+
+	response, err = receiver.Receive(channelContext(2), transfer.ID)
+	require.Nil(t, err)
+	require.Equal(t, string(entities.StockTransferStatusRECEIVED), response.Status)
+	require.Equal(t, 4.0, branch.onHand(silk.ID))
+	require.Equal(t, 1.0, store.onHand(silk.ID))
+
+	require.Len(t, branch.lots, 2)
+	require.Equal(t, "DL-01", branch.lots[0].LotCode)
+	require.Equal(t, 2.0, branch.lots[0].Quantity)
+	require.Equal(t, supplierId, *branch.lots[0].SupplierId)
+	require.Equal(t, "DL-02", branch.lots[1].LotCode)
+	require.Equal(t, 2.0, branch.lots[1].Quantity)
+
+	_, err = receiver.Receive(channelContext(2), transfer.ID)
+	require.NotNil(t, err)
+	require.Equal(t, errs.VALIDATION, err.Code)
+
+	// A transfer of more than is in stock is not dispatched
+	short := addTransfer(5)
+	_, err = sender.Dispatch(testContext(), short.ID)
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "Insufficient stock")
+	require.Equal(t, entities.StockTransferStatusDRAFT, short.Status)
+	require.Equal(t, 1.0, store.onHand(silk.ID))
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type SupplierService interface {
+	SaveSupplier(*context.Context, requestModel.Supplier) *errs.XError
+	UpdateSupplier(*context.Context, requestModel.Supplier, uint) *errs.XError
+	Get(*context.Context, uint) (*responseModel.Supplier, *errs.XError)
+	GetAll(*context.Context, string) ([]responseModel.Supplier, *errs.XError)
+	Delete(*context.Context, uint) *errs.XError
+	AutocompleteSupplier(*context.Context, string) ([]responseModel.SupplierAutoComplete, *errs.XError)
+}
+
+type supplierService struct {
+	supplierRepo repository.SupplierRepository
+	mapper       mapper.Mapper
+	respMapper   mapper.ResponseMapper
+}
+
+func ProvideSupplierService(
+	repo repository.SupplierRepository,
+	mapper mapper.Mapper,
+	respMapper mapper.ResponseMapper,
+) SupplierService {
+	return supplierService{
+		supplierRepo: repo,
+		mapper:       mapper,
+		respMapper:   respMapper,
+	}
+}
+
+func (svc supplierService) SaveSupplier(ctx *context.Context, supplier requestModel.Supplier) *errs.XError {
+	if strings.TrimSpace(supplier.Name) == "" {
+		return errs.NewXError(errs.VALIDATION, "Supplier name is required", nil)
+	}
+
+	dbSupplier, err := svc.mapper.Supplier(supplier)
+	if err != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save supplier", err)
+	}
+
+	errr := svc.supplierRepo.Create(ctx, dbSupplier)
+	if errr != nil {
+		return errr
+	}
+
+	return nil
+}
+
+func (svc supplierService) UpdateSupplier(ctx *context.Context, supplier requestModel.Supplier, id uint) *errs.XError {
+	if strings.TrimSpace(supplier.Name) == "" {
+		return errs.NewXError(errs.VALIDATION, "Supplier name is required", nil)
+	}
+
+	dbSupplier, err := svc.mapper.Supplier(supplier)
+	if err != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update supplier", err)
+	}
+
+	dbSupplier.ID = id
+	errr := svc.supplierRepo.Update(ctx, dbSupplier)
+	if errr != nil {
+		return errr
+	}
+	return nil
+}
+
+func (svc supplierService) Get(ctx *context.Context, id uint) (*responseModel.Supplier, *errs.XError) {
+	supplier, err := svc.supplierRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if supplier.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Supplier not found", nil)
+	}
+
+	mappedSupplier, mapErr := svc.respMapper.Supplier(supplier)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Supplier data", mapErr)
+	}
+
+	return mappedSupplier, nil
+}
+
+func (svc supplierService) GetAll(ctx *context.Context, search string) ([]responseModel.Supplier, *errs.XError) {
+	suppliers, err := svc.supplierRepo.GetAll(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedSuppliers, mapErr := svc.respMapper.Suppliers(suppliers)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Supplier data", mapErr)
+	}
+
+	return mappedSuppliers, nil
+}
+
+func (svc supplierService) Delete(ctx *context.Context, id uint) *errs.XError {
+	err := svc.supplierRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (svc supplierService) AutocompleteSupplier(ctx *context.Context, search string) ([]responseModel.SupplierAutoComplete, *errs.XError) {
+	suppliers, err := svc.supplierRepo.AutocompleteSupplier(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]responseModel.SupplierAutoComplete, 0)
+	for _, supplier := range suppliers {
+		res = append(res, responseModel.SupplierAutoComplete{
+			ID:   supplier.ID,
+			Name: supplier.Name,
+		})
+	}
+
+	return res, nil
+}
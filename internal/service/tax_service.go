@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type TaxService interface {
+	ApplyOrderItemTaxes(*context.Context, *uint, []entities.OrderItem) *errs.XError
+	ApplySaleTaxes(*context.Context, *entities.Sale) *errs.XError
+}
+
+type taxService struct {
+	channelRepo     repository.ChannelRepository
+	customerRepo    repository.CustomerRepository
+	measurementRepo repository.MeasurementRepository
+}
+
+func ProvideTaxService(channelRepo repository.ChannelRepository, customerRepo repository.CustomerRepository, measurementRepo repository.MeasurementRepository) TaxService {
+	return taxService{
+		channelRepo:     channelRepo,
+		customerRepo:    customerRepo,
+		measurementRepo: measurementRepo,
+	}
+}
+
+// ApplyOrderItemTaxes computes GST for each item from the HSN code and rate of its dress type
+func (svc taxService) ApplyOrderItemTaxes(ctx *context.Context, customerId *uint, items []entities.OrderItem) *errs.XError {
+	if len(items) == 0 {
+		return nil
+	}
+
+	interState, err := svc.isInterState(ctx, customerId)
+	if err != nil {
+		return err
+	}
+
+	measurementIds := make([]uint, 0)
+	for _, item := range items {
+		if item.MeasurementId != nil {
+			measurementIds = append(measurementIds, *item.MeasurementId)
+		}
+	}
+
+	measurements, err := svc.measurementRepo.GetByIds(ctx, measurementIds)
+	if err != nil {
+		return err
+	}
+
+	dressTypes := make(map[uint]*entities.DressType)
+	for _, measurement := range measurements {
+		dressTypes[measurement.ID] = measurement.DressType
+	}
+
+	for i := range items {
+		var hsnCode string
+		var rate float64
+		if items[i].MeasurementId != nil {
+			if dressType := dressTypes[*items[i].MeasurementId]; dressType != nil {
+				hsnCode = dressType.HSNCode
+				rate = dressType.TaxRate
+			}
+		}
+		items[i].ApplyTax(hsnCode, rate, interState)
+	}
+
+	return nil
+}
+
+// ApplySaleTaxes works out the totals of a counter sale with GST at the rate of each product
+func (svc taxService) ApplySaleTaxes(ctx *context.Context, sale *entities.Sale) *errs.XError {
+	interState, err := svc.isInterState(ctx, sale.CustomerId)
+	if err != nil {
+		return err
+	}
+
+	sale.ApplyTotals(interState)
+	return nil
+}
+
+func (svc taxService) isInterState(ctx *context.Context, customerId *uint) (bool, *errs.XError) {
+	if customerId == nil {
+		return false, nil
+	}
+
+	channel, err := svc.channelRepo.Get(ctx, utils.GetChannelId(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	customer, err := svc.customerRepo.Get(ctx, *customerId)
+	if err != nil {
+		return false, err
+	}
+
+	return entities.IsInterStateSupply(channel.State, customer.State), nil
+}
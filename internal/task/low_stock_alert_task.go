@@ -0,0 +1,63 @@
+package task
+
+import (
+	"context"
+
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/task"
+)
+
+type LowStockAlertTaskParam struct {
+	*task.BaseTaskParam
+}
+
+// LowStockAlertTask emails each channel owner the items that ran low on stock since the last run
+type LowStockAlertTask struct {
+	*task.BaseTask
+
+	*LowStockAlertTaskParam
+
+	inventorySvc service.InventoryService
+}
+
+func ProvideLowStockAlertTask(param *LowStockAlertTaskParam, svc service.InventoryService) task.IBaseTask {
+	context := context.Background()
+	return &LowStockAlertTask{
+		BaseTask: &task.BaseTask{
+			Param: param.BaseTaskParam,
+			Ctx:   &context,
+		},
+		LowStockAlertTaskParam: param,
+		inventorySvc:           svc,
+	}
+}
+
+func (t *LowStockAlertTask) FetchEntitySet() (bool, []task.TaskResponse, *errs.XError) {
+	alerts, err := t.inventorySvc.GetLowStockAlerts(t.Ctx)
+	if err != nil {
+		return false, nil, err
+	}
+
+	res := make([]task.TaskResponse, len(alerts))
+	for i := range alerts {
+		res[i] = alerts[i]
+	}
+	return true, res, nil
+}
+
+func (t *LowStockAlertTask) ProcessEntitySet(alerts []task.TaskResponse) (bool, *errs.XError) {
+
+	// an unsent alert stays pending for the next run, the other channels are still alerted
+	var failed *errs.XError
+	for _, item := range alerts {
+		alert := item.(responseModel.LowStockAlert)
+		err := t.inventorySvc.SendLowStockAlert(t.Ctx, alert)
+		if err != nil && failed == nil {
+			failed = err
+		}
+	}
+
+	return false, failed
+}
@@ -0,0 +1,78 @@
+package pdf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Code128(t *testing.T) {
+
+	for _, pattern := range code128Patterns[:code128Stop] {
+		width := 0
+		for _, w := range pattern {
+			width += int(w - '0')
+		}
+		require.Equal(t, 11, width, pattern)
+	}
+
+	// Start B, 3 data symbols, checksum and the 13 module stop
+	modules, err := Code128("AB1")
+	require.NoError(t, err)
+	require.Len(t, modules, 11*5+13)
+	require.True(t, modules[0])
+	require.True(t, modules[len(modules)-1])
+
+	_, err = Code128("Ünicode")
+	require.Error(t, err)
+}
+
+func Test_QRCode(t *testing.T) {
+
+	// Error correction of the 1-M "HELLO WORLD" example from the specification
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	require.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, reedSolomon(data, 10))
+
+	require.Equal(t, 0b101000100100101, qrFormatBits(1))
+	require.Equal(t, 0b100010111111001, qrFormatBits(4))
+
+	modules, err := QRCode("FAB-SILK-0042")
+	require.NoError(t, err)
+	require.Len(t, modules, 21)
+
+	// Finder pattern corners and the always dark module
+	require.True(t, modules[0][0])
+	require.True(t, modules[0][20])
+	require.True(t, modules[20][0])
+	require.False(t, modules[7][7])
+	require.True(t, modules[21-8][8])
+
+	// Longer codes move up a version
+	modules, err = QRCode("https://shop.example.com/p/FAB-SILK-0042")
+	require.NoError(t, err)
+	require.Len(t, modules, 29)
+}
+
+func Test_LabelSheet(t *testing.T) {
+
+	labels := make([]Label, 30)
+	for i := range labels {
+		labels[i] = Label{Code: "FAB-SILK-0042", Title: "Silk fabric", Caption: "Rs. 450"}
+	}
+
+	out, err := LabelSheet(LabelLayouts[DefaultLabelLayout], LabelCode128, labels)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "/Count 2")
+
+	out, err = LabelSheet(LabelLayouts["A4-65"], LabelQR, labels)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "/Count 1")
+
+	_, err = LabelSheet(LabelLayout{}, LabelQR, labels)
+	require.Error(t, err)
+
+	for _, layout := range LabelLayouts {
+		require.NoError(t, layout.Validate())
+	}
+	require.Error(t, NewLabelLayout(3, 8, 75, 37, 0, 0, 0, 0).Validate())
+}
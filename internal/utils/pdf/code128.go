@@ -0,0 +1,81 @@
+package pdf
+
+import "fmt"
+
+// code128Patterns holds the bar and space widths of every Code 128 symbol, starting with a bar
+var code128Patterns = [...]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+const (
+	code128StartB = 104
+	code128Stop   = 106
+)
+
+// Code128 encodes printable ASCII text in code set B and returns the modules of the symbol
+func Code128(text string) ([]bool, error) {
+	if text == "" {
+		return nil, fmt.Errorf("nothing to encode")
+	}
+
+	symbols := []int{code128StartB}
+	checksum := code128StartB
+	for i, r := range text {
+		if r < 32 || r > 126 {
+			return nil, fmt.Errorf("character %q cannot be encoded in Code 128", r)
+		}
+		value := int(r) - 32
+		symbols = append(symbols, value)
+		checksum += value * (i + 1)
+	}
+	symbols = append(symbols, checksum%103, code128Stop)
+
+	var modules []bool
+	for _, symbol := range symbols {
+		bar := true
+		for _, width := range code128Patterns[symbol] {
+			for n := 0; n < int(width-'0'); n++ {
+				modules = append(modules, bar)
+			}
+			bar = !bar
+		}
+	}
+	return modules, nil
+}
+
+// code128QuietZone is the light margin scanners need on either side of the symbol, in modules
+const code128QuietZone = 10
+
+// Barcode128 draws the Code 128 symbol of text over the w by h box at (x, y)
+func (d *Document) Barcode128(x, y, w, h float64, text string) error {
+	modules, err := Code128(text)
+	if err != nil {
+		return err
+	}
+
+	moduleWidth := w / float64(len(modules)+2*code128QuietZone)
+	x += code128QuietZone * moduleWidth
+	for i := 0; i < len(modules); {
+		if !modules[i] {
+			i++
+			continue
+		}
+		// Adjacent bar modules are drawn as one rectangle
+		start := i
+		for i < len(modules) && modules[i] {
+			i++
+		}
+		d.Rect(x+float64(start)*moduleWidth, y, float64(i-start)*moduleWidth, h, true)
+	}
+	return nil
+}
@@ -0,0 +1,486 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf16"
+)
+
+// FontPath is the folder the embedded fonts are read from, relative to the working directory
+const FontPath = "templates/fonts"
+
+const (
+	regularFontFile = "DejaVuSans.ttf"
+	boldFontFile    = "DejaVuSans-Bold.ttf"
+)
+
+// Font is a TrueType font embedded into documents, only the glyphs drawn are kept in the file
+type Font struct {
+	Name       string // PostScript name
+	tables     map[string][]byte
+	unitsPerEm float64
+	numGlyphs  int
+	advances   []uint16 // By glyph id
+	glyphs     map[rune]uint16
+	longLoca   bool
+}
+
+var defaultFonts struct {
+	once          sync.Once
+	regular, bold []*Font
+}
+
+// loadDefaultFonts reads the fonts in FontPath once, without them documents fall back to Helvetica
+func loadDefaultFonts() ([]*Font, []*Font) {
+	defaultFonts.once.Do(func() {
+		regular, bold, err := LoadFonts(FontPath)
+		if err == nil {
+			defaultFonts.regular, defaultFonts.bold = regular, bold
+		}
+	})
+	return defaultFonts.regular, defaultFonts.bold
+}
+
+// LoadFonts reads the regular and bold fonts from dir, every other .ttf file in it is a fallback
+// for the characters they lack, e.g. a Tamil font
+func LoadFonts(dir string) (regular []*Font, bold []*Font, err error) {
+	regularFont, err := LoadFont(filepath.Join(dir, regularFontFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	boldFont, err := LoadFont(filepath.Join(dir, boldFontFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	regular, bold = []*Font{regularFont}, []*Font{boldFont}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.ttf"))
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		name := filepath.Base(file)
+		if name == regularFontFile || name == boldFontFile {
+			continue
+		}
+		fallback, err := LoadFont(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		regular, bold = append(regular, fallback), append(bold, fallback)
+	}
+	return regular, bold, nil
+}
+
+func LoadFont(path string) (*Font, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	font, err := ParseFont(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return font, nil
+}
+
+// ParseFont reads the tables of a TrueType font needed to embed it
+func ParseFont(data []byte) (*Font, error) {
+	if len(data) < 12 {
+		return nil, fmt.Errorf("not a TrueType font")
+	}
+	if version := binary.BigEndian.Uint32(data); version != 0x00010000 && version != 0x74727565 {
+		return nil, fmt.Errorf("only TrueType outlines are supported")
+	}
+
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	if len(data) < 12+numTables*16 {
+		return nil, fmt.Errorf("truncated table directory")
+	}
+	tables := make(map[string][]byte, numTables)
+	for i := 0; i < numTables; i++ {
+		record := data[12+i*16:]
+		offset, length := binary.BigEndian.Uint32(record[8:]), binary.BigEndian.Uint32(record[12:])
+		if uint64(offset)+uint64(length) > uint64(len(data)) {
+			return nil, fmt.Errorf("table %s is out of bounds", record[:4])
+		}
+		tables[string(record[:4])] = data[offset : offset+length]
+	}
+
+	minSizes := map[string]int{"head": 54, "hhea": 36, "maxp": 6, "hmtx": 0, "loca": 0, "glyf": 0, "cmap": 4}
+	for tag, size := range minSizes {
+		if table, ok := tables[tag]; !ok || len(table) < size {
+			return nil, fmt.Errorf("missing or short %s table", tag)
+		}
+	}
+
+	head, hhea := tables["head"], tables["hhea"]
+	font := &Font{
+		Name:       fontName(tables["name"]),
+		tables:     tables,
+		unitsPerEm: float64(binary.BigEndian.Uint16(head[18:])),
+		numGlyphs:  int(binary.BigEndian.Uint16(tables["maxp"][4:])),
+		longLoca:   binary.BigEndian.Uint16(head[50:]) == 1,
+	}
+	if font.unitsPerEm == 0 {
+		return nil, fmt.Errorf("units per em is 0")
+	}
+
+	locaSize := 2
+	if font.longLoca {
+		locaSize = 4
+	}
+	if len(tables["loca"]) < (font.numGlyphs+1)*locaSize {
+		return nil, fmt.Errorf("short loca table")
+	}
+
+	// Glyphs past the last metric share its advance
+	metrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	if metrics == 0 || metrics > font.numGlyphs || len(tables["hmtx"]) < metrics*4 {
+		return nil, fmt.Errorf("bad horizontal metrics")
+	}
+	font.advances = make([]uint16, font.numGlyphs)
+	for gid := range font.advances {
+		font.advances[gid] = binary.BigEndian.Uint16(tables["hmtx"][min(gid, metrics-1)*4:])
+	}
+
+	glyphs, err := parseCmap(tables["cmap"])
+	if err != nil {
+		return nil, err
+	}
+	font.glyphs = glyphs
+	return font, nil
+}
+
+// fontName is the PostScript name from the name table, without characters a PDF name cannot hold
+func fontName(table []byte) string {
+	name := "Font"
+	if len(table) < 6 {
+		return name
+	}
+	count, storage := int(binary.BigEndian.Uint16(table[2:])), int(binary.BigEndian.Uint16(table[4:]))
+	for i := 0; i < count && 6+i*12+12 <= len(table); i++ {
+		record := table[6+i*12:]
+		platform, nameId := binary.BigEndian.Uint16(record), binary.BigEndian.Uint16(record[6:])
+		length, offset := int(binary.BigEndian.Uint16(record[8:])), int(binary.BigEndian.Uint16(record[10:]))
+		if nameId != 6 || storage+offset+length > len(table) {
+			continue
+		}
+		value := table[storage+offset : storage+offset+length]
+		if platform == 0 || platform == 3 {
+			units := make([]uint16, len(value)/2)
+			for j := range units {
+				units[j] = binary.BigEndian.Uint16(value[j*2:])
+			}
+			name = string(utf16.Decode(units))
+		} else {
+			name = string(value)
+		}
+		break
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= 32 || r >= 127 || strings.ContainsRune("()<>[]{}/%#", r) {
+			return -1
+		}
+		return r
+	}, name)
+}
+
+// parseCmap maps characters to glyphs with the full Unicode subtable, or the BMP one
+func parseCmap(table []byte) (map[rune]uint16, error) {
+	var bmp, full []byte
+	count := int(binary.BigEndian.Uint16(table[2:]))
+	for i := 0; i < count && 4+i*8+8 <= len(table); i++ {
+		record := table[4+i*8:]
+		platform, encoding := binary.BigEndian.Uint16(record), binary.BigEndian.Uint16(record[2:])
+		offset := int(binary.BigEndian.Uint32(record[4:]))
+		if offset+4 > len(table) {
+			continue
+		}
+		subtable := table[offset:]
+		switch format := binary.BigEndian.Uint16(subtable); {
+		case format == 12 && (platform == 3 && encoding == 10 || platform == 0):
+			full = subtable
+		case format == 4 && (platform == 3 && encoding == 1 || platform == 0):
+			bmp = subtable
+		}
+	}
+
+	glyphs := make(map[rune]uint16)
+	switch {
+	case full != nil:
+		if len(full) < 16 {
+			return nil, fmt.Errorf("short cmap subtable")
+		}
+		groups := int(binary.BigEndian.Uint32(full[12:]))
+		if len(full) < 16+groups*12 {
+			return nil, fmt.Errorf("short cmap subtable")
+		}
+		for i := 0; i < groups; i++ {
+			group := full[16+i*12:]
+			start, end, gid := binary.BigEndian.Uint32(group), binary.BigEndian.Uint32(group[4:]), binary.BigEndian.Uint32(group[8:])
+			for c := start; c <= end && c <= 0x10FFFF; c++ {
+				glyphs[rune(c)] = uint16(gid + c - start)
+			}
+		}
+	case bmp != nil:
+		if len(bmp) < 14 {
+			return nil, fmt.Errorf("short cmap subtable")
+		}
+		segments := int(binary.BigEndian.Uint16(bmp[6:])) / 2
+		ends, starts, deltas, ranges := 14, 16+segments*2, 16+segments*4, 16+segments*6
+		if len(bmp) < ranges+segments*2 {
+			return nil, fmt.Errorf("short cmap subtable")
+		}
+		for i := 0; i < segments; i++ {
+			start, end := int(binary.BigEndian.Uint16(bmp[starts+i*2:])), int(binary.BigEndian.Uint16(bmp[ends+i*2:]))
+			delta, rangeOffset := binary.BigEndian.Uint16(bmp[deltas+i*2:]), int(binary.BigEndian.Uint16(bmp[ranges+i*2:]))
+			for c := start; c <= end && c != 0xFFFF; c++ {
+				gid := uint16(c) + delta
+				if rangeOffset != 0 {
+					at := ranges + i*2 + rangeOffset + (c-start)*2
+					if at+2 > len(bmp) {
+						continue
+					}
+					gid = binary.BigEndian.Uint16(bmp[at:])
+					if gid != 0 {
+						gid += delta
+					}
+				}
+				if gid != 0 {
+					glyphs[rune(c)] = gid
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("no Unicode cmap subtable")
+	}
+	return glyphs, nil
+}
+
+// glyph returns the glyph drawing r, 0 when the font has none
+func (f *Font) glyph(r rune) uint16 {
+	gid := f.glyphs[r]
+	if int(gid) >= f.numGlyphs {
+		return 0
+	}
+	return gid
+}
+
+// width is the advance of the glyph in thousandths of the font size
+func (f *Font) width(gid uint16) float64 {
+	return float64(f.advances[gid]) * 1000 / f.unitsPerEm
+}
+
+// scaled converts a value in font units to thousandths of the font size
+func (f *Font) scaled(value int16) int {
+	return int(float64(value) * 1000 / f.unitsPerEm)
+}
+
+func (f *Font) glyphData(gid uint16) []byte {
+	loca, glyf := f.tables["loca"], f.tables["glyf"]
+	var start, end int
+	if f.longLoca {
+		start, end = int(binary.BigEndian.Uint32(loca[int(gid)*4:])), int(binary.BigEndian.Uint32(loca[int(gid)*4+4:]))
+	} else {
+		start, end = int(binary.BigEndian.Uint16(loca[int(gid)*2:]))*2, int(binary.BigEndian.Uint16(loca[int(gid)*2+2:]))*2
+	}
+	if start >= end || end > len(glyf) {
+		return nil
+	}
+	return glyf[start:end]
+}
+
+// components lists the glyphs a composite glyph is built from
+func components(data []byte) []uint16 {
+	if len(data) < 10 || int16(binary.BigEndian.Uint16(data)) >= 0 {
+		return nil
+	}
+
+	const (
+		argsAreWords = 0x0001
+		hasScale     = 0x0008
+		moreFollow   = 0x0020
+		hasXYScale   = 0x0040
+		hasTwoByTwo  = 0x0080
+	)
+	var gids []uint16
+	for at := 10; at+4 <= len(data); {
+		flags := binary.BigEndian.Uint16(data[at:])
+		gids = append(gids, binary.BigEndian.Uint16(data[at+2:]))
+
+		// Flags and glyph id, then the offsets as bytes or words and the optional transform
+		at += 4
+		if flags&argsAreWords != 0 {
+			at += 4
+		} else {
+			at += 2
+		}
+		switch {
+		case flags&hasScale != 0:
+			at += 2
+		case flags&hasXYScale != 0:
+			at += 4
+		case flags&hasTwoByTwo != 0:
+			at += 8
+		}
+		if flags&moreFollow == 0 {
+			break
+		}
+	}
+	return gids
+}
+
+// subset builds a font file with the outlines of the used glyphs only, glyph ids are kept
+// so the document can map character ids to glyphs one to one
+func (f *Font) subset(used map[uint16]rune) []byte {
+	keep := make(map[uint16]bool)
+	pending := []uint16{0} // .notdef is always kept
+	for gid := range used {
+		pending = append(pending, gid)
+	}
+	for len(pending) > 0 {
+		gid := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if keep[gid] {
+			continue
+		}
+		keep[gid] = true
+		for _, component := range components(f.glyphData(gid)) {
+			if int(component) < f.numGlyphs && !keep[component] {
+				pending = append(pending, component)
+			}
+		}
+	}
+
+	var glyf bytes.Buffer
+	loca := make([]byte, (f.numGlyphs+1)*4)
+	for gid := 0; gid < f.numGlyphs; gid++ {
+		binary.BigEndian.PutUint32(loca[gid*4:], uint32(glyf.Len()))
+		if keep[uint16(gid)] {
+			glyf.Write(f.glyphData(uint16(gid)))
+			for glyf.Len()%4 != 0 {
+				glyf.WriteByte(0)
+			}
+		}
+	}
+	binary.BigEndian.PutUint32(loca[f.numGlyphs*4:], uint32(glyf.Len()))
+
+	head := append([]byte(nil), f.tables["head"]...)
+	binary.BigEndian.PutUint32(head[8:], 0)
+	binary.BigEndian.PutUint16(head[50:], 1)
+
+	tables := map[string][]byte{
+		"head": head,
+		"hhea": f.tables["hhea"],
+		"maxp": f.tables["maxp"],
+		"hmtx": f.tables["hmtx"],
+		"loca": loca,
+		"glyf": glyf.Bytes(),
+	}
+	// Hinting programs are kept, glyphs refer to them
+	for _, tag := range []string{"cvt ", "fpgm", "prep"} {
+		if table, ok := f.tables[tag]; ok {
+			tables[tag] = table
+		}
+	}
+
+	out := writeFont(tables)
+	binary.BigEndian.PutUint32(out[headOffset(out)+8:], 0xB1B0AFBA-checksum(out))
+	return out
+}
+
+func writeFont(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	searchRange, entrySelector := 1, 0
+	for searchRange*2 <= len(tags) {
+		searchRange *= 2
+		entrySelector++
+	}
+
+	var out bytes.Buffer
+	binary.Write(&out, binary.BigEndian, []uint32{0x00010000})
+	binary.Write(&out, binary.BigEndian, []uint16{uint16(len(tags)), uint16(searchRange * 16), uint16(entrySelector), uint16(len(tags)*16 - searchRange*16)})
+
+	offset := 12 + len(tags)*16
+	for _, tag := range tags {
+		table := tables[tag]
+		out.WriteString(tag)
+		binary.Write(&out, binary.BigEndian, []uint32{checksum(table), uint32(offset), uint32(len(table))})
+		offset += (len(table) + 3) &^ 3
+	}
+	for _, tag := range tags {
+		out.Write(tables[tag])
+		for out.Len()%4 != 0 {
+			out.WriteByte(0)
+		}
+	}
+	return out.Bytes()
+}
+
+func headOffset(font []byte) int {
+	numTables := int(binary.BigEndian.Uint16(font[4:]))
+	for i := 0; i < numTables; i++ {
+		if string(font[12+i*16:16+i*16]) == "head" {
+			return int(binary.BigEndian.Uint32(font[12+i*16+8:]))
+		}
+	}
+	return 0
+}
+
+func checksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
+
+// visualOrder puts characters in the order their glyphs are drawn. Tamil vowel signs written
+// before the consonant are moved in front of it and the two part signs are split around it,
+// fonts without layout support draw them in place otherwise
+func visualOrder(text []rune) []rune {
+	ordered := make([]rune, 0, len(text)+1)
+	for i, r := range text {
+		var before, after rune
+		switch r {
+		case 0x0BC6, 0x0BC7, 0x0BC8:
+			before = r
+		case 0x0BCA:
+			before, after = 0x0BC6, 0x0BBE
+		case 0x0BCB:
+			before, after = 0x0BC7, 0x0BBE
+		case 0x0BCC:
+			before, after = 0x0BC6, 0x0BD7
+		}
+
+		last := len(ordered) - 1
+		if before == 0 || i == 0 || last < 0 || !isTamilConsonant(text[i-1]) {
+			ordered = append(ordered, r)
+			continue
+		}
+		ordered = append(ordered[:last], before, ordered[last])
+		if after != 0 {
+			ordered = append(ordered, after)
+		}
+	}
+	return ordered
+}
+
+func isTamilConsonant(r rune) bool {
+	return r >= 0x0B95 && r <= 0x0BB9
+}
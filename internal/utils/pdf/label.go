@@ -0,0 +1,174 @@
+package pdf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// mm converts millimetres to points
+func mm(v float64) float64 {
+	return v * 72 / 25.4
+}
+
+// LabelLayout is a sheet of equally sized labels on an A4 page, sizes are in points
+type LabelLayout struct {
+	Columns     int
+	Rows        int
+	LabelWidth  float64
+	LabelHeight float64
+	MarginLeft  float64
+	MarginTop   float64
+	GapX        float64
+	GapY        float64
+}
+
+// LabelLayouts are the common A4 label sheets, named after the labels per sheet
+var LabelLayouts = map[string]LabelLayout{
+	"A4-24": {Columns: 3, Rows: 8, LabelWidth: mm(70), LabelHeight: mm(37), MarginLeft: 0, MarginTop: mm(0.5)},
+	"A4-40": {Columns: 4, Rows: 10, LabelWidth: mm(48.5), LabelHeight: mm(25.4), MarginLeft: mm(8), MarginTop: mm(21.5)},
+	"A4-65": {Columns: 5, Rows: 13, LabelWidth: mm(38.1), LabelHeight: mm(21.2), MarginLeft: mm(4.7), MarginTop: mm(10.7), GapX: mm(2.5)},
+}
+
+const DefaultLabelLayout = "A4-24"
+
+// NewLabelLayout builds a layout from sizes given in millimetres
+func NewLabelLayout(columns, rows int, labelWidth, labelHeight, marginLeft, marginTop, gapX, gapY float64) LabelLayout {
+	return LabelLayout{
+		Columns:     columns,
+		Rows:        rows,
+		LabelWidth:  mm(labelWidth),
+		LabelHeight: mm(labelHeight),
+		MarginLeft:  mm(marginLeft),
+		MarginTop:   mm(marginTop),
+		GapX:        mm(gapX),
+		GapY:        mm(gapY),
+	}
+}
+
+// Validate checks the labels are sized and fit on the page
+func (l LabelLayout) Validate() error {
+	if l.Columns <= 0 || l.Rows <= 0 || l.LabelWidth <= 0 || l.LabelHeight <= 0 {
+		return fmt.Errorf("label layout needs columns, rows and a label size")
+	}
+	if l.MarginLeft < 0 || l.MarginTop < 0 || l.GapX < 0 || l.GapY < 0 {
+		return fmt.Errorf("label layout margins and gaps cannot be negative")
+	}
+	width := l.MarginLeft + float64(l.Columns)*l.LabelWidth + float64(l.Columns-1)*l.GapX
+	height := l.MarginTop + float64(l.Rows)*l.LabelHeight + float64(l.Rows-1)*l.GapY
+	// Allow for rounding in the sizes of the published sheets
+	if width > PageWidth+1 || height > PageHeight+1 {
+		return fmt.Errorf("labels do not fit on an A4 page")
+	}
+	return nil
+}
+
+// LabelLayoutNames lists the names of the known layouts
+func LabelLayoutNames() []string {
+	names := make([]string, 0, len(LabelLayouts))
+	for name := range LabelLayouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type LabelSymbology string
+
+const (
+	LabelCode128 LabelSymbology = "CODE128"
+	LabelQR      LabelSymbology = "QR"
+)
+
+// Label is the content printed on one label, Code is what the symbol encodes
+type Label struct {
+	Code    string
+	Title   string
+	Caption string
+}
+
+// LabelSheet renders the labels onto as many sheets of the layout as needed
+func LabelSheet(layout LabelLayout, symbology LabelSymbology, labels []Label) ([]byte, error) {
+	err := layout.Validate()
+	if err != nil {
+		return nil, err
+	}
+	if symbology != LabelCode128 && symbology != LabelQR {
+		return nil, fmt.Errorf("unknown label symbology %s", symbology)
+	}
+
+	doc := NewDocument()
+	perPage := layout.Columns * layout.Rows
+	for i, label := range labels {
+		if i > 0 && i%perPage == 0 {
+			doc.AddPage()
+		}
+		slot := i % perPage
+		x := layout.MarginLeft + float64(slot%layout.Columns)*(layout.LabelWidth+layout.GapX)
+		y := layout.MarginTop + float64(slot/layout.Columns)*(layout.LabelHeight+layout.GapY)
+
+		err := drawLabel(doc, x, y, layout.LabelWidth, layout.LabelHeight, symbology, label)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return doc.Bytes(), nil
+}
+
+func drawLabel(doc *Document, x, y, w, h float64, symbology LabelSymbology, label Label) error {
+	padding := h * 0.08
+	textSize := h * 0.11
+	if textSize > 9 {
+		textSize = 9
+	}
+
+	if symbology == LabelQR {
+		// Symbol on the left, text beside it
+		size := h - 2*padding
+		err := doc.QRCode(x+padding, y+padding, size, label.Code)
+		if err != nil {
+			return err
+		}
+		textX := x + 2*padding + size
+		lines := []string{label.Title, label.Code, label.Caption}
+		lineY := y + padding + textSize
+		for i, line := range lines {
+			if line == "" {
+				continue
+			}
+			doc.Text(textX, lineY, textSize, i == 0, doc.fitText(line, x+w-padding-textX, textSize, i == 0))
+			lineY += textSize * 1.3
+		}
+		return nil
+	}
+
+	// Title above the bars, the code in plain text below them
+	titleY := y + padding + textSize
+	doc.Text(x+padding, titleY, textSize, true, doc.fitText(label.Title, w-2*padding, textSize, true))
+	barTop := titleY + padding/2
+	barHeight := h - (barTop - y) - 2*padding - textSize
+	err := doc.Barcode128(x+padding, barTop, w-2*padding, barHeight, label.Code)
+	if err != nil {
+		return err
+	}
+
+	caption := label.Code
+	if label.Caption != "" {
+		caption = label.Code + "  " + label.Caption
+	}
+	doc.Text(x+padding, barTop+barHeight+textSize+padding/2, textSize, false, doc.fitText(caption, w-2*padding, textSize, false))
+	return nil
+}
+
+// fitText shortens text until it fits in the width
+func (d *Document) fitText(text string, width, size float64, bold bool) string {
+	if d.TextWidth(text, size, bold) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && d.TextWidth(string(runes)+"...", size, bold) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimSpace(string(runes)) + "..."
+}
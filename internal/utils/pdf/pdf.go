@@ -0,0 +1,323 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// A4 page size in points
+const (
+	PageWidth  = 595.0
+	PageHeight = 842.0
+)
+
+// Document is a minimal PDF writer, text is drawn with the embedded fonts or the standard Helvetica
+// fonts when there are none
+type Document struct {
+	pages []*bytes.Buffer
+
+	// The first font of the list having a glyph for a character draws it
+	regular, bold []*Font
+
+	embedded []*Font                   // Fonts drawn with, in the order of their resource names
+	used     map[*Font]map[uint16]rune // Glyphs drawn per font and the character each stands for
+}
+
+// NewDocument starts a document with the fonts in FontPath
+func NewDocument() *Document {
+	doc := &Document{used: make(map[*Font]map[uint16]rune)}
+	doc.regular, doc.bold = loadDefaultFonts()
+	doc.AddPage()
+	return doc
+}
+
+// SetFonts replaces the fonts text is drawn with, nil falls back to Helvetica
+func (d *Document) SetFonts(regular, bold []*Font) {
+	d.regular, d.bold = regular, bold
+}
+
+// AddPage starts a new page; subsequent drawing goes to it
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+func (d *Document) current() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// Text writes a single line of text with its baseline at (x, y)
+func (d *Document) Text(x, y, size float64, bold bool, text string) {
+	fonts := d.fonts(bold)
+	if len(fonts) == 0 {
+		font := "F1"
+		if bold {
+			font = "F2"
+		}
+		fmt.Fprintf(d.current(), "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, PageHeight-y, escape(text))
+		return
+	}
+
+	// Each run is shown in its font, the text position moves on by the width of the run
+	fmt.Fprintf(d.current(), "BT %.2f %.2f Td", x, PageHeight-y)
+	for _, run := range textRuns(fonts, text) {
+		fmt.Fprintf(d.current(), " /%s %.2f Tf <", d.resourceName(run.font), size)
+		for i, gid := range run.glyphs {
+			d.used[run.font][gid] = run.chars[i]
+			fmt.Fprintf(d.current(), "%04X", gid)
+		}
+		d.current().WriteString("> Tj")
+	}
+	d.current().WriteString(" ET\n")
+}
+
+// TextRight writes text so that it ends at x
+func (d *Document) TextRight(x, y, size float64, bold bool, text string) {
+	d.Text(x-d.TextWidth(text, size, bold), y, size, bold, text)
+}
+
+// TextWidth is the rendered width of text
+func (d *Document) TextWidth(text string, size float64, bold bool) float64 {
+	fonts := d.fonts(bold)
+	if len(fonts) == 0 {
+		return TextWidth(text, size)
+	}
+
+	width := 0.0
+	for _, run := range textRuns(fonts, text) {
+		for _, gid := range run.glyphs {
+			width += run.font.width(gid)
+		}
+	}
+	return width * size / 1000
+}
+
+func (d *Document) fonts(bold bool) []*Font {
+	if bold {
+		return d.bold
+	}
+	return d.regular
+}
+
+// resourceName names the font on the pages, E1 for the first font drawn with and so on
+func (d *Document) resourceName(font *Font) string {
+	for i, embedded := range d.embedded {
+		if embedded == font {
+			return fmt.Sprintf("E%d", i+1)
+		}
+	}
+	d.embedded = append(d.embedded, font)
+	d.used[font] = make(map[uint16]rune)
+	return fmt.Sprintf("E%d", len(d.embedded))
+}
+
+// textRun is a part of a line drawn with one font
+type textRun struct {
+	font   *Font
+	glyphs []uint16
+	chars  []rune // Character each glyph stands for
+}
+
+// textRuns splits text into runs of the first font having each character, a character
+// no font has is drawn as the missing glyph box of the first font
+func textRuns(fonts []*Font, text string) []textRun {
+	var runs []textRun
+	for _, r := range visualOrder([]rune(text)) {
+		font, gid := fonts[0], fonts[0].glyph(r)
+		for _, fallback := range fonts[1:] {
+			if gid != 0 {
+				break
+			}
+			if fallbackGid := fallback.glyph(r); fallbackGid != 0 {
+				font, gid = fallback, fallbackGid
+			}
+		}
+
+		if len(runs) == 0 || runs[len(runs)-1].font != font {
+			runs = append(runs, textRun{font: font})
+		}
+		run := &runs[len(runs)-1]
+		run.glyphs = append(run.glyphs, gid)
+		run.chars = append(run.chars, r)
+	}
+	return runs
+}
+
+// Line draws a straight line between two points
+func (d *Document) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(d.current(), "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+// Rect draws a rectangle whose top-left corner is at (x, y)
+func (d *Document) Rect(x, y, w, h float64, fill bool) {
+	op := "S"
+	if fill {
+		op = "f"
+	}
+	fmt.Fprintf(d.current(), "%.2f %.2f %.2f %.2f re %s\n", x, PageHeight-y-h, w, h, op)
+}
+
+// Bytes serialises the document
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	writeObj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// 1: catalog, 2: pages, 3-4: Helvetica, then a page and content object per page,
+	// then five objects per embedded font
+	pageCount := len(d.pages)
+	kids := make([]string, 0, pageCount)
+	for i := 0; i < pageCount; i++ {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 5+i*2))
+	}
+
+	fontBase := 5 + pageCount*2
+	fonts := "/F1 3 0 R /F2 4 0 R"
+	for i := range d.embedded {
+		fonts += fmt.Sprintf(" /E%d %d 0 R", i+1, fontBase+i*5)
+	}
+
+	writeObj("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), pageCount))
+	writeObj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	writeObj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		writeObj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, fonts, 6+i*2))
+		writeObj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	for i, font := range d.embedded {
+		for _, body := range embedFont(font, d.used[font], fontBase+i*5) {
+			writeObj(body)
+		}
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// embedFont writes a Type0 font with Identity-H encoding, so text is shown as glyph ids, followed by
+// its CID font, descriptor, font file and the map back to Unicode used to copy and search the text
+func embedFont(font *Font, used map[uint16]rune, first int) []string {
+	gids := make([]int, 0, len(used))
+	for gid := range used {
+		gids = append(gids, int(gid))
+	}
+	sort.Ints(gids)
+
+	// Subset fonts are named with a tag of six capitals derived from the glyphs kept
+	hash := fnv.New32a()
+	for _, gid := range gids {
+		fmt.Fprintf(hash, "%d,", gid)
+	}
+	tag := make([]byte, 6)
+	for i, sum := 0, hash.Sum32(); i < len(tag); i, sum = i+1, sum/26 {
+		tag[i] = byte('A' + sum%26)
+	}
+	name := string(tag) + "+" + font.Name
+
+	var widths strings.Builder
+	for _, gid := range gids {
+		fmt.Fprintf(&widths, "%d [%.0f] ", gid, font.width(uint16(gid)))
+	}
+
+	head, hhea := font.tables["head"], font.tables["hhea"]
+	metric := func(table []byte, offset int) int {
+		return font.scaled(int16(uint16(table[offset])<<8 | uint16(table[offset+1])))
+	}
+	ascent, descent := metric(hhea, 4), metric(hhea, 6)
+
+	var file bytes.Buffer
+	raw := font.subset(used)
+	writer := zlib.NewWriter(&file)
+	writer.Write(raw)
+	writer.Close()
+
+	var toUnicode strings.Builder
+	toUnicode.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	// The missing glyph box stands for no character in particular
+	mapped := gids
+	if len(mapped) > 0 && mapped[0] == 0 {
+		mapped = mapped[1:]
+	}
+	for start := 0; start < len(mapped); start += 100 {
+		block := mapped[start:min(start+100, len(mapped))]
+		fmt.Fprintf(&toUnicode, "%d beginbfchar\n", len(block))
+		for _, gid := range block {
+			fmt.Fprintf(&toUnicode, "<%04X> <", gid)
+			for _, unit := range utf16.Encode([]rune{used[uint16(gid)]}) {
+				fmt.Fprintf(&toUnicode, "%04X", unit)
+			}
+			toUnicode.WriteString(">\n")
+		}
+		toUnicode.WriteString("endbfchar\n")
+	}
+	toUnicode.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+
+	return []string{
+		fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+			name, first+1, first+4),
+		fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /CIDToGIDMap /Identity /W [%s] >>",
+			name, first+2, strings.TrimSpace(widths.String())),
+		fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+			name, metric(head, 36), metric(head, 38), metric(head, 40), metric(head, 42), ascent, descent, ascent, first+3),
+		fmt.Sprintf("<< /Length %d /Length1 %d /Filter /FlateDecode >>\nstream\n%s\nendstream", file.Len(), len(raw), file.String()),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", toUnicode.Len(), toUnicode.String()),
+	}
+}
+
+// TextWidth approximates the rendered width of text in Helvetica
+func TextWidth(text string, size float64) float64 {
+	width := 0.0
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			width += 0.556
+		case r == ' ' || r == '.' || r == ',' || r == ':':
+			width += 0.278
+		case r >= 'A' && r <= 'Z':
+			width += 0.667
+		default:
+			width += 0.5
+		}
+	}
+	return width * size
+}
+
+// escape makes text safe for a PDF string literal
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 126:
+			b.WriteRune('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Document(t *testing.T) {
+
+	doc := NewDocument()
+	doc.Text(40, 60, 12, true, "Invoice (copy)")
+	doc.Line(40, 70, 555, 70, 1)
+	doc.AddPage()
+	doc.Rect(40, 40, 100, 20, true)
+
+	out := doc.Bytes()
+
+	require.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4")))
+	require.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	require.Contains(t, string(out), "/Count 2")
+	require.Contains(t, string(out), `(Invoice \(copy\)) Tj`)
+}
+
+func Test_Document_Unicode(t *testing.T) {
+
+	regular, bold, err := LoadFonts("../../../templates/fonts")
+	require.NoError(t, err)
+
+	doc := NewDocument()
+	doc.SetFonts(regular, bold)
+	doc.Text(40, 60, 12, false, "Total ₹1,250.00")
+	doc.TextRight(555, 80, 12, true, "Paid ₹500")
+
+	out := string(doc.Bytes())
+
+	require.Contains(t, out, "/Subtype /Type0")
+	require.Contains(t, out, "/Encoding /Identity-H")
+	require.Contains(t, out, "/FontFile2")
+	require.Contains(t, out, "/E1 12.00 Tf")
+	require.Contains(t, out, "/E2 12.00 Tf")
+	require.Contains(t, out, "<20B9>")
+	require.NotContains(t, out, "(Total")
+	require.Greater(t, doc.TextWidth("₹", 12, false), 0.0)
+
+	// Glyph ids are kept in the subset, so the drawn glyphs keep their outlines
+	font := regular[0]
+	gid := font.glyph('₹')
+	require.NotZero(t, gid)
+
+	out = string(font.subset(map[uint16]rune{gid: '₹'}))
+	tables := make(map[string][]byte)
+	for i := 0; i < int(binary.BigEndian.Uint16([]byte(out[4:]))); i++ {
+		record := []byte(out[12+i*16:])
+		offset, length := binary.BigEndian.Uint32(record[8:]), binary.BigEndian.Uint32(record[12:])
+		tables[string(record[:4])] = []byte(out[offset : offset+length])
+	}
+	subset := &Font{tables: tables, numGlyphs: font.numGlyphs, longLoca: true}
+	require.Equal(t, font.glyphData(gid), subset.glyphData(gid))
+	require.Empty(t, subset.glyphData(font.glyph('A')))
+	require.Less(t, len(out), len(font.tables["glyf"]))
+}
+
+func Test_VisualOrder(t *testing.T) {
+
+	// கெ is drawn with the vowel sign before the consonant, கொ around it
+	require.Equal(t, []rune{0x0BC6, 0x0B95}, visualOrder([]rune("கெ")))
+	require.Equal(t, []rune{0x0BC6, 0x0B95, 0x0BBE}, visualOrder([]rune("கொ")))
+	require.Equal(t, []rune("ரூபாய்"), visualOrder([]rune("ரூபாய்")))
+	require.Equal(t, []rune{0x0BC6}, visualOrder([]rune{0x0BC6}))
+}
@@ -0,0 +1,491 @@
+package pdf
+
+import "fmt"
+
+// qrVersion describes a QR code version at error correction level M
+type qrVersion struct {
+	ecPerBlock int
+	blocks     [][2]int // Groups of blocks as block count and data codewords per block
+	alignment  []int    // Centre coordinates of the alignment patterns
+}
+
+// qrVersions holds versions 1 to 10, enough for up to 213 bytes
+var qrVersions = []qrVersion{
+	{10, [][2]int{{1, 16}}, nil},
+	{16, [][2]int{{1, 28}}, []int{6, 18}},
+	{26, [][2]int{{1, 44}}, []int{6, 22}},
+	{18, [][2]int{{2, 32}}, []int{6, 26}},
+	{24, [][2]int{{2, 43}}, []int{6, 30}},
+	{16, [][2]int{{4, 27}}, []int{6, 34}},
+	{18, [][2]int{{4, 31}}, []int{6, 22, 38}},
+	{22, [][2]int{{2, 38}, {2, 39}}, []int{6, 24, 42}},
+	{22, [][2]int{{3, 36}, {2, 37}}, []int{6, 26, 46}},
+	{26, [][2]int{{4, 43}, {1, 44}}, []int{6, 28, 50}},
+}
+
+func (v qrVersion) dataCodewords() int {
+	total := 0
+	for _, group := range v.blocks {
+		total += group[0] * group[1]
+	}
+	return total
+}
+
+// QRCode encodes text in byte mode at error correction level M, true for a dark module
+func QRCode(text string) ([][]bool, error) {
+	data := []byte(text)
+
+	version := 0
+	for i, v := range qrVersions {
+		countBits := 8
+		if i+1 >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) <= 8*v.dataCodewords() {
+			version = i + 1
+			break
+		}
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("text of %d bytes is too long for a QR code label", len(data))
+	}
+
+	codewords := qrCodewords(data, version)
+	return qrMatrix(codewords, version), nil
+}
+
+// qrCodewords builds the data codewords of the version and interleaves them with their error correction
+func qrCodewords(data []byte, version int) []byte {
+	v := qrVersions[version-1]
+	capacity := v.dataCodewords()
+
+	var bits qrBits
+	bits.append(0b0100, 4) // Byte mode
+	if version >= 10 {
+		bits.append(len(data), 16)
+	} else {
+		bits.append(len(data), 8)
+	}
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	// Terminator, then pad to a whole codeword and fill the capacity with the pad bytes
+	terminator := 8*capacity - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	if len(bits)%8 != 0 {
+		bits.append(0, 8-len(bits)%8)
+	}
+	for pad := 0xEC; len(bits) < 8*capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	dataCodewords := bits.bytes()
+
+	var dataBlocks, ecBlocks [][]byte
+	offset := 0
+	for _, group := range v.blocks {
+		for n := 0; n < group[0]; n++ {
+			block := dataCodewords[offset : offset+group[1]]
+			offset += group[1]
+			dataBlocks = append(dataBlocks, block)
+			ecBlocks = append(ecBlocks, reedSolomon(block, v.ecPerBlock))
+		}
+	}
+
+	result := make([]byte, 0, capacity+len(ecBlocks)*v.ecPerBlock)
+	result = append(result, interleave(dataBlocks)...)
+	return append(result, interleave(ecBlocks)...)
+}
+
+func interleave(blocks [][]byte) []byte {
+	longest := 0
+	for _, block := range blocks {
+		if len(block) > longest {
+			longest = len(block)
+		}
+	}
+
+	var out []byte
+	for i := 0; i < longest; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				out = append(out, block[i])
+			}
+		}
+	}
+	return out
+}
+
+type qrBits []bool
+
+func (b *qrBits) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 == 1)
+	}
+}
+
+func (b qrBits) bytes() []byte {
+	out := make([]byte, len(b)/8)
+	for i, bit := range b {
+		if bit {
+			out[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	return out
+}
+
+// gfMultiply multiplies in GF(256) with the QR code polynomial x^8 + x^4 + x^3 + x^2 + 1
+func gfMultiply(x, y byte) byte {
+	var product byte
+	for i := 7; i >= 0; i-- {
+		carry := product&0x80 != 0
+		product <<= 1
+		if carry {
+			product ^= 0x1D
+		}
+		if (y>>i)&1 == 1 {
+			product ^= x
+		}
+	}
+	return product
+}
+
+// reedSolomon returns the error correction codewords of a block
+func reedSolomon(data []byte, degree int) []byte {
+	// Generator polynomial (x - 2^0)(x - 2^1)...(x - 2^(degree-1)), leading coefficient dropped
+	generator := make([]byte, degree)
+	generator[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := 0; j < degree; j++ {
+			generator[j] = gfMultiply(generator[j], root)
+			if j+1 < degree {
+				generator[j] ^= generator[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+
+	remainder := make([]byte, degree)
+	for _, b := range data {
+		factor := b ^ remainder[0]
+		copy(remainder, remainder[1:])
+		remainder[degree-1] = 0
+		for i := range remainder {
+			remainder[i] ^= gfMultiply(generator[i], factor)
+		}
+	}
+	return remainder
+}
+
+type qrGrid struct {
+	size      int
+	modules   [][]bool
+	functions [][]bool // Finder, timing, alignment, format and version modules that data skips
+}
+
+func newQRGrid(size int) *qrGrid {
+	g := &qrGrid{size: size, modules: make([][]bool, size), functions: make([][]bool, size)}
+	for i := range g.modules {
+		g.modules[i] = make([]bool, size)
+		g.functions[i] = make([]bool, size)
+	}
+	return g
+}
+
+func (g *qrGrid) setFunction(x, y int, dark bool) {
+	g.modules[y][x] = dark
+	g.functions[y][x] = true
+}
+
+func qrMatrix(codewords []byte, version int) [][]bool {
+	size := version*4 + 17
+	g := newQRGrid(size)
+
+	// Timing patterns, then finders with their separators over the corners
+	for i := 0; i < size; i++ {
+		g.setFunction(6, i, i%2 == 0)
+		g.setFunction(i, 6, i%2 == 0)
+	}
+	for _, centre := range [][2]int{{3, 3}, {size - 4, 3}, {3, size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := centre[0]+dx, centre[1]+dy
+				if x < 0 || x >= size || y < 0 || y >= size {
+					continue
+				}
+				dist := max(abs(dx), abs(dy))
+				g.setFunction(x, y, dist != 2 && dist != 4)
+			}
+		}
+	}
+
+	positions := qrVersions[version-1].alignment
+	last := len(positions) - 1
+	for i, cy := range positions {
+		for j, cx := range positions {
+			// The corners taken by the finder patterns have no alignment pattern
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					g.setFunction(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// Reserve the format areas, they are written once the mask is chosen
+	g.drawFormat(0)
+	if version >= 7 {
+		g.drawVersion(version)
+	}
+
+	// Data runs in two-module columns from the bottom right, alternating up and down
+	bit := 0
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < size; vert++ {
+			y := vert
+			if upward {
+				y = size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if g.functions[y][x] {
+					continue
+				}
+				if bit < len(codewords)*8 {
+					g.modules[y][x] = (codewords[bit/8]>>(7-bit%8))&1 == 1
+					bit++
+				}
+			}
+		}
+	}
+
+	// Keep the mask with the lowest penalty
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		g.applyMask(mask)
+		g.drawFormat(mask)
+		penalty := g.penalty()
+		if bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		g.applyMask(mask) // Masking twice undoes it
+	}
+	g.applyMask(best)
+	g.drawFormat(best)
+
+	return g.modules
+}
+
+func (g *qrGrid) applyMask(mask int) {
+	for y := 0; y < g.size; y++ {
+		for x := 0; x < g.size; x++ {
+			if g.functions[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				g.modules[y][x] = !g.modules[y][x]
+			}
+		}
+	}
+}
+
+// qrFormatBits returns the 15 format bits for level M and the mask, with their BCH code
+func qrFormatBits(mask int) int {
+	data := mask // Level M is 00
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+func (g *qrGrid) drawFormat(mask int) {
+	bits := qrFormatBits(mask)
+	bitAt := func(i int) bool { return (bits>>i)&1 == 1 }
+
+	// Around the top-left finder
+	for i := 0; i <= 5; i++ {
+		g.setFunction(8, i, bitAt(i))
+	}
+	g.setFunction(8, 7, bitAt(6))
+	g.setFunction(8, 8, bitAt(7))
+	g.setFunction(7, 8, bitAt(8))
+	for i := 9; i < 15; i++ {
+		g.setFunction(14-i, 8, bitAt(i))
+	}
+
+	// Split between the other two finders
+	for i := 0; i < 8; i++ {
+		g.setFunction(g.size-1-i, 8, bitAt(i))
+	}
+	for i := 8; i < 15; i++ {
+		g.setFunction(8, g.size-15+i, bitAt(i))
+	}
+	g.setFunction(8, g.size-8, true) // Always dark
+}
+
+func (g *qrGrid) drawVersion(version int) {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 == 1
+		a, b := g.size-11+i%3, i/3
+		g.setFunction(a, b, dark)
+		g.setFunction(b, a, dark)
+	}
+}
+
+// penalty scores the symbol by the rules used to pick the mask, lower reads more reliably
+func (g *qrGrid) penalty() int {
+	penalty := 0
+	at := func(x, y int, vertical bool) bool {
+		if vertical {
+			return g.modules[x][y]
+		}
+		return g.modules[y][x]
+	}
+
+	finderLike := []bool{true, false, true, true, true, false, true}
+	for _, vertical := range []bool{false, true} {
+		for y := 0; y < g.size; y++ {
+			// Runs of five or more modules of one colour
+			run := 1
+			for x := 1; x < g.size; x++ {
+				if at(x, y, vertical) == at(x-1, y, vertical) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					penalty += run - 2
+				}
+				run = 1
+			}
+			if run >= 5 {
+				penalty += run - 2
+			}
+
+			// Patterns that look like a finder with four light modules on one side
+			for x := 0; x+7 <= g.size; x++ {
+				matches := true
+				for k, dark := range finderLike {
+					if at(x+k, y, vertical) != dark {
+						matches = false
+						break
+					}
+				}
+				if !matches {
+					continue
+				}
+				if g.lightRun(x-4, x, y, vertical) || g.lightRun(x+7, x+11, y, vertical) {
+					penalty += 40
+				}
+			}
+		}
+	}
+
+	// 2x2 blocks of one colour
+	dark := 0
+	for y := 0; y < g.size; y++ {
+		for x := 0; x < g.size; x++ {
+			if g.modules[y][x] {
+				dark++
+			}
+			if x+1 < g.size && y+1 < g.size {
+				c := g.modules[y][x]
+				if g.modules[y][x+1] == c && g.modules[y+1][x] == c && g.modules[y+1][x+1] == c {
+					penalty += 3
+				}
+			}
+		}
+	}
+
+	// Balance of dark and light modules
+	total := g.size * g.size
+	deviation := abs(dark*20-total*10) / total
+	penalty += deviation * 10
+
+	return penalty
+}
+
+// lightRun reports whether the modules from start up to end are light, positions outside the symbol count as light
+func (g *qrGrid) lightRun(start, end, line int, vertical bool) bool {
+	for i := start; i < end; i++ {
+		if i < 0 || i >= g.size {
+			continue
+		}
+		module := g.modules[line][i]
+		if vertical {
+			module = g.modules[i][line]
+		}
+		if module {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// QRCode draws the QR code of text as a size by size square whose top-left corner is at (x, y)
+func (d *Document) QRCode(x, y, size float64, text string) error {
+	modules, err := QRCode(text)
+	if err != nil {
+		return err
+	}
+
+	moduleSize := size / float64(len(modules))
+	for row := range modules {
+		for col := 0; col < len(modules); {
+			if !modules[row][col] {
+				col++
+				continue
+			}
+			start := col
+			for col < len(modules) && modules[row][col] {
+				col++
+			}
+			d.Rect(x+float64(start)*moduleSize, y+float64(row)*moduleSize, float64(col-start)*moduleSize, moduleSize, true)
+		}
+	}
+	return nil
+}
@@ -68,3 +68,17 @@ func GetSession(ctx *context.Context) *models.Session {
 	return session
 
 }
+
+// NewChannelSession returns a context with a system session in the channel, for background jobs
+func NewChannelSession(ctx *context.Context, channel entities.Channel) context.Context {
+	userId := channel.OwnerUserID
+	session := &models.Session{
+		Role:                  entities.SUPERADMIN,
+		UserId:                &userId,
+		ChannelId:             channel.ID,
+		ChannelName:           channel.Name,
+		AccessibleLocationIds: []uint{channel.ID},
+		IsSystemSession:       true,
+	}
+	return context.WithValue(*ctx, pkgConst.SESSION, session)
+}
@@ -0,0 +1,15 @@
+-- Migration: 009_add_order_history_reason
+-- Generated: 2026-10-16T10:12:41+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add column to stich.OrderHistories
+ALTER TABLE stich."OrderHistories" ADD COLUMN reason TEXT;
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
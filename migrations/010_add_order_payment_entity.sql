@@ -0,0 +1,34 @@
+-- Migration: 010_add_order_payment_entity
+-- Generated: 2026-10-16T11:05:18+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.OrderPayments
+CREATE TABLE IF NOT EXISTS stich."OrderPayments" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  amount DOUBLE PRECISION NOT NULL,
+  mode VARCHAR(20) NOT NULL,
+  reference TEXT,
+  paid_at TIMESTAMPTZ NOT NULL,
+  order_id BIGINT NOT NULL,
+  PRIMARY KEY (id)
+);
+
+-- Foreign key to Orders
+ALTER TABLE stich."OrderPayments" ADD CONSTRAINT fk_OrderPayment_order_id FOREIGN KEY (order_id) REFERENCES stich."Orders" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_order_payments_order_id ON stich."OrderPayments" (order_id);
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- DROP TABLE IF EXISTS stich."OrderPayments";
@@ -0,0 +1,47 @@
+-- Migration: 011_add_gst_fields
+-- Generated: 2026-10-16T12:20:47+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add columns to stich.Channels
+ALTER TABLE stich."Channels" ADD COLUMN gstin TEXT;
+ALTER TABLE stich."Channels" ADD COLUMN state TEXT;
+
+-- Add column to stich.Customers
+ALTER TABLE stich."Customers" ADD COLUMN state TEXT;
+
+-- Add columns to stich.DressTypes
+ALTER TABLE stich."DressTypes" ADD COLUMN hsn_code TEXT;
+ALTER TABLE stich."DressTypes" ADD COLUMN tax_rate DECIMAL(5,2) DEFAULT 0;
+
+-- Add columns to stich.Products
+ALTER TABLE stich."Products" ADD COLUMN hsn_code TEXT;
+ALTER TABLE stich."Products" ADD COLUMN tax_rate DECIMAL(5,2) DEFAULT 0;
+
+-- Add columns to stich.OrderItems
+ALTER TABLE stich."OrderItems" ADD COLUMN hsn_code TEXT;
+ALTER TABLE stich."OrderItems" ADD COLUMN tax_rate DECIMAL(5,2) DEFAULT 0;
+ALTER TABLE stich."OrderItems" ADD COLUMN taxable_value DOUBLE PRECISION DEFAULT 0;
+ALTER TABLE stich."OrderItems" ADD COLUMN cgst_amount DOUBLE PRECISION DEFAULT 0;
+ALTER TABLE stich."OrderItems" ADD COLUMN sgst_amount DOUBLE PRECISION DEFAULT 0;
+ALTER TABLE stich."OrderItems" ADD COLUMN igst_amount DOUBLE PRECISION DEFAULT 0;
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS igst_amount;
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS sgst_amount;
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS cgst_amount;
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS taxable_value;
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS tax_rate;
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS hsn_code;
+-- ALTER TABLE stich."Products" DROP COLUMN IF EXISTS tax_rate;
+-- ALTER TABLE stich."Products" DROP COLUMN IF EXISTS hsn_code;
+-- ALTER TABLE stich."DressTypes" DROP COLUMN IF EXISTS tax_rate;
+-- ALTER TABLE stich."DressTypes" DROP COLUMN IF EXISTS hsn_code;
+-- ALTER TABLE stich."Customers" DROP COLUMN IF EXISTS state;
+-- ALTER TABLE stich."Channels" DROP COLUMN IF EXISTS state;
+-- ALTER TABLE stich."Channels" DROP COLUMN IF EXISTS gstin;
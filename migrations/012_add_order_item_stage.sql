@@ -0,0 +1,33 @@
+-- Migration: 012_add_order_item_stage
+-- Generated: 2026-10-16T13:02:15+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add columns to stich.OrderItems
+ALTER TABLE stich."OrderItems" ADD COLUMN stage TEXT;
+ALTER TABLE stich."OrderItems" ADD COLUMN stage_updated_at TIMESTAMPTZ;
+ALTER TABLE stich."OrderItems" ADD COLUMN cutting_at TIMESTAMPTZ;
+ALTER TABLE stich."OrderItems" ADD COLUMN stitching_at TIMESTAMPTZ;
+ALTER TABLE stich."OrderItems" ADD COLUMN finishing_at TIMESTAMPTZ;
+ALTER TABLE stich."OrderItems" ADD COLUMN qc_at TIMESTAMPTZ;
+ALTER TABLE stich."OrderItems" ADD COLUMN ready_at TIMESTAMPTZ;
+ALTER TABLE stich."OrderItems" ADD COLUMN assigned_tailor_id BIGINT;
+
+-- Add foreign key constraint
+ALTER TABLE stich."OrderItems" ADD CONSTRAINT fk_OrderItem_assigned_tailor_id FOREIGN KEY (assigned_tailor_id) REFERENCES stich."Users" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- ALTER TABLE stich."OrderItems" DROP CONSTRAINT IF EXISTS fk_OrderItem_assigned_tailor_id;
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS assigned_tailor_id;
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS ready_at;
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS qc_at;
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS finishing_at;
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS stitching_at;
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS cutting_at;
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS stage_updated_at;
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS stage;
@@ -0,0 +1,39 @@
+-- Migration: 013_add_dress_type_bill_of_materials
+-- Generated: 2026-10-16T13:48:02+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.DressTypeComponents
+CREATE TABLE IF NOT EXISTS stich."DressTypeComponents" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  dress_type_id BIGINT NOT NULL,
+  product_id BIGINT NOT NULL,
+  quantity BIGINT NOT NULL,
+  notes TEXT,
+  PRIMARY KEY (id)
+);
+
+-- Foreign keys to DressTypes and Products
+ALTER TABLE stich."DressTypeComponents" ADD CONSTRAINT fk_DressTypeComponent_dress_type_id FOREIGN KEY (dress_type_id) REFERENCES stich."DressTypes" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."DressTypeComponents" ADD CONSTRAINT fk_DressTypeComponent_product_id FOREIGN KEY (product_id) REFERENCES stich."Products" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_dress_type_components_dress_type_id ON stich."DressTypeComponents" (dress_type_id);
+
+-- Add column to stich.InventoryLogs
+ALTER TABLE stich."InventoryLogs" ADD COLUMN order_item_id BIGINT;
+ALTER TABLE stich."InventoryLogs" ADD CONSTRAINT fk_InventoryLog_order_item_id FOREIGN KEY (order_item_id) REFERENCES stich."OrderItems" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- ALTER TABLE stich."InventoryLogs" DROP COLUMN IF EXISTS order_item_id;
+-- DROP TABLE IF EXISTS stich."DressTypeComponents";
@@ -0,0 +1,18 @@
+-- Migration: 014_add_inventory_log_idempotency_key
+-- Generated: 2026-10-16T14:21:36+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add column to stich.InventoryLogs
+ALTER TABLE stich."InventoryLogs" ADD COLUMN idempotency_key TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stich_InventoryLogs_idempotency_key ON stich."InventoryLogs" (idempotency_key);
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- DROP INDEX IF EXISTS stich.idx_stich_InventoryLogs_idempotency_key;
+-- ALTER TABLE stich."InventoryLogs" DROP COLUMN IF EXISTS idempotency_key;
@@ -0,0 +1,55 @@
+-- Migration: 015_add_stock_take_entities
+-- Generated: 2026-10-16T15:12:40+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.StockTakes
+CREATE TABLE IF NOT EXISTS stich."StockTakes" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  status VARCHAR(20) NOT NULL,
+  category_id BIGINT,
+  notes TEXT,
+  reason TEXT,
+  opened_at TIMESTAMPTZ NOT NULL,
+  posted_at TIMESTAMPTZ,
+  PRIMARY KEY (id)
+);
+
+-- Create table: stich.StockTakeLines
+CREATE TABLE IF NOT EXISTS stich."StockTakeLines" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  stock_take_id BIGINT NOT NULL,
+  product_id BIGINT NOT NULL,
+  expected_quantity BIGINT,
+  counted_quantity BIGINT,
+  posted_variance BIGINT,
+  PRIMARY KEY (id)
+);
+
+-- Foreign keys
+ALTER TABLE stich."StockTakes" ADD CONSTRAINT fk_StockTake_category_id FOREIGN KEY (category_id) REFERENCES stich."Categories" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."StockTakeLines" ADD CONSTRAINT fk_StockTakeLine_stock_take_id FOREIGN KEY (stock_take_id) REFERENCES stich."StockTakes" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."StockTakeLines" ADD CONSTRAINT fk_StockTakeLine_product_id FOREIGN KEY (product_id) REFERENCES stich."Products" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_stock_take_lines_stock_take_id ON stich."StockTakeLines" (stock_take_id);
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- DROP TABLE IF EXISTS stich."StockTakeLines";
+-- DROP TABLE IF EXISTS stich."StockTakes";
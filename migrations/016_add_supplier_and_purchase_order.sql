@@ -0,0 +1,86 @@
+-- Migration: 016_add_supplier_and_purchase_order
+-- Generated: 2026-10-16T16:05:21+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.Suppliers
+CREATE TABLE IF NOT EXISTS stich."Suppliers" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  name TEXT NOT NULL,
+  contact_person TEXT,
+  phone_number TEXT,
+  email TEXT,
+  address TEXT,
+  gstin TEXT,
+  notes TEXT,
+  PRIMARY KEY (id)
+);
+
+-- Create table: stich.PurchaseOrders
+CREATE TABLE IF NOT EXISTS stich."PurchaseOrders" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  supplier_id BIGINT NOT NULL,
+  status VARCHAR(30) NOT NULL,
+  order_date TIMESTAMPTZ,
+  expected_date TIMESTAMPTZ,
+  notes TEXT,
+  PRIMARY KEY (id)
+);
+
+-- Create table: stich.PurchaseOrderLines
+CREATE TABLE IF NOT EXISTS stich."PurchaseOrderLines" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  purchase_order_id BIGINT NOT NULL,
+  product_id BIGINT NOT NULL,
+  quantity BIGINT NOT NULL,
+  received_quantity BIGINT NOT NULL DEFAULT 0,
+  unit_cost DOUBLE PRECISION,
+  PRIMARY KEY (id)
+);
+
+ALTER TABLE stich."PurchaseOrders" ADD CONSTRAINT fk_PurchaseOrder_supplier_id FOREIGN KEY (supplier_id) REFERENCES stich."Suppliers" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."PurchaseOrderLines" ADD CONSTRAINT fk_PurchaseOrderLine_purchase_order_id FOREIGN KEY (purchase_order_id) REFERENCES stich."PurchaseOrders" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."PurchaseOrderLines" ADD CONSTRAINT fk_PurchaseOrderLine_product_id FOREIGN KEY (product_id) REFERENCES stich."Products" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_purchase_order_lines_purchase_order_id ON stich."PurchaseOrderLines" (purchase_order_id);
+
+-- Add columns to stich.Expenses
+ALTER TABLE stich."Expenses" ADD COLUMN supplier_id BIGINT;
+ALTER TABLE stich."Expenses" ADD COLUMN purchase_order_id BIGINT;
+ALTER TABLE stich."Expenses" ADD CONSTRAINT fk_Expense_supplier_id FOREIGN KEY (supplier_id) REFERENCES stich."Suppliers" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."Expenses" ADD CONSTRAINT fk_Expense_purchase_order_id FOREIGN KEY (purchase_order_id) REFERENCES stich."PurchaseOrders" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+-- Add column to stich.InventoryLogs
+ALTER TABLE stich."InventoryLogs" ADD COLUMN purchase_order_id BIGINT;
+ALTER TABLE stich."InventoryLogs" ADD CONSTRAINT fk_InventoryLog_purchase_order_id FOREIGN KEY (purchase_order_id) REFERENCES stich."PurchaseOrders" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- ALTER TABLE stich."InventoryLogs" DROP COLUMN IF EXISTS purchase_order_id;
+-- ALTER TABLE stich."Expenses" DROP COLUMN IF EXISTS purchase_order_id;
+-- ALTER TABLE stich."Expenses" DROP COLUMN IF EXISTS supplier_id;
+-- DROP TABLE IF EXISTS stich."PurchaseOrderLines";
+-- DROP TABLE IF EXISTS stich."PurchaseOrders";
+-- DROP TABLE IF EXISTS stich."Suppliers";
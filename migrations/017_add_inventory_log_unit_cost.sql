@@ -0,0 +1,23 @@
+-- Migration: 017_add_inventory_log_unit_cost
+-- Generated: 2026-10-16T16:48:10+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add column to stich.InventoryLogs
+ALTER TABLE stich."InventoryLogs" ADD COLUMN unit_cost DOUBLE PRECISION;
+
+-- Migrate existing data: IN movements recorded before costing take the product's cost price
+UPDATE stich."InventoryLogs" L
+SET unit_cost = P.cost_price
+FROM stich."Products" P
+WHERE P.id = L.product_id
+  AND L.change_type = 'IN'
+  AND L.unit_cost IS NULL;
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- ALTER TABLE stich."InventoryLogs" DROP COLUMN IF EXISTS unit_cost;
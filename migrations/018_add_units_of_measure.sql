@@ -0,0 +1,62 @@
+-- Migration: 018_add_units_of_measure
+-- Generated: 2026-10-16T17:35:20+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add column to stich.Products
+ALTER TABLE stich."Products" ADD COLUMN unit VARCHAR(20) NOT NULL DEFAULT 'PIECE';
+
+-- Add columns to stich.InventoryLogs
+ALTER TABLE stich."InventoryLogs" ADD COLUMN entered_quantity NUMERIC(12,3);
+ALTER TABLE stich."InventoryLogs" ADD COLUMN entered_unit VARCHAR(20);
+
+-- Alter quantity columns to decimals
+ALTER TABLE stich."Inventories" ALTER COLUMN quantity TYPE NUMERIC(12,3);
+ALTER TABLE stich."Inventories" ALTER COLUMN low_stock_threshold TYPE NUMERIC(12,3);
+ALTER TABLE stich."InventoryLogs" ALTER COLUMN quantity TYPE NUMERIC(12,3);
+ALTER TABLE stich."DressTypeComponents" ALTER COLUMN quantity TYPE NUMERIC(12,3);
+ALTER TABLE stich."StockTakeLines" ALTER COLUMN expected_quantity TYPE NUMERIC(12,3);
+ALTER TABLE stich."StockTakeLines" ALTER COLUMN counted_quantity TYPE NUMERIC(12,3);
+ALTER TABLE stich."StockTakeLines" ALTER COLUMN posted_variance TYPE NUMERIC(12,3);
+ALTER TABLE stich."PurchaseOrderLines" ALTER COLUMN quantity TYPE NUMERIC(12,3);
+ALTER TABLE stich."PurchaseOrderLines" ALTER COLUMN received_quantity TYPE NUMERIC(12,3);
+
+-- Create table: stich.ProductUnitConversions
+CREATE TABLE IF NOT EXISTS stich."ProductUnitConversions" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  product_id BIGINT NOT NULL,
+  unit VARCHAR(20) NOT NULL,
+  factor NUMERIC(12,3) NOT NULL,
+  PRIMARY KEY (id)
+);
+
+-- Foreign keys
+ALTER TABLE stich."ProductUnitConversions" ADD CONSTRAINT fk_ProductUnitConversion_product_id FOREIGN KEY (product_id) REFERENCES stich."Products" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_product_unit_conversions_product_id ON stich."ProductUnitConversions" (product_id);
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- DROP TABLE IF EXISTS stich."ProductUnitConversions";
+-- ALTER TABLE stich."PurchaseOrderLines" ALTER COLUMN received_quantity TYPE BIGINT;
+-- ALTER TABLE stich."PurchaseOrderLines" ALTER COLUMN quantity TYPE BIGINT;
+-- ALTER TABLE stich."StockTakeLines" ALTER COLUMN posted_variance TYPE BIGINT;
+-- ALTER TABLE stich."StockTakeLines" ALTER COLUMN counted_quantity TYPE BIGINT;
+-- ALTER TABLE stich."StockTakeLines" ALTER COLUMN expected_quantity TYPE BIGINT;
+-- ALTER TABLE stich."DressTypeComponents" ALTER COLUMN quantity TYPE BIGINT;
+-- ALTER TABLE stich."InventoryLogs" ALTER COLUMN quantity TYPE BIGINT;
+-- ALTER TABLE stich."Inventories" ALTER COLUMN low_stock_threshold TYPE BIGINT;
+-- ALTER TABLE stich."Inventories" ALTER COLUMN quantity TYPE BIGINT;
+-- ALTER TABLE stich."InventoryLogs" DROP COLUMN IF EXISTS entered_unit;
+-- ALTER TABLE stich."InventoryLogs" DROP COLUMN IF EXISTS entered_quantity;
+-- ALTER TABLE stich."Products" DROP COLUMN IF EXISTS unit;
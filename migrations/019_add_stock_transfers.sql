@@ -0,0 +1,69 @@
+-- Migration: 019_add_stock_transfers
+-- Generated: 2026-10-16T19:10:42+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Inventory is kept per channel, one row per product in each channel
+ALTER TABLE stich."Inventories" DROP CONSTRAINT IF EXISTS "Inventories_product_id_key";
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stich_Inventories_product_id_channel_id ON stich."Inventories" (product_id, channel_id);
+
+-- Add column to stich.InventoryLogs
+ALTER TABLE stich."InventoryLogs" ADD COLUMN stock_transfer_id BIGINT;
+
+-- Create table: stich.StockTransfers
+CREATE TABLE IF NOT EXISTS stich."StockTransfers" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  from_channel_id BIGINT NOT NULL,
+  to_channel_id BIGINT NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  notes TEXT,
+  dispatched_at TIMESTAMPTZ,
+  received_at TIMESTAMPTZ,
+  PRIMARY KEY (id)
+);
+
+-- Create table: stich.StockTransferLines
+CREATE TABLE IF NOT EXISTS stich."StockTransferLines" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  stock_transfer_id BIGINT NOT NULL,
+  product_id BIGINT NOT NULL,
+  quantity NUMERIC(12,3) NOT NULL,
+  PRIMARY KEY (id)
+);
+
+-- Foreign keys
+ALTER TABLE stich."InventoryLogs" ADD CONSTRAINT fk_InventoryLog_stock_transfer_id FOREIGN KEY (stock_transfer_id) REFERENCES stich."StockTransfers" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."StockTransfers" ADD CONSTRAINT fk_StockTransfer_from_channel_id FOREIGN KEY (from_channel_id) REFERENCES stich."Channels" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."StockTransfers" ADD CONSTRAINT fk_StockTransfer_to_channel_id FOREIGN KEY (to_channel_id) REFERENCES stich."Channels" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."StockTransferLines" ADD CONSTRAINT fk_StockTransferLine_stock_transfer_id FOREIGN KEY (stock_transfer_id) REFERENCES stich."StockTransfers" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."StockTransferLines" ADD CONSTRAINT fk_StockTransferLine_product_id FOREIGN KEY (product_id) REFERENCES stich."Products" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_from_channel_id ON stich."StockTransfers" (from_channel_id);
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_to_channel_id ON stich."StockTransfers" (to_channel_id);
+CREATE INDEX IF NOT EXISTS idx_stock_transfer_lines_stock_transfer_id ON stich."StockTransferLines" (stock_transfer_id);
+CREATE INDEX IF NOT EXISTS idx_inventory_logs_stock_transfer_id ON stich."InventoryLogs" (stock_transfer_id);
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- ALTER TABLE stich."InventoryLogs" DROP CONSTRAINT IF EXISTS fk_InventoryLog_stock_transfer_id;
+-- DROP TABLE IF EXISTS stich."StockTransferLines";
+-- DROP TABLE IF EXISTS stich."StockTransfers";
+-- ALTER TABLE stich."InventoryLogs" DROP COLUMN IF EXISTS stock_transfer_id;
+-- DROP INDEX IF EXISTS stich.idx_stich_Inventories_product_id_channel_id;
+-- ALTER TABLE stich."Inventories" ADD CONSTRAINT "Inventories_product_id_key" UNIQUE (product_id);
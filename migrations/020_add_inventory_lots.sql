@@ -0,0 +1,56 @@
+-- Migration: 020_add_inventory_lots
+-- Generated: 2026-10-16T20:02:17+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.InventoryLots
+CREATE TABLE IF NOT EXISTS stich."InventoryLots" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  product_id BIGINT NOT NULL,
+  supplier_id BIGINT,
+  lot_code TEXT,
+  received_at TIMESTAMPTZ NOT NULL,
+  quantity NUMERIC(12,3) NOT NULL,
+  remaining_quantity NUMERIC(12,3) NOT NULL,
+  expires_at TIMESTAMPTZ,
+  PRIMARY KEY (id)
+);
+
+-- Add column to stich.InventoryLogs
+ALTER TABLE stich."InventoryLogs" ADD COLUMN lot_id BIGINT;
+
+-- A movement drawn from several lots is logged once per lot, each log carries the idempotency key
+DROP INDEX IF EXISTS stich.idx_stich_InventoryLogs_idempotency_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stich_InventoryLogs_idempotency_key_lot_id ON stich."InventoryLogs" (idempotency_key, lot_id);
+
+-- Foreign keys
+ALTER TABLE stich."InventoryLots" ADD CONSTRAINT fk_InventoryLot_product_id FOREIGN KEY (product_id) REFERENCES stich."Products" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."InventoryLots" ADD CONSTRAINT fk_InventoryLot_supplier_id FOREIGN KEY (supplier_id) REFERENCES stich."Suppliers" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."InventoryLogs" ADD CONSTRAINT fk_InventoryLog_lot_id FOREIGN KEY (lot_id) REFERENCES stich."InventoryLots" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_inventory_lots_product_id_channel_id ON stich."InventoryLots" (product_id, channel_id);
+CREATE INDEX IF NOT EXISTS idx_inventory_logs_lot_id ON stich."InventoryLogs" (lot_id);
+
+-- Stock on hand before lots were tracked becomes one opening lot per product and channel
+INSERT INTO stich."InventoryLots" (created_at, updated_at, is_active, channel_id, product_id, lot_code, received_at, quantity, remaining_quantity)
+SELECT NOW(), NOW(), true, channel_id, product_id, '', COALESCE(updated_at, NOW()), quantity, quantity
+FROM stich."Inventories"
+WHERE is_active = true AND quantity > 0;
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- ALTER TABLE stich."InventoryLogs" DROP CONSTRAINT IF EXISTS fk_InventoryLog_lot_id;
+-- DROP INDEX IF EXISTS stich.idx_stich_InventoryLogs_idempotency_key_lot_id;
+-- CREATE UNIQUE INDEX IF NOT EXISTS idx_stich_InventoryLogs_idempotency_key ON stich."InventoryLogs" (idempotency_key);
+-- ALTER TABLE stich."InventoryLogs" DROP COLUMN IF EXISTS lot_id;
+-- DROP TABLE IF EXISTS stich."InventoryLots";
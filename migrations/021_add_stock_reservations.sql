@@ -0,0 +1,38 @@
+-- Migration: 021_add_stock_reservations
+-- Generated: 2026-10-16T21:05:18+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.StockReservations
+CREATE TABLE IF NOT EXISTS stich."StockReservations" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  product_id BIGINT NOT NULL,
+  order_id BIGINT NOT NULL,
+  order_item_id BIGINT NOT NULL,
+  quantity NUMERIC(12,3) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'RESERVED',
+  PRIMARY KEY (id)
+);
+
+-- Foreign keys
+ALTER TABLE stich."StockReservations" ADD CONSTRAINT fk_StockReservation_product_id FOREIGN KEY (product_id) REFERENCES stich."Products" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."StockReservations" ADD CONSTRAINT fk_StockReservation_order_id FOREIGN KEY (order_id) REFERENCES stich."Orders" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."StockReservations" ADD CONSTRAINT fk_StockReservation_order_item_id FOREIGN KEY (order_item_id) REFERENCES stich."OrderItems" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_product_id_channel_id_status ON stich."StockReservations" (product_id, channel_id, status);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_order_id ON stich."StockReservations" (order_id);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_order_item_id ON stich."StockReservations" (order_item_id);
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- DROP TABLE IF EXISTS stich."StockReservations";
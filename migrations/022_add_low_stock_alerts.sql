@@ -0,0 +1,15 @@
+-- Migration: 022_add_low_stock_alerts
+-- Generated: 2026-10-16T21:48:36+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add column to stich.Inventories
+ALTER TABLE stich."Inventories" ADD COLUMN low_stock_alerted_at TIMESTAMPTZ;
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- ALTER TABLE stich."Inventories" DROP COLUMN IF EXISTS low_stock_alerted_at;
@@ -0,0 +1,58 @@
+-- Migration: 023_add_product_variants
+-- Generated: 2026-10-16T22:41:07+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add column to stich.Products
+ALTER TABLE stich."Products" ADD COLUMN parent_id BIGINT;
+
+-- Create table: stich.ProductAttributes
+CREATE TABLE IF NOT EXISTS stich."ProductAttributes" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  product_id BIGINT NOT NULL,
+  name VARCHAR(50) NOT NULL,
+  position BIGINT NOT NULL DEFAULT 0,
+  PRIMARY KEY (id)
+);
+
+-- Create table: stich.ProductVariantValues
+CREATE TABLE IF NOT EXISTS stich."ProductVariantValues" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  product_id BIGINT NOT NULL,
+  attribute_id BIGINT NOT NULL,
+  value VARCHAR(50) NOT NULL,
+  PRIMARY KEY (id)
+);
+
+-- Foreign keys
+ALTER TABLE stich."Products" ADD CONSTRAINT fk_Product_parent_id FOREIGN KEY (parent_id) REFERENCES stich."Products" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."ProductAttributes" ADD CONSTRAINT fk_ProductAttribute_product_id FOREIGN KEY (product_id) REFERENCES stich."Products" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."ProductVariantValues" ADD CONSTRAINT fk_ProductVariantValue_product_id FOREIGN KEY (product_id) REFERENCES stich."Products" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."ProductVariantValues" ADD CONSTRAINT fk_ProductVariantValue_attribute_id FOREIGN KEY (attribute_id) REFERENCES stich."ProductAttributes" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_products_parent_id ON stich."Products" (parent_id);
+CREATE INDEX IF NOT EXISTS idx_product_attributes_product_id ON stich."ProductAttributes" (product_id);
+CREATE INDEX IF NOT EXISTS idx_product_variant_values_product_id ON stich."ProductVariantValues" (product_id);
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- DROP TABLE IF EXISTS stich."ProductVariantValues";
+-- DROP TABLE IF EXISTS stich."ProductAttributes";
+-- ALTER TABLE stich."Products" DROP CONSTRAINT IF EXISTS fk_Product_parent_id;
+-- ALTER TABLE stich."Products" DROP COLUMN IF EXISTS parent_id;
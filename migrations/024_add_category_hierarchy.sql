@@ -0,0 +1,29 @@
+-- Migration: 024_add_category_hierarchy
+-- Generated: 2026-10-16T23:18:52+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add columns to stich.Categories
+ALTER TABLE stich."Categories" ADD COLUMN parent_id BIGINT;
+ALTER TABLE stich."Categories" ADD COLUMN path VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE stich."Categories" ADD COLUMN depth BIGINT NOT NULL DEFAULT 0;
+
+-- Existing categories become roots
+UPDATE stich."Categories" SET path = '/' || id || '/' WHERE path = '';
+
+-- Foreign keys
+ALTER TABLE stich."Categories" ADD CONSTRAINT fk_Category_parent_id FOREIGN KEY (parent_id) REFERENCES stich."Categories" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON stich."Categories" (parent_id);
+CREATE INDEX IF NOT EXISTS idx_categories_path ON stich."Categories" (path varchar_pattern_ops);
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- ALTER TABLE stich."Categories" DROP CONSTRAINT IF EXISTS fk_Category_parent_id;
+-- ALTER TABLE stich."Categories" DROP COLUMN IF EXISTS depth;
+-- ALTER TABLE stich."Categories" DROP COLUMN IF EXISTS path;
+-- ALTER TABLE stich."Categories" DROP COLUMN IF EXISTS parent_id;
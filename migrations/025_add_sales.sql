@@ -0,0 +1,76 @@
+-- Migration: 025_add_sales
+-- Generated: 2026-10-16T23:41:07+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add column to stich.InventoryLogs
+ALTER TABLE stich."InventoryLogs" ADD COLUMN sale_id BIGINT;
+
+-- Create table: stich.Sales
+CREATE TABLE IF NOT EXISTS stich."Sales" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  customer_id BIGINT,
+  sold_at TIMESTAMPTZ NOT NULL,
+  payment_mode VARCHAR(20) NOT NULL,
+  payment_reference TEXT,
+  notes TEXT,
+  discount NUMERIC(10,2) NOT NULL DEFAULT 0,
+  sub_total NUMERIC(12,2) NOT NULL DEFAULT 0,
+  taxable_value NUMERIC(12,2) NOT NULL DEFAULT 0,
+  cgst_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+  sgst_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+  igst_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+  grand_total NUMERIC(12,2) NOT NULL DEFAULT 0,
+  PRIMARY KEY (id)
+);
+
+-- Create table: stich.SaleLines
+CREATE TABLE IF NOT EXISTS stich."SaleLines" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  sale_id BIGINT NOT NULL,
+  product_id BIGINT NOT NULL,
+  quantity NUMERIC(12,3) NOT NULL,
+  selling_price NUMERIC(10,2) NOT NULL,
+  discount NUMERIC(10,2) NOT NULL DEFAULT 0,
+  hsn_code TEXT,
+  tax_rate NUMERIC(5,2) DEFAULT 0,
+  taxable_value NUMERIC(12,2) NOT NULL DEFAULT 0,
+  cgst_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+  sgst_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+  igst_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+  PRIMARY KEY (id)
+);
+
+-- Foreign keys
+ALTER TABLE stich."InventoryLogs" ADD CONSTRAINT fk_InventoryLog_sale_id FOREIGN KEY (sale_id) REFERENCES stich."Sales" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."Sales" ADD CONSTRAINT fk_Sale_customer_id FOREIGN KEY (customer_id) REFERENCES stich."Customers" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."SaleLines" ADD CONSTRAINT fk_SaleLine_sale_id FOREIGN KEY (sale_id) REFERENCES stich."Sales" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."SaleLines" ADD CONSTRAINT fk_SaleLine_product_id FOREIGN KEY (product_id) REFERENCES stich."Products" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_sales_channel_id_sold_at ON stich."Sales" (channel_id, sold_at);
+CREATE INDEX IF NOT EXISTS idx_sales_customer_id ON stich."Sales" (customer_id);
+CREATE INDEX IF NOT EXISTS idx_sale_lines_sale_id ON stich."SaleLines" (sale_id);
+CREATE INDEX IF NOT EXISTS idx_inventory_logs_sale_id ON stich."InventoryLogs" (sale_id);
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- ALTER TABLE stich."InventoryLogs" DROP CONSTRAINT IF EXISTS fk_InventoryLog_sale_id;
+-- DROP TABLE IF EXISTS stich."SaleLines";
+-- DROP TABLE IF EXISTS stich."Sales";
+-- ALTER TABLE stich."InventoryLogs" DROP COLUMN IF EXISTS sale_id;
@@ -0,0 +1,27 @@
+-- Migration: 026_add_inventory_log_reversal
+-- Generated: 2026-10-16T23:58:14+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add columns to stich.InventoryLogs
+ALTER TABLE stich."InventoryLogs" ADD COLUMN reversal_of_log_id BIGINT;
+ALTER TABLE stich."InventoryLogs" ADD COLUMN reversed_by_log_id BIGINT;
+
+-- Foreign keys
+ALTER TABLE stich."InventoryLogs" ADD CONSTRAINT fk_InventoryLog_reversal_of_log_id FOREIGN KEY (reversal_of_log_id) REFERENCES stich."InventoryLogs" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."InventoryLogs" ADD CONSTRAINT fk_InventoryLog_reversed_by_log_id FOREIGN KEY (reversed_by_log_id) REFERENCES stich."InventoryLogs" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+-- A movement is reversed at most once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_inventory_logs_reversal_of_log_id ON stich."InventoryLogs" (reversal_of_log_id);
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- DROP INDEX IF EXISTS stich.idx_inventory_logs_reversal_of_log_id;
+-- ALTER TABLE stich."InventoryLogs" DROP CONSTRAINT IF EXISTS fk_InventoryLog_reversed_by_log_id;
+-- ALTER TABLE stich."InventoryLogs" DROP CONSTRAINT IF EXISTS fk_InventoryLog_reversal_of_log_id;
+-- ALTER TABLE stich."InventoryLogs" DROP COLUMN IF EXISTS reversed_by_log_id;
+-- ALTER TABLE stich."InventoryLogs" DROP COLUMN IF EXISTS reversal_of_log_id;
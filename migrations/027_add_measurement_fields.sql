@@ -0,0 +1,52 @@
+-- Migration: 027_add_measurement_fields
+-- Generated: 2026-10-17T00:26:45+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.MeasurementFields
+CREATE TABLE IF NOT EXISTS stich."MeasurementFields" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  dress_type_id BIGINT NOT NULL,
+  key VARCHAR(50) NOT NULL,
+  label TEXT NOT NULL,
+  unit VARCHAR(10) NOT NULL,
+  min_value NUMERIC(8,2),
+  max_value NUMERIC(8,2),
+  required BOOL NOT NULL DEFAULT false,
+  group_name TEXT,
+  display_order BIGINT NOT NULL DEFAULT 0,
+  PRIMARY KEY (id)
+);
+
+-- Foreign keys
+ALTER TABLE stich."MeasurementFields" ADD CONSTRAINT fk_MeasurementField_dress_type_id FOREIGN KEY (dress_type_id) REFERENCES stich."DressTypes" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_measurement_fields_dress_type_id ON stich."MeasurementFields" (dress_type_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_measurement_fields_dress_type_id_key ON stich."MeasurementFields" (dress_type_id, key) WHERE is_active = true;
+
+-- Existing dress types get a schema from their measurement list, in inches with no bounds
+INSERT INTO stich."MeasurementFields" (created_at, updated_at, is_active, channel_id, dress_type_id, key, label, unit, required, group_name, display_order)
+SELECT DISTINCT ON (D.id, L.key) NOW(), NOW(), true, D.channel_id, D.id, L.key, L.label, 'INCH', false, '', L.position
+FROM stich."DressTypes" D
+CROSS JOIN LATERAL (
+  SELECT TRIM(M.label) AS label,
+         TRIM(BOTH '_' FROM REGEXP_REPLACE(LOWER(TRIM(M.label)), '[^a-z0-9]+', '_', 'g')) AS key,
+         M.position
+  FROM UNNEST(STRING_TO_ARRAY(D.measurements, ',')) WITH ORDINALITY AS M(label, position)
+) L
+WHERE D.measurements IS NOT NULL AND L.key ~ '^[a-z][a-z0-9_]*$'
+ORDER BY D.id, L.key, L.position;
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- DROP TABLE IF EXISTS stich."MeasurementFields";
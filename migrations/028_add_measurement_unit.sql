@@ -0,0 +1,16 @@
+-- Migration: 028_add_measurement_unit
+-- Generated: 2026-10-17T00:58:32+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add column to stich.Measurements
+-- Values are kept in inches, the measurements taken so far were all taken in inches
+ALTER TABLE stich."Measurements" ADD COLUMN unit VARCHAR(10) NOT NULL DEFAULT 'INCH';
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- ALTER TABLE stich."Measurements" DROP COLUMN IF EXISTS unit;
@@ -0,0 +1,18 @@
+-- Migration: 029_add_order_item_measurement_snapshot
+-- Generated: 2026-10-17T11:20:14+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add columns to stich.OrderItems
+-- Taken when the order is confirmed, orders confirmed earlier get theirs when their items are next saved
+ALTER TABLE stich."OrderItems" ADD COLUMN measurement_snapshot JSONB;
+ALTER TABLE stich."OrderItems" ADD COLUMN measurement_snapshot_at TIMESTAMPTZ;
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS measurement_snapshot_at;
+-- ALTER TABLE stich."OrderItems" DROP COLUMN IF EXISTS measurement_snapshot;
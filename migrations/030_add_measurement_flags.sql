@@ -0,0 +1,39 @@
+-- Migration: 030_add_measurement_flags
+-- Generated: 2026-10-17T14:05:51+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.MeasurementFlags
+CREATE TABLE IF NOT EXISTS stich."MeasurementFlags" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  measurement_id BIGINT NOT NULL,
+  field VARCHAR(50) NOT NULL,
+  kind VARCHAR(20) NOT NULL,
+  message TEXT NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+  reviewed_by_id BIGINT,
+  reviewed_at TIMESTAMPTZ,
+  review_note TEXT,
+  PRIMARY KEY (id)
+);
+
+-- Foreign keys
+ALTER TABLE stich."MeasurementFlags" ADD CONSTRAINT fk_MeasurementFlag_measurement_id FOREIGN KEY (measurement_id) REFERENCES stich."Measurements" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+ALTER TABLE stich."MeasurementFlags" ADD CONSTRAINT fk_MeasurementFlag_reviewed_by_id FOREIGN KEY (reviewed_by_id) REFERENCES stich."Users" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_measurement_flags_measurement_id ON stich."MeasurementFlags" (measurement_id);
+CREATE INDEX IF NOT EXISTS idx_measurement_flags_status ON stich."MeasurementFlags" (channel_id, status);
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- DROP TABLE IF EXISTS stich."MeasurementFlags";
@@ -0,0 +1,43 @@
+-- Migration: 031_rename_measurement_value_keys
+-- Generated: 2026-10-17T15:12:08+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Values taken before 027 are keyed by the dress type's labels ("Hip"), rename them to the field keys ("hip")
+-- A key already stored under the field key wins over a renamed one
+UPDATE stich."Measurements" M
+SET value = K.value
+FROM (
+  SELECT V.id, JSONB_OBJECT_AGG(COALESCE(F.key, E.key), E.value ORDER BY COALESCE(F.key, E.key) = E.key) AS value
+  FROM stich."Measurements" V
+  CROSS JOIN LATERAL JSONB_EACH(V.value) E
+  LEFT JOIN stich."MeasurementFields" F ON F.dress_type_id = V.dress_type_id AND F.is_active = true
+    AND F.key = TRIM(BOTH '_' FROM REGEXP_REPLACE(LOWER(TRIM(E.key)), '[^a-z0-9]+', '_', 'g'))
+  WHERE JSONB_TYPEOF(V.value) = 'object'
+  GROUP BY V.id
+  HAVING BOOL_OR(F.key IS NOT NULL AND F.key <> E.key)
+) K
+WHERE M.id = K.id;
+
+UPDATE stich."MeasurementHistories" H
+SET old_values = K.old_values
+FROM (
+  SELECT V.id, JSONB_OBJECT_AGG(COALESCE(F.key, E.key), E.value ORDER BY COALESCE(F.key, E.key) = E.key) AS old_values
+  FROM stich."MeasurementHistories" V
+  JOIN stich."Measurements" M ON M.id = V.measurement_id
+  CROSS JOIN LATERAL JSONB_EACH(V.old_values) E
+  LEFT JOIN stich."MeasurementFields" F ON F.dress_type_id = M.dress_type_id AND F.is_active = true
+    AND F.key = TRIM(BOTH '_' FROM REGEXP_REPLACE(LOWER(TRIM(E.key)), '[^a-z0-9]+', '_', 'g'))
+  WHERE JSONB_TYPEOF(V.old_values) = 'object'
+  GROUP BY V.id
+  HAVING BOOL_OR(F.key IS NOT NULL AND F.key <> E.key)
+) K
+WHERE H.id = K.id;
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- Keys are not renamed back, the labels are still on stich."MeasurementFields".label
@@ -0,0 +1,21 @@
+-- Migration: 032_scope_inventory_log_idempotency_key
+-- Generated: 2026-10-17T15:40:27+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Idempotency keys are unique per channel, two channels may use the same key.
+-- A key is unique per lot on movements drawn from lots and unique on its own otherwise,
+-- a plain index would let movements without a lot repeat a key as NULLs are distinct
+DROP INDEX IF EXISTS stich.idx_stich_InventoryLogs_idempotency_key_lot_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stich_InventoryLogs_channel_id_idempotency_key_lot_id ON stich."InventoryLogs" (channel_id, idempotency_key, lot_id) WHERE lot_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stich_InventoryLogs_channel_id_idempotency_key ON stich."InventoryLogs" (channel_id, idempotency_key) WHERE lot_id IS NULL;
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- DROP INDEX IF EXISTS stich.idx_stich_InventoryLogs_channel_id_idempotency_key;
+-- DROP INDEX IF EXISTS stich.idx_stich_InventoryLogs_channel_id_idempotency_key_lot_id;
+-- CREATE UNIQUE INDEX IF NOT EXISTS idx_stich_InventoryLogs_idempotency_key_lot_id ON stich."InventoryLogs" (idempotency_key, lot_id);
@@ -0,0 +1,17 @@
+-- Migration: 033_add_order_invoice
+-- Generated: 2026-10-17T16:02:44+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add columns to stich.Orders
+ALTER TABLE stich."Orders" ADD COLUMN invoice_number VARCHAR(20);
+ALTER TABLE stich."Orders" ADD COLUMN invoiced_at TIMESTAMPTZ;
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- ALTER TABLE stich."Orders" DROP COLUMN IF EXISTS invoiced_at;
+-- ALTER TABLE stich."Orders" DROP COLUMN IF EXISTS invoice_number;
@@ -0,0 +1,15 @@
+-- Migration: 034_add_dress_type_component_single_lot
+-- Generated: 2026-10-17T16:40:12+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add columns to stich.DressTypeComponents
+ALTER TABLE stich."DressTypeComponents" ADD COLUMN single_lot BOOLEAN NOT NULL DEFAULT false;
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- ALTER TABLE stich."DressTypeComponents" DROP COLUMN IF EXISTS single_lot;
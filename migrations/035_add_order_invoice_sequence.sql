@@ -0,0 +1,31 @@
+-- Migration: 035_add_order_invoice_sequence
+-- Generated: 2026-10-17T17:05:31+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add columns to stich.Orders
+ALTER TABLE stich."Orders" ADD COLUMN invoice_sequence BIGINT;
+
+-- Viewing the invoice of a draft or cancelled order issued one, those are withdrawn
+UPDATE stich."Orders" SET invoice_number = NULL, invoiced_at = NULL WHERE status IN ('DRAFT', 'CANCELLED') AND invoiced_at IS NOT NULL;
+
+-- Invoices already issued are renumbered per channel in the order they were issued
+UPDATE stich."Orders" O
+SET invoice_sequence = N.sequence, invoice_number = 'INV-' || LPAD(N.sequence::TEXT, 6, '0')
+FROM (
+  SELECT id, ROW_NUMBER() OVER (PARTITION BY channel_id ORDER BY invoiced_at, id) AS sequence
+  FROM stich."Orders"
+  WHERE invoiced_at IS NOT NULL
+) N
+WHERE O.id = N.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stich_Orders_channel_id_invoice_sequence ON stich."Orders" (channel_id, invoice_sequence);
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- DROP INDEX IF EXISTS stich.idx_stich_Orders_channel_id_invoice_sequence;
+-- ALTER TABLE stich."Orders" DROP COLUMN IF EXISTS invoice_sequence;